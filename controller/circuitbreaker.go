@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import "time"
+
+// circuitBreaker opens after a number of consecutive failures, and then backs off the next
+// attempt exponentially, starting from twice the base delay up to the max backoff.
+// It is closed again by the first success.
+type circuitBreaker struct {
+	threshold  int
+	baseDelay  time.Duration
+	maxBackoff time.Duration
+	failures   int
+}
+
+// failure records a failure and returns the delay before the next attempt while the breaker
+// is open, or zero while it is closed. A threshold of 0 disables the breaker.
+func (b *circuitBreaker) failure() time.Duration {
+	b.failures++
+	if !b.open() {
+		return 0
+	}
+	backoff := 2 * b.baseDelay
+	for i := b.threshold; i < b.failures && backoff < b.maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, b.maxBackoff)
+}
+
+// success records a success, closing the breaker.
+func (b *circuitBreaker) success() {
+	b.failures = 0
+}
+
+// open returns whether the consecutive failures reached the threshold.
+func (b *circuitBreaker) open() bool {
+	return b.threshold > 0 && b.failures >= b.threshold
+}
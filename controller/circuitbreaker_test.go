@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := &circuitBreaker{threshold: 3, baseDelay: time.Minute, maxBackoff: 10 * time.Minute}
+
+	// The breaker stays closed below the threshold.
+	assert.Zero(t, b.failure())
+	assert.Zero(t, b.failure())
+	assert.False(t, b.open())
+
+	// Then it backs off exponentially up to the max backoff.
+	for _, expected := range []time.Duration{2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute, 10 * time.Minute} {
+		assert.Equal(t, expected, b.failure())
+		assert.True(t, b.open())
+	}
+
+	// A success closes it again.
+	b.success()
+	assert.False(t, b.open())
+	assert.Zero(t, b.failure())
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	b := &circuitBreaker{baseDelay: time.Minute, maxBackoff: 10 * time.Minute}
+
+	for range 10 {
+		assert.Zero(t, b.failure())
+	}
+	assert.False(t, b.open())
+}
+
+func TestControllerCircuitBreaker(t *testing.T) {
+	ctrl := &Controller{
+		Interval:             time.Minute,
+		MinEventSyncInterval: 5 * time.Second,
+		FailureThreshold:     2,
+		MaxBackoff:           time.Hour,
+		ProviderName:         "test-provider",
+	}
+
+	now := time.Now()
+	assert.True(t, ctrl.ShouldRunOnce(now))
+
+	// Below the threshold, the next run happens after the interval.
+	ctrl.recordFailure(now)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, circuitBreakerOpen.Gauge, map[string]string{"provider": "test-provider"})
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(time.Minute)))
+
+	// The breaker opens and postpones the next run by twice the interval.
+	now = now.Add(time.Minute)
+	ctrl.recordFailure(now)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, circuitBreakerOpen.Gauge, map[string]string{"provider": "test-provider"})
+	assert.False(t, ctrl.ShouldRunOnce(now.Add(time.Minute)))
+
+	// Events don't trigger a run while backing off.
+	ctrl.ScheduleRunOnce(now.Add(time.Minute))
+	assert.False(t, ctrl.ShouldRunOnce(now.Add(time.Minute+5*time.Second)))
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(2*time.Minute)))
+
+	// The backoff doubles on the next failure.
+	now = now.Add(2 * time.Minute)
+	ctrl.recordFailure(now)
+	assert.False(t, ctrl.ShouldRunOnce(now.Add(3*time.Minute)))
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(4*time.Minute)))
+
+	// A success closes the breaker and events trigger runs again.
+	now = now.Add(4 * time.Minute)
+	ctrl.recordSuccess()
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, circuitBreakerOpen.Gauge, map[string]string{"provider": "test-provider"})
+	ctrl.lastRunAt = now
+	ctrl.ScheduleRunOnce(now)
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(5*time.Second)))
+}
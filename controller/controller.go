@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"sync"
 	"time"
 
@@ -56,6 +57,13 @@ var (
 			Help:      "Number of Endpoints in all sources",
 		},
 	)
+	sourceInvalidObjects = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "source",
+			Name:      "invalid_objects",
+			Help:      "Number of invalid objects skipped by the sources in the last reconciliation.",
+		},
+	)
 	registryEndpointsTotal = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Subsystem: "registry",
@@ -133,12 +141,30 @@ var (
 			Help:      "Number of consecutive soft errors in reconciliation loop.",
 		},
 	)
+
+	deferredDeletions = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "deferred_deletions",
+			Help:      "Number of record deletions deferred to the next reconciliations by the maximum number of deletions per run.",
+		},
+	)
+
+	circuitBreakerOpen = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "circuit_breaker_open",
+			Help:      "Whether the reconciliation of the provider is backing off after consecutive soft errors (vector).",
+		},
+		[]string{"provider"},
+	)
 )
 
 func init() {
 	metrics.RegisterMetric.MustRegister(registryErrorsTotal)
 	metrics.RegisterMetric.MustRegister(sourceErrorsTotal)
 	metrics.RegisterMetric.MustRegister(sourceEndpointsTotal)
+	metrics.RegisterMetric.MustRegister(sourceInvalidObjects)
 	metrics.RegisterMetric.MustRegister(registryEndpointsTotal)
 	metrics.RegisterMetric.MustRegister(lastSyncTimestamp)
 	metrics.RegisterMetric.MustRegister(lastReconcileTimestamp)
@@ -151,6 +177,8 @@ func init() {
 	metrics.RegisterMetric.MustRegister(verifiedRecords)
 
 	metrics.RegisterMetric.MustRegister(consecutiveSoftErrors)
+	metrics.RegisterMetric.MustRegister(deferredDeletions)
+	metrics.RegisterMetric.MustRegister(circuitBreakerOpen)
 }
 
 // Controller is responsible for orchestrating the different components.
@@ -178,8 +206,36 @@ type Controller struct {
 	ManagedRecordTypes []string
 	// ExcludeRecordTypes are DNS record types that will be excluded from management.
 	ExcludeRecordTypes []string
+	// RecordTypeReplacement allows to replace records of a domain by records of a conflicting type
+	RecordTypeReplacement bool
+	// RecordTypePriority orders the record types winning a conflict between CNAME and other record types
+	RecordTypePriority []string
 	// MinEventSyncInterval is used as a window for batching events
 	MinEventSyncInterval time.Duration
+	// DeleteAfterCreate applies the deletes after the creates and updates, in a separate batch
+	DeleteAfterCreate bool
+	// MaxDeletionsPerRun caps the number of records deleted by a reconciliation, 0 doesn't limit them
+	MaxDeletionsPerRun int
+	// AllowApexSOANS allows changes to the SOA and NS records at the zone apex
+	AllowApexSOANS bool
+	// ExternalRecords matches the DNS names of the records managed by other tools, which are never changed
+	ExternalRecords *regexp.Regexp
+	// ExternalRecordsTXTMarker is the value of the TXT records marking their DNS name as managed by other tools
+	ExternalRecordsTXTMarker string
+	// FailureThreshold is the number of consecutive soft errors opening the circuit breaker, 0 disables it
+	FailureThreshold int
+	// MaxBackoff caps the exponential backoff of the reconciliation while the circuit breaker is open
+	MaxBackoff time.Duration
+	// ProviderName is the name of the provider, used to label the circuit breaker metric
+	ProviderName string
+	// ProviderDefaults are provider specific properties set on the desired records of their domains which don't set them
+	ProviderDefaults []plan.ProviderSpecificDefault
+	// DryRun logs the itemized changes of the updated records at the info level instead of the debug level
+	DryRun bool
+	// The breaker counts the consecutive soft errors of the reconciliation loop
+	breaker circuitBreaker
+	// The backoffUntil postpones all the reconciliations while the circuit breaker is open
+	backoffUntil time.Time
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
@@ -206,11 +262,14 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
 
 	sourceEndpoints, err := c.Source.Endpoints(ctx)
-	if err != nil {
+	if err != nil && !source.IsInvalidObjectsError(err) {
 		sourceErrorsTotal.Counter.Inc()
 		deprecatedSourceErrors.Counter.Inc()
 		return err
 	}
+	// the invalid objects are skipped, the records of the valid ones are still synchronized
+	invalidObjects := invalidSourceObjects(err)
+	sourceInvalidObjects.Gauge.Set(float64(len(invalidObjects)))
 
 	sourceEndpointsTotal.Gauge.Set(float64(len(sourceEndpoints)))
 
@@ -220,26 +279,44 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	vaMetrics := newMetricsRecorder()
 	countMatchingAddressRecords(vaMetrics, sourceEndpoints, regRecords, verifiedRecords)
 
-	endpoints, err := c.Registry.AdjustEndpoints(sourceEndpoints)
+	endpoints := plan.ApplyProviderSpecificDefaults(sourceEndpoints, c.ProviderDefaults)
+	endpoints, err = c.Registry.AdjustEndpoints(endpoints)
 	if err != nil {
 		return fmt.Errorf("adjusting endpoints: %w", err)
 	}
 	registryFilter := c.Registry.GetDomainFilter()
+	minTTL, maxTTL := c.Registry.TTLLimits()
 
 	plan := &plan.Plan{
-		Policies:       []plan.Policy{c.Policy},
-		Current:        regRecords,
-		Desired:        endpoints,
-		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
-		ManagedRecords: c.ManagedRecordTypes,
-		ExcludeRecords: c.ExcludeRecordTypes,
-		OwnerID:        c.Registry.OwnerID(),
+		Policies:                 []plan.Policy{c.Policy},
+		Current:                  regRecords,
+		Desired:                  endpoints,
+		DomainFilter:             endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
+		ManagedRecords:           c.ManagedRecordTypes,
+		ExcludeRecords:           c.ExcludeRecordTypes,
+		SupportedRecords:         c.Registry.SupportedRecordTypes(),
+		MinTTL:                   minTTL,
+		MaxTTL:                   maxTTL,
+		OwnerID:                  c.Registry.OwnerID(),
+		RecordTypeReplacement:    c.RecordTypeReplacement,
+		AllowApexSOANS:           c.AllowApexSOANS,
+		ZoneFilter:               registryFilter,
+		RecordTypePriority:       c.RecordTypePriority,
+		MaxDeletions:             c.MaxDeletionsPerRun,
+		ExternalRecords:          c.ExternalRecords,
+		ExternalRecordsTXTMarker: c.ExternalRecordsTXTMarker,
 	}
 
 	plan = plan.Calculate()
+	deferredDeletions.Gauge.Set(float64(len(plan.DeferredDeletes)))
+	if len(plan.DeferredDeletes) > 0 {
+		log.Infof("Deferring the deletion of %d records to the next reconciliations, at most %d records are deleted per run", len(plan.DeferredDeletes), c.MaxDeletionsPerRun)
+	}
+	plan.Changes.Delete = withoutInvalidObjectRecords(plan.Changes.Delete, invalidObjects)
 
 	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
+		c.logUpdateDiffs(plan.Changes)
+		err = c.applyChanges(ctx, plan.Changes)
 		if err != nil {
 			registryErrorsTotal.Counter.Inc()
 			deprecatedRegistryErrors.Counter.Inc()
@@ -255,6 +332,73 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	return nil
 }
 
+// invalidSourceObjects returns the invalid objects skipped by the sources reported by err, logging them.
+func invalidSourceObjects(err error) []*source.InvalidObjectError {
+	var invalid *source.InvalidObjectsError
+	if !errors.As(err, &invalid) {
+		return nil
+	}
+	for _, objectErr := range invalid.Errors {
+		log.WithField("resource", objectErr.Resource).Warnf("Skipping invalid object: %v", objectErr.Err)
+	}
+	return invalid.Errors
+}
+
+// withoutInvalidObjectRecords returns the records but the ones of the invalid objects skipped by the sources,
+// which are kept until the objects are fixed or deleted.
+func withoutInvalidObjectRecords(records []*endpoint.Endpoint, invalidObjects []*source.InvalidObjectError) []*endpoint.Endpoint {
+	if len(invalidObjects) == 0 {
+		return records
+	}
+	resources := make(map[string]struct{}, len(invalidObjects))
+	for _, objectErr := range invalidObjects {
+		resources[objectErr.Resource] = struct{}{}
+	}
+	result := make([]*endpoint.Endpoint, 0, len(records))
+	for _, ep := range records {
+		if _, ok := resources[ep.Labels[endpoint.ResourceLabelKey]]; ok {
+			log.Debugf("Keeping record %s %s of the invalid object %s", ep.RecordType, ep.DNSName, ep.Labels[endpoint.ResourceLabelKey])
+			continue
+		}
+		result = append(result, ep)
+	}
+	return result
+}
+
+// logUpdateDiffs logs what changed in each updated record: the added and removed targets, the TTL
+// and the provider specific properties.
+func (c *Controller) logUpdateDiffs(changes *plan.Changes) {
+	level := log.DebugLevel
+	if c.DryRun {
+		level = log.InfoLevel
+	}
+	if !log.IsLevelEnabled(level) {
+		return
+	}
+	for _, diff := range changes.UpdateDiffs() {
+		log.StandardLogger().Logf(level, "Planned update of %s", diff)
+	}
+}
+
+// applyChanges applies the changes to the registry, in two batches when the deletes are applied
+// after the creates and updates, for the providers that don't apply a batch atomically.
+func (c *Controller) applyChanges(ctx context.Context, changes *plan.Changes) error {
+	if !c.DeleteAfterCreate {
+		return c.Registry.ApplyChanges(ctx, changes)
+	}
+
+	changes, deletes := changes.SplitDeletes()
+	for _, batch := range []*plan.Changes{changes, deletes} {
+		if !batch.HasChanges() {
+			continue
+		}
+		if err := c.Registry.ApplyChanges(ctx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func earliest(r time.Time, times ...time.Time) time.Time {
 	for _, t := range times {
 		if t.Before(r) {
@@ -320,6 +464,7 @@ func (c *Controller) ScheduleRunOnce(now time.Time) {
 			now.Add(5*time.Second),
 			c.nextRunAt,
 		),
+		c.backoffUntil,
 	)
 }
 
@@ -333,6 +478,41 @@ func (c *Controller) ShouldRunOnce(now time.Time) bool {
 	return true
 }
 
+// recordFailure counts a soft error and, once the circuit breaker is open, postpones the
+// next reconciliation by the backoff, including the ones triggered by events.
+func (c *Controller) recordFailure(now time.Time) {
+	c.breaker.threshold = c.FailureThreshold
+	c.breaker.baseDelay = c.Interval
+	c.breaker.maxBackoff = c.MaxBackoff
+
+	backoff := c.breaker.failure()
+	if backoff == 0 {
+		return
+	}
+
+	c.runAtMutex.Lock()
+	c.backoffUntil = now.Add(backoff)
+	c.nextRunAt = c.backoffUntil
+	c.runAtMutex.Unlock()
+
+	circuitBreakerOpen.SetWithLabels(1, c.ProviderName)
+	log.Warnf("Circuit breaker open after %d consecutive soft errors, backing off for %s", c.breaker.failures, backoff)
+}
+
+// recordSuccess closes the circuit breaker.
+func (c *Controller) recordSuccess() {
+	if c.breaker.open() {
+		log.Infof("Circuit breaker closed after %d consecutive soft errors", c.breaker.failures)
+	}
+	c.breaker.success()
+
+	c.runAtMutex.Lock()
+	c.backoffUntil = time.Time{}
+	c.runAtMutex.Unlock()
+
+	circuitBreakerOpen.SetWithLabels(0, c.ProviderName)
+}
+
 // Run runs RunOnce in a loop with a delay until context is canceled
 func (c *Controller) Run(ctx context.Context) {
 	ticker := time.NewTicker(time.Second)
@@ -345,6 +525,7 @@ func (c *Controller) Run(ctx context.Context) {
 					softErrorCount++
 					consecutiveSoftErrors.Gauge.Set(float64(softErrorCount))
 					log.Errorf("Failed to do run once: %v (consecutive soft errors: %d)", err, softErrorCount)
+					c.recordFailure(time.Now())
 				} else {
 					log.Fatalf("Failed to do run once: %v", err)
 				}
@@ -354,6 +535,7 @@ func (c *Controller) Run(ctx context.Context) {
 				}
 				softErrorCount = 0
 				consecutiveSoftErrors.Gauge.Set(0)
+				c.recordSuccess()
 			}
 		}
 		select {
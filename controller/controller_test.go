@@ -30,8 +30,13 @@ import (
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
 	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/wrappers"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -360,6 +365,356 @@ func testControllerFiltersDomains(t *testing.T, configuredEndpoints []*endpoint.
 	}
 }
 
+func TestControllerDeleteAfterCreate(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		deleteAfterCreate bool
+		expectedChanges   []*plan.Changes
+	}{
+		{
+			name: "single batch",
+			expectedChanges: []*plan.Changes{{
+				Create: []*endpoint.Endpoint{{DNSName: "new.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}}},
+				Delete: []*endpoint.Endpoint{{DNSName: "old.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}}},
+			}},
+		},
+		{
+			name:              "deletes after creates",
+			deleteAfterCreate: true,
+			expectedChanges: []*plan.Changes{
+				{
+					Create: []*endpoint.Endpoint{{DNSName: "new.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}}},
+				},
+				{
+					Delete: []*endpoint.Endpoint{{DNSName: "old.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}}},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			source := new(testutils.MockSource)
+			source.On("Endpoints").Return([]*endpoint.Endpoint{
+				{DNSName: "new.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}},
+			}, nil)
+
+			provider := &filteredMockProvider{
+				RecordsStore: []*endpoint.Endpoint{
+					{DNSName: "old.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+				},
+			}
+			r, err := registry.NewNoopRegistry(provider)
+			require.NoError(t, err)
+
+			ctrl := &Controller{
+				Source:             source,
+				Registry:           r,
+				Policy:             &plan.SyncPolicy{},
+				DomainFilter:       endpoint.NewDomainFilter([]string{"example.org"}),
+				ManagedRecordTypes: []string{endpoint.RecordTypeA},
+				DeleteAfterCreate:  tc.deleteAfterCreate,
+			}
+
+			require.NoError(t, ctrl.RunOnce(context.Background()))
+			require.Len(t, provider.ApplyChangesCalls, len(tc.expectedChanges))
+			for i, changes := range tc.expectedChanges {
+				assert.Equal(t, changes, provider.ApplyChangesCalls[i])
+			}
+		})
+	}
+}
+
+// noSRVMockProvider is a filteredMockProvider that doesn't support SRV records and fails to apply them.
+type noSRVMockProvider struct {
+	filteredMockProvider
+}
+
+func (p *noSRVMockProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+}
+
+func (p *noSRVMockProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	for _, ep := range changes.Create {
+		if ep.RecordType == endpoint.RecordTypeSRV {
+			return errors.New("SRV records are not supported")
+		}
+	}
+	return p.filteredMockProvider.ApplyChanges(ctx, changes)
+}
+
+func TestControllerSkipsUnsupportedRecordTypes(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "_sip._tcp.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"10 5 5060 sip.example.org"}},
+	}, nil)
+
+	provider := &noSRVMockProvider{}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeSRV},
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	assert.Equal(t, []*endpoint.Endpoint{
+		{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+	}, provider.ApplyChangesCalls[0].Create)
+}
+
+// maxTTLMockProvider is a filteredMockProvider that doesn't accept TTLs higher than an hour.
+type maxTTLMockProvider struct {
+	filteredMockProvider
+}
+
+func (p *maxTTLMockProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return 0, 3600
+}
+
+func TestControllerClampsTTLs(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}, RecordTTL: 86400},
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}, RecordTTL: 300},
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.3"}},
+	}, nil)
+
+	provider := &maxTTLMockProvider{}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}, RecordTTL: 3600},
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}, RecordTTL: 300},
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.3"}},
+	}, provider.ApplyChangesCalls[0].Create)
+}
+
+// recordsSource returns no endpoints and keeps the records of the reconciliation passed in its context.
+type recordsSource struct {
+	records []*endpoint.Endpoint
+}
+
+func (s *recordsSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	s.records, _ = ctx.Value(provider.RecordsContextKey).([]*endpoint.Endpoint)
+	return nil, nil
+}
+
+func (s *recordsSource) AddEventHandler(context.Context, func()) {}
+
+func TestControllerPassesRecordsToSources(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1").WithLabel(endpoint.ResourceLabelKey, "crd/default/app"),
+	}}))
+
+	src := &recordsSource{}
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           r,
+		Policy:             &plan.UpsertOnlyPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	// the sources see the records of the registry with their resource, e.g. to remove the finalizers
+	require.Len(t, src.records, 1)
+	assert.Equal(t, "crd/default/app", src.records[0].Labels[endpoint.ResourceLabelKey])
+}
+
+func TestControllerKeepsDeleteProtectedRecords(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeMX}, nil, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	run := func(endpoints []*endpoint.Endpoint) {
+		source := new(testutils.MockSource)
+		source.On("Endpoints").Return(endpoints, nil)
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeMX},
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+	}
+
+	run([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org").
+			WithLabel(endpoint.DeleteProtectionLabelKey, "true"),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1"),
+	})
+	// the source of the records disappears
+	run([]*endpoint.Endpoint{})
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	var names []string
+	for _, record := range records {
+		names = append(names, record.RecordType+" "+record.DNSName)
+	}
+	assert.ElementsMatch(t, []string{"MX example.org", "TXT mx-example.org"}, names)
+}
+
+func TestControllerKeepsRecordsOfInvalidObjects(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	run := func(endpoints []*endpoint.Endpoint, sourceErr error) {
+		src := new(testutils.MockSource)
+		src.On("Endpoints").Return(endpoints, sourceErr)
+		ctrl := &Controller{
+			Source:             src,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+	}
+
+	run([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/app"),
+		endpoint.NewEndpoint("db.example.org", endpoint.RecordTypeA, "192.0.2.2").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/db"),
+	}, nil)
+	// the object of app.example.org becomes invalid while a new one is added
+	run([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("db.example.org", endpoint.RecordTypeA, "192.0.2.2").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/db"),
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "192.0.2.3").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/web"),
+	}, &source.InvalidObjectsError{Errors: []*source.InvalidObjectError{
+		{Resource: "crd/default/app", Err: errors.New("illegal target")},
+	}})
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	var names []string
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeA {
+			names = append(names, record.DNSName)
+		}
+	}
+	assert.ElementsMatch(t, []string{"app.example.org", "db.example.org", "web.example.org"}, names)
+}
+
+func TestControllerDefersDeletionsOverTheMaximum(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	run := func(endpoints []*endpoint.Endpoint) []string {
+		src := new(testutils.MockSource)
+		src.On("Endpoints").Return(endpoints, nil)
+		ctrl := &Controller{
+			Source:             src,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+			MaxDeletionsPerRun: 2,
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+
+		records, err := p.Records(context.Background())
+		require.NoError(t, err)
+		var names []string
+		for _, record := range records {
+			if record.RecordType == endpoint.RecordTypeA {
+				names = append(names, record.DNSName)
+			}
+		}
+		return names
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		endpoints = append(endpoints, endpoint.NewEndpoint(name+".example.org", endpoint.RecordTypeA, "192.0.2.1"))
+	}
+	assert.Len(t, run(endpoints), 5)
+
+	// the sources of the records disappear, they are deleted over several runs
+	assert.ElementsMatch(t, []string{"c.example.org", "d.example.org", "e.example.org"}, run(nil))
+	assert.ElementsMatch(t, []string{"e.example.org"}, run(nil))
+	assert.Empty(t, run(nil))
+}
+
+func TestControllerRoutesEndpointsToProviders(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "db.cluster.local", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+		{
+			DNSName:          "internal.example.org",
+			RecordType:       endpoint.RecordTypeA,
+			Targets:          endpoint.Targets{"10.0.0.2"},
+			ProviderSpecific: endpoint.ProviderSpecific{{Name: annotations.ProviderKey, Value: "coredns"}},
+		},
+	}, nil)
+
+	router, err := wrappers.NewProviderRouter("aws", []string{"coredns"}, []string{"coredns=cluster.local"})
+	require.NoError(t, err)
+
+	providers := map[string]*filteredMockProvider{
+		"aws":     {},
+		"coredns": {},
+	}
+	for name, p := range providers {
+		r, err := registry.NewNoopRegistry(p)
+		require.NoError(t, err)
+
+		ctrl := &Controller{
+			Source:             wrappers.NewProviderRouteSource(source, router, name),
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			DomainFilter:       endpoint.NewDomainFilter(nil),
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+	}
+
+	require.Len(t, providers["aws"].ApplyChangesCalls, 1)
+	assert.Equal(t, []*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+	}, providers["aws"].ApplyChangesCalls[0].Create)
+
+	require.Len(t, providers["coredns"].ApplyChangesCalls, 1)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		{DNSName: "db.cluster.local", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+		{DNSName: "internal.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.2"}, ProviderSpecific: endpoint.ProviderSpecific{}},
+	}, providers["coredns"].ApplyChangesCalls[0].Create)
+}
+
+func TestControllerLogsUpdateDiffs(t *testing.T) {
+	changes := &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 300, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 600, "1.2.3.4")},
+	}
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	(&Controller{}).logUpdateDiffs(changes)
+	testutils.TestHelperLogNotContains("Planned update of app.example.org A: ttl 300 -> 600", hook, t)
+
+	(&Controller{DryRun: true}).logUpdateDiffs(changes)
+	testutils.TestHelperLogContains("Planned update of app.example.org A: ttl 300 -> 600", hook, t)
+}
+
 func TestControllerSkipsEmptyChanges(t *testing.T) {
 	testControllerFiltersDomains(
 		t,
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// watchCredentialsSecret calls onChange each time the data of the secret, given as <namespace>/<name>, changes.
+func watchCredentialsSecret(ctx context.Context, kubeClient kubernetes.Interface, secret string, onChange func(*corev1.Secret)) error {
+	namespace, name, _ := strings.Cut(secret, "/")
+	if namespace == "" || name == "" {
+		return fmt.Errorf("invalid credentials secret %q, expected format: <namespace>/<name>", secret)
+	}
+
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0,
+		kubeinformers.WithNamespace(namespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+	secretInformer := informerFactory.Core().V1().Secrets()
+	_, err := secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if s, ok := obj.(*corev1.Secret); ok && s.Name == name && !isInInitialList {
+				log.Infof("Credentials secret %s created", secret)
+				onChange(s)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, ok := oldObj.(*corev1.Secret)
+			if !ok {
+				return
+			}
+			newSecret, ok := newObj.(*corev1.Secret)
+			if !ok || newSecret.Name != name || reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+				return
+			}
+			log.Infof("Credentials secret %s changed", secret)
+			onChange(newSecret)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	informerFactory.Start(ctx.Done())
+
+	return informers.WaitForCacheSync(ctx, informerFactory)
+}
+
+// reloadCredentials returns the handler of the credentials secret changes, reloading the provider
+// and scheduling a reconciliation of the controllers. The environment variables named after the keys
+// of the secret, e.g. set with envFrom, are updated before the reload.
+func reloadCredentials(p *provider.ReloadableProvider, controllers []*Controller) func(*corev1.Secret) {
+	return func(secret *corev1.Secret) {
+		for key, value := range secret.Data {
+			if _, ok := os.LookupEnv(key); !ok {
+				continue
+			}
+			if err := os.Setenv(key, string(value)); err != nil {
+				log.Warnf("Failed to update the environment variable %s: %v", key, err)
+			}
+		}
+		if err := p.Reload(); err != nil {
+			log.Errorf("Failed to reload the provider with the new credentials: %v", err)
+			return
+		}
+		for _, ctrl := range controllers {
+			ctrl.ScheduleRunOnce(time.Now())
+		}
+	}
+}
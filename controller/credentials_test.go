@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func TestWatchCredentialsSecret(t *testing.T) {
+	kubeClient := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "credentials"},
+		Data:       map[string][]byte{"EXTERNAL_DNS_TEST_TOKEN": []byte("old"), "EXTERNAL_DNS_TEST_UNSET": []byte("old")},
+	})
+	t.Setenv("EXTERNAL_DNS_TEST_TOKEN", "old")
+
+	var builds atomic.Int32
+	var token string
+	reloadable := provider.NewReloadableProvider(&MockProvider{}, func() (provider.Provider, error) {
+		token = os.Getenv("EXTERNAL_DNS_TEST_TOKEN")
+		builds.Add(1)
+		return &MockProvider{}, nil
+	})
+	nextRunAt := time.Now().Add(time.Hour)
+	ctrl := &Controller{nextRunAt: nextRunAt}
+	require.NoError(t, watchCredentialsSecret(t.Context(), kubeClient, "external-dns/credentials", reloadCredentials(reloadable, []*Controller{ctrl})))
+
+	// the initial list and the changes of the other fields do not reload the provider
+	_, err := kubeClient.CoreV1().Secrets("external-dns").Update(t.Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "credentials", Labels: map[string]string{"rotated": "false"}},
+		Data:       map[string][]byte{"EXTERNAL_DNS_TEST_TOKEN": []byte("old"), "EXTERNAL_DNS_TEST_UNSET": []byte("old")},
+	}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Never(t, func() bool { return builds.Load() > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+	assert.Equal(t, nextRunAt, ctrl.nextRunAt)
+
+	_, err = kubeClient.CoreV1().Secrets("external-dns").Update(t.Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "credentials"},
+		Data:       map[string][]byte{"EXTERNAL_DNS_TEST_TOKEN": []byte("new"), "EXTERNAL_DNS_TEST_UNSET": []byte("new")},
+	}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	// the reconciliation is scheduled after the reload
+	require.Eventually(t, func() bool {
+		ctrl.runAtMutex.Lock()
+		defer ctrl.runAtMutex.Unlock()
+		return ctrl.nextRunAt.Before(nextRunAt)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), builds.Load())
+	assert.Equal(t, "new", token)
+	_, ok := os.LookupEnv("EXTERNAL_DNS_TEST_UNSET")
+	assert.False(t, ok, "only the environment variables already set are updated")
+}
+
+func TestWatchCredentialsSecretInvalid(t *testing.T) {
+	err := watchCredentialsSecret(t.Context(), fake.NewClientset(), "credentials", func(*corev1.Secret) {})
+	require.EqualError(t, err, `invalid credentials secret "credentials", expected format: <namespace>/<name>`)
+}
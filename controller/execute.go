@@ -19,9 +19,14 @@ package controller
 import (
 	"context"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -70,6 +75,7 @@ import (
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
 	"sigs.k8s.io/external-dns/registry"
 	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 func Execute() {
@@ -99,7 +105,7 @@ func Execute() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go serveMetrics(cfg.MetricsAddress)
+	go serveMetrics(cfg.MetricsAddress, cfg.LogLevelEndpoint)
 	go handleSigterm(cancel)
 
 	endpointsSource, err := buildSource(ctx, cfg)
@@ -114,34 +120,106 @@ func Execute() {
 		log.Fatal(err)
 	}
 
+	var reloadable *provider.ReloadableProvider
+	if cfg.ReconcileOnSecretChange != "" {
+		reloadable = provider.NewReloadableProvider(prvdr, func() (provider.Provider, error) {
+			return buildProvider(ctx, cfg, domainFilter)
+		})
+		prvdr = reloadable
+	}
+
 	if cfg.WebhookServer {
 		webhookapi.StartHTTPApi(prvdr, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
 		os.Exit(0)
 	}
 
-	ctrl, err := buildController(cfg, endpointsSource, prvdr, domainFilter)
+	controllers, err := buildControllers(ctx, cfg, endpointsSource, prvdr, domainFilter)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if cfg.CleanupOrphans {
+		for _, ctrl := range controllers {
+			if _, err := ctrl.CleanupOrphans(ctx, cfg.CleanupOrphansConfirm); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		os.Exit(0)
+	}
+
 	if cfg.Once {
-		err := ctrl.RunOnce(ctx)
+		for _, ctrl := range controllers {
+			err := ctrl.RunOnce(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		os.Exit(0)
+	}
+
+	if reloadable != nil {
+		kubeClient, err := (&source.SingletonClientGenerator{
+			KubeConfig:   cfg.KubeConfig,
+			APIServerURL: cfg.APIServerURL,
+		}).KubeClient()
 		if err != nil {
 			log.Fatal(err)
 		}
+		if err := watchCredentialsSecret(ctx, kubeClient, cfg.ReconcileOnSecretChange, reloadCredentials(reloadable, controllers)); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-		os.Exit(0)
+	var wg sync.WaitGroup
+	for _, ctrl := range controllers {
+		if cfg.UpdateEvents {
+			// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
+			// Note that k8s Informers will perform an initial list operation, which results in the handler
+			// function initially being called for every Service/Ingress that exists
+			ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
+		}
+
+		ctrl.ScheduleRunOnce(time.Now())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctrl.Run(ctx)
+		}()
 	}
+	wg.Wait()
+}
 
-	if cfg.UpdateEvents {
-		// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
-		// Note that k8s Informers will perform an initial list operation, which results in the handler
-		// function initially being called for every Service/Ingress that exists
-		ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
+// buildControllers creates one controller per provider: one for the provider and one for
+// each additional provider, each with its own registry. Every controller only receives the
+// endpoints routed to its provider by the provider annotation or the provider domains.
+func buildControllers(ctx context.Context, cfg *externaldns.Config, src source.Source, p provider.Provider, filter *endpoint.DomainFilter) ([]*Controller, error) {
+	router, err := wrappers.NewProviderRouter(cfg.Provider, cfg.AdditionalProviders, cfg.ProviderDomains)
+	if err != nil {
+		return nil, err
 	}
 
-	ctrl.ScheduleRunOnce(time.Now())
-	ctrl.Run(ctx)
+	controllers := make([]*Controller, 0, len(router.Providers()))
+	for _, name := range router.Providers() {
+		prvdr := p
+		if name != cfg.Provider {
+			providerCfg := *cfg
+			providerCfg.Provider = name
+			prvdr, err = buildProvider(ctx, &providerCfg, filter)
+			if err != nil {
+				return nil, fmt.Errorf("additional provider %s: %w", name, err)
+			}
+		}
+
+		ctrl, err := buildController(cfg, wrappers.NewProviderRouteSource(src, router, name), prvdr, filter)
+		if err != nil {
+			return nil, err
+		}
+		ctrl.ProviderName = name
+		controllers = append(controllers, ctrl)
+	}
+	return controllers, nil
 }
 
 func buildProvider(
@@ -156,6 +234,11 @@ func buildProvider(
 	zoneIDFilter := provider.NewZoneIDFilter(cfg.ZoneIDFilter)
 	zoneTypeFilter := provider.NewZoneTypeFilter(cfg.AWSZoneType)
 	zoneTagFilter := provider.NewZoneTagFilter(cfg.AWSZoneTagFilter)
+	provenance := provider.ProvenanceConfig{
+		Enabled:     cfg.RecordProvenance,
+		OwnerID:     cfg.TXTOwnerID,
+		ClusterName: cfg.RecordProvenanceClusterName,
+	}
 
 	switch cfg.Provider {
 	case "akamai":
@@ -170,6 +253,7 @@ func buildProvider(
 				EdgercPath:            cfg.AkamaiEdgercPath,
 				EdgercSection:         cfg.AkamaiEdgercSection,
 				DryRun:                cfg.DryRun,
+				ZoneContracts:         cfg.AkamaiZoneContracts,
 			}, nil)
 	case "alibabacloud":
 		p, err = alibabacloud.NewAlibabaCloudProvider(cfg.AlibabaCloudConfigFile, domainFilter, zoneIDFilter, cfg.AlibabaCloudZoneType, cfg.DryRun)
@@ -193,6 +277,7 @@ func buildProvider(
 				BatchChangeInterval:   cfg.AWSBatchChangeInterval,
 				EvaluateTargetHealth:  cfg.AWSEvaluateTargetHealth,
 				PreferCNAME:           cfg.AWSPreferCNAME,
+				PreferCNAMEDomains:    cfg.AWSPreferCNAMEDomains,
 				DryRun:                cfg.DryRun,
 				ZoneCacheDuration:     cfg.AWSZoneCacheDuration,
 			},
@@ -204,7 +289,7 @@ func buildProvider(
 			log.Infof("Registry \"%s\" cannot be used with AWS Cloud Map. Switching to \"aws-sd\".", cfg.Registry)
 			cfg.Registry = "aws-sd"
 		}
-		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, sd.NewFromConfig(aws.CreateDefaultV2Config(cfg)))
+		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, cfg.AWSSDServiceTTL, cfg.AWSSDRoutingPolicy, sd.NewFromConfig(aws.CreateDefaultV2Config(cfg)))
 	case "azure-dns", "azure":
 		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun)
 	case "azure-private-dns":
@@ -227,15 +312,19 @@ func buildProvider(
 				CertificateAuthority: cfg.CloudflareCustomHostnamesCertificateAuthority,
 			},
 			cloudflare.DNSRecordsConfig{
-				PerPage: cfg.CloudflareDNSRecordsPerPage,
-				Comment: cfg.CloudflareDNSRecordsComment,
+				PerPage:    cfg.CloudflareDNSRecordsPerPage,
+				Comment:    cfg.CloudflareDNSRecordsComment,
+				Provenance: provenance,
+			},
+			cloudflare.LoadBalancersConfig{
+				Enabled: cfg.CloudflareLoadBalancers,
 			})
 	case "google":
-		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
+		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun, cfg.GoogleContinueOnError)
 	case "digitalocean":
 		p, err = digitalocean.NewDigitalOceanProvider(ctx, domainFilter, cfg.DryRun, cfg.DigitalOceanAPIPageSize)
 	case "ovh":
-		p, err = ovh.NewOVHProvider(ctx, domainFilter, cfg.OVHEndpoint, cfg.OVHApiRateLimit, cfg.OVHEnableCNAMERelative, cfg.DryRun)
+		p, err = ovh.NewOVHProvider(ctx, domainFilter, cfg.OVHEndpoint, cfg.OVHApiRateLimit, cfg.OVHEnableCNAMERelative, cfg.OVHUseZoneExport, cfg.DryRun)
 	case "linode":
 		p, err = linode.NewLinodeProvider(domainFilter, cfg.DryRun)
 	case "dnsimple":
@@ -253,7 +342,13 @@ func buildProvider(
 			exoscale.ExoscaleWithLogging(),
 		)
 	case "inmemory":
-		p, err = inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones(cfg.InMemoryZones), inmemory.InMemoryWithDomain(domainFilter), inmemory.InMemoryWithLogging()), nil
+		p, err = inmemory.NewInMemoryProvider(
+			inmemory.InMemoryInitZones(cfg.InMemoryZones),
+			inmemory.InMemoryWithDomain(domainFilter),
+			inmemory.InMemoryWithLogging(),
+			inmemory.InMemoryWithDefaultTTL(endpoint.TTL(cfg.InMemoryDefaultTTL)),
+			inmemory.InMemoryWithTTLLimits(endpoint.TTL(cfg.InMemoryMinTTL), endpoint.TTL(cfg.InMemoryMaxTTL)),
+		), nil
 	case "pdns":
 		p, err = pdns.NewPDNSProvider(
 			ctx,
@@ -269,6 +364,10 @@ func buildProvider(
 					ClientCertFilePath:    cfg.TLSClientCert,
 					ClientCertKeyFilePath: cfg.TLSClientCertKey,
 				},
+				Provenance:      provenance,
+				CreateZones:     cfg.PDNSCreateZones,
+				ZoneNameservers: cfg.PDNSZoneNameservers,
+				ZoneSOA:         cfg.PDNSZoneSOA,
 			},
 		)
 	case "oci":
@@ -314,7 +413,7 @@ func buildProvider(
 	case "scaleway":
 		p, err = scaleway.NewScalewayProvider(ctx, domainFilter, cfg.DryRun)
 	case "godaddy":
-		p, err = godaddy.NewGoDaddyProvider(ctx, domainFilter, cfg.GoDaddyTTL, cfg.GoDaddyAPIKey, cfg.GoDaddySecretKey, cfg.GoDaddyOTE, cfg.DryRun)
+		p, err = godaddy.NewGoDaddyProvider(ctx, domainFilter, cfg.GoDaddyTTL, cfg.GoDaddyAPIKey, cfg.GoDaddySecretKey, cfg.GoDaddyAPIRateLimit, cfg.GoDaddyOTE, cfg.DryRun)
 	case "gandi":
 		p, err = gandi.NewGandiProvider(ctx, domainFilter, cfg.DryRun)
 	case "pihole":
@@ -331,7 +430,7 @@ func buildProvider(
 	case "plural":
 		p, err = plural.NewPluralProvider(cfg.PluralCluster, cfg.PluralProvider)
 	case "webhook":
-		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL)
+		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL, cfg.WebhookProviderMaxAttempts, cfg.WebhookProviderRetryBaseDelay)
 	default:
 		err = fmt.Errorf("unknown dns provider: %s", cfg.Provider)
 	}
@@ -353,18 +452,58 @@ func buildController(cfg *externaldns.Config, src source.Source, p provider.Prov
 	if err != nil {
 		return nil, err
 	}
+	defaults, err := parseProviderSpecificDefaults(cfg.ProviderSpecificDefaults)
+	if err != nil {
+		return nil, err
+	}
+	var externalRecords *regexp.Regexp
+	if cfg.ExternalRecordsRegex != nil && cfg.ExternalRecordsRegex.String() != "" {
+		externalRecords = cfg.ExternalRecordsRegex
+	}
 	return &Controller{
-		Source:               src,
-		Registry:             reg,
-		Policy:               policy,
-		Interval:             cfg.Interval,
-		DomainFilter:         filter,
-		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
-		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
-		MinEventSyncInterval: cfg.MinEventSyncInterval,
+		Source:                   src,
+		Registry:                 reg,
+		Policy:                   policy,
+		Interval:                 cfg.Interval,
+		DomainFilter:             filter,
+		ManagedRecordTypes:       cfg.ManagedDNSRecordTypes,
+		ExcludeRecordTypes:       cfg.ExcludeDNSRecordTypes,
+		MinEventSyncInterval:     cfg.MinEventSyncInterval,
+		RecordTypeReplacement:    cfg.RecordTypeReplacement,
+		DeleteAfterCreate:        cfg.DeleteAfterCreate,
+		MaxDeletionsPerRun:       cfg.MaxDeletionsPerRun,
+		AllowApexSOANS:           cfg.AllowApexSOANS,
+		ExternalRecords:          externalRecords,
+		RecordTypePriority:       cfg.RecordTypePriority,
+		FailureThreshold:         cfg.ProviderFailureThreshold,
+		MaxBackoff:               cfg.ProviderMaxBackoff,
+		ProviderName:             cfg.Provider,
+		ProviderDefaults:         defaults,
+		DryRun:                   cfg.DryRun,
+		ExternalRecordsTXTMarker: cfg.ExternalRecordsTXTMarker,
 	}, nil
 }
 
+// parseProviderSpecificDefaults parses the provider specific defaults in the format <domain>=<annotation>=<value>,
+// mapping the annotations to provider specific properties as the sources do.
+func parseProviderSpecificDefaults(values []string) ([]plan.ProviderSpecificDefault, error) {
+	defaults := make([]plan.ProviderSpecificDefault, 0, len(values))
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid provider specific default %q, expected format: <domain>=<annotation>=<value>", value)
+		}
+		properties, _ := annotations.ProviderSpecificAnnotations(map[string]string{parts[1]: parts[2]})
+		if len(properties) == 0 {
+			return nil, fmt.Errorf("invalid provider specific default %q, %s is not a provider specific annotation", value, parts[1])
+		}
+		for _, property := range properties {
+			defaults = append(defaults, plan.ProviderSpecificDefault{Domain: parts[0], Property: property})
+		}
+	}
+	return defaults, nil
+}
+
 // This function configures the logger format and level based on the provided configuration.
 func configureLogger(cfg *externaldns.Config) {
 	if cfg.LogFormat == "json" {
@@ -397,9 +536,14 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 	case "noop":
 		r, err = registry.NewNoopRegistry(p)
 	case "txt":
-		r, err = registry.NewTXTRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID, cfg.TXTCacheInterval, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey))
+		r, err = registry.NewTXTRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID, cfg.TXTCacheInterval, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey), cfg.TXTAdoptOwnerIDs, cfg.TXTRepairOwnership, cfg.TXTSkipRecordTypes, cfg.TXTIgnoreForeignRecords)
 	case "aws-sd":
 		r, err = registry.NewAWSSDRegistry(p, cfg.TXTOwnerID)
+	case "comment":
+		if cfg.Provider != "cloudflare" {
+			return nil, fmt.Errorf("the comment registry is not supported by the %s provider", cfg.Provider)
+		}
+		r, err = registry.NewCommentRegistry(p, cfg.TXTOwnerID, annotations.CloudflareRecordCommentKey, cloudflare.MaxCommentLength)
 	default:
 		log.Fatalf("unknown registry: %s", cfg.Registry)
 	}
@@ -411,28 +555,116 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 // deduplicated source. Returns the combined source or an error if source creation fails.
 func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, error) {
 	sourceCfg := source.NewSourceConfig(cfg)
-	sources, err := source.ByNames(ctx, &source.SingletonClientGenerator{
-		KubeConfig:   cfg.KubeConfig,
-		APIServerURL: cfg.APIServerURL,
-		RequestTimeout: func() time.Duration {
-			if cfg.UpdateEvents {
-				return 0
-			}
-			return cfg.RequestTimeout
-		}(),
-	}, cfg.Sources, sourceCfg)
+	clusters, err := sourceClusters(cfg, sourceCfg)
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := parseSourceIntervals(cfg.SourceIntervals)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := buildClusterSources(ctx, cfg.Sources, clusters, intervals)
 	if err != nil {
 		return nil, err
 	}
 	// Combine multiple sources into a single, deduplicated source.
 	combinedSource := wrappers.NewDedupSource(wrappers.NewMultiSource(sources, sourceCfg.DefaultTargets, sourceCfg.ForceDefaultTargets))
+	if cfg.MergeTXTRecords {
+		combinedSource = wrappers.NewTXTMergeSource(combinedSource)
+	}
 	// Filter targets
 	targetFilter := endpoint.NewTargetNetFilterWithExclusions(cfg.TargetNetFilter, cfg.ExcludeTargetNets)
+	if cfg.FlattenMultiTargetCNAME {
+		combinedSource = wrappers.NewCNAMEFlatteningSource(combinedSource, net.DefaultResolver)
+	}
 	combinedSource = wrappers.NewNAT64Source(combinedSource, cfg.NAT64Networks)
 	combinedSource = wrappers.NewTargetFilterSource(combinedSource, targetFilter)
 	return combinedSource, nil
 }
 
+// sourceCluster is a cluster to read the sources from.
+type sourceCluster struct {
+	clientGenerator source.ClientGenerator
+	config          *source.Config
+}
+
+// sourceClusters returns the cluster of the --kubeconfig and the clusters of the --source-cluster flags,
+// in the format <kubeconfig>[=<context>].
+func sourceClusters(cfg *externaldns.Config, sourceCfg *source.Config) ([]sourceCluster, error) {
+	requestTimeout := cfg.RequestTimeout
+	if cfg.UpdateEvents {
+		requestTimeout = 0
+	}
+	clusters := []sourceCluster{{
+		clientGenerator: &source.SingletonClientGenerator{
+			KubeConfig:     cfg.KubeConfig,
+			APIServerURL:   cfg.APIServerURL,
+			RequestTimeout: requestTimeout,
+		},
+		config: sourceCfg,
+	}}
+	for _, value := range cfg.SourceClusters {
+		kubeConfig, kubeContext, _ := strings.Cut(value, "=")
+		if kubeConfig == "" {
+			return nil, fmt.Errorf("invalid source cluster %q, expected format: <kubeconfig>[=<context>]", value)
+		}
+		clusterCfg := *sourceCfg
+		clusterCfg.KubeConfig = kubeConfig
+		clusterCfg.KubeContext = kubeContext
+		clusterCfg.APIServerURL = ""
+		clusters = append(clusters, sourceCluster{
+			clientGenerator: &source.SingletonClientGenerator{
+				KubeConfig:     kubeConfig,
+				KubeContext:    kubeContext,
+				RequestTimeout: requestTimeout,
+			},
+			config: &clusterCfg,
+		})
+	}
+	return clusters, nil
+}
+
+// parseSourceIntervals parses the polling intervals of the sources in the format <source>=<duration>.
+func parseSourceIntervals(values []string) (map[string]time.Duration, error) {
+	intervals := make(map[string]time.Duration, len(values))
+	for _, value := range values {
+		name, duration, _ := strings.Cut(value, "=")
+		interval, err := time.ParseDuration(duration)
+		if name == "" || err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid source interval %q, expected format: <source>=<duration>", value)
+		}
+		intervals[name] = interval
+	}
+	return intervals, nil
+}
+
+// buildClusterSources creates the sources of each cluster, so that their endpoints are combined.
+// The sources with an interval are only polled every interval.
+func buildClusterSources(ctx context.Context, names []string, clusters []sourceCluster, intervals map[string]time.Duration) ([]source.Source, error) {
+	var sources []source.Source
+	for _, cluster := range clusters {
+		clusterSources, err := source.ByNames(ctx, cluster.clientGenerator, names, cluster.config)
+		if err != nil {
+			return nil, err
+		}
+		// Only the sources with events enabled trigger reconciliations on their changes.
+		if cluster.config.UpdateEvents {
+			for i, name := range names {
+				if !cluster.config.UpdateEventsFor(name) {
+					clusterSources[i] = wrappers.NewNoEventsSource(name, clusterSources[i])
+				}
+			}
+		}
+		for i, name := range names {
+			if interval, ok := intervals[name]; ok {
+				clusterSources[i] = wrappers.NewIntervalSource(name, clusterSources[i], interval)
+			}
+		}
+		sources = append(sources, clusterSources...)
+	}
+	return sources, nil
+}
+
 // RegexDomainFilter overrides DomainFilter
 func createDomainFilter(cfg *externaldns.Config) *endpoint.DomainFilter {
 	if cfg.RegexDomainFilter != nil && cfg.RegexDomainFilter.String() != "" {
@@ -455,8 +687,9 @@ func handleSigterm(cancel func()) {
 // serveMetrics starts an HTTP server that serves health and metrics endpoints.
 // The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
 // The /metrics endpoint serves Prometheus metrics.
+// The /loglevel endpoint, when enabled, gets and sets the log level at runtime.
 // The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
+func serveMetrics(address string, logLevelEndpoint bool) {
 	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
@@ -468,5 +701,36 @@ func serveMetrics(address string) {
 
 	http.Handle("/metrics", promhttp.Handler())
 
+	if logLevelEndpoint {
+		log.Debugf("serving 'loglevel' on '%s/loglevel'", address)
+		http.HandleFunc("/loglevel", logLevelHandler)
+	}
+
 	log.Fatal(http.ListenAndServe(address, nil))
 }
+
+// logLevelHandler returns the current log level on GET requests, and sets the log level
+// given in the body of PUT requests, e.g. "curl -X PUT -d debug http://localhost:7979/loglevel".
+func logLevelHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		body, err := io.ReadAll(io.LimitReader(r.Body, 64))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		level, err := log.ParseLevel(strings.TrimSpace(string(body)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Infof("Setting the log level to %s", level)
+		log.SetLevel(level)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	_, _ = w.Write([]byte(log.GetLevel().String()))
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -27,17 +28,32 @@ import (
 	"os/signal"
 	"reflect"
 	"regexp"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
 
+	cfclient "github.com/cloudfoundry-community/go-cfclient"
+	openshift "github.com/openshift/client-go/route/clientset/versioned"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	istioclient "istio.io/client-go/pkg/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/wrappers"
 )
 
 func TestSelectRegistry(t *testing.T) {
@@ -98,6 +114,17 @@ func TestSelectRegistry(t *testing.T) {
 			wantErr:  false,
 			wantType: "AWSSDRegistry",
 		},
+		{
+			name: "Comment registry",
+			cfg: &externaldns.Config{
+				Registry:   "comment",
+				Provider:   "cloudflare",
+				TXTOwnerID: "owner-id",
+			},
+			provider: &MockProvider{},
+			wantErr:  false,
+			wantType: "CommentRegistry",
+		},
 		{
 			name: "Unknown registry",
 			cfg: &externaldns.Config{
@@ -129,6 +156,11 @@ func TestSelectRegistry(t *testing.T) {
 	}
 }
 
+func TestSelectRegistryCommentUnsupportedProvider(t *testing.T) {
+	_, err := selectRegistry(&externaldns.Config{Registry: "comment", Provider: "aws", TXTOwnerID: "owner-id"}, &MockProvider{})
+	require.EqualError(t, err, "the comment registry is not supported by the aws provider")
+}
+
 func TestCreateDomainFilter(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -240,7 +272,7 @@ func TestServeMetrics(t *testing.T) {
 	require.NoError(t, err)
 	addresse := fmt.Sprintf("localhost:%d", port)
 
-	go serveMetrics(fmt.Sprintf(":%d", port))
+	go serveMetrics(fmt.Sprintf(":%d", port), false)
 
 	// Wait for the TCP socket to be ready
 	require.Eventually(t, func() bool {
@@ -261,6 +293,45 @@ func TestServeMetrics(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestLogLevelHandler(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	defer log.SetLevel(log.InfoLevel)
+
+	request := func(method, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		logLevelHandler(rec, httptest.NewRequest(method, "/loglevel", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := request(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "info", rec.Body.String())
+
+	log.Debug("debug message before")
+	testutils.TestHelperLogNotContains("debug message before", hook, t)
+
+	rec = request(http.MethodPut, "debug\n")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "debug", rec.Body.String())
+	assert.Equal(t, log.DebugLevel, log.GetLevel())
+
+	log.Debug("debug message after")
+	testutils.TestHelperLogContainsWithLogLevel("debug message after", log.DebugLevel, hook, t)
+
+	rec = request(http.MethodPut, "error")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	log.Info("info message after")
+	testutils.TestHelperLogNotContains("info message after", hook, t)
+
+	rec = request(http.MethodPut, "verbose")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, log.ErrorLevel, log.GetLevel())
+
+	rec = request(http.MethodPost, "debug")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, log.ErrorLevel, log.GetLevel())
+}
+
 func TestConfigureLogger(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -477,6 +548,138 @@ func TestBuildSource(t *testing.T) {
 	}
 }
 
+// fakeClientGenerator generates the clients of a fake cluster, only the kube client being supported.
+type fakeClientGenerator struct {
+	kubeClient kubernetes.Interface
+}
+
+func (g *fakeClientGenerator) KubeClient() (kubernetes.Interface, error) { return g.kubeClient, nil }
+func (g *fakeClientGenerator) GatewayClient() (gateway.Interface, error) {
+	return nil, errors.New("not supported")
+}
+func (g *fakeClientGenerator) IstioClient() (istioclient.Interface, error) {
+	return nil, errors.New("not supported")
+}
+func (g *fakeClientGenerator) CloudFoundryClient(string, string, string) (*cfclient.Client, error) {
+	return nil, errors.New("not supported")
+}
+func (g *fakeClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
+	return nil, errors.New("not supported")
+}
+func (g *fakeClientGenerator) OpenShiftClient() (openshift.Interface, error) {
+	return nil, errors.New("not supported")
+}
+
+// newFakeCluster returns a cluster with a load balancer service published with the hostname and the IP.
+func newFakeCluster(t *testing.T, sourceCfg *source.Config, hostname, ip string) sourceCluster {
+	t.Helper()
+	kubeClient := fake.NewClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "app",
+			Annotations: map[string]string{annotations.HostnameKey: hostname},
+		},
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: ip}}},
+		},
+	})
+	return sourceCluster{clientGenerator: &fakeClientGenerator{kubeClient: kubeClient}, config: sourceCfg}
+}
+
+func TestBuildClusterSources(t *testing.T) {
+	sourceCfg := source.NewSourceConfig(externaldns.NewConfig())
+	sources, err := buildClusterSources(t.Context(), []string{"service"}, []sourceCluster{
+		newFakeCluster(t, sourceCfg, "app.example.org", "192.0.2.1"),
+		newFakeCluster(t, sourceCfg, "app.eu.example.org", "192.0.2.2"),
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+
+	endpoints, err := wrappers.NewDedupSource(wrappers.NewMultiSource(sources, nil, false)).Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}, Labels: endpoint.Labels{endpoint.ResourceLabelKey: "service/default/app"}, ProviderSpecific: endpoint.ProviderSpecific{}},
+		{DNSName: "app.eu.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}, Labels: endpoint.Labels{endpoint.ResourceLabelKey: "service/default/app"}, ProviderSpecific: endpoint.ProviderSpecific{}},
+	}, endpoints)
+}
+
+func TestParseSourceIntervals(t *testing.T) {
+	intervals, err := parseSourceIntervals([]string{"node=10m", "ingress=30s"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"node": 10 * time.Minute, "ingress": 30 * time.Second}, intervals)
+
+	for _, value := range []string{"node", "node=", "=10m", "node=10", "node=0s", "node=-1m"} {
+		_, err := parseSourceIntervals([]string{value})
+		assert.EqualError(t, err, fmt.Sprintf("invalid source interval %q, expected format: <source>=<duration>", value))
+	}
+}
+
+func TestBuildClusterSourcesWithIntervals(t *testing.T) {
+	sourceCfg := source.NewSourceConfig(externaldns.NewConfig())
+	sources, err := buildClusterSources(t.Context(), []string{"service"}, []sourceCluster{
+		newFakeCluster(t, sourceCfg, "app.example.org", "192.0.2.1"),
+	}, map[string]time.Duration{"service": 10 * time.Minute})
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.IsType(t, wrappers.NewIntervalSource("service", nil, time.Minute), sources[0])
+
+	endpoints, err := sources[0].Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+}
+
+func TestSourceClusters(t *testing.T) {
+	cfg := &externaldns.Config{
+		KubeConfig:     "/main",
+		APIServerURL:   "http://127.0.0.1:8080",
+		SourceClusters: []string{"/other=other-context", "/third"},
+	}
+	sourceCfg := source.NewSourceConfig(cfg)
+	clusters, err := sourceClusters(cfg, sourceCfg)
+	require.NoError(t, err)
+	require.Len(t, clusters, 3)
+
+	assert.Same(t, sourceCfg, clusters[0].config)
+	assert.Equal(t, "/main", clusters[0].clientGenerator.(*source.SingletonClientGenerator).KubeConfig)
+	assert.Equal(t, "http://127.0.0.1:8080", clusters[0].clientGenerator.(*source.SingletonClientGenerator).APIServerURL)
+
+	other := clusters[1].clientGenerator.(*source.SingletonClientGenerator)
+	assert.Equal(t, "/other", other.KubeConfig)
+	assert.Equal(t, "other-context", other.KubeContext)
+	assert.Empty(t, other.APIServerURL)
+	assert.Equal(t, "/other", clusters[1].config.KubeConfig)
+	assert.Equal(t, "other-context", clusters[1].config.KubeContext)
+
+	third := clusters[2].clientGenerator.(*source.SingletonClientGenerator)
+	assert.Equal(t, "/third", third.KubeConfig)
+	assert.Empty(t, third.KubeContext)
+
+	_, err = sourceClusters(&externaldns.Config{SourceClusters: []string{"=context"}}, sourceCfg)
+	require.EqualError(t, err, `invalid source cluster "=context", expected format: <kubeconfig>[=<context>]`)
+}
+
+func TestParseProviderSpecificDefaults(t *testing.T) {
+	defaults, err := parseProviderSpecificDefaults([]string{
+		"internal.example.com=external-dns.alpha.kubernetes.io/cloudflare-proxied=false",
+		"example.org=external-dns.alpha.kubernetes.io/aws-evaluate-target-health=true",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []plan.ProviderSpecificDefault{
+		{Domain: "internal.example.com", Property: endpoint.ProviderSpecificProperty{Name: "external-dns.alpha.kubernetes.io/cloudflare-proxied", Value: "false"}},
+		{Domain: "example.org", Property: endpoint.ProviderSpecificProperty{Name: "aws/evaluate-target-health", Value: "true"}},
+	}, defaults)
+
+	for _, value := range []string{
+		"internal.example.com",
+		"=external-dns.alpha.kubernetes.io/cloudflare-proxied=false",
+		"internal.example.com=external-dns.alpha.kubernetes.io/ttl=60",
+	} {
+		_, err := parseProviderSpecificDefaults([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
 // mocks
 type MockProvider struct{}
 
@@ -495,3 +698,11 @@ func (m *MockProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 func (m *MockProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return nil
 }
+
+func (m *MockProvider) SupportedRecordTypes() []string {
+	return nil
+}
+
+func (m *MockProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return 0, 0
+}
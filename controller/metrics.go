@@ -34,6 +34,8 @@ func newMetricsRecorder() *metricsRecorder {
 			endpoint.RecordTypePTR:   0,
 			endpoint.RecordTypeMX:    0,
 			endpoint.RecordTypeNAPTR: 0,
+			endpoint.RecordTypeSVCB:  0,
+			endpoint.RecordTypeHTTPS: 0,
 		},
 	}
 }
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// CleanupOrphans finds the records owned by the registry owner whose source objects no longer
+// exist, and deletes them when confirmed. Unlike RunOnce, it doesn't depend on the policy nor
+// on the managed record types: every owned record is checked. It returns the orphaned records.
+func (c *Controller) CleanupOrphans(ctx context.Context, confirm bool) ([]*endpoint.Endpoint, error) {
+	records, err := c.Registry.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	desired, err := c.Source.Endpoints(ctx)
+	if err != nil && !source.IsInvalidObjectsError(err) {
+		return nil, err
+	}
+	invalidObjects := invalidSourceObjects(err)
+
+	// the desired endpoints are compared as the provider stores them, e.g. AWS CNAMEs as alias A records
+	desired, err = c.Registry.AdjustEndpoints(plan.ApplyProviderSpecificDefaults(desired, c.ProviderDefaults))
+	if err != nil {
+		return nil, fmt.Errorf("adjusting endpoints: %w", err)
+	}
+
+	filter := endpoint.MatchAllDomainFilters{c.DomainFilter, c.Registry.GetDomainFilter()}
+	orphans := withoutInvalidObjectRecords(findOrphans(records, desired, c.Registry.OwnerID(), filter), invalidObjects)
+
+	for _, ep := range orphans {
+		log.WithField("resource", ep.Labels[endpoint.ResourceLabelKey]).Infof("Found orphaned record %s %s", ep.RecordType, ep.DNSName)
+	}
+
+	if len(orphans) == 0 {
+		log.Info("No orphaned records found")
+		return orphans, nil
+	}
+	if !confirm {
+		log.Infof("Found %d orphaned records, not deleting them without confirmation", len(orphans))
+		return orphans, nil
+	}
+
+	if err := c.Registry.ApplyChanges(ctx, &plan.Changes{Delete: orphans}); err != nil {
+		return nil, fmt.Errorf("deleting orphaned records: %w", err)
+	}
+	log.Infof("Deleted %d orphaned records", len(orphans))
+
+	return orphans, nil
+}
+
+// findOrphans returns the records owned by ownerID that are not desired anymore. A record
+// labeled with its resource is only an orphan when no desired endpoint comes from that
+// resource anymore, so that the records of renamed hosts are left to the regular sync.
+// The records protected from the deletion are never orphans.
+func findOrphans(records, desired []*endpoint.Endpoint, ownerID string, filter endpoint.DomainFilterInterface) []*endpoint.Endpoint {
+	desiredKeys := make(map[endpoint.EndpointKey]struct{}, len(desired))
+	desiredResources := make(map[string]struct{}, len(desired))
+	for _, ep := range desired {
+		desiredKeys[orphanKey(ep)] = struct{}{}
+		if resource, ok := ep.Labels[endpoint.ResourceLabelKey]; ok {
+			desiredResources[resource] = struct{}{}
+		}
+	}
+
+	var orphans []*endpoint.Endpoint
+	for _, ep := range records {
+		if !ep.IsOwnedBy(ownerID) || !filter.Match(ep.DNSName) || ep.Labels[endpoint.DeleteProtectionLabelKey] == "true" {
+			continue
+		}
+		if _, ok := desiredKeys[orphanKey(ep)]; ok {
+			continue
+		}
+		if resource, ok := ep.Labels[endpoint.ResourceLabelKey]; ok && resource != "" {
+			if _, ok := desiredResources[resource]; ok {
+				continue
+			}
+		}
+		orphans = append(orphans, ep)
+	}
+	return orphans
+}
+
+// orphanKey returns the key of the endpoint with its DNS name normalized.
+func orphanKey(ep *endpoint.Endpoint) endpoint.EndpointKey {
+	return endpoint.EndpointKey{
+		DNSName:       strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")),
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestFindOrphans(t *testing.T) {
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("kept.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("deleted.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "owner").WithLabel(endpoint.ResourceLabelKey, "ingress/default/deleted"),
+		endpoint.NewEndpoint("renamed.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "owner").WithLabel(endpoint.ResourceLabelKey, "ingress/default/renamed"),
+		endpoint.NewEndpoint("unlabeled.example.org", endpoint.RecordTypeCNAME, "lb.example.com").
+			WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "other-owner"),
+		endpoint.NewEndpoint("unowned.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("deleted.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("protected.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "owner").WithLabel(endpoint.DeleteProtectionLabelKey, "true"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("Kept.example.org.", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/renamed"),
+	}
+
+	orphans := findOrphans(records, desired, "owner", endpoint.NewDomainFilter([]string{"example.org"}))
+
+	assert.Equal(t, []*endpoint.Endpoint{records[1], records[3]}, orphans)
+}
+
+func TestCleanupOrphans(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		confirm  bool
+		expected []string
+	}{
+		{
+			title:    "not confirmed",
+			expected: []string{"kept.example.org", "orphan.example.org", "other.example.org"},
+		},
+		{
+			title:    "confirmed",
+			confirm:  true,
+			expected: []string{"kept.example.org", "other.example.org"},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			p := inmemory.NewInMemoryProvider()
+			require.NoError(t, p.CreateZone("example.org"))
+
+			for _, owner := range []string{"owner", "other-owner"} {
+				r, err := registry.NewTXTRegistry(p, "", "", owner, 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, nil, false, nil, false)
+				require.NoError(t, err)
+				var records []*endpoint.Endpoint
+				if owner == "owner" {
+					records = []*endpoint.Endpoint{
+						endpoint.NewEndpoint("kept.example.org", endpoint.RecordTypeA, "1.2.3.4").
+							WithLabel(endpoint.ResourceLabelKey, "ingress/default/kept"),
+						endpoint.NewEndpoint("orphan.example.org", endpoint.RecordTypeA, "1.2.3.4").
+							WithLabel(endpoint.ResourceLabelKey, "ingress/default/orphan"),
+					}
+				} else {
+					records = []*endpoint.Endpoint{endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "1.2.3.4")}
+				}
+				require.NoError(t, r.ApplyChanges(t.Context(), &plan.Changes{Create: records}))
+			}
+
+			r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, nil, false, nil, false)
+			require.NoError(t, err)
+
+			source := new(testutils.MockSource)
+			source.On("Endpoints").Return([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("kept.example.org", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "ingress/default/kept"),
+			}, nil)
+
+			ctrl := &Controller{
+				Source:       source,
+				Registry:     r,
+				Policy:       &plan.SyncPolicy{},
+				DomainFilter: endpoint.NewDomainFilter(nil),
+			}
+
+			orphans, err := ctrl.CleanupOrphans(t.Context(), tc.confirm)
+			require.NoError(t, err)
+			require.Len(t, orphans, 1)
+			assert.Equal(t, "orphan.example.org", orphans[0].DNSName)
+
+			records, err := r.Records(t.Context())
+			require.NoError(t, err)
+			names := make([]string, 0, len(records))
+			for _, ep := range records {
+				names = append(names, ep.DNSName)
+			}
+			assert.ElementsMatch(t, tc.expected, names)
+		})
+	}
+}
+
+// aliasProvider stores the CNAME records as alias A records, as the AWS provider does.
+type aliasProvider struct {
+	*inmemory.InMemoryProvider
+}
+
+func (p aliasProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if ep.RecordType == endpoint.RecordTypeCNAME {
+			ep.RecordType = endpoint.RecordTypeA
+			ep.WithProviderSpecific("alias", "true")
+		}
+	}
+	return endpoints, nil
+}
+
+func TestCleanupOrphansAdjustsEndpoints(t *testing.T) {
+	p := aliasProvider{inmemory.NewInMemoryProvider()}
+	require.NoError(t, p.CreateZone("example.org"))
+
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME}, nil, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("alias.example.org", endpoint.RecordTypeA, "lb.example.com").WithProviderSpecific("alias", "true"),
+	}}))
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("alias.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+	}, nil)
+
+	ctrl := &Controller{
+		Source:       source,
+		Registry:     r,
+		Policy:       &plan.SyncPolicy{},
+		DomainFilter: endpoint.NewDomainFilter(nil),
+	}
+
+	orphans, err := ctrl.CleanupOrphans(t.Context(), true)
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+
+	records, err := r.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, endpoint.RecordTypeA, records[0].RecordType)
+}
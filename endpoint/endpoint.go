@@ -46,6 +46,12 @@ const (
 	RecordTypeMX = "MX"
 	// RecordTypeNAPTR is a RecordType enum value
 	RecordTypeNAPTR = "NAPTR"
+	// RecordTypeSOA is a RecordType enum value
+	RecordTypeSOA = "SOA"
+	// RecordTypeSVCB is a RecordType enum value
+	RecordTypeSVCB = "SVCB"
+	// RecordTypeHTTPS is a RecordType enum value
+	RecordTypeHTTPS = "HTTPS"
 )
 
 var (
@@ -58,6 +64,8 @@ var (
 		RecordTypePTR,
 		RecordTypeMX,
 		RecordTypeNAPTR,
+		RecordTypeSVCB,
+		RecordTypeHTTPS,
 	}
 )
 
@@ -411,6 +419,8 @@ func (e *Endpoint) CheckEndpoint() bool {
 		return e.Targets.ValidateMXRecord()
 	case RecordTypeSRV:
 		return e.Targets.ValidateSRVRecord()
+	case RecordTypeSVCB, RecordTypeHTTPS:
+		return e.Targets.ValidateSVCBRecord()
 	}
 	return true
 }
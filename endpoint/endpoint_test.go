@@ -907,6 +907,24 @@ func TestCheckEndpoint(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Valid HTTPS record target",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeHTTPS,
+				Targets:    Targets{"1 . alpn=h2,h3 port=443"},
+			},
+			expected: true,
+		},
+		{
+			description: "Invalid SVCB record target",
+			endpoint: Endpoint{
+				DNSName:    "_dns.example.com",
+				RecordType: RecordTypeSVCB,
+				Targets:    Targets{"1 dns.example.com port=dns"},
+			},
+			expected: false,
+		},
 		{
 			description: "Non-MX/SRV record type",
 			endpoint: Endpoint{
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode"
 )
 
 // ErrInvalidHeritage is returned when heritage was not found, or different heritage is found
@@ -36,6 +37,15 @@ const (
 	ResourceLabelKey = "resource"
 	// OwnedRecordLabelKey is the name of the label that identifies the record that is owned by the labeled TXT registry record
 	OwnedRecordLabelKey = "ownedRecord"
+	// ExcludedLabelKey is the name of the label that marks the endpoints of a resource excluded from the management,
+	// whose records are left untouched by the plan
+	ExcludedLabelKey = "excluded"
+	// DeleteProtectionLabelKey is the name of the label that protects the records of an Endpoint from the deletion,
+	// stored by the registry so that the records are kept once their resource is deleted
+	DeleteProtectionLabelKey = "delete-protection"
+	// DescriptionLabelKey is the name of the label holding the human description of the records of an Endpoint,
+	// used by the providers supporting comments. It is free text, so it is not serialized with the other labels
+	DescriptionLabelKey = "description"
 
 	// AWSSDDescriptionLabel label responsible for storing raw owner/resource combination information in the Labels
 	// supposed to be inserted by AWS SD Provider, and parsed into OwnerLabelKey and ResourceLabelKey key by AWS SD Registry
@@ -87,6 +97,38 @@ func NewLabelsFromStringPlain(labelText string) (Labels, error) {
 	return endpointLabels, nil
 }
 
+// RecoverLabelsFromString constructs endpoints labels from a malformed format string, e.g. after a manual edit:
+// tokens may be separated by commas, semicolons or whitespace, surrounded by quotes, the heritage may be
+// missing or differ in case. The labels are only recovered when they include an owner,
+// a heritage set to another value returns invalidHeritage error.
+func RecoverLabelsFromString(labelText string) (Labels, error) {
+	endpointLabels := map[string]string{}
+	tokens := strings.FieldsFunc(labelText, func(r rune) bool {
+		return r == ',' || r == ';' || r == '"' || unicode.IsSpace(r)
+	})
+	for _, token := range tokens {
+		key, val, ok := strings.Cut(token, "=")
+		if !ok || key == "" || val == "" {
+			continue
+		}
+		if strings.EqualFold(key, "heritage") {
+			if !strings.EqualFold(val, heritage) {
+				return nil, ErrInvalidHeritage
+			}
+			continue
+		}
+		if len(key) > len(heritage)+1 && strings.EqualFold(key[:len(heritage)+1], heritage+"/") {
+			endpointLabels[key[len(heritage)+1:]] = val
+		}
+	}
+
+	if endpointLabels[OwnerLabelKey] == "" {
+		return nil, ErrInvalidHeritage
+	}
+
+	return endpointLabels, nil
+}
+
 func NewLabelsFromString(labelText string, aesKey []byte) (Labels, error) {
 	if len(aesKey) != 0 {
 		decryptedText, encryptionNonce, err := DecryptText(strings.Trim(labelText, "\""), aesKey)
@@ -116,7 +158,7 @@ func (l Labels) SerializePlain(withQuotes bool) string {
 	sort.Strings(keys) // sort for consistency
 
 	for _, key := range keys {
-		if key == txtEncryptionNonce {
+		if key == txtEncryptionNonce || key == DescriptionLabelKey {
 			continue
 		}
 		tokens = append(tokens, fmt.Sprintf("%s/%s=%s", heritage, key, l[key]))
@@ -75,6 +75,11 @@ func (suite *LabelsSuite) TestSerialize() {
 	suite.NotEqual(suite.fooAsTextWithQuotes, suite.foo.Serialize(true, true, suite.aesKey), "should serializeLabel and encrypt")
 }
 
+func (suite *LabelsSuite) TestSerializeSkipsDescription() {
+	labels := Labels{OwnerLabelKey: "foo-owner", ResourceLabelKey: "foo-resource", DescriptionLabelKey: "Checkout, EU region"}
+	suite.Equal(suite.fooAsText, labels.SerializePlain(false), "should not serialize the description")
+}
+
 func (suite *LabelsSuite) TestEncryptionNonceReUsage() {
 	foo, err := NewLabelsFromString(suite.fooAsTextEncrypted, suite.aesKey)
 	suite.NoError(err, "should succeed for valid label text")
@@ -178,6 +183,34 @@ func (suite *LabelsSuite) TestDeserialize() {
 	suite.Nil(multipleHeritage, "if error should return nil")
 }
 
+func (suite *LabelsSuite) TestRecover() {
+	for _, text := range []string{
+		suite.fooAsText,
+		suite.fooAsTextWithQuotes,
+		"heritage=external-dns;external-dns/owner=foo-owner;external-dns/resource=foo-resource",
+		"heritage=external-dns, external-dns/owner=foo-owner, external-dns/resource=foo-resource",
+		"\"\"heritage=external-dns external-dns/owner=foo-owner external-dns/resource=foo-resource\"\"",
+		"Heritage=External-DNS,External-DNS/owner=foo-owner,external-dns/resource=foo-resource",
+		"external-dns/owner=foo-owner,external-dns/resource=foo-resource",
+	} {
+		foo, err := RecoverLabelsFromString(text)
+		suite.NoError(err, "should recover %q", text)
+		suite.Equal(suite.foo, foo, "should recover %q", text)
+	}
+
+	wrongHeritage, err := RecoverLabelsFromString(suite.wrongHeritageText)
+	suite.Equal(ErrInvalidHeritage, err, "should fail if wrong heritage is found")
+	suite.Nil(wrongHeritage, "if error should return nil")
+
+	noOwner, err := RecoverLabelsFromString("heritage=external-dns;external-dns/resource=foo-resource")
+	suite.Equal(ErrInvalidHeritage, err, "should fail if no owner is found")
+	suite.Nil(noOwner, "if error should return nil")
+
+	random, err := RecoverLabelsFromString("v=spf1 include:example.com ~all")
+	suite.Equal(ErrInvalidHeritage, err, "should fail for unrelated text")
+	suite.Nil(random, "if error should return nil")
+}
+
 func TestLabels(t *testing.T) {
 	suite.Run(t, new(LabelsSuite))
 }
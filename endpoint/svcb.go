@@ -0,0 +1,240 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// svcParamKeys maps the SvcParamKey names to their numbers, as registered in
+// https://www.iana.org/assignments/dns-svcb/dns-svcb.xhtml
+var svcParamKeys = map[string]uint16{
+	"mandatory":       0,
+	"alpn":            1,
+	"no-default-alpn": 2,
+	"port":            3,
+	"ipv4hint":        4,
+	"ech":             5,
+	"ipv6hint":        6,
+	"dohpath":         7,
+}
+
+// SVCBParam is a single SvcParam of an SVCB or HTTPS record target, e.g. "alpn=h2,h3".
+type SVCBParam struct {
+	Key   string
+	Value string
+	key   uint16
+}
+
+// String returns the presentation format of the SvcParam.
+func (p SVCBParam) String() string {
+	if p.Value == "" {
+		return p.Key
+	}
+	return p.Key + "=" + p.Value
+}
+
+// SVCBTarget represents a single SVCB or HTTPS record target, including its priority,
+// its target name and its SvcParams, as specified by RFC 9460.
+type SVCBTarget struct {
+	priority uint16
+	target   string
+	params   []SVCBParam
+}
+
+// NewSVCBRecord parses a string representation of an SVCB or HTTPS record target
+// (e.g., "1 . alpn=h2,h3 port=443") and returns an SVCBTarget struct, with its SvcParams
+// sorted by key. Returns an error if the input is invalid.
+func NewSVCBRecord(target string) (*SVCBTarget, error) {
+	parts := strings.Fields(strings.TrimSpace(target))
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid SVCB record target: %s. SVCB records must have a priority and a target name, e.g. '1 . alpn=h2'", target)
+	}
+
+	priority, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer value in target: %s", target)
+	}
+
+	// AliasMode records only point to another name
+	if priority == 0 && len(parts) > 2 {
+		return nil, fmt.Errorf("invalid SVCB record target: %s. SVCB records with a priority of 0 must not have parameters", target)
+	}
+
+	svcb := &SVCBTarget{
+		priority: uint16(priority),
+		target:   parts[1],
+		params:   make([]SVCBParam, 0, len(parts)-2),
+	}
+
+	for _, part := range parts[2:] {
+		param, err := newSVCBParam(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SVCB record target: %s. %w", target, err)
+		}
+		if slices.ContainsFunc(svcb.params, func(p SVCBParam) bool { return p.key == param.key }) {
+			return nil, fmt.Errorf("invalid SVCB record target: %s. Duplicated parameter %q", target, param.Key)
+		}
+		svcb.params = append(svcb.params, param)
+	}
+
+	slices.SortFunc(svcb.params, func(a, b SVCBParam) int { return int(a.key) - int(b.key) })
+
+	for _, param := range svcb.params {
+		if param.Key != "mandatory" {
+			continue
+		}
+		for _, key := range strings.Split(param.Value, ",") {
+			if !slices.ContainsFunc(svcb.params, func(p SVCBParam) bool { return p.Key == key }) {
+				return nil, fmt.Errorf("invalid SVCB record target: %s. Mandatory parameter %q is missing", target, key)
+			}
+		}
+	}
+
+	return svcb, nil
+}
+
+// newSVCBParam parses and validates a single SvcParam, e.g. "port=443" or `alpn="h2,h3"`.
+func newSVCBParam(param string) (SVCBParam, error) {
+	name, value, hasValue := strings.Cut(param, "=")
+	name = strings.ToLower(name)
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+	if strings.Contains(value, `"`) {
+		return SVCBParam{}, fmt.Errorf("invalid value of parameter %q", name)
+	}
+
+	key, ok := svcParamKeys[name]
+	if !ok {
+		// unregistered keys use the generic keyNNNNN format
+		number, err := strconv.ParseUint(strings.TrimPrefix(name, "key"), 10, 16)
+		if !strings.HasPrefix(name, "key") || err != nil {
+			return SVCBParam{}, fmt.Errorf("unknown parameter %q", name)
+		}
+		key = uint16(number)
+	}
+
+	_, registered := svcParamKeys[name]
+	switch {
+	case name == "no-default-alpn" && hasValue:
+		return SVCBParam{}, fmt.Errorf("parameter %q must not have a value", name)
+	case name != "no-default-alpn" && registered && !hasValue:
+		// only the unregistered keys may have no value
+		return SVCBParam{}, fmt.Errorf("parameter %q must have a value", name)
+	case hasValue && value == "":
+		return SVCBParam{}, fmt.Errorf("parameter %q must not be empty", name)
+	}
+
+	var err error
+	switch name {
+	case "mandatory":
+		for _, k := range strings.Split(value, ",") {
+			if _, ok := svcParamKeys[k]; (!ok && !strings.HasPrefix(k, "key")) || k == "mandatory" {
+				err = fmt.Errorf("invalid mandatory key %q", k)
+			}
+		}
+	case "alpn":
+		if slices.Contains(strings.Split(value, ","), "") {
+			err = fmt.Errorf("invalid value %q of parameter %q", value, name)
+		}
+	case "port":
+		if _, parseErr := strconv.ParseUint(value, 10, 16); parseErr != nil {
+			err = fmt.Errorf("invalid port %q", value)
+		}
+	case "ipv4hint", "ipv6hint":
+		for _, ip := range strings.Split(value, ",") {
+			addr, parseErr := netip.ParseAddr(ip)
+			if parseErr != nil || (name == "ipv4hint") != addr.Is4() {
+				err = fmt.Errorf("invalid address %q of parameter %q", ip, name)
+			}
+		}
+	case "ech":
+		if _, decodeErr := base64.StdEncoding.DecodeString(value); decodeErr != nil {
+			err = fmt.Errorf("invalid value of parameter %q: %w", name, decodeErr)
+		}
+	}
+	if err != nil {
+		return SVCBParam{}, err
+	}
+
+	return SVCBParam{Key: name, Value: value, key: key}, nil
+}
+
+// GetPriority returns the priority of the SVCB record target.
+func (s *SVCBTarget) GetPriority() *uint16 {
+	return &s.priority
+}
+
+// GetTarget returns the target name of the SVCB record target.
+func (s *SVCBTarget) GetTarget() *string {
+	return &s.target
+}
+
+// GetParams returns the SvcParams of the SVCB record target, sorted by key.
+func (s *SVCBTarget) GetParams() []SVCBParam {
+	return s.params
+}
+
+// GetParamsString returns the presentation format of the SvcParams, e.g. "alpn=h2,h3 port=443".
+func (s *SVCBTarget) GetParamsString() string {
+	params := make([]string, 0, len(s.params))
+	for _, param := range s.params {
+		params = append(params, param.String())
+	}
+	return strings.Join(params, " ")
+}
+
+// String returns the normalized representation of the SVCB record target.
+func (s *SVCBTarget) String() string {
+	if len(s.params) == 0 {
+		return fmt.Sprintf("%d %s", s.priority, s.target)
+	}
+	return fmt.Sprintf("%d %s %s", s.priority, s.target, s.GetParamsString())
+}
+
+func (t Targets) ValidateSVCBRecord() bool {
+	for _, target := range t {
+		_, err := NewSVCBRecord(target)
+		if err != nil {
+			log.Debugf("Invalid SVCB record target: %s. %v", target, err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// NormalizeSVCBRecords returns the targets with the same representation as the providers,
+// keeping the invalid targets unchanged.
+func (t Targets) NormalizeSVCBRecords() Targets {
+	normalized := make(Targets, 0, len(t))
+	for _, target := range t {
+		if svcb, err := NewSVCBRecord(target); err == nil {
+			target = svcb.String()
+		}
+		normalized = append(normalized, target)
+	}
+	return normalized
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSVCBRecord(t *testing.T) {
+	svcb, err := NewSVCBRecord(`1 . port=443 alpn="h2,h3"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(1), *svcb.GetPriority())
+	assert.Equal(t, ".", *svcb.GetTarget())
+	assert.Equal(t, []SVCBParam{{Key: "alpn", Value: "h2,h3", key: 1}, {Key: "port", Value: "443", key: 3}}, svcb.GetParams())
+	assert.Equal(t, "alpn=h2,h3 port=443", svcb.GetParamsString())
+	assert.Equal(t, "1 . alpn=h2,h3 port=443", svcb.String())
+}
+
+func TestNewSVCBRecordValid(t *testing.T) {
+	for _, tc := range []struct {
+		target   string
+		expected string
+	}{
+		{target: "0 svc.example.com.", expected: "0 svc.example.com."},
+		{target: "1 .", expected: "1 ."},
+		{target: "  16  svc.example.com  ALPN=h3 ", expected: "16 svc.example.com alpn=h3"},
+		{target: "1 . mandatory=alpn alpn=h2 no-default-alpn", expected: "1 . mandatory=alpn alpn=h2 no-default-alpn"},
+		{target: "1 . ipv6hint=2001:db8::1 ipv4hint=192.0.2.1,192.0.2.2", expected: "1 . ipv4hint=192.0.2.1,192.0.2.2 ipv6hint=2001:db8::1"},
+		{target: "1 . ech=AEX+DQBB key65000=a key65001", expected: "1 . ech=AEX+DQBB key65000=a key65001"},
+		{target: "1 doh.example.net alpn=h2 dohpath=/dns-query{?dns}", expected: "1 doh.example.net alpn=h2 dohpath=/dns-query{?dns}"},
+	} {
+		t.Run(tc.target, func(t *testing.T) {
+			svcb, err := NewSVCBRecord(tc.target)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, svcb.String())
+		})
+	}
+}
+
+func TestNewSVCBRecordInvalid(t *testing.T) {
+	for _, target := range []string{
+		"",
+		"1",
+		"high . alpn=h2",
+		"65536 . alpn=h2",
+		"0 svc.example.com. alpn=h2",
+		"1 . unknown=value",
+		"1 . alpn",
+		"1 . alpn=",
+		"1 . alpn=h2,,h3",
+		"1 . alpn=h2 alpn=h3",
+		"1 . port=https",
+		"1 . port=65536",
+		"1 . no-default-alpn=true",
+		"1 . ipv4hint=2001:db8::1",
+		"1 . ipv6hint=192.0.2.1",
+		"1 . ipv4hint=192.0.2",
+		"1 . ech=not-base64",
+		`1 . alpn="h2`,
+		"1 . mandatory=port alpn=h2",
+		"1 . mandatory=mandatory",
+		"1 . key65536=value",
+	} {
+		t.Run(target, func(t *testing.T) {
+			_, err := NewSVCBRecord(target)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNormalizeSVCBRecords(t *testing.T) {
+	targets := Targets{`1 . port="443" alpn="h3,h2"`, "invalid"}
+
+	assert.Equal(t, Targets{"1 . alpn=h3,h2 port=443", "invalid"}, targets.NormalizeSVCBRecords())
+	assert.True(t, Targets{"1 . alpn=h3,h2 port=443"}.ValidateSVCBRecord())
+	assert.False(t, targets.ValidateSVCBRecord())
+}
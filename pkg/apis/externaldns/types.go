@@ -40,6 +40,7 @@ const (
 type Config struct {
 	APIServerURL                                  string
 	KubeConfig                                    string
+	SourceClusters                                []string
 	RequestTimeout                                time.Duration
 	DefaultTargets                                []string
 	GlooNamespaces                                []string
@@ -49,6 +50,9 @@ type Config struct {
 	AnnotationFilter                              string
 	LabelFilter                                   string
 	IngressClassNames                             []string
+	IngressClassServices                          []string
+	IngressClassTargets                           []string
+	ConfigMapSourceNames                          []string
 	FQDNTemplate                                  string
 	CombineFQDNAndAnnotation                      bool
 	IgnoreHostnameAnnotation                      bool
@@ -60,18 +64,27 @@ type Config struct {
 	GatewayName                                   string
 	GatewayNamespace                              string
 	GatewayLabelFilter                            string
+	GatewayAddressTypes                           []string
+	GatewayFallbackTargets                        []string
+	GatewayRequireProgrammed                      bool
 	Compatibility                                 string
 	PodSourceDomain                               string
 	PublishInternal                               bool
 	PublishHostIP                                 bool
 	AlwaysPublishNotReadyAddresses                bool
+	PublishHeadlessSRV                            bool
+	ServiceAggregateHostname                      string
 	ConnectorSourceServer                         string
 	Provider                                      string
 	ProviderCacheTime                             time.Duration
+	AdditionalProviders                           []string
+	ProviderDomains                               []string
+	ProviderSpecificDefaults                      []string
 	GoogleProject                                 string
 	GoogleBatchChangeSize                         int
 	GoogleBatchChangeInterval                     time.Duration
 	GoogleZoneVisibility                          string
+	GoogleContinueOnError                         bool
 	DomainFilter                                  []string
 	ExcludeDomains                                []string
 	RegexDomainFilter                             *regexp.Regexp
@@ -94,9 +107,12 @@ type Config struct {
 	AWSEvaluateTargetHealth                       bool
 	AWSAPIRetries                                 int
 	AWSPreferCNAME                                bool
+	AWSPreferCNAMEDomains                         []string
 	AWSZoneCacheDuration                          time.Duration
 	AWSSDServiceCleanup                           bool
 	AWSSDCreateTag                                map[string]string
+	AWSSDServiceTTL                               int64
+	AWSSDRoutingPolicy                            string
 	AWSZoneMatchParent                            bool
 	AWSDynamoDBRegion                             string
 	AWSDynamoDBTable                              string
@@ -114,6 +130,7 @@ type Config struct {
 	CloudflareCustomHostnamesMinTLSVersion        string
 	CloudflareCustomHostnamesCertificateAuthority string
 	CloudflareRegionalServices                    bool
+	CloudflareLoadBalancers                       bool
 	CloudflareRegionKey                           string
 	CoreDNSPrefix                                 string
 	AkamaiServiceConsumerDomain                   string
@@ -122,37 +139,66 @@ type Config struct {
 	AkamaiAccessToken                             string
 	AkamaiEdgercPath                              string
 	AkamaiEdgercSection                           string
+	AkamaiZoneContracts                           []string
 	OCIConfigFile                                 string
 	OCICompartmentOCID                            string
 	OCIAuthInstancePrincipal                      bool
 	OCIZoneScope                                  string
 	OCIZoneCacheDuration                          time.Duration
 	InMemoryZones                                 []string
+	InMemoryDefaultTTL                            int64
+	InMemoryMinTTL                                int64
+	InMemoryMaxTTL                                int64
 	OVHEndpoint                                   string
 	OVHApiRateLimit                               int
 	OVHEnableCNAMERelative                        bool
+	OVHUseZoneExport                              bool
 	PDNSServer                                    string
 	PDNSServerID                                  string
 	PDNSAPIKey                                    string `secure:"yes"`
 	PDNSSkipTLSVerify                             bool
+	PDNSCreateZones                               bool
+	PDNSZoneNameservers                           []string
+	PDNSZoneSOA                                   string
 	TLSCA                                         string
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
 	Policy                                        string
+	RecordTypeReplacement                         bool
+	RecordTypePriority                            []string
+	DeleteAfterCreate                             bool
+	MaxDeletionsPerRun                            int
+	ExternalRecordsRegex                          *regexp.Regexp
+	ExternalRecordsTXTMarker                      string
+	AllowApexSOANS                                bool
+	RecordProvenance                              bool
+	RecordProvenanceClusterName                   string
 	Registry                                      string
 	TXTOwnerID                                    string
+	TXTAdoptOwnerIDs                              []string
+	TXTRepairOwnership                            bool
+	TXTSkipRecordTypes                            []string
+	TXTIgnoreForeignRecords                       bool
 	TXTPrefix                                     string
 	TXTSuffix                                     string
 	TXTEncryptEnabled                             bool
 	TXTEncryptAESKey                              string `secure:"yes"`
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
+	ProviderFailureThreshold                      int
+	ProviderMaxBackoff                            time.Duration
 	Once                                          bool
 	DryRun                                        bool
+	CleanupOrphans                                bool
+	CleanupOrphansConfirm                         bool
 	UpdateEvents                                  bool
+	UpdateEventsSources                           []string
+	SourceIntervals                               []string
 	LogFormat                                     string
 	MetricsAddress                                string
 	LogLevel                                      string
+	LogLevelEndpoint                              bool
+	ReconcileOnSecretChange                       string
 	TXTCacheInterval                              time.Duration
 	TXTWildcardReplacement                        string
 	ExoscaleEndpoint                              string
@@ -162,11 +208,17 @@ type Config struct {
 	ExoscaleAPIZone                               string
 	CRDSourceAPIVersion                           string
 	CRDSourceKind                                 string
+	CRDSourceClusterScoped                        bool
+	CRDSourceFinalizer                            bool
+	CRDSourceHostnameJSONPath                     string
+	CRDSourceTargetsJSONPath                      string
+	CRDSourceTTLJSONPath                          string
 	ServiceTypeFilter                             []string
 	CFAPIEndpoint                                 string
 	CFUsername                                    string
 	CFPassword                                    string
 	ResolveServiceLoadBalancerHostname            bool
+	ServiceCiliumLoadBalancerIPAM                 bool
 	RFC2136Host                                   []string
 	RFC2136Port                                   int
 	RFC2136Zone                                   []string
@@ -197,6 +249,7 @@ type Config struct {
 	GoDaddySecretKey                              string `secure:"yes"`
 	GoDaddyTTL                                    int64
 	GoDaddyOTE                                    bool
+	GoDaddyAPIRateLimit                           int
 	OCPRouterName                                 string
 	PiholeServer                                  string
 	PiholePassword                                string `secure:"yes"`
@@ -207,10 +260,22 @@ type Config struct {
 	WebhookProviderURL                            string
 	WebhookProviderReadTimeout                    time.Duration
 	WebhookProviderWriteTimeout                   time.Duration
+	WebhookProviderMaxAttempts                    int
+	WebhookProviderRetryBaseDelay                 time.Duration
 	WebhookServer                                 bool
 	TraefikEnableLegacy                           bool
 	TraefikDisableNew                             bool
+	F5VirtualServerAllowedHosts                   []string
+	F5VirtualServerDeniedHosts                    []string
+	F5RequireHealthyPoolMembers                   bool
+	KnativeGatewayService                         string
+	CertManagerCertificateTargets                 []string
+	HAProxyTCPServicesConfigMap                   string
+	HAProxyService                                string
+	HostnameSourcePriority                        []string
 	NAT64Networks                                 []string
+	FlattenMultiTargetCNAME                       bool
+	MergeTXTRecords                               bool
 	ExcludeUnschedulable                          bool
 	ForceDefaultTargets                           bool
 }
@@ -221,6 +286,7 @@ var defaultConfig = &Config{
 	AkamaiClientToken:           "",
 	AkamaiEdgercPath:            "",
 	AkamaiEdgercSection:         "",
+	AkamaiZoneContracts:         []string{},
 	AkamaiServiceConsumerDomain: "",
 	AlibabaCloudConfigFile:      "/etc/kubernetes/alibaba-cloud.json",
 	AnnotationFilter:            "",
@@ -236,8 +302,11 @@ var defaultConfig = &Config{
 	AWSDynamoDBTable:            "external-dns",
 	AWSEvaluateTargetHealth:     true,
 	AWSPreferCNAME:              false,
+	AWSPreferCNAMEDomains:       nil,
 	AWSSDCreateTag:              map[string]string{},
 	AWSSDServiceCleanup:         false,
+	AWSSDServiceTTL:             300,
+	AWSSDRoutingPolicy:          "",
 	AWSZoneCacheDuration:        0 * time.Second,
 	AWSZoneMatchParent:          false,
 	AWSZoneTagFilter:            []string{},
@@ -256,126 +325,174 @@ var defaultConfig = &Config{
 	CloudflareDNSRecordsPerPage:                   100,
 	CloudflareProxied:                             false,
 	CloudflareRegionalServices:                    false,
+	CloudflareLoadBalancers:                       false,
 	CloudflareRegionKey:                           "earth",
 
-	CombineFQDNAndAnnotation:     false,
-	Compatibility:                "",
-	ConnectorSourceServer:        "localhost:8080",
-	CoreDNSPrefix:                "/skydns/",
-	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
-	CRDSourceKind:                "DNSEndpoint",
-	DefaultTargets:               []string{},
-	DigitalOceanAPIPageSize:      50,
-	DomainFilter:                 []string{},
-	DryRun:                       false,
-	ExcludeDNSRecordTypes:        []string{},
-	ExcludeDomains:               []string{},
-	ExcludeTargetNets:            []string{},
-	ExcludeUnschedulable:         true,
-	ExoscaleAPIEnvironment:       "api",
-	ExoscaleAPIKey:               "",
-	ExoscaleAPISecret:            "",
-	ExoscaleAPIZone:              "ch-gva-2",
-	ExposeInternalIPV6:           false,
-	FQDNTemplate:                 "",
-	GatewayLabelFilter:           "",
-	GatewayName:                  "",
-	GatewayNamespace:             "",
-	GlooNamespaces:               []string{"gloo-system"},
-	GoDaddyAPIKey:                "",
-	GoDaddyOTE:                   false,
-	GoDaddySecretKey:             "",
-	GoDaddyTTL:                   600,
-	GoogleBatchChangeInterval:    time.Second,
-	GoogleBatchChangeSize:        1000,
-	GoogleProject:                "",
-	GoogleZoneVisibility:         "",
-	IgnoreHostnameAnnotation:     false,
-	IgnoreIngressRulesSpec:       false,
-	IgnoreIngressTLSSpec:         false,
-	IngressClassNames:            nil,
-	InMemoryZones:                []string{},
-	Interval:                     time.Minute,
-	KubeConfig:                   "",
-	LabelFilter:                  labels.Everything().String(),
-	LogFormat:                    "text",
-	LogLevel:                     logrus.InfoLevel.String(),
-	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
-	MetricsAddress:               ":7979",
-	MinEventSyncInterval:         5 * time.Second,
-	Namespace:                    "",
-	NAT64Networks:                []string{},
-	NS1Endpoint:                  "",
-	NS1IgnoreSSL:                 false,
-	OCIConfigFile:                "/etc/kubernetes/oci.yaml",
-	OCIZoneCacheDuration:         0 * time.Second,
-	OCIZoneScope:                 "GLOBAL",
-	Once:                         false,
-	OVHApiRateLimit:              20,
-	OVHEnableCNAMERelative:       false,
-	OVHEndpoint:                  "ovh-eu",
-	PDNSAPIKey:                   "",
-	PDNSServer:                   "http://localhost:8081",
-	PDNSServerID:                 "localhost",
-	PDNSSkipTLSVerify:            false,
-	PiholeApiVersion:             "5",
-	PiholePassword:               "",
-	PiholeServer:                 "",
-	PiholeTLSInsecureSkipVerify:  false,
-	PluralCluster:                "",
-	PluralProvider:               "",
-	PodSourceDomain:              "",
-	Policy:                       "sync",
-	Provider:                     "",
-	ProviderCacheTime:            0,
-	PublishHostIP:                false,
-	PublishInternal:              false,
-	RegexDomainExclusion:         regexp.MustCompile(""),
-	RegexDomainFilter:            regexp.MustCompile(""),
-	Registry:                     "txt",
-	RequestTimeout:               time.Second * 30,
-	RFC2136BatchChangeSize:       50,
-	RFC2136GSSTSIG:               false,
-	RFC2136Host:                  []string{""},
-	RFC2136Insecure:              false,
-	RFC2136KerberosPassword:      "",
-	RFC2136KerberosRealm:         "",
-	RFC2136KerberosUsername:      "",
-	RFC2136LoadBalancingStrategy: "disabled",
-	RFC2136MinTTL:                0,
-	RFC2136Port:                  0,
-	RFC2136SkipTLSVerify:         false,
-	RFC2136TAXFR:                 true,
-	RFC2136TSIGKeyName:           "",
-	RFC2136TSIGSecret:            "",
-	RFC2136TSIGSecretAlg:         "",
-	RFC2136UseTLS:                false,
-	RFC2136Zone:                  []string{},
-	ServiceTypeFilter:            []string{},
-	SkipperRouteGroupVersion:     "zalando.org/v1",
-	Sources:                      nil,
-	TargetNetFilter:              []string{},
-	TLSCA:                        "",
-	TLSClientCert:                "",
-	TLSClientCertKey:             "",
-	TraefikEnableLegacy:          false,
-	TraefikDisableNew:            false,
-	TransIPAccountName:           "",
-	TransIPPrivateKeyFile:        "",
-	TXTCacheInterval:             0,
-	TXTEncryptAESKey:             "",
-	TXTEncryptEnabled:            false,
-	TXTOwnerID:                   "default",
-	TXTPrefix:                    "",
-	TXTSuffix:                    "",
-	TXTWildcardReplacement:       "",
-	UpdateEvents:                 false,
-	WebhookProviderReadTimeout:   5 * time.Second,
-	WebhookProviderURL:           "http://localhost:8888",
-	WebhookProviderWriteTimeout:  10 * time.Second,
-	WebhookServer:                false,
-	ZoneIDFilter:                 []string{},
-	ForceDefaultTargets:          false,
+	CombineFQDNAndAnnotation:      false,
+	Compatibility:                 "",
+	ConnectorSourceServer:         "localhost:8080",
+	CoreDNSPrefix:                 "/skydns/",
+	CRDSourceAPIVersion:           "externaldns.k8s.io/v1alpha1",
+	CRDSourceKind:                 "DNSEndpoint",
+	CRDSourceClusterScoped:        false,
+	CRDSourceFinalizer:            false,
+	CRDSourceHostnameJSONPath:     "",
+	CRDSourceTargetsJSONPath:      "",
+	CRDSourceTTLJSONPath:          "",
+	DefaultTargets:                []string{},
+	DigitalOceanAPIPageSize:       50,
+	DomainFilter:                  []string{},
+	DryRun:                        false,
+	ExcludeDNSRecordTypes:         []string{},
+	ExcludeDomains:                []string{},
+	ExcludeTargetNets:             []string{},
+	ExcludeUnschedulable:          true,
+	ExoscaleAPIEnvironment:        "api",
+	ExoscaleAPIKey:                "",
+	ExoscaleAPISecret:             "",
+	ExoscaleAPIZone:               "ch-gva-2",
+	ExposeInternalIPV6:            false,
+	FQDNTemplate:                  "",
+	GatewayLabelFilter:            "",
+	GatewayAddressTypes:           []string{},
+	GatewayFallbackTargets:        []string{},
+	GatewayRequireProgrammed:      false,
+	GatewayName:                   "",
+	GatewayNamespace:              "",
+	GlooNamespaces:                []string{"gloo-system"},
+	GoDaddyAPIKey:                 "",
+	GoDaddyAPIRateLimit:           60,
+	GoDaddyOTE:                    false,
+	GoDaddySecretKey:              "",
+	GoDaddyTTL:                    600,
+	GoogleBatchChangeInterval:     time.Second,
+	GoogleBatchChangeSize:         1000,
+	GoogleProject:                 "",
+	GoogleZoneVisibility:          "",
+	GoogleContinueOnError:         false,
+	IgnoreHostnameAnnotation:      false,
+	IgnoreIngressRulesSpec:        false,
+	IgnoreIngressTLSSpec:          false,
+	IngressClassNames:             nil,
+	IngressClassServices:          nil,
+	IngressClassTargets:           nil,
+	ConfigMapSourceNames:          nil,
+	InMemoryZones:                 []string{},
+	InMemoryDefaultTTL:            0,
+	InMemoryMinTTL:                0,
+	InMemoryMaxTTL:                0,
+	Interval:                      time.Minute,
+	KubeConfig:                    "",
+	SourceClusters:                []string{},
+	LabelFilter:                   labels.Everything().String(),
+	LogFormat:                     "text",
+	LogLevel:                      logrus.InfoLevel.String(),
+	ManagedDNSRecordTypes:         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	MetricsAddress:                ":7979",
+	MinEventSyncInterval:          5 * time.Second,
+	ProviderFailureThreshold:      0,
+	ProviderMaxBackoff:            30 * time.Minute,
+	Namespace:                     "",
+	NAT64Networks:                 []string{},
+	FlattenMultiTargetCNAME:       false,
+	MergeTXTRecords:               false,
+	NS1Endpoint:                   "",
+	NS1IgnoreSSL:                  false,
+	OCIConfigFile:                 "/etc/kubernetes/oci.yaml",
+	OCIZoneCacheDuration:          0 * time.Second,
+	OCIZoneScope:                  "GLOBAL",
+	Once:                          false,
+	OVHApiRateLimit:               20,
+	OVHEnableCNAMERelative:        false,
+	OVHUseZoneExport:              false,
+	OVHEndpoint:                   "ovh-eu",
+	PDNSAPIKey:                    "",
+	PDNSServer:                    "http://localhost:8081",
+	PDNSServerID:                  "localhost",
+	PDNSSkipTLSVerify:             false,
+	PDNSCreateZones:               false,
+	PDNSZoneNameservers:           []string{},
+	PDNSZoneSOA:                   "",
+	PiholeApiVersion:              "5",
+	PiholePassword:                "",
+	PiholeServer:                  "",
+	PiholeTLSInsecureSkipVerify:   false,
+	PluralCluster:                 "",
+	PluralProvider:                "",
+	PodSourceDomain:               "",
+	Policy:                        "sync",
+	RecordTypeReplacement:         false,
+	RecordTypePriority:            []string{},
+	DeleteAfterCreate:             false,
+	MaxDeletionsPerRun:            0,
+	ExternalRecordsRegex:          regexp.MustCompile(""),
+	ExternalRecordsTXTMarker:      "",
+	AllowApexSOANS:                false,
+	RecordProvenance:              false,
+	RecordProvenanceClusterName:   "",
+	Provider:                      "",
+	ProviderCacheTime:             0,
+	AdditionalProviders:           []string{},
+	ProviderDomains:               []string{},
+	PublishHostIP:                 false,
+	PublishHeadlessSRV:            false,
+	ServiceAggregateHostname:      "",
+	PublishInternal:               false,
+	RegexDomainExclusion:          regexp.MustCompile(""),
+	RegexDomainFilter:             regexp.MustCompile(""),
+	Registry:                      "txt",
+	RequestTimeout:                time.Second * 30,
+	RFC2136BatchChangeSize:        50,
+	RFC2136GSSTSIG:                false,
+	RFC2136Host:                   []string{""},
+	RFC2136Insecure:               false,
+	RFC2136KerberosPassword:       "",
+	RFC2136KerberosRealm:          "",
+	RFC2136KerberosUsername:       "",
+	RFC2136LoadBalancingStrategy:  "disabled",
+	RFC2136MinTTL:                 0,
+	RFC2136Port:                   0,
+	RFC2136SkipTLSVerify:          false,
+	RFC2136TAXFR:                  true,
+	RFC2136TSIGKeyName:            "",
+	RFC2136TSIGSecret:             "",
+	RFC2136TSIGSecretAlg:          "",
+	RFC2136UseTLS:                 false,
+	RFC2136Zone:                   []string{},
+	ServiceTypeFilter:             []string{},
+	SkipperRouteGroupVersion:      "zalando.org/v1",
+	Sources:                       nil,
+	TargetNetFilter:               []string{},
+	TLSCA:                         "",
+	TLSClientCert:                 "",
+	TLSClientCertKey:              "",
+	TraefikEnableLegacy:           false,
+	TraefikDisableNew:             false,
+	F5VirtualServerAllowedHosts:   []string{},
+	F5VirtualServerDeniedHosts:    []string{},
+	F5RequireHealthyPoolMembers:   false,
+	TransIPAccountName:            "",
+	TransIPPrivateKeyFile:         "",
+	TXTCacheInterval:              0,
+	TXTEncryptAESKey:              "",
+	TXTEncryptEnabled:             false,
+	TXTOwnerID:                    "default",
+	TXTAdoptOwnerIDs:              []string{},
+	TXTRepairOwnership:            false,
+	TXTSkipRecordTypes:            []string{},
+	TXTIgnoreForeignRecords:       false,
+	TXTPrefix:                     "",
+	TXTSuffix:                     "",
+	TXTWildcardReplacement:        "",
+	UpdateEvents:                  false,
+	WebhookProviderReadTimeout:    5 * time.Second,
+	WebhookProviderURL:            "http://localhost:8888",
+	WebhookProviderWriteTimeout:   10 * time.Second,
+	WebhookProviderMaxAttempts:    1,
+	WebhookProviderRetryBaseDelay: 500 * time.Millisecond,
+	WebhookServer:                 false,
+	ZoneIDFilter:                  []string{},
+	ForceDefaultTargets:           false,
 }
 
 // NewConfig returns new Config object
@@ -435,8 +552,10 @@ func App(cfg *Config) *kingpin.Application {
 	// Flags related to Kubernetes
 	app.Flag("server", "The Kubernetes API server to connect to (default: auto-detect)").Default(defaultConfig.APIServerURL).StringVar(&cfg.APIServerURL)
 	app.Flag("kubeconfig", "Retrieve target cluster configuration from a Kubernetes configuration file (default: auto-detect)").Default(defaultConfig.KubeConfig).StringVar(&cfg.KubeConfig)
+	app.Flag("source-cluster", "An additional cluster to read the sources from, in the format <kubeconfig>[=<context>], the current context of the kubeconfig being used without context; specify multiple times for multiple clusters (optional)").StringsVar(&cfg.SourceClusters)
 	app.Flag("request-timeout", "Request timeout when calling Kubernetes APIs. 0s means no timeout").Default(defaultConfig.RequestTimeout.String()).DurationVar(&cfg.RequestTimeout)
 	app.Flag("resolve-service-load-balancer-hostname", "Resolve the hostname of LoadBalancer-type Service object to IP addresses in order to create DNS A/AAAA records instead of CNAMEs").BoolVar(&cfg.ResolveServiceLoadBalancerHostname)
+	app.Flag("service-cilium-lb-ipam", "When using the service source, fall back to the IPs requested through the Cilium LB IPAM annotations for LoadBalancer-type Service objects without any status.loadBalancer.ingress (default: false)").BoolVar(&cfg.ServiceCiliumLoadBalancerIPAM)
 	app.Flag("listen-endpoint-events", "Trigger a reconcile on changes to EndpointSlices, for Service source (default: false)").BoolVar(&cfg.ListenEndpointEvents)
 
 	// Flags related to cloud foundry
@@ -455,9 +574,15 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("annotation-filter", "Filter resources queried for endpoints by annotation, using label selector semantics").Default(defaultConfig.AnnotationFilter).StringVar(&cfg.AnnotationFilter)
 	app.Flag("combine-fqdn-annotation", "Combine FQDN template and Annotations instead of overwriting (default: false)").BoolVar(&cfg.CombineFQDNAndAnnotation)
 	app.Flag("compatibility", "Process annotation semantics from legacy implementations (optional, options: mate, molecule, kops-dns-controller)").Default(defaultConfig.Compatibility).EnumVar(&cfg.Compatibility, "", "mate", "molecule", "kops-dns-controller")
+	app.Flag("configmap-source-name", "A ConfigMap mapping hostnames to targets for the configmap source, in the format <namespace>/<name>; specify multiple times for multiple ConfigMaps, valid only when using configmap source").StringsVar(&cfg.ConfigMapSourceNames)
 	app.Flag("connector-source-server", "The server to connect for connector source, valid only when using connector source").Default(defaultConfig.ConnectorSourceServer).StringVar(&cfg.ConnectorSourceServer)
 	app.Flag("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1`, valid only when using crd source").Default(defaultConfig.CRDSourceAPIVersion).StringVar(&cfg.CRDSourceAPIVersion)
 	app.Flag("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion").Default(defaultConfig.CRDSourceKind).StringVar(&cfg.CRDSourceKind)
+	app.Flag("crd-source-cluster-scoped", "Read the custom resources of the crd source without a namespace, for a cluster-scoped CRD; --namespace is ignored (default: false)").BoolVar(&cfg.CRDSourceClusterScoped)
+	app.Flag("crd-source-finalizer", "Add a finalizer to the custom resources of the crd source, removed once the records of a deleted resource are gone from the registry (default: false)").BoolVar(&cfg.CRDSourceFinalizer)
+	app.Flag("crd-source-hostname-jsonpath", "JSONPath expression selecting the hostnames of the custom resources, e.g. `{.spec.host}`, valid only when using crd-jsonpath source").Default(defaultConfig.CRDSourceHostnameJSONPath).StringVar(&cfg.CRDSourceHostnameJSONPath)
+	app.Flag("crd-source-targets-jsonpath", "JSONPath expression selecting the targets of the custom resources, e.g. `{.status.addresses[*].ip}`, valid only when using crd-jsonpath source").Default(defaultConfig.CRDSourceTargetsJSONPath).StringVar(&cfg.CRDSourceTargetsJSONPath)
+	app.Flag("crd-source-ttl-jsonpath", "JSONPath expression selecting the TTL of the custom resources (optional), valid only when using crd-jsonpath source").Default(defaultConfig.CRDSourceTTLJSONPath).StringVar(&cfg.CRDSourceTTLJSONPath)
 	app.Flag("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Specify multiple times for multiple targets (optional)").StringsVar(&cfg.DefaultTargets)
 	app.Flag("force-default-targets", "Force the application of --default-targets, overriding any targets provided by the source (DEPRECATED: This reverts to (improved) legacy behavior which allows empty CRD targets for migration to new state)").Default(strconv.FormatBool(defaultConfig.ForceDefaultTargets)).BoolVar(&cfg.ForceDefaultTargets)
 	app.Flag("exclude-record-types", "Record types to exclude from management; specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
@@ -465,6 +590,9 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)").Default(strconv.FormatBool(defaultConfig.ExcludeUnschedulable)).BoolVar(&cfg.ExcludeUnschedulable)
 	app.Flag("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional, default: false)").BoolVar(&cfg.ExposeInternalIPV6)
 	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
+	app.Flag("gateway-address-type", "Only use the Gateway status addresses of this type as targets of Route endpoints, e.g. IPAddress or Hostname; specify multiple times for multiple types (default: all types)").StringsVar(&cfg.GatewayAddressTypes)
+	app.Flag("gateway-fallback-target", "The targets of the Route endpoints whose Gateway has no address yet, e.g. during the cluster bootstrap, replaced by the Gateway addresses once set; specify multiple times for multiple targets (optional)").StringsVar(&cfg.GatewayFallbackTargets)
+	app.Flag("gateway-require-programmed", "Only use the targets of the Gateways with the Programmed condition for the Route endpoints, the other Gateways use the fallback targets, if any (default: disabled)").BoolVar(&cfg.GatewayRequireProgrammed)
 	app.Flag("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)").StringVar(&cfg.GatewayLabelFilter)
 	app.Flag("gateway-name", "Limit Gateways of Route endpoints to a specific name (default: all names)").StringVar(&cfg.GatewayName)
 	app.Flag("gateway-namespace", "Limit Gateways of Route endpoints to a specific namespace (default: all namespaces)").StringVar(&cfg.GatewayNamespace)
@@ -473,24 +601,41 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("ignore-ingress-tls-spec", "Ignore the spec.tls section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressTLSSpec)
 	app.Flag("ignore-non-host-network-pods", "Ignore pods not running on host network when using pod source (default: false)").BoolVar(&cfg.IgnoreNonHostNetworkPods)
 	app.Flag("ingress-class", "Require an Ingress to have this class name; specify multiple times to allow more than one class (optional; defaults to any class)").StringsVar(&cfg.IngressClassNames)
+	app.Flag("ingress-class-service", "Use the load balancer addresses of a service as the targets of the ingresses of a class, in the format <class>=<namespace>/<name>; specify multiple times for multiple classes (optional)").StringsVar(&cfg.IngressClassServices)
+	app.Flag("ingress-class-target", "Use a target for the ingresses of a class without load balancer address, e.g. the stable IP of a bare-metal load balancer, in the format <class>=<target>; specify multiple times for multiple targets or classes (optional)").StringsVar(&cfg.IngressClassTargets)
 	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, node, openshift-route, service and ambassador-host").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
 	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
 	app.Flag("managed-record-types", managedRecordTypesHelp).Default(defaultConfig.ManagedDNSRecordTypes...).StringsVar(&cfg.ManagedDNSRecordTypes)
 	app.Flag("namespace", "Limit resources queried for endpoints to a specific namespace (default: all namespaces)").Default(defaultConfig.Namespace).StringVar(&cfg.Namespace)
 	app.Flag("nat64-networks", "Adding an A record for each AAAA record in NAT64-enabled networks; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.NAT64Networks)
+	app.Flag("flatten-multi-target-cname", "Replace CNAME endpoints with multiple targets by A/AAAA endpoints with the addresses of all the targets, resolved on every synchronization (default: disabled)").BoolVar(&cfg.FlattenMultiTargetCNAME)
+	app.Flag("merge-txt-records", "Merge the TXT endpoints with the same name and set identifier into a single endpoint with the targets of all of them, except a second SPF policy, instead of treating them as a conflict (default: disabled)").BoolVar(&cfg.MergeTXTRecords)
 	app.Flag("openshift-router-name", "if source is openshift-route then you can pass the ingress controller name. Based on this name external-dns will select the respective router from the route status and map that routerCanonicalHostname to the route host while creating a CNAME record.").StringVar(&cfg.OCPRouterName)
 	app.Flag("pod-source-domain", "Domain to use for pods records (optional)").Default(defaultConfig.PodSourceDomain).StringVar(&cfg.PodSourceDomain)
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
+	app.Flag("publish-headless-srv", "Allow external-dns to publish SRV records _<port>._<protocol>.<hostname> for the named ports of headless services, targeting the hostnames of their pods (optional)").BoolVar(&cfg.PublishHeadlessSRV)
+	app.Flag("service-aggregate-hostname", "When using the service source, publish a record with this hostname targeting the load balancer IPs of all the LoadBalancer services (optional)").Default(defaultConfig.ServiceAggregateHostname).StringVar(&cfg.ServiceAggregateHostname)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
 	app.Flag("service-type-filter", "The service types to filter by. Specify multiple times for multiple filters to be applied. (optional, default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").Default(defaultConfig.ServiceTypeFilter...).StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, configmap, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, crd-jsonpath, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy, knative-domainmapping, knative-route, cert-manager-certificate, haproxy-tcp-services)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "configmap", "crd", "crd-jsonpath", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy", "knative-domainmapping", "knative-route", "cert-manager-certificate", "haproxy-tcp-services")
 	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
 	app.Flag("traefik-enable-legacy", "Enable legacy listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikEnableLegacy)).BoolVar(&cfg.TraefikEnableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
+	app.Flag("f5-virtualserver-allowed-host", "Only publish the hosts of F5 VirtualServers ending with this domain; specify multiple times for multiple domains (optional, default: all hosts)").StringsVar(&cfg.F5VirtualServerAllowedHosts)
+	app.Flag("f5-virtualserver-denied-host", "Never publish the hosts of F5 VirtualServers ending with this domain, e.g. the cluster apex; specify multiple times for multiple domains (optional)").StringsVar(&cfg.F5VirtualServerDeniedHosts)
+	app.Flag("f5-require-healthy-pool-members", "Skip the F5 VirtualServers whose pool services have no ready endpoints, to avoid publishing the address of a dead service (default: disabled)").Default(strconv.FormatBool(defaultConfig.F5RequireHealthyPoolMembers)).BoolVar(&cfg.F5RequireHealthyPoolMembers)
+	app.Flag("hostname-source-priority", "The origins of the hostnames of the f5-virtualserver, ingress, knative and service sources by priority: the hostnames of the first origin having any are used, the sources having none of the origins keep their default hostnames; specify multiple times for multiple origins (optional, default: the origins are combined as per the source, options: annotation, spec, status)").EnumsVar(&cfg.HostnameSourcePriority, "annotation", "spec", "status")
+	app.Flag("knative-gateway-service", "The Knative ingress gateway service whose load balancer addresses are the targets of the knative-domainmapping and knative-route sources, in the format <namespace>/<name>, e.g. kourier-system/kourier (optional)").StringVar(&cfg.KnativeGatewayService)
+	app.Flag("cert-manager-certificate-target", "The target of the DNS names of the cert-manager Certificates, e.g. the address of a shared ingress, valid only when using cert-manager-certificate source; specify multiple times for multiple targets (optional)").StringsVar(&cfg.CertManagerCertificateTargets)
+	app.Flag("haproxy-tcp-services-configmap", "The TCP services ConfigMap of the HAProxy Ingress Controller, in the format <namespace>/<name>, valid only when using haproxy-tcp-services source").StringVar(&cfg.HAProxyTCPServicesConfigMap)
+	app.Flag("haproxy-service", "The HAProxy service whose load balancer addresses are the targets of the haproxy-tcp-services source, in the format <namespace>/<name>, e.g. haproxy-ingress/haproxy-ingress").StringVar(&cfg.HAProxyService)
 
 	// Flags related to providers
 	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "transip", "webhook"}
 	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
+	app.Flag("additional-provider", "An additional DNS provider to route endpoints to, each with its own registry; specify multiple times for multiple providers (optional, options: "+strings.Join(providers, ", ")+")").EnumsVar(&cfg.AdditionalProviders, providers...)
+	app.Flag("provider-domain", "Route the endpoints of a domain to the given provider or additional provider, in the format <provider>=<domain>; specify multiple times for multiple domains (optional)").StringsVar(&cfg.ProviderDomains)
+	app.Flag("provider-specific-default", "Set a provider specific annotation on the records of a domain and its subdomains not annotated with it, in the format <domain>=<annotation>=<value>, e.g. internal.example.com=external-dns.alpha.kubernetes.io/cloudflare-proxied=false; specify multiple times for multiple defaults (optional)").StringsVar(&cfg.ProviderSpecificDefaults)
 	app.Flag("provider-cache-time", "The time to cache the DNS provider record list requests.").Default(defaultConfig.ProviderCacheTime.String()).DurationVar(&cfg.ProviderCacheTime)
 	app.Flag("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
 	app.Flag("exclude-domains", "Exclude subdomains (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
@@ -502,6 +647,7 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("google-batch-change-size", "When using the Google provider, set the maximum number of changes that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.GoogleBatchChangeSize)).IntVar(&cfg.GoogleBatchChangeSize)
 	app.Flag("google-batch-change-interval", "When using the Google provider, set the interval between batch changes.").Default(defaultConfig.GoogleBatchChangeInterval.String()).DurationVar(&cfg.GoogleBatchChangeInterval)
 	app.Flag("google-zone-visibility", "When using the Google provider, filter for zones with this visibility (optional, options: public, private)").Default(defaultConfig.GoogleZoneVisibility).EnumVar(&cfg.GoogleZoneVisibility, "", "public", "private")
+	app.Flag("google-continue-on-error", "When using the Google provider, apply the changes of a failed batch one record name at a time, continuing past the failed changes and reporting their errors, instead of failing the whole batch (default: disabled)").BoolVar(&cfg.GoogleContinueOnError)
 	app.Flag("alibaba-cloud-config-file", "When using the Alibaba Cloud provider, specify the Alibaba Cloud configuration file (required when --provider=alibabacloud)").Default(defaultConfig.AlibabaCloudConfigFile).StringVar(&cfg.AlibabaCloudConfigFile)
 	app.Flag("alibaba-cloud-zone-type", "When using the Alibaba Cloud provider, filter for zones of this type (optional, options: public, private)").Default(defaultConfig.AlibabaCloudZoneType).EnumVar(&cfg.AlibabaCloudZoneType, "", "public", "private")
 	app.Flag("aws-zone-type", "When using the AWS provider, filter for zones of this type (optional, options: public, private)").Default(defaultConfig.AWSZoneType).EnumVar(&cfg.AWSZoneType, "", "public", "private")
@@ -516,10 +662,13 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("aws-evaluate-target-health", "When using the AWS provider, set whether to evaluate the health of a DNS target (default: enabled, disable with --no-aws-evaluate-target-health)").Default(strconv.FormatBool(defaultConfig.AWSEvaluateTargetHealth)).BoolVar(&cfg.AWSEvaluateTargetHealth)
 	app.Flag("aws-api-retries", "When using the AWS API, set the maximum number of retries before giving up.").Default(strconv.Itoa(defaultConfig.AWSAPIRetries)).IntVar(&cfg.AWSAPIRetries)
 	app.Flag("aws-prefer-cname", "When using the AWS provider, prefer using CNAME instead of ALIAS (default: disabled)").BoolVar(&cfg.AWSPreferCNAME)
+	app.Flag("aws-prefer-cname-domain", "When using the AWS provider, prefer using CNAME instead of ALIAS only for the records of this domain and its subdomains; specify multiple times for multiple domains (optional)").StringsVar(&cfg.AWSPreferCNAMEDomains)
 	app.Flag("aws-zones-cache-duration", "When using the AWS provider, set the zones list cache TTL (0s to disable).").Default(defaultConfig.AWSZoneCacheDuration.String()).DurationVar(&cfg.AWSZoneCacheDuration)
 	app.Flag("aws-zone-match-parent", "Expand limit possible target by sub-domains (default: disabled)").BoolVar(&cfg.AWSZoneMatchParent)
 	app.Flag("aws-sd-service-cleanup", "When using the AWS CloudMap provider, delete empty Services without endpoints (default: disabled)").BoolVar(&cfg.AWSSDServiceCleanup)
 	app.Flag("aws-sd-create-tag", "When using the AWS CloudMap provider, add tag to created services. The flag can be used multiple times").StringMapVar(&cfg.AWSSDCreateTag)
+	app.Flag("aws-sd-service-ttl", "When using the AWS CloudMap provider, set the DNS TTL (in seconds) of the services of the endpoints without TTL (default: 300)").Default(strconv.FormatInt(defaultConfig.AWSSDServiceTTL, 10)).Int64Var(&cfg.AWSSDServiceTTL)
+	app.Flag("aws-sd-routing-policy", "When using the AWS CloudMap provider, set the routing policy of the services of the A and AAAA endpoints, CNAME and alias endpoints always use WEIGHTED (default: MULTIVALUE, options: MULTIVALUE, WEIGHTED)").Default(defaultConfig.AWSSDRoutingPolicy).EnumVar(&cfg.AWSSDRoutingPolicy, "", "MULTIVALUE", "WEIGHTED")
 	app.Flag("azure-config-file", "When using the Azure provider, specify the Azure configuration file (required when --provider=azure)").Default(defaultConfig.AzureConfigFile).StringVar(&cfg.AzureConfigFile)
 	app.Flag("azure-resource-group", "When using the Azure provider, override the Azure resource group to use (optional)").Default(defaultConfig.AzureResourceGroup).StringVar(&cfg.AzureResourceGroup)
 	app.Flag("azure-subscription-id", "When using the Azure provider, override the Azure subscription to use (optional)").Default(defaultConfig.AzureSubscriptionID).StringVar(&cfg.AzureSubscriptionID)
@@ -533,6 +682,7 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("cloudflare-custom-hostnames-certificate-authority", "When using the Cloudflare provider with the Custom Hostnames, specify which Certificate Authority will be used. A value of none indicates no Certificate Authority will be sent to the Cloudflare API (default: none, options: google, ssl_com, lets_encrypt, none)").Default("none").EnumVar(&cfg.CloudflareCustomHostnamesCertificateAuthority, "google", "ssl_com", "lets_encrypt", "none")
 	app.Flag("cloudflare-dns-records-per-page", "When using the Cloudflare provider, specify how many DNS records listed per page, max possible 5,000 (default: 100)").Default(strconv.Itoa(defaultConfig.CloudflareDNSRecordsPerPage)).IntVar(&cfg.CloudflareDNSRecordsPerPage)
 	app.Flag("cloudflare-regional-services", "When using the Cloudflare provider, specify if Regional Services feature will be used (default: disabled)").Default(strconv.FormatBool(defaultConfig.CloudflareRegionalServices)).BoolVar(&cfg.CloudflareRegionalServices)
+	app.Flag("cloudflare-load-balancers", "When using the Cloudflare provider, specify if endpoints with the cloudflare-load-balancer-pool annotation are published as load balancers bound to existing pools instead of DNS records (default: disabled)").Default(strconv.FormatBool(defaultConfig.CloudflareLoadBalancers)).BoolVar(&cfg.CloudflareLoadBalancers)
 	app.Flag("cloudflare-region-key", "When using the Cloudflare provider, specify the default region for Regional Services. Any value other than an empty string will enable the Regional Services feature (optional)").StringVar(&cfg.CloudflareRegionKey)
 	app.Flag("cloudflare-record-comment", "When using the Cloudflare provider, specify the comment for the DNS records (default: '')").Default("").StringVar(&cfg.CloudflareDNSRecordsComment)
 
@@ -543,19 +693,27 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("akamai-access-token", "When using the Akamai provider, specify the access token (required when --provider=akamai and edgerc-path not specified)").Default(defaultConfig.AkamaiAccessToken).StringVar(&cfg.AkamaiAccessToken)
 	app.Flag("akamai-edgerc-path", "When using the Akamai provider, specify the .edgerc file path. Path must be reachable form invocation environment. (required when --provider=akamai and *-token, secret serviceconsumerdomain not specified)").Default(defaultConfig.AkamaiEdgercPath).StringVar(&cfg.AkamaiEdgercPath)
 	app.Flag("akamai-edgerc-section", "When using the Akamai provider, specify the .edgerc file path (Optional when edgerc-path is specified)").Default(defaultConfig.AkamaiEdgercSection).StringVar(&cfg.AkamaiEdgercSection)
+	app.Flag("akamai-zone-contract", "When using the Akamai provider, manage the zone in the given contract in the format <zone>=<contractId>, also when the contract is not one of the --zone-id-filter contracts; specify multiple times for multiple zones (optional, no group is mapped: the groups only apply to the creation of the zones, which is out of scope)").StringsVar(&cfg.AkamaiZoneContracts)
 	app.Flag("oci-config-file", "When using the OCI provider, specify the OCI configuration file (required when --provider=oci").Default(defaultConfig.OCIConfigFile).StringVar(&cfg.OCIConfigFile)
 	app.Flag("oci-compartment-ocid", "When using the OCI provider, specify the OCID of the OCI compartment containing all managed zones and records.  Required when using OCI IAM instance principal authentication.").StringVar(&cfg.OCICompartmentOCID)
 	app.Flag("oci-zone-scope", "When using OCI provider, filter for zones with this scope (optional, options: GLOBAL, PRIVATE). Defaults to GLOBAL, setting to empty value will target both.").Default(defaultConfig.OCIZoneScope).EnumVar(&cfg.OCIZoneScope, "", "GLOBAL", "PRIVATE")
 	app.Flag("oci-auth-instance-principal", "When using the OCI provider, specify whether OCI IAM instance principal authentication should be used (instead of key-based auth via the OCI config file).").Default(strconv.FormatBool(defaultConfig.OCIAuthInstancePrincipal)).BoolVar(&cfg.OCIAuthInstancePrincipal)
 	app.Flag("oci-zones-cache-duration", "When using the OCI provider, set the zones list cache TTL (0s to disable).").Default(defaultConfig.OCIZoneCacheDuration.String()).DurationVar(&cfg.OCIZoneCacheDuration)
 	app.Flag("inmemory-zone", "Provide a list of pre-configured zones for the inmemory provider; specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.InMemoryZones)
+	app.Flag("inmemory-default-ttl", "When using the inmemory provider, set the TTL (in seconds) of the records without TTL, like the default TTL of the real providers (default: 0, no TTL)").Default(strconv.FormatInt(defaultConfig.InMemoryDefaultTTL, 10)).Int64Var(&cfg.InMemoryDefaultTTL)
+	app.Flag("inmemory-min-ttl", "When using the inmemory provider, set the minimal TTL (in seconds) of the records; the lower TTLs are raised to it (default: 0, no limit)").Default(strconv.FormatInt(defaultConfig.InMemoryMinTTL, 10)).Int64Var(&cfg.InMemoryMinTTL)
+	app.Flag("inmemory-max-ttl", "When using the inmemory provider, set the maximal TTL (in seconds) of the records; the higher TTLs are lowered to it (default: 0, no limit)").Default(strconv.FormatInt(defaultConfig.InMemoryMaxTTL, 10)).Int64Var(&cfg.InMemoryMaxTTL)
 	app.Flag("ovh-endpoint", "When using the OVH provider, specify the endpoint (default: ovh-eu)").Default(defaultConfig.OVHEndpoint).StringVar(&cfg.OVHEndpoint)
 	app.Flag("ovh-api-rate-limit", "When using the OVH provider, specify the API request rate limit, X operations by seconds (default: 20)").Default(strconv.Itoa(defaultConfig.OVHApiRateLimit)).IntVar(&cfg.OVHApiRateLimit)
 	app.Flag("ovh-enable-cname-relative", "When using the OVH provider, specify if CNAME should be treated as relative on target without final dot (default: false)").Default(strconv.FormatBool(defaultConfig.OVHEnableCNAMERelative)).BoolVar(&cfg.OVHEnableCNAMERelative)
+	app.Flag("ovh-use-zone-export", "When using the OVH provider, specify if the records should be read from the zone export in a single call instead of one call per record (default: false)").Default(strconv.FormatBool(defaultConfig.OVHUseZoneExport)).BoolVar(&cfg.OVHUseZoneExport)
 	app.Flag("pdns-server", "When using the PowerDNS/PDNS provider, specify the URL to the pdns server (required when --provider=pdns)").Default(defaultConfig.PDNSServer).StringVar(&cfg.PDNSServer)
 	app.Flag("pdns-server-id", "When using the PowerDNS/PDNS provider, specify the id of the server to retrieve. Should be `localhost` except when the server is behind a proxy (optional when --provider=pdns) (default: localhost)").Default(defaultConfig.PDNSServerID).StringVar(&cfg.PDNSServerID)
 	app.Flag("pdns-api-key", "When using the PowerDNS/PDNS provider, specify the API key to use to authorize requests (required when --provider=pdns)").Default(defaultConfig.PDNSAPIKey).StringVar(&cfg.PDNSAPIKey)
 	app.Flag("pdns-skip-tls-verify", "When using the PowerDNS/PDNS provider, disable verification of any TLS certificates (optional when --provider=pdns) (default: false)").Default(strconv.FormatBool(defaultConfig.PDNSSkipTLSVerify)).BoolVar(&cfg.PDNSSkipTLSVerify)
+	app.Flag("pdns-create-zones", "When using the PowerDNS/PDNS provider, create the zones of the domain filter missing from the server before applying the changes (optional when --provider=pdns) (default: false)").BoolVar(&cfg.PDNSCreateZones)
+	app.Flag("pdns-zone-nameserver", "When using the PowerDNS/PDNS provider, the nameserver of the created zones, with the trailing dot; specify multiple times for multiple nameservers (optional when --provider=pdns)").StringsVar(&cfg.PDNSZoneNameservers)
+	app.Flag("pdns-zone-soa", "When using the PowerDNS/PDNS provider, the content of the SOA record of the created zones (optional when --provider=pdns) (default: the server default)").Default(defaultConfig.PDNSZoneSOA).StringVar(&cfg.PDNSZoneSOA)
 	app.Flag("ns1-endpoint", "When using the NS1 provider, specify the URL of the API endpoint to target (default: https://api.nsone.net/v1/)").Default(defaultConfig.NS1Endpoint).StringVar(&cfg.NS1Endpoint)
 	app.Flag("ns1-ignoressl", "When using the NS1 provider, specify whether to verify the SSL certificate (default: false)").Default(strconv.FormatBool(defaultConfig.NS1IgnoreSSL)).BoolVar(&cfg.NS1IgnoreSSL)
 	app.Flag("ns1-min-ttl", "Minimal TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is lower than this.").IntVar(&cfg.NS1MinTTLSeconds)
@@ -565,6 +723,7 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("godaddy-api-secret", "When using the GoDaddy provider, specify the API secret (required when --provider=godaddy)").Default(defaultConfig.GoDaddySecretKey).StringVar(&cfg.GoDaddySecretKey)
 	app.Flag("godaddy-api-ttl", "TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is not provided.").Int64Var(&cfg.GoDaddyTTL)
 	app.Flag("godaddy-api-ote", "When using the GoDaddy provider, use OTE api (optional, default: false, when --provider=godaddy)").BoolVar(&cfg.GoDaddyOTE)
+	app.Flag("godaddy-api-rate-limit", "When using the GoDaddy provider, specify the API request rate limit, X requests by minute (default: 60)").Default(strconv.Itoa(defaultConfig.GoDaddyAPIRateLimit)).IntVar(&cfg.GoDaddyAPIRateLimit)
 
 	// Flags related to TLS communication
 	app.Flag("tls-ca", "When using TLS communication, the path to the certificate authority to verify server communications (optionally specify --tls-client-cert for two-way TLS)").Default(defaultConfig.TLSCA).StringVar(&cfg.TLSCA)
@@ -613,10 +772,23 @@ func App(cfg *Config) *kingpin.Application {
 
 	// Flags related to policies
 	app.Flag("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only)").Default(defaultConfig.Policy).EnumVar(&cfg.Policy, "sync", "upsert-only", "create-only")
+	app.Flag("record-type-priority", "When CNAME and other record types are desired for the same domain, the record type that wins, e.g. CNAME; specify multiple times to order several record types (default: A, AAAA and other types win over CNAME)").StringsVar(&cfg.RecordTypePriority)
+	app.Flag("record-type-replacement", "When the records of a domain change between CNAME and A/AAAA, only create the new records along with the deletion of the current ones, which the policy must allow (default: disabled)").BoolVar(&cfg.RecordTypeReplacement)
+	app.Flag("delete-after-create", "Apply the deletes after the creates and updates of a synchronization, in a separate batch, for the providers that don't apply changes atomically (default: disabled)").BoolVar(&cfg.DeleteAfterCreate)
+	app.Flag("max-deletions-per-run", "The maximum number of records deleted by a synchronization, the excess deletions are deferred to the next synchronizations along with the creations of the records replacing them (default: 0, not limited)").Default(strconv.Itoa(defaultConfig.MaxDeletionsPerRun)).IntVar(&cfg.MaxDeletionsPerRun)
+	app.Flag("external-records-regex", "Never change the records whose DNS name matches this regex, e.g. the records managed by other tools in the managed zones (optional)").Default(defaultConfig.ExternalRecordsRegex.String()).RegexpVar(&cfg.ExternalRecordsRegex)
+	app.Flag("external-records-txt-marker", "Never change the records of the DNS names with a TXT record of this value, e.g. the records managed by other tools in the managed zones (optional)").Default(defaultConfig.ExternalRecordsTXTMarker).StringVar(&cfg.ExternalRecordsTXTMarker)
+	app.Flag("allow-apex-soa-ns", "Allow changes to the SOA and NS records at the zone apex, which are otherwise never changed to protect the zones (default: disabled)").BoolVar(&cfg.AllowApexSOANS)
+	app.Flag("record-provenance", "Embed the owner ID, source type and cluster name in the comments of the created records, when supported by the provider (default: disabled, supported: cloudflare, pdns)").BoolVar(&cfg.RecordProvenance)
+	app.Flag("record-provenance-cluster-name", "When using --record-provenance, the name of the cluster to embed in the comments of the created records (optional)").Default(defaultConfig.RecordProvenanceClusterName).StringVar(&cfg.RecordProvenanceClusterName)
 
 	// Flags related to the registry
-	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd")
+	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd, comment)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd", "comment")
 	app.Flag("txt-owner-id", "When using the TXT or DynamoDB registry, a name that identifies this instance of ExternalDNS (default: default)").Default(defaultConfig.TXTOwnerID).StringVar(&cfg.TXTOwnerID)
+	app.Flag("txt-adopt-owner-id", "When using the TXT registry, an owner id whose records are taken over by this instance, rewriting their ownership to --txt-owner-id; specify multiple times to adopt the records of many owner ids (optional)").Default().StringsVar(&cfg.TXTAdoptOwnerIDs)
+	app.Flag("txt-repair-ownership", "When using the TXT registry, rewrite in the canonical format the malformed TXT records that can be recovered and have the owner id of this instance, instead of treating their records as unowned (default: disabled)").BoolVar(&cfg.TXTRepairOwnership)
+	app.Flag("txt-skip-record-types", "When using the TXT registry, record types for which no TXT records are created; their records are considered owned by this instance, which requires --policy=sync; specify multiple times for multiple record types (optional)").Default().StringsVar(&cfg.TXTSkipRecordTypes)
+	app.Flag("txt-ignore-foreign-records", "When using the TXT registry, leave alone the names holding TXT records not written by external-dns, e.g. by another controller: no registry TXT records are written there, and the records whose registry TXT records would be there are not created (default: disabled)").BoolVar(&cfg.TXTIgnoreForeignRecords)
 	app.Flag("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain record type template like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!").Default(defaultConfig.TXTPrefix).StringVar(&cfg.TXTPrefix)
 	app.Flag("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain record type template like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!").Default(defaultConfig.TXTSuffix).StringVar(&cfg.TXTSuffix)
 	app.Flag("txt-wildcard-replacement", "When using the TXT registry, a custom string that's used instead of an asterisk for TXT records corresponding to wildcard DNS records (optional)").Default(defaultConfig.TXTWildcardReplacement).StringVar(&cfg.TXTWildcardReplacement)
@@ -628,20 +800,30 @@ func App(cfg *Config) *kingpin.Application {
 	// Flags related to the main control loop
 	app.Flag("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)").Default(defaultConfig.TXTCacheInterval.String()).DurationVar(&cfg.TXTCacheInterval)
 	app.Flag("interval", "The interval between two consecutive synchronizations in duration format (default: 1m)").Default(defaultConfig.Interval.String()).DurationVar(&cfg.Interval)
+	app.Flag("source-interval", "The interval between two consecutive polls of a source in the format <source>=<duration>, e.g. node=10m; the source is polled by the first synchronization once the interval elapsed, and on its events with --events (optional, can be specified multiple times)").StringsVar(&cfg.SourceIntervals)
 	app.Flag("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)").Default(defaultConfig.MinEventSyncInterval.String()).DurationVar(&cfg.MinEventSyncInterval)
+	app.Flag("provider-failure-threshold", "The number of consecutive soft errors of the provider after which the synchronizations back off exponentially, starting from twice the interval (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.ProviderFailureThreshold)).IntVar(&cfg.ProviderFailureThreshold)
+	app.Flag("provider-max-backoff", "The maximum backoff between two consecutive synchronizations once the provider failure threshold is reached, in duration format (default: 30m)").Default(defaultConfig.ProviderMaxBackoff.String()).DurationVar(&cfg.ProviderMaxBackoff)
 	app.Flag("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)").BoolVar(&cfg.Once)
 	app.Flag("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)").BoolVar(&cfg.DryRun)
+	app.Flag("cleanup-orphans", "When enabled, lists the records owned by the txt-owner-id whose source objects no longer exist and exits, instead of running the synchronization loop (default: disabled)").BoolVar(&cfg.CleanupOrphans)
+	app.Flag("cleanup-orphans-confirm", "When enabled with cleanup-orphans, deletes the orphaned records instead of only listing them (default: disabled)").BoolVar(&cfg.CleanupOrphansConfirm)
 	app.Flag("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)").BoolVar(&cfg.UpdateEvents)
+	app.Flag("events-source", "Limit the reconciliations triggered by --events to the changes of the given sources, all the sources when not specified (optional, can be specified multiple times)").StringsVar(&cfg.UpdateEventsSources)
+	app.Flag("reconcile-on-secret-change", "Watch the Secret holding the provider credentials, given as <namespace>/<name>, and rebuild the provider and trigger a reconciliation when it changes (optional)").Default(defaultConfig.ReconcileOnSecretChange).StringVar(&cfg.ReconcileOnSecretChange)
 
 	// Miscellaneous flags
 	app.Flag("log-format", "The format in which log messages are printed (default: text, options: text, json)").Default(defaultConfig.LogFormat).EnumVar(&cfg.LogFormat, "text", "json")
 	app.Flag("metrics-address", "Specify where to serve the metrics and health check endpoint (default: :7979)").Default(defaultConfig.MetricsAddress).StringVar(&cfg.MetricsAddress)
 	app.Flag("log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)").Default(defaultConfig.LogLevel).EnumVar(&cfg.LogLevel, allLogLevelsAsStrings()...)
+	app.Flag("log-level-endpoint", "When enabled, serves the /loglevel endpoint on the metrics address to get (GET) and set (PUT) the log level at runtime (default: disabled)").BoolVar(&cfg.LogLevelEndpoint)
 
 	// Webhook provider
 	app.Flag("webhook-provider-url", "The URL of the remote endpoint to call for the webhook provider (default: http://localhost:8888)").Default(defaultConfig.WebhookProviderURL).StringVar(&cfg.WebhookProviderURL)
 	app.Flag("webhook-provider-read-timeout", "The read timeout for the webhook provider in duration format (default: 5s)").Default(defaultConfig.WebhookProviderReadTimeout.String()).DurationVar(&cfg.WebhookProviderReadTimeout)
 	app.Flag("webhook-provider-write-timeout", "The write timeout for the webhook provider in duration format (default: 10s)").Default(defaultConfig.WebhookProviderWriteTimeout.String()).DurationVar(&cfg.WebhookProviderWriteTimeout)
+	app.Flag("webhook-provider-max-attempts", "The number of times idempotent requests (records and adjustendpoints) to the webhook provider are attempted on connection errors or 5xx responses (default: 1, no retries)").Default(strconv.Itoa(defaultConfig.WebhookProviderMaxAttempts)).IntVar(&cfg.WebhookProviderMaxAttempts)
+	app.Flag("webhook-provider-retry-base-delay", "The initial delay of the exponential backoff between attempts to the webhook provider in duration format (default: 500ms)").Default(defaultConfig.WebhookProviderRetryBaseDelay.String()).DurationVar(&cfg.WebhookProviderRetryBaseDelay)
 
 	app.Flag("webhook-server", "When enabled, runs as a webhook server instead of a controller. (default: false).").BoolVar(&cfg.WebhookServer)
 
@@ -49,6 +49,7 @@ var (
 		ExcludeDomains:                         []string{""},
 		RegexDomainFilter:                      regexp.MustCompile(""),
 		RegexDomainExclusion:                   regexp.MustCompile(""),
+		ExternalRecordsRegex:                   regexp.MustCompile(""),
 		ZoneNameFilter:                         []string{""},
 		ZoneIDFilter:                           []string{""},
 		AlibabaCloudConfigFile:                 "/etc/kubernetes/alibaba-cloud.json",
@@ -68,6 +69,7 @@ var (
 		AWSZoneCacheDuration:                   0 * time.Second,
 		AWSSDServiceCleanup:                    false,
 		AWSSDCreateTag:                         map[string]string{},
+		AWSSDServiceTTL:                        300,
 		AWSDynamoDBTable:                       "external-dns",
 		AzureConfigFile:                        "/etc/kubernetes/azure.json",
 		AzureResourceGroup:                     "",
@@ -93,6 +95,7 @@ var (
 		InMemoryZones:                                 []string{""},
 		OVHEndpoint:                                   "ovh-eu",
 		OVHApiRateLimit:                               20,
+		GoDaddyAPIRateLimit:                           60,
 		PDNSServer:                                    "http://localhost:8081",
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "",
@@ -103,6 +106,7 @@ var (
 		TXTCacheInterval:                              0,
 		Interval:                                      time.Minute,
 		MinEventSyncInterval:                          5 * time.Second,
+		ProviderMaxBackoff:                            30 * time.Minute,
 		Once:                                          false,
 		DryRun:                                        false,
 		UpdateEvents:                                  false,
@@ -128,12 +132,15 @@ var (
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
+		WebhookProviderMaxAttempts:                    1,
+		WebhookProviderRetryBaseDelay:                 500 * time.Millisecond,
 		ExcludeUnschedulable:                          true,
 	}
 
 	overriddenConfig = &Config{
 		APIServerURL:                           "http://127.0.0.1:8080",
 		KubeConfig:                             "/some/path",
+		SourceClusters:                         []string{"/other/path=other", "/third/path"},
 		RequestTimeout:                         time.Second * 77,
 		GlooNamespaces:                         []string{"gloo-not-system", "gloo-second-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v2",
@@ -142,6 +149,9 @@ var (
 		IgnoreHostnameAnnotation:               true,
 		IgnoreNonHostNetworkPods:               true,
 		IgnoreIngressTLSSpec:                   true,
+		IngressClassServices:                   []string{"nginx=ingress-nginx/ingress-nginx-controller"},
+		IngressClassTargets:                    []string{"nginx=192.0.2.10", "nginx=192.0.2.11"},
+		ConfigMapSourceNames:                   []string{"default/static-records"},
 		IgnoreIngressRulesSpec:                 true,
 		FQDNTemplate:                           "{{.Name}}.service.example.com",
 		Compatibility:                          "mate",
@@ -150,6 +160,7 @@ var (
 		GoogleBatchChangeSize:                  100,
 		GoogleBatchChangeInterval:              time.Second * 2,
 		GoogleZoneVisibility:                   "private",
+		GoogleContinueOnError:                  true,
 		DomainFilter:                           []string{"example.org", "company.com"},
 		ExcludeDomains:                         []string{"xapi.example.org", "xapi.company.com"},
 		RegexDomainFilter:                      regexp.MustCompile("(example\\.org|company\\.com)$"),
@@ -171,10 +182,13 @@ var (
 		AWSEvaluateTargetHealth:                false,
 		AWSAPIRetries:                          13,
 		AWSPreferCNAME:                         true,
+		AWSPreferCNAMEDomains:                  []string{"cross.example.org"},
 		AWSProfiles:                            []string{"profile1", "profile2"},
 		AWSZoneCacheDuration:                   10 * time.Second,
 		AWSSDServiceCleanup:                    true,
 		AWSSDCreateTag:                         map[string]string{"key1": "value1", "key2": "value2"},
+		AWSSDServiceTTL:                        60,
+		AWSSDRoutingPolicy:                     "WEIGHTED",
 		AWSDynamoDBTable:                       "custom-table",
 		AzureConfigFile:                        "azure.json",
 		AzureResourceGroup:                     "arg",
@@ -186,6 +200,7 @@ var (
 		CloudflareCustomHostnamesCertificateAuthority: "google",
 		CloudflareDNSRecordsPerPage:                   5000,
 		CloudflareRegionalServices:                    true,
+		CloudflareLoadBalancers:                       true,
 		CloudflareRegionKey:                           "us",
 		CoreDNSPrefix:                                 "/coredns/",
 		AkamaiServiceConsumerDomain:                   "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
@@ -194,33 +209,81 @@ var (
 		AkamaiAccessToken:                             "o184671d5307a388180fbf7f11dbdf46",
 		AkamaiEdgercPath:                              "/home/test/.edgerc",
 		AkamaiEdgercSection:                           "default",
+		AkamaiZoneContracts:                           []string{"example.org=ctr-1", "company.com=ctr-2"},
 		OCIConfigFile:                                 "oci.yaml",
 		OCIZoneScope:                                  "PRIVATE",
 		OCIZoneCacheDuration:                          30 * time.Second,
 		InMemoryZones:                                 []string{"example.org", "company.com"},
+		InMemoryDefaultTTL:                            300,
+		InMemoryMinTTL:                                60,
+		InMemoryMaxTTL:                                86400,
 		OVHEndpoint:                                   "ovh-ca",
 		OVHApiRateLimit:                               42,
+		GoDaddyAPIRateLimit:                           30,
+		OVHUseZoneExport:                              true,
 		PDNSServer:                                    "http://ns.example.com:8081",
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "some-secret-key",
 		PDNSSkipTLSVerify:                             true,
+		PDNSCreateZones:                               true,
+		PDNSZoneNameservers:                           []string{"ns1.example.org.", "ns2.example.org."},
+		PDNSZoneSOA:                                   "ns1.example.org. hostmaster.example.org. 1 10800 3600 604800 3600",
 		TLSCA:                                         "/path/to/ca.crt",
 		TLSClientCert:                                 "/path/to/cert.pem",
 		TLSClientCertKey:                              "/path/to/key.pem",
 		PodSourceDomain:                               "example.org",
 		Policy:                                        "upsert-only",
+		RecordTypeReplacement:                         true,
+		DeleteAfterCreate:                             true,
+		MaxDeletionsPerRun:                            10,
+		ExternalRecordsRegex:                          regexp.MustCompile("^legacy\\."),
+		ExternalRecordsTXTMarker:                      "managed-by=terraform",
+		AllowApexSOANS:                                true,
+		GatewayAddressTypes:                           []string{"IPAddress", "Hostname"},
+		GatewayFallbackTargets:                        []string{"192.0.2.1", "192.0.2.2"},
+		GatewayRequireProgrammed:                      true,
+		FlattenMultiTargetCNAME:                       true,
+		PublishHeadlessSRV:                            true,
+		ServiceAggregateHostname:                      "ingress.example.org",
+		MergeTXTRecords:                               true,
+		RecordTypePriority:                            []string{"CNAME", "A"},
+		F5VirtualServerAllowedHosts:                   []string{"example.org"},
+		F5VirtualServerDeniedHosts:                    []string{"apex.example.org"},
+		F5RequireHealthyPoolMembers:                   true,
+		KnativeGatewayService:                         "kourier-system/kourier",
+		CertManagerCertificateTargets:                 []string{"ingress.example.org"},
+		HAProxyTCPServicesConfigMap:                   "haproxy-ingress/tcp-services",
+		HAProxyService:                                "haproxy-ingress/haproxy-ingress",
+		HostnameSourcePriority:                        []string{"spec", "annotation"},
+		ProviderFailureThreshold:                      5,
+		ProviderMaxBackoff:                            time.Hour,
+		AdditionalProviders:                           []string{"coredns"},
+		ProviderDomains:                               []string{"coredns=cluster.local"},
+		ProviderSpecificDefaults:                      []string{"internal.example.com=external-dns.alpha.kubernetes.io/cloudflare-proxied=false"},
+		RecordProvenance:                              true,
+		RecordProvenanceClusterName:                   "production",
 		Registry:                                      "noop",
 		TXTOwnerID:                                    "owner-1",
+		TXTAdoptOwnerIDs:                              []string{"owner-0", "owner-legacy"},
+		TXTRepairOwnership:                            true,
+		TXTSkipRecordTypes:                            []string{"NS", "MX"},
+		TXTIgnoreForeignRecords:                       true,
 		TXTPrefix:                                     "associated-txt-record",
 		TXTCacheInterval:                              12 * time.Hour,
 		Interval:                                      10 * time.Minute,
 		MinEventSyncInterval:                          50 * time.Second,
 		Once:                                          true,
 		DryRun:                                        true,
+		CleanupOrphans:                                true,
+		CleanupOrphansConfirm:                         true,
 		UpdateEvents:                                  true,
+		UpdateEventsSources:                           []string{"ingress", "service"},
+		SourceIntervals:                               []string{"node=10m", "service=5m"},
+		ReconcileOnSecretChange:                       "external-dns/credentials",
 		LogFormat:                                     "json",
 		MetricsAddress:                                "127.0.0.1:9099",
 		LogLevel:                                      logrus.DebugLevel.String(),
+		LogLevelEndpoint:                              true,
 		ConnectorSourceServer:                         "localhost:8081",
 		ExoscaleAPIEnvironment:                        "api1",
 		ExoscaleAPIZone:                               "zone1",
@@ -228,6 +291,11 @@ var (
 		ExoscaleAPISecret:                             "2",
 		CRDSourceAPIVersion:                           "test.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "Endpoint",
+		CRDSourceClusterScoped:                        true,
+		CRDSourceFinalizer:                            true,
+		CRDSourceHostnameJSONPath:                     "{.spec.host}",
+		CRDSourceTargetsJSONPath:                      "{.status.addresses[*]}",
+		CRDSourceTTLJSONPath:                          "{.spec.ttl}",
 		NS1Endpoint:                                   "https://api.example.com/v1",
 		NS1IgnoreSSL:                                  true,
 		TransIPAccountName:                            "transip",
@@ -241,7 +309,10 @@ var (
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
+		WebhookProviderMaxAttempts:                    3,
+		WebhookProviderRetryBaseDelay:                 time.Second,
 		ExcludeUnschedulable:                          false,
+		ServiceCiliumLoadBalancerIPAM:                 true,
 	}
 )
 
@@ -267,6 +338,8 @@ func TestParseFlags(t *testing.T) {
 			args: []string{
 				"--server=http://127.0.0.1:8080",
 				"--kubeconfig=/some/path",
+				"--source-cluster=/other/path=other",
+				"--source-cluster=/third/path",
 				"--request-timeout=77s",
 				"--gloo-namespace=gloo-not-system",
 				"--gloo-namespace=gloo-second-system",
@@ -279,6 +352,10 @@ func TestParseFlags(t *testing.T) {
 				"--ignore-non-host-network-pods",
 				"--ignore-hostname-annotation",
 				"--ignore-ingress-tls-spec",
+				"--ingress-class-service=nginx=ingress-nginx/ingress-nginx-controller",
+				"--ingress-class-target=nginx=192.0.2.10",
+				"--ingress-class-target=nginx=192.0.2.11",
+				"--configmap-source-name=default/static-records",
 				"--ignore-ingress-rules-spec",
 				"--compatibility=mate",
 				"--provider=google",
@@ -286,16 +363,20 @@ func TestParseFlags(t *testing.T) {
 				"--google-batch-change-size=100",
 				"--google-batch-change-interval=2s",
 				"--google-zone-visibility=private",
+				"--google-continue-on-error",
 				"--azure-config-file=azure.json",
 				"--azure-resource-group=arg",
 				"--azure-subscription-id=arg",
 				"--azure-maxretries-count=4",
+				"--webhook-provider-max-attempts=3",
+				"--webhook-provider-retry-base-delay=1s",
 				"--cloudflare-proxied",
 				"--cloudflare-custom-hostnames",
 				"--cloudflare-custom-hostnames-min-tls-version=1.3",
 				"--cloudflare-custom-hostnames-certificate-authority=google",
 				"--cloudflare-dns-records-per-page=5000",
 				"--cloudflare-regional-services",
+				"--cloudflare-load-balancers",
 				"--cloudflare-region-key=us",
 				"--coredns-prefix=/coredns/",
 				"--akamai-serviceconsumerdomain=oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
@@ -304,14 +385,25 @@ func TestParseFlags(t *testing.T) {
 				"--akamai-access-token=o184671d5307a388180fbf7f11dbdf46",
 				"--akamai-edgerc-path=/home/test/.edgerc",
 				"--akamai-edgerc-section=default",
+				"--akamai-zone-contract=example.org=ctr-1",
+				"--akamai-zone-contract=company.com=ctr-2",
 				"--inmemory-zone=example.org",
 				"--inmemory-zone=company.com",
+				"--inmemory-default-ttl=300",
+				"--inmemory-min-ttl=60",
+				"--inmemory-max-ttl=86400",
 				"--ovh-endpoint=ovh-ca",
 				"--ovh-api-rate-limit=42",
+				"--godaddy-api-rate-limit=30",
+				"--ovh-use-zone-export",
 				"--pdns-server=http://ns.example.com:8081",
 				"--pdns-server-id=localhost",
 				"--pdns-api-key=some-secret-key",
 				"--pdns-skip-tls-verify",
+				"--pdns-create-zones",
+				"--pdns-zone-nameserver=ns1.example.org.",
+				"--pdns-zone-nameserver=ns2.example.org.",
+				"--pdns-zone-soa=ns1.example.org. hostmaster.example.org. 1 10800 3600 604800 3600",
 				"--oci-config-file=oci.yaml",
 				"--oci-zone-scope=PRIVATE",
 				"--oci-zones-cache-duration=30s",
@@ -344,17 +436,59 @@ func TestParseFlags(t *testing.T) {
 				"--aws-batch-change-interval=2s",
 				"--aws-api-retries=13",
 				"--aws-prefer-cname",
+				"--aws-prefer-cname-domain=cross.example.org",
 				"--aws-profile=profile1",
 				"--aws-profile=profile2",
 				"--aws-zones-cache-duration=10s",
 				"--aws-sd-service-cleanup",
 				"--aws-sd-create-tag=key1=value1",
 				"--aws-sd-create-tag=key2=value2",
+				"--aws-sd-service-ttl=60",
+				"--aws-sd-routing-policy=WEIGHTED",
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
 				"--policy=upsert-only",
+				"--record-type-replacement",
+				"--delete-after-create",
+				"--max-deletions-per-run=10",
+				"--external-records-regex=^legacy\\.",
+				"--external-records-txt-marker=managed-by=terraform",
+				"--allow-apex-soa-ns",
+				"--gateway-address-type=IPAddress",
+				"--gateway-address-type=Hostname",
+				"--gateway-fallback-target=192.0.2.1",
+				"--gateway-fallback-target=192.0.2.2",
+				"--gateway-require-programmed",
+				"--flatten-multi-target-cname",
+				"--publish-headless-srv",
+				"--service-aggregate-hostname=ingress.example.org",
+				"--merge-txt-records",
+				"--record-type-priority=CNAME",
+				"--record-type-priority=A",
+				"--f5-virtualserver-allowed-host=example.org",
+				"--f5-virtualserver-denied-host=apex.example.org",
+				"--f5-require-healthy-pool-members",
+				"--knative-gateway-service=kourier-system/kourier",
+				"--cert-manager-certificate-target=ingress.example.org",
+				"--haproxy-tcp-services-configmap=haproxy-ingress/tcp-services",
+				"--haproxy-service=haproxy-ingress/haproxy-ingress",
+				"--hostname-source-priority=spec",
+				"--hostname-source-priority=annotation",
+				"--provider-failure-threshold=5",
+				"--provider-max-backoff=1h",
+				"--additional-provider=coredns",
+				"--provider-domain=coredns=cluster.local",
+				"--provider-specific-default=internal.example.com=external-dns.alpha.kubernetes.io/cloudflare-proxied=false",
+				"--record-provenance",
+				"--record-provenance-cluster-name=production",
 				"--registry=noop",
 				"--txt-owner-id=owner-1",
+				"--txt-adopt-owner-id=owner-0",
+				"--txt-adopt-owner-id=owner-legacy",
+				"--txt-repair-ownership",
+				"--txt-skip-record-types=NS",
+				"--txt-skip-record-types=MX",
+				"--txt-ignore-foreign-records",
 				"--txt-prefix=associated-txt-record",
 				"--txt-cache-interval=12h",
 				"--dynamodb-table=custom-table",
@@ -362,10 +496,18 @@ func TestParseFlags(t *testing.T) {
 				"--min-event-sync-interval=50s",
 				"--once",
 				"--dry-run",
+				"--cleanup-orphans",
+				"--cleanup-orphans-confirm",
 				"--events",
+				"--events-source=ingress",
+				"--events-source=service",
+				"--source-interval=node=10m",
+				"--source-interval=service=5m",
+				"--reconcile-on-secret-change=external-dns/credentials",
 				"--log-format=json",
 				"--metrics-address=127.0.0.1:9099",
 				"--log-level=debug",
+				"--log-level-endpoint",
 				"--connector-source-server=localhost:8081",
 				"--exoscale-apienv=api1",
 				"--exoscale-apizone=zone1",
@@ -373,6 +515,11 @@ func TestParseFlags(t *testing.T) {
 				"--exoscale-apisecret=2",
 				"--crd-source-apiversion=test.k8s.io/v1alpha1",
 				"--crd-source-kind=Endpoint",
+				"--crd-source-cluster-scoped",
+				"--crd-source-finalizer",
+				"--crd-source-hostname-jsonpath={.spec.host}",
+				"--crd-source-targets-jsonpath={.status.addresses[*]}",
+				"--crd-source-ttl-jsonpath={.spec.ttl}",
 				"--ns1-endpoint=https://api.example.com/v1",
 				"--ns1-ignoressl",
 				"--transip-account=transip",
@@ -383,6 +530,7 @@ func TestParseFlags(t *testing.T) {
 				"--managed-record-types=CNAME",
 				"--managed-record-types=NS",
 				"--no-exclude-unschedulable",
+				"--service-cilium-lb-ipam",
 				"--rfc2136-batch-change-size=100",
 				"--rfc2136-load-balancing-strategy=round-robin",
 				"--rfc2136-host=rfc2136-host1",
@@ -397,6 +545,7 @@ func TestParseFlags(t *testing.T) {
 			envVars: map[string]string{
 				"EXTERNAL_DNS_SERVER":                                            "http://127.0.0.1:8080",
 				"EXTERNAL_DNS_KUBECONFIG":                                        "/some/path",
+				"EXTERNAL_DNS_SOURCE_CLUSTER":                                    "/other/path=other\n/third/path",
 				"EXTERNAL_DNS_REQUEST_TIMEOUT":                                   "77s",
 				"EXTERNAL_DNS_CONTOUR_LOAD_BALANCER":                             "heptio-contour-other/contour-other",
 				"EXTERNAL_DNS_GLOO_NAMESPACE":                                    "gloo-not-system\ngloo-second-system",
@@ -407,6 +556,9 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_IGNORE_NON_HOST_NETWORK_PODS":                      "1",
 				"EXTERNAL_DNS_IGNORE_HOSTNAME_ANNOTATION":                        "1",
 				"EXTERNAL_DNS_IGNORE_INGRESS_TLS_SPEC":                           "1",
+				"EXTERNAL_DNS_INGRESS_CLASS_SERVICE":                             "nginx=ingress-nginx/ingress-nginx-controller",
+				"EXTERNAL_DNS_INGRESS_CLASS_TARGET":                              "nginx=192.0.2.10\nnginx=192.0.2.11",
+				"EXTERNAL_DNS_CONFIGMAP_SOURCE_NAME":                             "default/static-records",
 				"EXTERNAL_DNS_IGNORE_INGRESS_RULES_SPEC":                         "1",
 				"EXTERNAL_DNS_COMPATIBILITY":                                     "mate",
 				"EXTERNAL_DNS_PROVIDER":                                          "google",
@@ -414,16 +566,20 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_GOOGLE_BATCH_CHANGE_SIZE":                          "100",
 				"EXTERNAL_DNS_GOOGLE_BATCH_CHANGE_INTERVAL":                      "2s",
 				"EXTERNAL_DNS_GOOGLE_ZONE_VISIBILITY":                            "private",
+				"EXTERNAL_DNS_GOOGLE_CONTINUE_ON_ERROR":                          "1",
 				"EXTERNAL_DNS_AZURE_CONFIG_FILE":                                 "azure.json",
 				"EXTERNAL_DNS_AZURE_RESOURCE_GROUP":                              "arg",
 				"EXTERNAL_DNS_AZURE_SUBSCRIPTION_ID":                             "arg",
 				"EXTERNAL_DNS_AZURE_MAXRETRIES_COUNT":                            "4",
+				"EXTERNAL_DNS_WEBHOOK_PROVIDER_MAX_ATTEMPTS":                     "3",
+				"EXTERNAL_DNS_WEBHOOK_PROVIDER_RETRY_BASE_DELAY":                 "1s",
 				"EXTERNAL_DNS_CLOUDFLARE_PROXIED":                                "1",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES":                       "1",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES_MIN_TLS_VERSION":       "1.3",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES_CERTIFICATE_AUTHORITY": "google",
 				"EXTERNAL_DNS_CLOUDFLARE_DNS_RECORDS_PER_PAGE":                   "5000",
 				"EXTERNAL_DNS_CLOUDFLARE_REGIONAL_SERVICES":                      "1",
+				"EXTERNAL_DNS_CLOUDFLARE_LOAD_BALANCERS":                         "1",
 				"EXTERNAL_DNS_CLOUDFLARE_REGION_KEY":                             "us",
 				"EXTERNAL_DNS_COREDNS_PREFIX":                                    "/coredns/",
 				"EXTERNAL_DNS_AKAMAI_SERVICECONSUMERDOMAIN":                      "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
@@ -432,12 +588,18 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AKAMAI_ACCESS_TOKEN":                               "o184671d5307a388180fbf7f11dbdf46",
 				"EXTERNAL_DNS_AKAMAI_EDGERC_PATH":                                "/home/test/.edgerc",
 				"EXTERNAL_DNS_AKAMAI_EDGERC_SECTION":                             "default",
+				"EXTERNAL_DNS_AKAMAI_ZONE_CONTRACT":                              "example.org=ctr-1\ncompany.com=ctr-2",
 				"EXTERNAL_DNS_OCI_CONFIG_FILE":                                   "oci.yaml",
 				"EXTERNAL_DNS_OCI_ZONE_SCOPE":                                    "PRIVATE",
 				"EXTERNAL_DNS_OCI_ZONES_CACHE_DURATION":                          "30s",
 				"EXTERNAL_DNS_INMEMORY_ZONE":                                     "example.org\ncompany.com",
+				"EXTERNAL_DNS_INMEMORY_DEFAULT_TTL":                              "300",
+				"EXTERNAL_DNS_INMEMORY_MIN_TTL":                                  "60",
+				"EXTERNAL_DNS_INMEMORY_MAX_TTL":                                  "86400",
 				"EXTERNAL_DNS_OVH_ENDPOINT":                                      "ovh-ca",
 				"EXTERNAL_DNS_OVH_API_RATE_LIMIT":                                "42",
+				"EXTERNAL_DNS_GODADDY_API_RATE_LIMIT":                            "30",
+				"EXTERNAL_DNS_OVH_USE_ZONE_EXPORT":                               "1",
 				"EXTERNAL_DNS_POD_SOURCE_DOMAIN":                                 "example.org",
 				"EXTERNAL_DNS_DOMAIN_FILTER":                                     "example.org\ncompany.com",
 				"EXTERNAL_DNS_EXCLUDE_DOMAINS":                                   "xapi.example.org\nxapi.company.com",
@@ -449,6 +611,9 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_PDNS_ID":                                           "localhost",
 				"EXTERNAL_DNS_PDNS_API_KEY":                                      "some-secret-key",
 				"EXTERNAL_DNS_PDNS_SKIP_TLS_VERIFY":                              "1",
+				"EXTERNAL_DNS_PDNS_CREATE_ZONES":                                 "1",
+				"EXTERNAL_DNS_PDNS_ZONE_NAMESERVER":                              "ns1.example.org.\nns2.example.org.",
+				"EXTERNAL_DNS_PDNS_ZONE_SOA":                                     "ns1.example.org. hostmaster.example.org. 1 10800 3600 604800 3600",
 				"EXTERNAL_DNS_RDNS_ROOT_DOMAIN":                                  "lb.rancher.cloud",
 				"EXTERNAL_DNS_TLS_CA":                                            "/path/to/ca.crt",
 				"EXTERNAL_DNS_TLS_CLIENT_CERT":                                   "/path/to/cert.pem",
@@ -467,15 +632,51 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AWS_EVALUATE_TARGET_HEALTH":                        "0",
 				"EXTERNAL_DNS_AWS_API_RETRIES":                                   "13",
 				"EXTERNAL_DNS_AWS_PREFER_CNAME":                                  "true",
+				"EXTERNAL_DNS_AWS_PREFER_CNAME_DOMAIN":                           "cross.example.org",
 				"EXTERNAL_DNS_AWS_PROFILE":                                       "profile1\nprofile2",
 				"EXTERNAL_DNS_AWS_ZONES_CACHE_DURATION":                          "10s",
 				"EXTERNAL_DNS_AWS_SD_SERVICE_CLEANUP":                            "true",
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
+				"EXTERNAL_DNS_AWS_SD_SERVICE_TTL":                                "60",
+				"EXTERNAL_DNS_AWS_SD_ROUTING_POLICY":                             "WEIGHTED",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
+				"EXTERNAL_DNS_RECORD_TYPE_REPLACEMENT":                           "1",
+				"EXTERNAL_DNS_DELETE_AFTER_CREATE":                               "1",
+				"EXTERNAL_DNS_MAX_DELETIONS_PER_RUN":                             "10",
+				"EXTERNAL_DNS_EXTERNAL_RECORDS_REGEX":                            "^legacy\\.",
+				"EXTERNAL_DNS_EXTERNAL_RECORDS_TXT_MARKER":                       "managed-by=terraform",
+				"EXTERNAL_DNS_ALLOW_APEX_SOA_NS":                                 "1",
+				"EXTERNAL_DNS_GATEWAY_ADDRESS_TYPE":                              "IPAddress\nHostname",
+				"EXTERNAL_DNS_GATEWAY_FALLBACK_TARGET":                           "192.0.2.1\n192.0.2.2",
+				"EXTERNAL_DNS_GATEWAY_REQUIRE_PROGRAMMED":                        "1",
+				"EXTERNAL_DNS_FLATTEN_MULTI_TARGET_CNAME":                        "1",
+				"EXTERNAL_DNS_PUBLISH_HEADLESS_SRV":                              "1",
+				"EXTERNAL_DNS_SERVICE_AGGREGATE_HOSTNAME":                        "ingress.example.org",
+				"EXTERNAL_DNS_MERGE_TXT_RECORDS":                                 "1",
+				"EXTERNAL_DNS_RECORD_TYPE_PRIORITY":                              "CNAME\nA",
+				"EXTERNAL_DNS_F5_VIRTUALSERVER_ALLOWED_HOST":                     "example.org",
+				"EXTERNAL_DNS_F5_VIRTUALSERVER_DENIED_HOST":                      "apex.example.org",
+				"EXTERNAL_DNS_F5_REQUIRE_HEALTHY_POOL_MEMBERS":                   "1",
+				"EXTERNAL_DNS_KNATIVE_GATEWAY_SERVICE":                           "kourier-system/kourier",
+				"EXTERNAL_DNS_CERT_MANAGER_CERTIFICATE_TARGET":                   "ingress.example.org",
+				"EXTERNAL_DNS_HAPROXY_TCP_SERVICES_CONFIGMAP":                    "haproxy-ingress/tcp-services",
+				"EXTERNAL_DNS_HAPROXY_SERVICE":                                   "haproxy-ingress/haproxy-ingress",
+				"EXTERNAL_DNS_HOSTNAME_SOURCE_PRIORITY":                          "spec\nannotation",
+				"EXTERNAL_DNS_PROVIDER_FAILURE_THRESHOLD":                        "5",
+				"EXTERNAL_DNS_PROVIDER_MAX_BACKOFF":                              "1h",
+				"EXTERNAL_DNS_ADDITIONAL_PROVIDER":                               "coredns",
+				"EXTERNAL_DNS_PROVIDER_DOMAIN":                                   "coredns=cluster.local",
+				"EXTERNAL_DNS_PROVIDER_SPECIFIC_DEFAULT":                         "internal.example.com=external-dns.alpha.kubernetes.io/cloudflare-proxied=false",
+				"EXTERNAL_DNS_RECORD_PROVENANCE":                                 "1",
+				"EXTERNAL_DNS_RECORD_PROVENANCE_CLUSTER_NAME":                    "production",
 				"EXTERNAL_DNS_REGISTRY":                                          "noop",
 				"EXTERNAL_DNS_TXT_OWNER_ID":                                      "owner-1",
+				"EXTERNAL_DNS_TXT_ADOPT_OWNER_ID":                                "owner-0\nowner-legacy",
+				"EXTERNAL_DNS_TXT_REPAIR_OWNERSHIP":                              "1",
+				"EXTERNAL_DNS_TXT_SKIP_RECORD_TYPES":                             "NS\nMX",
+				"EXTERNAL_DNS_TXT_IGNORE_FOREIGN_RECORDS":                        "1",
 				"EXTERNAL_DNS_TXT_PREFIX":                                        "associated-txt-record",
 				"EXTERNAL_DNS_TXT_CACHE_INTERVAL":                                "12h",
 				"EXTERNAL_DNS_TXT_NEW_FORMAT_ONLY":                               "1",
@@ -483,10 +684,16 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_MIN_EVENT_SYNC_INTERVAL":                           "50s",
 				"EXTERNAL_DNS_ONCE":                                              "1",
 				"EXTERNAL_DNS_DRY_RUN":                                           "1",
+				"EXTERNAL_DNS_CLEANUP_ORPHANS":                                   "1",
+				"EXTERNAL_DNS_CLEANUP_ORPHANS_CONFIRM":                           "1",
 				"EXTERNAL_DNS_EVENTS":                                            "1",
+				"EXTERNAL_DNS_EVENTS_SOURCE":                                     "ingress\nservice",
+				"EXTERNAL_DNS_SOURCE_INTERVAL":                                   "node=10m\nservice=5m",
+				"EXTERNAL_DNS_RECONCILE_ON_SECRET_CHANGE":                        "external-dns/credentials",
 				"EXTERNAL_DNS_LOG_FORMAT":                                        "json",
 				"EXTERNAL_DNS_METRICS_ADDRESS":                                   "127.0.0.1:9099",
 				"EXTERNAL_DNS_LOG_LEVEL":                                         "debug",
+				"EXTERNAL_DNS_LOG_LEVEL_ENDPOINT":                                "1",
 				"EXTERNAL_DNS_CONNECTOR_SOURCE_SERVER":                           "localhost:8081",
 				"EXTERNAL_DNS_EXOSCALE_APIENV":                                   "api1",
 				"EXTERNAL_DNS_EXOSCALE_APIZONE":                                  "zone1",
@@ -494,6 +701,11 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_EXOSCALE_APISECRET":                                "2",
 				"EXTERNAL_DNS_CRD_SOURCE_APIVERSION":                             "test.k8s.io/v1alpha1",
 				"EXTERNAL_DNS_CRD_SOURCE_KIND":                                   "Endpoint",
+				"EXTERNAL_DNS_CRD_SOURCE_CLUSTER_SCOPED":                         "1",
+				"EXTERNAL_DNS_CRD_SOURCE_FINALIZER":                              "1",
+				"EXTERNAL_DNS_CRD_SOURCE_HOSTNAME_JSONPATH":                      "{.spec.host}",
+				"EXTERNAL_DNS_CRD_SOURCE_TARGETS_JSONPATH":                       "{.status.addresses[*]}",
+				"EXTERNAL_DNS_CRD_SOURCE_TTL_JSONPATH":                           "{.spec.ttl}",
 				"EXTERNAL_DNS_NS1_ENDPOINT":                                      "https://api.example.com/v1",
 				"EXTERNAL_DNS_NS1_IGNORESSL":                                     "1",
 				"EXTERNAL_DNS_TRANSIP_ACCOUNT":                                   "transip",
@@ -501,6 +713,7 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_DIGITALOCEAN_API_PAGE_SIZE":                        "100",
 				"EXTERNAL_DNS_MANAGED_RECORD_TYPES":                              "A\nAAAA\nCNAME\nNS",
 				"EXTERNAL_DNS_EXCLUDE_UNSCHEDULABLE":                             "false",
+				"EXTERNAL_DNS_SERVICE_CILIUM_LB_IPAM":                            "1",
 				"EXTERNAL_DNS_RFC2136_BATCH_CHANGE_SIZE":                         "100",
 				"EXTERNAL_DNS_RFC2136_LOAD_BALANCING_STRATEGY":                   "round-robin",
 				"EXTERNAL_DNS_RFC2136_HOST":                                      "rfc2136-host1\nrfc2136-host2",
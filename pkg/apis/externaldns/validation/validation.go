@@ -19,6 +19,8 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -45,6 +47,31 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	if len(cfg.TXTSkipRecordTypes) > 0 && cfg.Policy != "sync" {
+		return errors.New("txt-skip-record-types requires the sync policy")
+	}
+
+	for _, name := range cfg.UpdateEventsSources {
+		if !slices.Contains(cfg.Sources, name) {
+			return fmt.Errorf("--events-source %s is not one of the configured sources", name)
+		}
+	}
+
+	for _, value := range cfg.SourceIntervals {
+		name, _, _ := strings.Cut(value, "=")
+		if !slices.Contains(cfg.Sources, name) {
+			return fmt.Errorf("--source-interval %s is not one of the configured sources", value)
+		}
+	}
+
+	if cfg.CleanupOrphans && cfg.Registry == "noop" {
+		return errors.New("cleanup-orphans requires a registry recording the ownership of the records")
+	}
+
+	if cfg.CleanupOrphansConfirm && !cfg.CleanupOrphans {
+		return errors.New("cleanup-orphans-confirm requires cleanup-orphans")
+	}
+
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
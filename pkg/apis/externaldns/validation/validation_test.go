@@ -94,6 +94,48 @@ func TestValidateBadIgnoreHostnameAnnotationsConfig(t *testing.T) {
 	assert.Error(t, ValidateConfig(cfg))
 }
 
+func TestValidateTXTSkipRecordTypesConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TXTSkipRecordTypes = []string{"NS"}
+	cfg.Policy = "sync"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Policy = "upsert-only"
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateUpdateEventsSourcesConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.UpdateEventsSources = []string{"test-source"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.UpdateEventsSources = []string{"test-source", "node"}
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateSourceIntervalsConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.SourceIntervals = []string{"test-source=10m"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.SourceIntervals = []string{"test-source=10m", "node=10m"}
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateCleanupOrphansConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.CleanupOrphans = true
+	cfg.CleanupOrphansConfirm = true
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Registry = "noop"
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.CleanupOrphansConfirm = true
+	assert.Error(t, ValidateConfig(cfg))
+}
+
 func TestValidateBadRfc2136Config(t *testing.T) {
 	cfg := externaldns.NewConfig()
 
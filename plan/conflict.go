@@ -33,7 +33,12 @@ type ConflictResolver interface {
 }
 
 // PerResource allows only one resource to own a given dns name
-type PerResource struct{}
+type PerResource struct {
+	// RecordTypePriority orders the record types when CNAME and other record types conflict
+	// for a domain: the first of these types with a candidate wins. Without it, or when none
+	// of these types has a candidate, the non-CNAME record types win.
+	RecordTypePriority []string
+}
 
 // ResolveCreate is invoked when dns name is not owned by any resource
 // ResolveCreate takes "minimal" (string comparison of Target) endpoint to acquire the DNS record
@@ -94,11 +99,16 @@ func (s PerResource) ResolveRecordTypes(key planKey, row *planTableRow) map[stri
 
 	// conflict was found, remove candiates of non-preferred record types
 	if cname && other {
-		log.Infof("Domain %s contains conflicting record type candidates; discarding CNAME record", key.dnsName)
+		preferCNAME := s.prefersCNAME(row.records)
+		if preferCNAME {
+			log.Infof("Domain %s contains conflicting record type candidates; discarding non-CNAME records", key.dnsName)
+		} else {
+			log.Infof("Domain %s contains conflicting record type candidates; discarding CNAME record", key.dnsName)
+		}
 		records := map[string]*domainEndpoints{}
 		for recordType, recs := range row.records {
-			// policy is to prefer the non-CNAME record types when a conflict is found
-			if recordType == endpoint.RecordTypeCNAME {
+			// policy is to prefer the non-CNAME record types when a conflict is found, unless configured otherwise
+			if (recordType == endpoint.RecordTypeCNAME) != preferCNAME {
 				// discard candidates of conflicting records
 				// keep currect so they can be deleted
 				records[recordType] = &domainEndpoints{
@@ -117,6 +127,19 @@ func (s PerResource) ResolveRecordTypes(key planKey, row *planTableRow) map[stri
 	return row.records
 }
 
+// prefersCNAME returns true if CNAME comes before the other record types with candidates in RecordTypePriority.
+func (s PerResource) prefersCNAME(records map[string]*domainEndpoints) bool {
+	for _, recordType := range s.RecordTypePriority {
+		if recordType == endpoint.RecordTypeCNAME {
+			return true
+		}
+		if recs, ok := records[recordType]; ok && len(recs.candidates) > 0 {
+			return false
+		}
+	}
+	return false
+}
+
 // less returns true if endpoint x is less than y
 func (s PerResource) less(x, y *endpoint.Endpoint) bool {
 	return x.Targets.IsLess(y.Targets)
@@ -142,6 +142,32 @@ func (suite *ResolverSuite) TestStrictResolver() {
 	suite.Equal(suite.bar127A, suite.perResource.ResolveUpdate(suite.legacyBar192A, []*endpoint.Endpoint{suite.bar127A, suite.bar192A}), " legacy record's resource value will not match, should pick minimum")
 }
 
+func (suite *ResolverSuite) TestPerResource_ResolveRecordTypesPriority() {
+	resolver := PerResource{RecordTypePriority: []string{endpoint.RecordTypeCNAME}}
+	row := &planTableRow{
+		candidates: []*endpoint.Endpoint{suite.fooV1Cname, suite.fooA5},
+		records: map[string]*domainEndpoints{
+			endpoint.RecordTypeCNAME: {
+				candidates: []*endpoint.Endpoint{suite.fooV1Cname},
+			},
+			endpoint.RecordTypeA: {
+				current:    suite.fooA5,
+				candidates: []*endpoint.Endpoint{suite.fooA5},
+			},
+		},
+	}
+
+	suite.Equal(map[string]*domainEndpoints{
+		endpoint.RecordTypeCNAME: {
+			candidates: []*endpoint.Endpoint{suite.fooV1Cname},
+		},
+		endpoint.RecordTypeA: {
+			current:    suite.fooA5,
+			candidates: []*endpoint.Endpoint{},
+		},
+	}, resolver.ResolveRecordTypes(planKey{dnsName: "foo"}, row), "should discard the A candidates and keep the current A record")
+}
+
 func (suite *ResolverSuite) TestPerResource_ResolveRecordTypes() {
 	type args struct {
 		key planKey
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ProviderSpecificDiff is the change of a provider specific property, an empty value meaning
+// that the property is not set.
+type ProviderSpecificDiff struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// EndpointDiff itemizes the changes of an updated endpoint.
+type EndpointDiff struct {
+	DNSName          string
+	RecordType       string
+	SetIdentifier    string
+	AddedTargets     endpoint.Targets
+	RemovedTargets   endpoint.Targets
+	OldTTL           endpoint.TTL
+	NewTTL           endpoint.TTL
+	ProviderSpecific []ProviderSpecificDiff
+}
+
+// NewEndpointDiff returns the changes from the current to the desired version of an endpoint.
+func NewEndpointDiff(current, desired *endpoint.Endpoint) EndpointDiff {
+	diff := EndpointDiff{
+		DNSName:       desired.DNSName,
+		RecordType:    desired.RecordType,
+		SetIdentifier: desired.SetIdentifier,
+		OldTTL:        current.RecordTTL,
+		NewTTL:        desired.RecordTTL,
+	}
+	for _, target := range desired.Targets {
+		if !slices.Contains(current.Targets, target) {
+			diff.AddedTargets = append(diff.AddedTargets, target)
+		}
+	}
+	for _, target := range current.Targets {
+		if !slices.Contains(desired.Targets, target) {
+			diff.RemovedTargets = append(diff.RemovedTargets, target)
+		}
+	}
+
+	names := map[string]bool{}
+	for _, property := range append(slices.Clone(current.ProviderSpecific), desired.ProviderSpecific...) {
+		names[property.Name] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		oldValue, _ := current.GetProviderSpecificProperty(name)
+		newValue, _ := desired.GetProviderSpecificProperty(name)
+		if oldValue != newValue {
+			diff.ProviderSpecific = append(diff.ProviderSpecific, ProviderSpecificDiff{Name: name, Old: oldValue, New: newValue})
+		}
+	}
+	return diff
+}
+
+// TTLChanged returns true if the TTL of the endpoint changed.
+func (d EndpointDiff) TTLChanged() bool {
+	return d.OldTTL != d.NewTTL
+}
+
+// String returns the itemized changes, e.g. "app.example.org A: targets +192.0.2.2 -192.0.2.1, ttl 300 -> 600".
+func (d EndpointDiff) String() string {
+	var items []string
+	if len(d.AddedTargets) > 0 || len(d.RemovedTargets) > 0 {
+		var targets []string
+		for _, target := range d.AddedTargets {
+			targets = append(targets, "+"+target)
+		}
+		for _, target := range d.RemovedTargets {
+			targets = append(targets, "-"+target)
+		}
+		items = append(items, "targets "+strings.Join(targets, " "))
+	}
+	if d.TTLChanged() {
+		items = append(items, fmt.Sprintf("ttl %d -> %d", d.OldTTL, d.NewTTL))
+	}
+	for _, property := range d.ProviderSpecific {
+		items = append(items, fmt.Sprintf("%s %q -> %q", property.Name, property.Old, property.New))
+	}
+	if len(items) == 0 {
+		items = append(items, "labels only")
+	}
+
+	name := d.DNSName + " " + d.RecordType
+	if d.SetIdentifier != "" {
+		name += " (" + d.SetIdentifier + ")"
+	}
+	return name + ": " + strings.Join(items, ", ")
+}
+
+// UpdateDiffs returns the itemized changes of the updated endpoints, matching the new and old versions
+// of each endpoint by name, record type and set identifier.
+func (c *Changes) UpdateDiffs() []EndpointDiff {
+	old := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range c.UpdateOld {
+		old[ep.Key()] = ep
+	}
+
+	diffs := make([]EndpointDiff, 0, len(c.UpdateNew))
+	for _, ep := range c.UpdateNew {
+		if current, ok := old[ep.Key()]; ok {
+			diffs = append(diffs, NewEndpointDiff(current, ep))
+		}
+	}
+	return diffs
+}
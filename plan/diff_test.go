@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestNewEndpointDiffTTLOnly(t *testing.T) {
+	current := endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2")
+	desired := endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 600, "192.0.2.2", "192.0.2.1")
+
+	diff := NewEndpointDiff(current, desired)
+	assert.Equal(t, EndpointDiff{
+		DNSName:    "app.example.org",
+		RecordType: endpoint.RecordTypeA,
+		OldTTL:     300,
+		NewTTL:     600,
+	}, diff)
+	assert.True(t, diff.TTLChanged())
+	assert.Equal(t, "app.example.org A: ttl 300 -> 600", diff.String())
+}
+
+func TestNewEndpointDiff(t *testing.T) {
+	current := endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2").
+		WithSetIdentifier("eu").
+		WithProviderSpecific("aws/weight", "10").
+		WithProviderSpecific("alias", "false")
+	desired := endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 300, "192.0.2.2", "192.0.2.3").
+		WithSetIdentifier("eu").
+		WithProviderSpecific("aws/weight", "20").
+		WithProviderSpecific("aws/evaluate-target-health", "true").
+		WithProviderSpecific("alias", "false")
+
+	diff := NewEndpointDiff(current, desired)
+	assert.Equal(t, endpoint.Targets{"192.0.2.3"}, diff.AddedTargets)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1"}, diff.RemovedTargets)
+	assert.False(t, diff.TTLChanged())
+	assert.Equal(t, []ProviderSpecificDiff{
+		{Name: "aws/evaluate-target-health", Old: "", New: "true"},
+		{Name: "aws/weight", Old: "10", New: "20"},
+	}, diff.ProviderSpecific)
+	assert.Equal(t, `app.example.org A (eu): targets +192.0.2.3 -192.0.2.1, aws/evaluate-target-health "" -> "true", aws/weight "10" -> "20"`, diff.String())
+}
+
+func TestChangesUpdateDiffs(t *testing.T) {
+	changes := &Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("b.example.org", endpoint.RecordTypeCNAME, 300, "lb.example.com"),
+			endpoint.NewEndpointWithTTL("a.example.org", endpoint.RecordTypeA, 300, "192.0.2.1"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("a.example.org", endpoint.RecordTypeA, 60, "192.0.2.1"),
+			endpoint.NewEndpointWithTTL("b.example.org", endpoint.RecordTypeCNAME, 300, "lb.example.com").
+				WithLabel(endpoint.ResourceLabelKey, "ingress/default/b"),
+		},
+	}
+
+	diffs := changes.UpdateDiffs()
+	require.Len(t, diffs, 2)
+	assert.Equal(t, "a.example.org A: ttl 300 -> 60", diffs[0].String())
+	assert.Equal(t, "b.example.org CNAME: labels only", diffs[1].String())
+}
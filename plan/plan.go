@@ -18,6 +18,7 @@ package plan
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -49,8 +50,38 @@ type Plan struct {
 	ManagedRecords []string
 	// ExcludeRecords are DNS record types that will be excluded from management.
 	ExcludeRecords []string
+	// SupportedRecords are the DNS record types supported by the provider, all of them when empty.
+	// The desired records of other types are skipped rather than failing the whole apply.
+	SupportedRecords []string
+	// MinTTL and MaxTTL are the TTLs accepted by the provider, not limited when zero.
+	// The configured TTLs of the desired records are clamped to them rather than failing the whole apply.
+	MinTTL endpoint.TTL
+	MaxTTL endpoint.TTL
 	// OwnerID of records to manage
 	OwnerID string
+	// RecordTypeReplacement treats the change of a domain between a CNAME and A/AAAA records as a replacement:
+	// the new records are only created along with the deletion of the current ones, when the policies allow it
+	RecordTypeReplacement bool
+	// AllowApexSOANS allows changes to the SOA and NS records at the apex of the zones,
+	// which are otherwise left untouched regardless of the provider
+	AllowApexSOANS bool
+	// ZoneFilter is the domain filter of the provider matching its zones, e.g. the AWS hosted zones,
+	// whose domains are the zone apexes along with the names of the current SOA records
+	ZoneFilter endpoint.DomainFilterInterface
+	// RecordTypePriority orders the record types that win when CNAME and other record types
+	// are desired for the same domain
+	RecordTypePriority []string
+	// MaxDeletions caps the number of records deleted by the plan, not limited when zero.
+	// The excess deletions are deferred, they are planned again by the next calculations.
+	MaxDeletions int
+	// ExternalRecords matches the DNS names of the records managed by other tools, which are left untouched
+	ExternalRecords *regexp.Regexp
+	// ExternalRecordsTXTMarker is the value of the TXT records marking the records of their DNS name
+	// as managed by other tools, which are left untouched
+	ExternalRecordsTXTMarker string
+	// DeferredDeletes are the records whose deletion was deferred because of MaxDeletions
+	// Populated after calling Calculate()
+	DeferredDeletes []*endpoint.Endpoint
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -94,8 +125,8 @@ type planTable struct {
 	resolver ConflictResolver
 }
 
-func newPlanTable() planTable { // TODO: make resolver configurable
-	return planTable{map[planKey]*planTableRow{}, PerResource{}}
+func newPlanTable(recordTypePriority []string) planTable { // TODO: make resolver configurable
+	return planTable{map[planKey]*planTableRow{}, PerResource{RecordTypePriority: recordTypePriority}}
 }
 
 // planTableRow represents a set of current and desired domain resource records.
@@ -163,24 +194,61 @@ func (c *Changes) HasChanges() bool {
 	return !cmp.Equal(c.UpdateNew, c.UpdateOld)
 }
 
+// SplitDeletes splits the changes in two batches, so that the deletes can be applied after the
+// creates and updates: the first batch holds the creates, the updates and the deletes of the
+// names that are created or updated as well (e.g. a record replaced by one of another type),
+// the second batch holds the other deletes.
+func (c *Changes) SplitDeletes() (*Changes, *Changes) {
+	names := map[planKey]struct{}{}
+	for _, ep := range c.Create {
+		names[planKey{dnsName: normalizeDNSName(ep.DNSName), setIdentifier: ep.SetIdentifier}] = struct{}{}
+	}
+	for _, ep := range c.UpdateNew {
+		names[planKey{dnsName: normalizeDNSName(ep.DNSName), setIdentifier: ep.SetIdentifier}] = struct{}{}
+	}
+
+	first := &Changes{Create: c.Create, UpdateOld: c.UpdateOld, UpdateNew: c.UpdateNew}
+	deletes := &Changes{}
+	for _, ep := range c.Delete {
+		if _, ok := names[planKey{dnsName: normalizeDNSName(ep.DNSName), setIdentifier: ep.SetIdentifier}]; ok {
+			first.Delete = append(first.Delete, ep)
+		} else {
+			deletes.Delete = append(deletes.Delete, ep)
+		}
+	}
+	return first, deletes
+}
+
 // Calculate computes the actions needed to move current state towards desired
 // state. It then passes those changes to the current policy for further
 // processing. It returns a copy of Plan with the changes populated.
 func (p *Plan) Calculate() *Plan {
-	t := newPlanTable()
+	t := newPlanTable(p.RecordTypePriority)
 
 	if p.DomainFilter == nil {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
 	}
 
-	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	current, desired := p.Current, p.Desired
+	if !p.AllowApexSOANS {
+		apex := apexNames(p.Current, p.ZoneFilter)
+		current = filterApexSOANS(current, apex)
+		desired = filterApexSOANS(desired, apex)
+	}
+	desired = filterUnsupportedRecords(desired, p.SupportedRecords)
+	desired = clampTTLs(desired, p.MinTTL, p.MaxTTL)
+	current, desired = filterExcludedRecords(current, desired)
+	current, desired = filterExternalRecords(current, desired, p.ExternalRecords, p.ExternalRecordsTXTMarker)
+
+	for _, current := range filterRecordsForPlan(current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
 		t.addCurrent(current)
 	}
-	for _, desired := range filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	for _, desired := range filterRecordsForPlan(desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
 		t.addCandidate(desired)
 	}
 
 	changes := &Changes{}
+	var replacements []recordTypeReplacement
 
 	for key, row := range t.rows {
 		// dns name not taken
@@ -201,6 +269,7 @@ func (p *Plan) Calculate() *Plan {
 		// dns name is taken
 		if len(row.current) > 0 && len(row.candidates) > 0 {
 			creates := []*endpoint.Endpoint{}
+			deletes := []*endpoint.Endpoint{}
 
 			// apply changes for each record type
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
@@ -208,6 +277,7 @@ func (p *Plan) Calculate() *Plan {
 				// record type not desired
 				if records.current != nil && len(records.candidates) == 0 {
 					changes.Delete = append(changes.Delete, records.current)
+					deletes = append(deletes, records.current)
 				}
 
 				// new record type desired
@@ -223,7 +293,7 @@ func (p *Plan) Calculate() *Plan {
 				if records.current != nil && len(records.candidates) > 0 {
 					update := t.resolver.ResolveUpdate(records.current, records.candidates)
 
-					if shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) {
+					if shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) || deleteProtectionChanged(update, records.current) {
 						inheritOwner(records.current, update)
 						changes.UpdateNew = append(changes.UpdateNew, update)
 						changes.UpdateOld = append(changes.UpdateOld, records.current)
@@ -242,6 +312,9 @@ func (p *Plan) Calculate() *Plan {
 
 				if ownersMatch {
 					changes.Create = append(changes.Create, creates...)
+					if replacement, ok := newRecordTypeReplacement(deletes, creates); ok {
+						replacements = append(replacements, replacement)
+					}
 				} else if log.GetLevel() == log.DebugLevel {
 					for _, current := range row.current {
 						log.Debugf(`Skipping endpoint %v because owner id does not match for one or more items to create, found: "%s", required: "%s"`, current, current.Labels[endpoint.OwnerLabelKey], p.OwnerID)
@@ -255,6 +328,14 @@ func (p *Plan) Calculate() *Plan {
 		changes = pol.Apply(changes)
 	}
 
+	if p.RecordTypeReplacement {
+		changes.Create = withoutConflictingCreates(changes.Create, keptReplacedRecords(changes.Delete, replacements))
+	}
+
+	var protected []*endpoint.Endpoint
+	changes.Delete, protected = filterDeleteProtectedRecords(changes.Delete)
+	changes.Create = withoutConflictingCreates(changes.Create, protected)
+
 	// filter out updates this external dns does not have ownership claim over
 	if p.OwnerID != "" {
 		changes.Delete = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete)
@@ -263,10 +344,16 @@ func (p *Plan) Calculate() *Plan {
 		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
 	}
 
+	var deferred []*endpoint.Endpoint
+	changes.Delete, deferred = limitDeletions(changes.Delete, p.MaxDeletions)
+	// the records replacing a deferred deletion by a conflicting record type are created along with it
+	changes.Create = withoutConflictingCreates(changes.Create, deferred)
+
 	plan := &Plan{
-		Current: p.Current,
-		Desired: p.Desired,
-		Changes: changes,
+		Current:         p.Current,
+		Desired:         p.Desired,
+		Changes:         changes,
+		DeferredDeletes: deferred,
 		// The default for ExternalDNS is to always only consider A/AAAA and CNAMEs.
 		// Everything else is an add on or something to be considered.
 		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
@@ -275,6 +362,67 @@ func (p *Plan) Calculate() *Plan {
 	return plan
 }
 
+// recordTypeReplacement is a change of a domain between a CNAME and A/AAAA records
+type recordTypeReplacement struct {
+	// current records being replaced
+	current []*endpoint.Endpoint
+	// desired records of the conflicting record type
+	desired []*endpoint.Endpoint
+}
+
+// newRecordTypeReplacement returns the replacement formed by the deleted and created records of a domain, if any.
+// Per RFC 1034 CNAME records conflict with all other records, so the deleted records conflicting with the created
+// ones must be removed for the creation to succeed.
+func newRecordTypeReplacement(deletes, creates []*endpoint.Endpoint) (recordTypeReplacement, bool) {
+	replacement := recordTypeReplacement{desired: creates}
+	for _, current := range deletes {
+		for _, desired := range creates {
+			if (current.RecordType == endpoint.RecordTypeCNAME) != (desired.RecordType == endpoint.RecordTypeCNAME) {
+				replacement.current = append(replacement.current, current)
+				break
+			}
+		}
+	}
+	return replacement, len(replacement.current) > 0
+}
+
+// keptReplacedRecords returns the current records replaced by a conflicting record type whose deletion
+// was removed by the policies, e.g. upsert-only, so that their replacements are not created either
+func keptReplacedRecords(deletes []*endpoint.Endpoint, replacements []recordTypeReplacement) []*endpoint.Endpoint {
+	var kept []*endpoint.Endpoint
+	for _, replacement := range replacements {
+		for _, current := range replacement.current {
+			if !slices.Contains(deletes, current) {
+				log.Debugf("Not replacing %s/%s record with a conflicting record type, its deletion is not planned", current.DNSName, current.RecordType)
+				kept = append(kept, current)
+			}
+		}
+	}
+	return kept
+}
+
+// limitDeletions returns the first max deletions in a stable order, so that the same records are deleted
+// first from one calculation to the next, and the deferred ones. The deletions are not limited when max is zero.
+func limitDeletions(deletes []*endpoint.Endpoint, max int) ([]*endpoint.Endpoint, []*endpoint.Endpoint) {
+	if max <= 0 || len(deletes) <= max {
+		return deletes, nil
+	}
+	sorted := slices.Clone(deletes)
+	slices.SortFunc(sorted, func(a, b *endpoint.Endpoint) int {
+		if c := strings.Compare(a.DNSName, b.DNSName); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.RecordType, b.RecordType); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SetIdentifier, b.SetIdentifier)
+	})
+	for _, ep := range sorted[max:] {
+		log.Debugf("Deferring the deletion of %s/%s, the maximum number of deletions %d is reached", ep.DNSName, ep.RecordType, max)
+	}
+	return sorted[:max], sorted[max:]
+}
+
 func inheritOwner(from, to *endpoint.Endpoint) {
 	if to.Labels == nil {
 		to.Labels = map[string]string{}
@@ -296,6 +444,15 @@ func shouldUpdateTTL(desired, current *endpoint.Endpoint) bool {
 	return desired.RecordTTL != current.RecordTTL
 }
 
+// deleteProtectionChanged returns whether the delete protection of the record changed. Only the current records
+// with an owner are considered, whose labels, and so their delete protection, are stored by the registry.
+func deleteProtectionChanged(desired, current *endpoint.Endpoint) bool {
+	if current.Labels[endpoint.OwnerLabelKey] == "" {
+		return false
+	}
+	return desired.Labels[endpoint.DeleteProtectionLabelKey] != current.Labels[endpoint.DeleteProtectionLabelKey]
+}
+
 func (p *Plan) shouldUpdateProviderSpecific(desired, current *endpoint.Endpoint) bool {
 	desiredProperties := map[string]endpoint.ProviderSpecificProperty{}
 
@@ -340,6 +497,190 @@ func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.Ma
 	return filtered
 }
 
+// filterUnsupportedRecords removes the records of the types not supported by the provider,
+// which would otherwise fail when applied.
+func filterUnsupportedRecords(records []*endpoint.Endpoint, supportedRecords []string) []*endpoint.Endpoint {
+	if len(supportedRecords) == 0 {
+		return records
+	}
+
+	filtered := []*endpoint.Endpoint{}
+	for _, record := range records {
+		if !slices.Contains(supportedRecords, record.RecordType) {
+			log.Warnf("Skipping record %s because the provider does not support %s records", record.DNSName, record.RecordType)
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered
+}
+
+// clampTTLs returns the records with their configured TTL clamped between minTTL and maxTTL when they are set.
+// The clamped records are copied, and the records without a configured TTL use the default of the provider.
+func clampTTLs(records []*endpoint.Endpoint, minTTL, maxTTL endpoint.TTL) []*endpoint.Endpoint {
+	if !minTTL.IsConfigured() && !maxTTL.IsConfigured() {
+		return records
+	}
+
+	clamped := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		ttl := record.RecordTTL
+		if ttl.IsConfigured() && minTTL.IsConfigured() && ttl < minTTL {
+			ttl = minTTL
+		}
+		if ttl.IsConfigured() && maxTTL.IsConfigured() && ttl > maxTTL {
+			ttl = maxTTL
+		}
+		if ttl != record.RecordTTL {
+			log.Warnf("Using the TTL %d of the %s record %s instead of %d, which is not accepted by the provider", ttl, record.RecordType, record.DNSName, record.RecordTTL)
+			record = record.DeepCopy()
+			record.RecordTTL = ttl
+		}
+		clamped = append(clamped, record)
+	}
+	return clamped
+}
+
+// filterExcludedRecords removes the desired records marked as excluded by their source, and all the
+// current and desired records of the same names and set identifiers, which are left untouched.
+func filterExcludedRecords(current, desired []*endpoint.Endpoint) ([]*endpoint.Endpoint, []*endpoint.Endpoint) {
+	type excludedKey struct {
+		dnsName       string
+		setIdentifier string
+	}
+	excluded := map[excludedKey]bool{}
+	for _, record := range desired {
+		if record.Labels[endpoint.ExcludedLabelKey] == "true" {
+			excluded[excludedKey{normalizeDNSName(record.DNSName), record.SetIdentifier}] = true
+		}
+	}
+	if len(excluded) == 0 {
+		return current, desired
+	}
+
+	filter := func(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+		filtered := make([]*endpoint.Endpoint, 0, len(records))
+		for _, record := range records {
+			if excluded[excludedKey{normalizeDNSName(record.DNSName), record.SetIdentifier}] {
+				log.Debugf("Leaving the %s record %s untouched because its resource is excluded", record.RecordType, record.DNSName)
+				continue
+			}
+			filtered = append(filtered, record)
+		}
+		return filtered
+	}
+	return filter(current), filter(desired)
+}
+
+// filterExternalRecords removes the current and desired records of the DNS names managed by other tools:
+// the names matching the pattern, and the names of the current TXT records with the marker value.
+func filterExternalRecords(current, desired []*endpoint.Endpoint, pattern *regexp.Regexp, marker string) ([]*endpoint.Endpoint, []*endpoint.Endpoint) {
+	if pattern == nil && marker == "" {
+		return current, desired
+	}
+
+	marked := map[string]bool{}
+	if marker != "" {
+		for _, record := range current {
+			if record.RecordType != endpoint.RecordTypeTXT {
+				continue
+			}
+			for _, target := range record.Targets {
+				if strings.Trim(target, `"`) == marker {
+					marked[normalizeDNSName(record.DNSName)] = true
+				}
+			}
+		}
+	}
+
+	filter := func(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+		filtered := make([]*endpoint.Endpoint, 0, len(records))
+		for _, record := range records {
+			name := normalizeDNSName(record.DNSName)
+			if marked[name] || (pattern != nil && pattern.MatchString(strings.TrimSuffix(name, "."))) {
+				log.Debugf("Leaving the %s record %s untouched because it is managed externally", record.RecordType, record.DNSName)
+				continue
+			}
+			filtered = append(filtered, record)
+		}
+		return filtered
+	}
+	return filter(current), filter(desired)
+}
+
+// filterDeleteProtectedRecords removes the deletions of the current records protected from the deletion,
+// which are kept even once their resource is deleted. It returns the remaining and the protected deletions.
+func filterDeleteProtectedRecords(deletes []*endpoint.Endpoint) ([]*endpoint.Endpoint, []*endpoint.Endpoint) {
+	if len(deletes) == 0 {
+		return deletes, nil
+	}
+	filtered := make([]*endpoint.Endpoint, 0, len(deletes))
+	var protected []*endpoint.Endpoint
+	for _, record := range deletes {
+		if record.Labels[endpoint.DeleteProtectionLabelKey] == "true" {
+			log.Debugf("Skipping the deletion of the %s record %s because it is protected", record.RecordType, record.DNSName)
+			protected = append(protected, record)
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered, protected
+}
+
+// withoutConflictingCreates removes the creations conflicting with the current records whose deletion
+// is not planned: per RFC 1034 a CNAME record conflicts with all the other records of its name, so the
+// provider would reject the creation of a CNAME next to the kept records, or of a record next to a kept CNAME.
+func withoutConflictingCreates(creates, kept []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if len(kept) == 0 {
+		return creates
+	}
+	return slices.DeleteFunc(slices.Clone(creates), func(create *endpoint.Endpoint) bool {
+		for _, record := range kept {
+			if normalizeDNSName(record.DNSName) == normalizeDNSName(create.DNSName) &&
+				(record.RecordType == endpoint.RecordTypeCNAME) != (create.RecordType == endpoint.RecordTypeCNAME) {
+				log.Debugf("Skipping the creation of the %s record %s conflicting with the kept %s record", create.RecordType, create.DNSName, record.RecordType)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// apexNames returns the normalized names of the zone apexes known to the plan: the names of the
+// current SOA records, and the domains of the zone filter of the provider. The domain filters of the
+// configuration are not zones, they may list the subdomains delegated with NS records.
+func apexNames(current []*endpoint.Endpoint, zoneFilter endpoint.DomainFilterInterface) map[string]bool {
+	names := map[string]bool{}
+	for _, record := range current {
+		if record.RecordType == endpoint.RecordTypeSOA {
+			names[normalizeDNSName(record.DNSName)] = true
+		}
+	}
+	if df, ok := zoneFilter.(*endpoint.DomainFilter); ok && df != nil {
+		for _, domain := range df.Filters {
+			// Filters starting with a dot only match subdomains.
+			if !strings.HasPrefix(domain, ".") {
+				names[normalizeDNSName(domain)] = true
+			}
+		}
+	}
+	return names
+}
+
+// filterApexSOANS removes SOA records, and NS records at one of the apex names.
+func filterApexSOANS(records []*endpoint.Endpoint, apex map[string]bool) []*endpoint.Endpoint {
+	filtered := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeSOA ||
+			(record.RecordType == endpoint.RecordTypeNS && apex[normalizeDNSName(record.DNSName)]) {
+			log.Debugf("ignoring %s record %s at the zone apex", record.RecordType, record.DNSName)
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered
+}
+
 var idnaProfile = idna.New(
 	idna.MapForLookup(),
 	idna.Transitional(true),
@@ -19,6 +19,8 @@ package plan
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -283,6 +285,507 @@ func TestPlan_ChangesJson_DecodeEncode(t *testing.T) {
 	assert.Equal(t, ch, &changes)
 }
 
+func TestPlan_ChangesSplitDeletes(t *testing.T) {
+	create := endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "192.0.2.2")
+	updateOld := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1")
+	updateNew := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.2")
+	replaced := endpoint.NewEndpoint("New.example.org.", endpoint.RecordTypeCNAME, "old.example.net")
+	otherSet := endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "192.0.2.3").WithSetIdentifier("other")
+	deleted := endpoint.NewEndpoint("old.example.org", endpoint.RecordTypeA, "192.0.2.1")
+
+	ch := &Changes{
+		Create:    []*endpoint.Endpoint{create},
+		UpdateOld: []*endpoint.Endpoint{updateOld},
+		UpdateNew: []*endpoint.Endpoint{updateNew},
+		Delete:    []*endpoint.Endpoint{replaced, otherSet, deleted},
+	}
+
+	changes, deletes := ch.SplitDeletes()
+	assert.Equal(t, &Changes{
+		Create:    []*endpoint.Endpoint{create},
+		UpdateOld: []*endpoint.Endpoint{updateOld},
+		UpdateNew: []*endpoint.Endpoint{updateNew},
+		Delete:    []*endpoint.Endpoint{replaced},
+	}, changes)
+	assert.Equal(t, &Changes{
+		Delete: []*endpoint.Endpoint{otherSet, deleted},
+	}, deletes)
+
+	changes, deletes = (&Changes{Delete: []*endpoint.Endpoint{deleted}}).SplitDeletes()
+	assert.False(t, changes.HasChanges())
+	assert.Equal(t, []*endpoint.Endpoint{deleted}, deletes.Delete)
+}
+
+func TestPlanApexSOANS(t *testing.T) {
+	apexSOA := endpoint.NewEndpoint("example.org", endpoint.RecordTypeSOA, "ns1.example.net. hostmaster.example.org. 1 7200 900 1209600 86400")
+	apexNS := endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns1.example.net")
+	desiredApexNS := endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns1.example.com")
+	desiredApexSOA := endpoint.NewEndpoint("example.org", endpoint.RecordTypeSOA, "ns1.example.com. hostmaster.example.org. 2 7200 900 1209600 86400")
+	otherApexNS := endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.example.com")
+	delegation := endpoint.NewEndpoint("sub.example.org", endpoint.RecordTypeNS, "ns1.sub.example.org")
+	managedRecords := []string{endpoint.RecordTypeA, endpoint.RecordTypeNS, endpoint.RecordTypeSOA}
+
+	for _, tc := range []struct {
+		name           string
+		current        []*endpoint.Endpoint
+		desired        []*endpoint.Endpoint
+		domainFilter   endpoint.MatchAllDomainFilters
+		zoneFilter     endpoint.DomainFilterInterface
+		allowApex      bool
+		expectedCreate []*endpoint.Endpoint
+		expectedUpdate []*endpoint.Endpoint
+		expectedDelete []*endpoint.Endpoint
+	}{
+		{
+			name:           "apex of a zone of the provider is protected",
+			current:        []*endpoint.Endpoint{apexNS},
+			desired:        []*endpoint.Endpoint{desiredApexNS, desiredApexSOA, delegation},
+			zoneFilter:     endpoint.NewDomainFilter([]string{"example.org", ".example.org"}),
+			expectedCreate: []*endpoint.Endpoint{delegation},
+		},
+		{
+			name:           "delegation of a subdomain of the domain filter is not protected",
+			current:        []*endpoint.Endpoint{apexNS},
+			desired:        []*endpoint.Endpoint{apexNS, delegation},
+			domainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.org", "sub.example.org"})},
+			zoneFilter:     endpoint.NewDomainFilter([]string{"example.org", ".example.org"}),
+			expectedCreate: []*endpoint.Endpoint{delegation},
+		},
+		{
+			name:           "apex of a zone with SOA record is protected",
+			current:        []*endpoint.Endpoint{apexSOA, apexNS, otherApexNS},
+			desired:        []*endpoint.Endpoint{},
+			expectedDelete: []*endpoint.Endpoint{otherApexNS},
+		},
+		{
+			name:           "unknown apex is not protected",
+			current:        []*endpoint.Endpoint{apexNS},
+			desired:        []*endpoint.Endpoint{desiredApexNS},
+			expectedUpdate: []*endpoint.Endpoint{desiredApexNS},
+		},
+		{
+			name:           "apex changes allowed",
+			current:        []*endpoint.Endpoint{apexSOA, apexNS},
+			desired:        []*endpoint.Endpoint{desiredApexSOA, desiredApexNS},
+			domainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.org"})},
+			allowApex:      true,
+			expectedUpdate: []*endpoint.Endpoint{desiredApexSOA, desiredApexNS},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        tc.current,
+				Desired:        tc.desired,
+				DomainFilter:   tc.domainFilter,
+				ZoneFilter:     tc.zoneFilter,
+				ManagedRecords: managedRecords,
+				AllowApexSOANS: tc.allowApex,
+			}
+
+			changes := p.Calculate().Changes
+			validateEntries(t, changes.Create, tc.expectedCreate)
+			validateEntries(t, changes.UpdateNew, tc.expectedUpdate)
+			validateEntries(t, changes.Delete, tc.expectedDelete)
+		})
+	}
+}
+
+func TestPlanRecordTypePriority(t *testing.T) {
+	fromService := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1").
+		WithLabel(endpoint.ResourceLabelKey, "service/default/app")
+	fromIngress := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeCNAME, "lb.example.com").
+		WithLabel(endpoint.ResourceLabelKey, "ingress/default/app")
+
+	for _, tc := range []struct {
+		name           string
+		priority       []string
+		expectedCreate []*endpoint.Endpoint
+	}{
+		{
+			name:           "A wins by default",
+			expectedCreate: []*endpoint.Endpoint{fromService},
+		},
+		{
+			name:           "CNAME first",
+			priority:       []string{endpoint.RecordTypeCNAME},
+			expectedCreate: []*endpoint.Endpoint{fromIngress},
+		},
+		{
+			name:           "A before CNAME",
+			priority:       []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+			expectedCreate: []*endpoint.Endpoint{fromService},
+		},
+		{
+			name:           "types without candidates are ignored",
+			priority:       []string{endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeA},
+			expectedCreate: []*endpoint.Endpoint{fromIngress},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:           []Policy{&SyncPolicy{}},
+				Current:            []*endpoint.Endpoint{},
+				Desired:            []*endpoint.Endpoint{fromService, fromIngress},
+				ManagedRecords:     []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+				RecordTypePriority: tc.priority,
+			}
+
+			changes := p.Calculate().Changes
+			validateEntries(t, changes.Create, tc.expectedCreate)
+			assert.Empty(t, changes.Delete)
+		})
+	}
+}
+
+func TestPlanSupportedRecords(t *testing.T) {
+	a := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1")
+	srv := endpoint.NewEndpoint("_sip._tcp.example.org", endpoint.RecordTypeSRV, "10 5 5060 sip.example.org")
+
+	for _, tc := range []struct {
+		name           string
+		supported      []string
+		expectedCreate []*endpoint.Endpoint
+	}{
+		{
+			name:           "all record types supported by default",
+			expectedCreate: []*endpoint.Endpoint{a, srv},
+		},
+		{
+			name:           "unsupported record types are skipped",
+			supported:      []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+			expectedCreate: []*endpoint.Endpoint{a},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:         []Policy{&SyncPolicy{}},
+				Current:          []*endpoint.Endpoint{},
+				Desired:          []*endpoint.Endpoint{a, srv},
+				ManagedRecords:   []string{endpoint.RecordTypeA, endpoint.RecordTypeSRV},
+				SupportedRecords: tc.supported,
+			}
+
+			changes := p.Calculate().Changes
+			validateEntries(t, changes.Create, tc.expectedCreate)
+			assert.Empty(t, changes.Delete)
+		})
+	}
+}
+
+func TestPlanClampsTTLs(t *testing.T) {
+	low := endpoint.NewEndpointWithTTL("low.example.org", endpoint.RecordTypeA, 5, "192.0.2.1")
+	high := endpoint.NewEndpointWithTTL("high.example.org", endpoint.RecordTypeA, 86400, "192.0.2.1")
+	unset := endpoint.NewEndpoint("unset.example.org", endpoint.RecordTypeA, "192.0.2.1")
+	current := endpoint.NewEndpointWithTTL("current.example.org", endpoint.RecordTypeA, 3600, "192.0.2.1")
+	desired := endpoint.NewEndpointWithTTL("current.example.org", endpoint.RecordTypeA, 7200, "192.0.2.1")
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{low, high, unset, desired},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		MinTTL:         60,
+		MaxTTL:         3600,
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(t, changes.Create, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("low.example.org", endpoint.RecordTypeA, 60, "192.0.2.1"),
+		endpoint.NewEndpointWithTTL("high.example.org", endpoint.RecordTypeA, 3600, "192.0.2.1"),
+		unset,
+	})
+	// the clamped TTL of the current record is already up to date
+	assert.Empty(t, changes.UpdateNew)
+	// the desired records are not modified
+	assert.Equal(t, endpoint.TTL(5), low.RecordTTL)
+	assert.Equal(t, endpoint.TTL(86400), high.RecordTTL)
+}
+
+func TestPlanExcludedRecords(t *testing.T) {
+	excluded := endpoint.NewEndpoint("excluded.example.org", endpoint.RecordTypeA, "192.0.2.2").
+		WithLabel(endpoint.ExcludedLabelKey, "true")
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("excluded.example.org", endpoint.RecordTypeA, "192.0.2.1"),
+		endpoint.NewEndpoint("excluded.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		endpoint.NewEndpoint("removed.example.org", endpoint.RecordTypeA, "192.0.2.1"),
+	}
+	app := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1")
+
+	for _, tc := range []struct {
+		name    string
+		desired []*endpoint.Endpoint
+	}{
+		{
+			name:    "the records of an excluded resource are left untouched",
+			desired: []*endpoint.Endpoint{excluded, app},
+		},
+		{
+			name:    "the records of an excluded resource are not updated by another resource",
+			desired: []*endpoint.Endpoint{excluded, app, endpoint.NewEndpoint("excluded.example.org", endpoint.RecordTypeA, "192.0.2.3")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        current,
+				Desired:        tc.desired,
+				ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+			}
+
+			changes := p.Calculate().Changes
+			validateEntries(t, changes.Create, []*endpoint.Endpoint{app})
+			assert.Empty(t, changes.UpdateOld)
+			assert.Empty(t, changes.UpdateNew)
+			validateEntries(t, changes.Delete, []*endpoint.Endpoint{current[2]})
+		})
+	}
+}
+
+func TestPlanDeleteProtection(t *testing.T) {
+	protected := endpoint.NewEndpoint("protected.example.org", endpoint.RecordTypeA, "192.0.2.1").
+		WithLabel(endpoint.OwnerLabelKey, "owner").
+		WithLabel(endpoint.DeleteProtectionLabelKey, "true")
+	protectedMX := endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org").
+		WithLabel(endpoint.OwnerLabelKey, "owner").
+		WithLabel(endpoint.DeleteProtectionLabelKey, "true")
+	unprotected := endpoint.NewEndpoint("unprotected.example.org", endpoint.RecordTypeA, "192.0.2.1").
+		WithLabel(endpoint.OwnerLabelKey, "owner")
+
+	for _, tc := range []struct {
+		name            string
+		desired         []*endpoint.Endpoint
+		expectedUpdates []*endpoint.Endpoint
+		expectedDeletes []*endpoint.Endpoint
+	}{
+		{
+			name:            "the protected records are not deleted once their resource disappears",
+			expectedDeletes: []*endpoint.Endpoint{unprotected},
+		},
+		{
+			name: "the protected records are still updated",
+			desired: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("protected.example.org", endpoint.RecordTypeA, "192.0.2.2").
+					WithLabel(endpoint.DeleteProtectionLabelKey, "true"),
+			},
+			expectedUpdates: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("protected.example.org", endpoint.RecordTypeA, "192.0.2.2").
+					WithLabel(endpoint.OwnerLabelKey, "owner"),
+			},
+			expectedDeletes: []*endpoint.Endpoint{unprotected},
+		},
+		{
+			name: "the protection is stored by updating the records",
+			desired: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("protected.example.org", endpoint.RecordTypeA, "192.0.2.1").
+					WithLabel(endpoint.DeleteProtectionLabelKey, "true"),
+				endpoint.NewEndpoint("unprotected.example.org", endpoint.RecordTypeA, "192.0.2.1").
+					WithLabel(endpoint.DeleteProtectionLabelKey, "true"),
+			},
+			expectedUpdates: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("unprotected.example.org", endpoint.RecordTypeA, "192.0.2.1").
+					WithLabel(endpoint.OwnerLabelKey, "owner"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        []*endpoint.Endpoint{protected, protectedMX, unprotected},
+				Desired:        tc.desired,
+				ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeMX},
+				OwnerID:        "owner",
+			}
+
+			changes := p.Calculate().Changes
+			assert.Empty(t, changes.Create)
+			validateEntries(t, changes.UpdateNew, tc.expectedUpdates)
+			validateEntries(t, changes.Delete, tc.expectedDeletes)
+		})
+	}
+}
+
+func TestPlanDeleteProtectionReplacedRecord(t *testing.T) {
+	protected := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1").
+		WithLabel(endpoint.OwnerLabelKey, "owner").
+		WithLabel(endpoint.DeleteProtectionLabelKey, "true")
+
+	for _, replacement := range []bool{false, true} {
+		t.Run(fmt.Sprintf("record type replacement %t", replacement), func(t *testing.T) {
+			p := &Plan{
+				Policies: []Policy{&SyncPolicy{}},
+				Current:  []*endpoint.Endpoint{protected},
+				Desired: []*endpoint.Endpoint{
+					endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+					endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+				},
+				ManagedRecords:        []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+				OwnerID:               "owner",
+				RecordTypeReplacement: replacement,
+			}
+
+			// the CNAME can't be created next to the protected A record
+			changes := p.Calculate().Changes
+			assert.Equal(t, []string{"new"}, planDNSNames(changes.Create))
+			assert.Empty(t, changes.Delete)
+		})
+	}
+}
+
+func TestPlanMaxDeletions(t *testing.T) {
+	var current []*endpoint.Endpoint
+	for _, name := range []string{"e", "c", "a", "d", "b"} {
+		current = append(current, endpoint.NewEndpoint(name+".example.org", endpoint.RecordTypeA, "192.0.2.1"))
+	}
+
+	for _, tc := range []struct {
+		name             string
+		maxDeletions     int
+		expectedDeletes  []string
+		expectedDeferred []string
+	}{
+		{
+			name:            "not limited",
+			expectedDeletes: []string{"e", "c", "a", "d", "b"},
+		},
+		{
+			name:             "more deletions than the cap",
+			maxDeletions:     2,
+			expectedDeletes:  []string{"a", "b"},
+			expectedDeferred: []string{"c", "d", "e"},
+		},
+		{
+			name:            "as many deletions as the cap",
+			maxDeletions:    5,
+			expectedDeletes: []string{"e", "c", "a", "d", "b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        current,
+				ManagedRecords: []string{endpoint.RecordTypeA},
+				MaxDeletions:   tc.maxDeletions,
+			}
+
+			plan := p.Calculate()
+			assert.ElementsMatch(t, tc.expectedDeletes, planDNSNames(plan.Changes.Delete))
+			assert.ElementsMatch(t, tc.expectedDeferred, planDNSNames(plan.DeferredDeletes))
+		})
+	}
+
+	// the deferred deletions are planned by the next calculation, once a and b are deleted
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current[0], current[1], current[3]},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		MaxDeletions:   2,
+	}
+	plan := p.Calculate()
+	assert.Equal(t, []string{"c", "d"}, planDNSNames(plan.Changes.Delete))
+	assert.Equal(t, []string{"e"}, planDNSNames(plan.DeferredDeletes))
+}
+
+func TestPlanMaxDeletionsReplacedRecord(t *testing.T) {
+	p := &Plan{
+		Policies: []Policy{&SyncPolicy{}},
+		Current: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "192.0.2.1"),
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "192.0.2.1"),
+		},
+		Desired: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+			endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "192.0.2.1"),
+		},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		MaxDeletions:   1,
+	}
+
+	// the CNAME replacing the deferred A record is not created before the A record is deleted
+	plan := p.Calculate()
+	assert.Equal(t, []string{"a"}, planDNSNames(plan.Changes.Delete))
+	assert.Equal(t, []string{"b"}, planDNSNames(plan.DeferredDeletes))
+	assert.Equal(t, []string{"c"}, planDNSNames(plan.Changes.Create))
+}
+
+func TestPlanExternalRecords(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("legacy.example.org", endpoint.RecordTypeA, "192.0.2.1"),
+		endpoint.NewEndpoint("marked.example.org", endpoint.RecordTypeA, "192.0.2.2"),
+		endpoint.NewEndpoint("marked.example.org", endpoint.RecordTypeTXT, `"managed-by=terraform"`),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "192.0.2.3"),
+		endpoint.NewEndpoint("stale.example.org", endpoint.RecordTypeA, "192.0.2.4"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("legacy.example.org", endpoint.RecordTypeA, "192.0.2.10"),
+		endpoint.NewEndpoint("marked.example.org", endpoint.RecordTypeA, "192.0.2.20"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "192.0.2.30"),
+		endpoint.NewEndpoint("legacy-new.example.org", endpoint.RecordTypeA, "192.0.2.40"),
+	}
+
+	for _, tc := range []struct {
+		name            string
+		externalRecords *regexp.Regexp
+		marker          string
+		expectedCreate  []string
+		expectedUpdate  []string
+		expectedDelete  []string
+	}{
+		{
+			name:           "no external records",
+			expectedCreate: []string{"legacy-new"},
+			expectedUpdate: []string{"legacy", "marked", "other"},
+			expectedDelete: []string{"marked", "stale"},
+		},
+		{
+			name:            "external records matching the pattern",
+			externalRecords: regexp.MustCompile(`^legacy`),
+			expectedUpdate:  []string{"marked", "other"},
+			expectedDelete:  []string{"marked", "stale"},
+		},
+		{
+			name:           "external records marked by a TXT record",
+			marker:         "managed-by=terraform",
+			expectedCreate: []string{"legacy-new"},
+			expectedUpdate: []string{"legacy", "other"},
+			expectedDelete: []string{"stale"},
+		},
+		{
+			name:            "external records matching the pattern or marked by a TXT record",
+			externalRecords: regexp.MustCompile(`^legacy\.`),
+			marker:          "managed-by=terraform",
+			expectedCreate:  []string{"legacy-new"},
+			expectedUpdate:  []string{"other"},
+			expectedDelete:  []string{"stale"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:                 []Policy{&SyncPolicy{}},
+				Current:                  current,
+				Desired:                  desired,
+				ManagedRecords:           []string{endpoint.RecordTypeA, endpoint.RecordTypeTXT},
+				ExternalRecords:          tc.externalRecords,
+				ExternalRecordsTXTMarker: tc.marker,
+			}
+
+			plan := p.Calculate()
+			assert.ElementsMatch(t, tc.expectedCreate, planDNSNames(plan.Changes.Create))
+			assert.ElementsMatch(t, tc.expectedUpdate, planDNSNames(plan.Changes.UpdateNew))
+			assert.ElementsMatch(t, tc.expectedDelete, planDNSNames(plan.Changes.Delete))
+		})
+	}
+}
+
+// planDNSNames returns the first label of the DNS names of the endpoints.
+func planDNSNames(endpoints []*endpoint.Endpoint) []string {
+	var names []string
+	for _, ep := range endpoints {
+		names = append(names, strings.TrimSuffix(ep.DNSName, ".example.org"))
+	}
+	return names
+}
+
 func TestPlan_ChangesJson_DecodeMixedCase(t *testing.T) {
 	input := `{"Create":[{"dnsName":"foo"}],"UpdateOld":[{"dnsName":"bar"}],"updateNew":[{"dnsName":"baz"}],"Delete":[{"dnsName":"qux"}]}`
 	var changes Changes
@@ -599,6 +1102,114 @@ func (suite *PlanTestSuite) TestExistingDualStackWithCNameDesired() {
 	validateEntries(suite.T(), changes.Delete, expectedDelete)
 }
 
+// TestRecordTypeReplacement validates that the current records of a domain are deleted along with the creation
+// of the records of a conflicting type replacing them, and that neither happens when the policy does not allow deletions.
+func (suite *PlanTestSuite) TestRecordTypeReplacement() {
+	suite.fooA5.Labels[endpoint.OwnerLabelKey] = suite.fooV1Cname.Labels[endpoint.OwnerLabelKey]
+	suite.fooAAAA.Labels[endpoint.OwnerLabelKey] = suite.fooV1Cname.Labels[endpoint.OwnerLabelKey]
+
+	for _, tc := range []struct {
+		name                  string
+		policy                Policy
+		current               []*endpoint.Endpoint
+		desired               []*endpoint.Endpoint
+		recordTypeReplacement bool
+		expectedCreate        []*endpoint.Endpoint
+		expectedDelete        []*endpoint.Endpoint
+	}{
+		{
+			name:                  "A to CNAME",
+			policy:                &SyncPolicy{},
+			current:               []*endpoint.Endpoint{suite.fooA5, suite.fooAAAA},
+			desired:               []*endpoint.Endpoint{suite.fooV1Cname},
+			recordTypeReplacement: true,
+			expectedCreate:        []*endpoint.Endpoint{suite.fooV1Cname},
+			expectedDelete:        []*endpoint.Endpoint{suite.fooA5, suite.fooAAAA},
+		},
+		{
+			name:                  "CNAME to A",
+			policy:                &SyncPolicy{},
+			current:               []*endpoint.Endpoint{suite.fooV1Cname},
+			desired:               []*endpoint.Endpoint{suite.fooA5},
+			recordTypeReplacement: true,
+			expectedCreate:        []*endpoint.Endpoint{suite.fooA5},
+			expectedDelete:        []*endpoint.Endpoint{suite.fooV1Cname},
+		},
+		{
+			name:                  "CNAME and AAAA to A",
+			policy:                &SyncPolicy{},
+			current:               []*endpoint.Endpoint{suite.fooV1Cname, suite.fooAAAA},
+			desired:               []*endpoint.Endpoint{suite.fooA5},
+			recordTypeReplacement: true,
+			expectedCreate:        []*endpoint.Endpoint{suite.fooA5},
+			expectedDelete:        []*endpoint.Endpoint{suite.fooV1Cname, suite.fooAAAA},
+		},
+		{
+			name:                  "A to CNAME with upsert-only",
+			policy:                &UpsertOnlyPolicy{},
+			current:               []*endpoint.Endpoint{suite.fooA5, suite.fooAAAA},
+			desired:               []*endpoint.Endpoint{suite.fooV1Cname},
+			recordTypeReplacement: true,
+			expectedCreate:        []*endpoint.Endpoint{},
+			expectedDelete:        []*endpoint.Endpoint{},
+		},
+		{
+			name:                  "CNAME to A with create-only",
+			policy:                &CreateOnlyPolicy{},
+			current:               []*endpoint.Endpoint{suite.fooV1Cname},
+			desired:               []*endpoint.Endpoint{suite.fooA5},
+			recordTypeReplacement: true,
+			expectedCreate:        []*endpoint.Endpoint{},
+			expectedDelete:        []*endpoint.Endpoint{},
+		},
+		{
+			name:           "A to CNAME with upsert-only without record type replacement",
+			policy:         &UpsertOnlyPolicy{},
+			current:        []*endpoint.Endpoint{suite.fooA5},
+			desired:        []*endpoint.Endpoint{suite.fooV1Cname},
+			expectedCreate: []*endpoint.Endpoint{suite.fooV1Cname},
+			expectedDelete: []*endpoint.Endpoint{},
+		},
+	} {
+		suite.Run(tc.name, func() {
+			p := &Plan{
+				Policies:              []Policy{tc.policy},
+				Current:               tc.current,
+				Desired:               tc.desired,
+				ManagedRecords:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+				OwnerID:               suite.fooV1Cname.Labels[endpoint.OwnerLabelKey],
+				RecordTypeReplacement: tc.recordTypeReplacement,
+			}
+
+			changes := p.Calculate().Changes
+			validateEntries(suite.T(), changes.Create, tc.expectedCreate)
+			validateEntries(suite.T(), changes.UpdateNew, []*endpoint.Endpoint{})
+			validateEntries(suite.T(), changes.UpdateOld, []*endpoint.Endpoint{})
+			validateEntries(suite.T(), changes.Delete, tc.expectedDelete)
+		})
+	}
+}
+
+// TestRecordTypeReplacementNotOwned validates that records of a conflicting type owned by another instance
+// are not replaced.
+func (suite *PlanTestSuite) TestRecordTypeReplacementNotOwned() {
+	suite.fooA5.Labels[endpoint.OwnerLabelKey] = "nerf"
+	p := &Plan{
+		Policies:              []Policy{&UpsertOnlyPolicy{}},
+		Current:               []*endpoint.Endpoint{suite.fooA5},
+		Desired:               []*endpoint.Endpoint{suite.fooV1Cname},
+		ManagedRecords:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+		OwnerID:               "pwner",
+		RecordTypeReplacement: true,
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, []*endpoint.Endpoint{})
+	validateEntries(suite.T(), changes.UpdateNew, []*endpoint.Endpoint{})
+	validateEntries(suite.T(), changes.UpdateOld, []*endpoint.Endpoint{})
+	validateEntries(suite.T(), changes.Delete, []*endpoint.Endpoint{})
+}
+
 // TestExistingOwnerNotMatchingDualStackDesired validates that if there is an existing
 // record for a domain but there is no ownership claim over it and there are desired
 // records no changes are planed. Only domains that have explicit ownership claims should
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ProviderSpecificDefault is a provider specific property set on the desired records of a domain
+// and its subdomains which don't set it themselves, for example from an annotation.
+type ProviderSpecificDefault struct {
+	Domain   string
+	Property endpoint.ProviderSpecificProperty
+}
+
+// ApplyProviderSpecificDefaults returns the endpoints with the provider specific defaults of their domains.
+// The default of the most specific domain wins when several domains set the same property, and the
+// endpoints are copied before being modified.
+func ApplyProviderSpecificDefaults(endpoints []*endpoint.Endpoint, defaults []ProviderSpecificDefault) []*endpoint.Endpoint {
+	if len(defaults) == 0 {
+		return endpoints
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		name := normalizeDNSName(ep.DNSName)
+		matched := map[string]ProviderSpecificDefault{}
+		for _, d := range defaults {
+			domain := normalizeDNSName(d.Domain)
+			if name != domain && !strings.HasSuffix(name, "."+domain) {
+				continue
+			}
+			if _, ok := ep.GetProviderSpecificProperty(d.Property.Name); ok {
+				continue
+			}
+			if current, ok := matched[d.Property.Name]; ok && len(current.Domain) >= len(d.Domain) {
+				continue
+			}
+			matched[d.Property.Name] = d
+		}
+
+		if len(matched) == 0 {
+			result = append(result, ep)
+			continue
+		}
+		ep = ep.DeepCopy()
+		for _, d := range defaults {
+			if matched[d.Property.Name] == d {
+				ep.SetProviderSpecificProperty(d.Property.Name, d.Property.Value)
+			}
+		}
+		result = append(result, ep)
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestApplyProviderSpecificDefaults(t *testing.T) {
+	const proxied = "external-dns.alpha.kubernetes.io/cloudflare-proxied"
+	defaults := []ProviderSpecificDefault{
+		{Domain: "example.com", Property: endpoint.ProviderSpecificProperty{Name: proxied, Value: "true"}},
+		{Domain: "internal.example.com", Property: endpoint.ProviderSpecificProperty{Name: proxied, Value: "false"}},
+	}
+
+	for _, tc := range []struct {
+		title    string
+		ep       *endpoint.Endpoint
+		expected *endpoint.Endpoint
+	}{
+		{
+			title:    "default of the domain",
+			ep:       endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			expected: endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1").WithProviderSpecific(proxied, "true"),
+		},
+		{
+			title:    "default of the most specific domain",
+			ep:       endpoint.NewEndpoint("app.internal.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+			expected: endpoint.NewEndpoint("app.internal.example.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(proxied, "false"),
+		},
+		{
+			title:    "domain apex",
+			ep:       endpoint.NewEndpoint("internal.example.com.", endpoint.RecordTypeA, "10.0.0.1"),
+			expected: endpoint.NewEndpoint("internal.example.com.", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(proxied, "false"),
+		},
+		{
+			title:    "annotation overrides the default",
+			ep:       endpoint.NewEndpoint("app.internal.example.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(proxied, "true"),
+			expected: endpoint.NewEndpoint("app.internal.example.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(proxied, "true"),
+		},
+		{
+			title:    "other domain",
+			ep:       endpoint.NewEndpoint("www.notexample.com", endpoint.RecordTypeA, "192.0.2.1"),
+			expected: endpoint.NewEndpoint("www.notexample.com", endpoint.RecordTypeA, "192.0.2.1"),
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			original := tc.ep.DeepCopy()
+
+			result := ApplyProviderSpecificDefaults([]*endpoint.Endpoint{tc.ep}, defaults)
+			assert.Equal(t, []*endpoint.Endpoint{tc.expected}, result)
+			// The endpoints of the sources are left untouched.
+			assert.Equal(t, original, tc.ep)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -62,6 +63,8 @@ type AkamaiConfig struct {
 	MaxBody               int
 	AccountKey            string
 	DryRun                bool
+	// ZoneContracts map zones to the contracts holding them, in the format <zone>=<contractId>
+	ZoneContracts []string
 }
 
 // AkamaiProvider implements the DNS provider for Akamai.
@@ -71,6 +74,8 @@ type AkamaiProvider struct {
 	domainFilter *endpoint.DomainFilter
 	// Contract Ids to filter on
 	zoneIDFilter provider.ZoneIDFilter
+	// Contract Ids of the zones managed in another contract than the filtered ones
+	zoneContracts map[string]string
 	// Edgegrid library configuration
 	config *edgegrid.Config
 	dryRun bool
@@ -91,6 +96,11 @@ type akamaiZone struct {
 func NewAkamaiProvider(akamaiConfig AkamaiConfig, akaService AkamaiDNSService) (provider.Provider, error) {
 	var edgeGridConfig edgegrid.Config
 
+	zoneContracts, err := parseZoneContracts(akamaiConfig.ZoneContracts)
+	if err != nil {
+		return nil, err
+	}
+
 	// environment overrides edgerc file but config needs to be complete
 	if akamaiConfig.ServiceConsumerDomain == "" || akamaiConfig.ClientToken == "" || akamaiConfig.ClientSecret == "" || akamaiConfig.AccessToken == "" {
 		// Kubernetes config incomplete or non existent. Can't mix and match.
@@ -135,10 +145,11 @@ func NewAkamaiProvider(akamaiConfig AkamaiConfig, akaService AkamaiDNSService) (
 	}
 
 	provider := &AkamaiProvider{
-		domainFilter: akamaiConfig.DomainFilter,
-		zoneIDFilter: akamaiConfig.ZoneIDFilter,
-		config:       &edgeGridConfig,
-		dryRun:       akamaiConfig.DryRun,
+		domainFilter:  akamaiConfig.DomainFilter,
+		zoneIDFilter:  akamaiConfig.ZoneIDFilter,
+		zoneContracts: zoneContracts,
+		config:        &edgeGridConfig,
+		dryRun:        akamaiConfig.DryRun,
 	}
 	if akaService != nil {
 		log.Debugf("Using STUB")
@@ -153,6 +164,21 @@ func NewAkamaiProvider(akamaiConfig AkamaiConfig, akaService AkamaiDNSService) (
 	return provider, nil
 }
 
+// parseZoneContracts parses the contracts of the zones in the format <zone>=<contractId>.
+func parseZoneContracts(values []string) (map[string]string, error) {
+	zoneContracts := make(map[string]string, len(values))
+	for _, value := range values {
+		zone, contract, _ := strings.Cut(value, "=")
+		zone = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(zone), "."))
+		contract = strings.TrimSpace(contract)
+		if zone == "" || contract == "" {
+			return nil, fmt.Errorf("invalid Akamai zone contract %q, expected format: <zone>=<contractId>", value)
+		}
+		zoneContracts[zone] = contract
+	}
+	return zoneContracts, nil
+}
+
 func (p AkamaiProvider) ListZones(queryArgs dns.ZoneListQueryArgs) (*dns.ZoneListResponse, error) {
 	return dns.ListZones(queryArgs)
 }
@@ -181,9 +207,16 @@ func (p AkamaiProvider) UpdateRecord(record *dns.RecordBody, zone string, recLoc
 func (p AkamaiProvider) fetchZones() (akamaiZones, error) {
 	filteredZones := akamaiZones{Zones: make([]akamaiZone, 0)}
 	queryArgs := dns.ZoneListQueryArgs{Types: "primary", ShowAll: true}
-	// filter based on contractIds
+	// filter based on contractIds, including the contracts of the zones mapped to other contracts
 	if len(p.zoneIDFilter.ZoneIDs) > 0 {
-		queryArgs.ContractIds = strings.Join(p.zoneIDFilter.ZoneIDs, ",")
+		var mappedContracts []string
+		for _, contract := range p.zoneContracts {
+			if !slices.Contains(p.zoneIDFilter.ZoneIDs, contract) && !slices.Contains(mappedContracts, contract) {
+				mappedContracts = append(mappedContracts, contract)
+			}
+		}
+		slices.Sort(mappedContracts)
+		queryArgs.ContractIds = strings.Join(append(slices.Clone(p.zoneIDFilter.ZoneIDs), mappedContracts...), ",")
 	}
 	resp, err := p.client.ListZones(queryArgs) // retrieve all primary zones filtered by contract ids
 	if err != nil {
@@ -192,6 +225,10 @@ func (p AkamaiProvider) fetchZones() (akamaiZones, error) {
 	}
 
 	for _, zone := range resp.Zones {
+		if !p.zoneInContract(zone) {
+			log.Debugf("Skipping zone '%s' of contract %s, it is not mapped to this contract", zone.Zone, zone.ContractId)
+			continue
+		}
 		if p.domainFilter.Match(zone.Zone) {
 			filteredZones.Zones = append(filteredZones.Zones, akamaiZone{ContractID: zone.ContractId, Zone: zone.Zone})
 			log.Debugf("Fetched zone: '%s' (ZoneID: %s)", zone.Zone, zone.ContractId)
@@ -207,6 +244,23 @@ func (p AkamaiProvider) fetchZones() (akamaiZones, error) {
 	return filteredZones, nil
 }
 
+// zoneInContract returns whether the zone is managed in its contract: the zones mapped to a contract are only
+// managed in that contract, and the contracts only listed for the mapped zones don't hold other managed zones.
+func (p AkamaiProvider) zoneInContract(zone *dns.ZoneResponse) bool {
+	if contract, ok := p.zoneContracts[strings.ToLower(strings.TrimSuffix(zone.Zone, "."))]; ok {
+		return zone.ContractId == contract
+	}
+	if len(p.zoneIDFilter.ZoneIDs) == 0 || slices.Contains(p.zoneIDFilter.ZoneIDs, zone.ContractId) {
+		return true
+	}
+	for _, contract := range p.zoneContracts {
+		if zone.ContractId == contract {
+			return false
+		}
+	}
+	return true
+}
+
 // Records returns the list of records in a given zone.
 func (p AkamaiProvider) Records(context.Context) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
@@ -19,6 +19,9 @@ package akamai
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
@@ -41,6 +44,8 @@ type edgednsStubData struct {
 
 type edgednsStub struct {
 	stubData map[string]edgednsStubData
+	// zoneQueryArgs are the query args of the last ListZones call
+	zoneQueryArgs dns.ZoneListQueryArgs
 }
 
 func newStub() *edgednsStub {
@@ -105,12 +110,20 @@ func (r *edgednsStub) setCreateRecords(objtype string, records []interface{}) {
 
 func (r *edgednsStub) ListZones(queryArgs dns.ZoneListQueryArgs) (*dns.ZoneListResponse, error) {
 	log.Debugf("Entering ListZones")
+	r.zoneQueryArgs = queryArgs
 	// Ignore Metadata`
 	resp := &dns.ZoneListResponse{}
 	zones := make([]*dns.ZoneResponse, 0)
 	for _, zname := range r.stubData["zone"].output {
 		log.Debugf("Processing output: %v", zname)
-		zn := &dns.ZoneResponse{Zone: zname.(string), ContractId: "contract"}
+		// the zones given with their contract are filtered by the contracts of the query
+		zn, ok := zname.(*dns.ZoneResponse)
+		if ok && queryArgs.ContractIds != "" && !slices.Contains(strings.Split(queryArgs.ContractIds, ","), zn.ContractId) {
+			continue
+		}
+		if !ok {
+			zn = &dns.ZoneResponse{Zone: zname.(string), ContractId: "contract"}
+		}
 		log.Debugf("Created Zone Object: %v", zn)
 		zones = append(zones, zn)
 	}
@@ -183,6 +196,78 @@ func TestFetchZonesEmpty(t *testing.T) {
 	}
 }
 
+func TestFetchZonesZoneContracts(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		idfilter            []string
+		zoneContracts       []string
+		expectedContractIDs string
+		expectedZones       []akamaiZone
+	}{
+		{
+			name:     "zones of the filtered contract",
+			idfilter: []string{"ctr-1"},
+			expectedZones: []akamaiZone{
+				{ContractID: "ctr-1", Zone: "one.testzone.com"},
+				{ContractID: "ctr-1", Zone: "other.testzone.com"},
+			},
+			expectedContractIDs: "ctr-1",
+		},
+		{
+			name:          "zone mapped to another contract",
+			idfilter:      []string{"ctr-1"},
+			zoneContracts: []string{"two.testzone.com=ctr-2"},
+			expectedZones: []akamaiZone{
+				{ContractID: "ctr-1", Zone: "one.testzone.com"},
+				{ContractID: "ctr-1", Zone: "other.testzone.com"},
+				{ContractID: "ctr-2", Zone: "two.testzone.com"},
+			},
+			expectedContractIDs: "ctr-1,ctr-2",
+		},
+		{
+			name:          "zones mapped to their contracts without contract filter",
+			zoneContracts: []string{"one.testzone.com=ctr-1", "Two.testzone.com.=ctr-2", "other.testzone.com=ctr-2"},
+			expectedZones: []akamaiZone{
+				{ContractID: "ctr-1", Zone: "one.testzone.com"},
+				{ContractID: "ctr-2", Zone: "two.testzone.com"},
+				{ContractID: "ctr-2", Zone: "unmapped.testzone.com"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stub := newStub()
+			c, err := NewAkamaiProvider(AkamaiConfig{
+				DomainFilter:          &endpoint.DomainFilter{},
+				ZoneIDFilter:          provider.NewZoneIDFilter(tc.idfilter),
+				ServiceConsumerDomain: "testzone.com",
+				ClientToken:           "test_token",
+				ClientSecret:          "test_client_secret",
+				AccessToken:           "test_access_token",
+				ZoneContracts:         tc.zoneContracts,
+			}, stub)
+			require.NoError(t, err)
+			stub.setOutput("zone", []interface{}{
+				&dns.ZoneResponse{Zone: "one.testzone.com", ContractId: "ctr-1"},
+				&dns.ZoneResponse{Zone: "other.testzone.com", ContractId: "ctr-1"},
+				&dns.ZoneResponse{Zone: "two.testzone.com", ContractId: "ctr-2"},
+				&dns.ZoneResponse{Zone: "unmapped.testzone.com", ContractId: "ctr-2"},
+			})
+
+			zones, err := c.(*AkamaiProvider).fetchZones()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedZones, zones.Zones)
+			assert.Equal(t, tc.expectedContractIDs, stub.zoneQueryArgs.ContractIds)
+		})
+	}
+}
+
+func TestNewAkamaiProviderInvalidZoneContracts(t *testing.T) {
+	for _, value := range []string{"testzone.com", "testzone.com=", "=ctr-1"} {
+		_, err := NewAkamaiProvider(AkamaiConfig{ZoneContracts: []string{value}}, newStub())
+		require.EqualError(t, err, fmt.Sprintf("invalid Akamai zone contract %q, expected format: <zone>=<contractId>", value))
+	}
+}
+
 // TestAkamaiRecords tests record endpoint
 func TestAkamaiRecords(t *testing.T) {
 	stub := newStub()
@@ -50,6 +50,10 @@ const (
 	// providerSpecificAlias specifies whether a CNAME endpoint maps to an AWS ALIAS record.
 	providerSpecificAlias            = "alias"
 	providerSpecificTargetHostedZone = "aws/target-hosted-zone"
+	// providerSpecificAWSAlias and providerSpecificTargetHostedZoneID are the names of
+	// `providerSpecificAlias` and `providerSpecificTargetHostedZone` set by the aws- annotations.
+	providerSpecificAWSAlias           = "aws/alias"
+	providerSpecificTargetHostedZoneID = "aws/target-hosted-zone-id"
 	// providerSpecificEvaluateTargetHealth specifies whether an AWS ALIAS record
 	// has the EvaluateTargetHealth field set to true. Present iff the endpoint
 	// has a `providerSpecificAlias` value of `true`.
@@ -67,6 +71,8 @@ const (
 	providerSpecificMultiValueAnswer                   = "aws/multi-value-answer"
 	providerSpecificHealthCheckID                      = "aws/health-check-id"
 	sameZoneAlias                                      = "same-zone"
+	// maxWeight is the highest weight of the weighted records accepted by Route 53
+	maxWeight = 255
 	// Currently supported up to 10 health checks or hosted zones.
 	// https://docs.aws.amazon.com/Route53/latest/APIReference/API_ListTagsForResources.html#API_ListTagsForResources_RequestSyntax
 	batchSize    = 10
@@ -307,7 +313,9 @@ type AWSProvider struct {
 	// extend filter for subdomains in the zone (e.g. first.us-east-1.example.com)
 	zoneMatchParent bool
 	preferCNAME     bool
-	zonesCache      *zonesListCache
+	// prefer CNAME over ALIAS only for the records of these domains
+	preferCNAMEDomains *endpoint.DomainFilter
+	zonesCache         *zonesListCache
 	// queue for collecting changes to submit them in the next iteration, but after all other changes
 	failedChangesQueue map[string]Route53Changes
 }
@@ -325,6 +333,7 @@ type AWSConfig struct {
 	BatchChangeInterval   time.Duration
 	EvaluateTargetHealth  bool
 	PreferCNAME           bool
+	PreferCNAMEDomains    []string
 	DryRun                bool
 	ZoneCacheDuration     time.Duration
 }
@@ -344,6 +353,7 @@ func NewAWSProvider(awsConfig AWSConfig, clients map[string]Route53API) (*AWSPro
 		batchChangeInterval:   awsConfig.BatchChangeInterval,
 		evaluateTargetHealth:  awsConfig.EvaluateTargetHealth,
 		preferCNAME:           awsConfig.PreferCNAME,
+		preferCNAMEDomains:    endpoint.NewDomainFilter(awsConfig.PreferCNAMEDomains),
 		dryRun:                awsConfig.DryRun,
 		zonesCache:            &zonesListCache{duration: awsConfig.ZoneCacheDuration},
 		failedChangesQueue:    make(map[string]Route53Changes),
@@ -435,10 +445,9 @@ func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, erro
 		}
 	}
 
-	if p.zonesCache.duration > time.Duration(0) {
-		p.zonesCache.zones = zones
-		p.zonesCache.age = time.Now()
-	}
+	// the zones are kept even when the cache is disabled, for AdjustEndpoints to compare the endpoints to their zones
+	p.zonesCache.zones = zones
+	p.zonesCache.age = time.Now()
 
 	return zones, nil
 }
@@ -498,86 +507,98 @@ func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZon
 				return nil, provider.NewSoftErrorf("failed to list resource records sets for zone %s using aws profile %q: %w", *z.zone.Id, z.profile, err)
 			}
 
-			for _, r := range resp.ResourceRecordSets {
-				newEndpoints := make([]*endpoint.Endpoint, 0)
+			for i := range resp.ResourceRecordSets {
+				endpoints = append(endpoints, p.recordSetEndpoints(&resp.ResourceRecordSets[i], *z.zone.Id)...)
+			}
+		}
+	}
 
-				if !p.SupportedRecordType(r.Type) {
-					continue
-				}
+	return endpoints, nil
+}
 
-				name := convertOctalToAscii(wildcardUnescape(*r.Name))
+// recordSetEndpoints converts a resource record set of the given hosted zone into endpoints.
+func (p *AWSProvider) recordSetEndpoints(r *route53types.ResourceRecordSet, zoneID string) []*endpoint.Endpoint {
+	if !p.SupportedRecordType(r.Type) {
+		return nil
+	}
 
-				var ttl endpoint.TTL
-				if r.TTL != nil {
-					ttl = endpoint.TTL(*r.TTL)
-				}
+	newEndpoints := make([]*endpoint.Endpoint, 0)
 
-				if len(r.ResourceRecords) > 0 {
-					targets := make([]string, len(r.ResourceRecords))
-					for idx, rr := range r.ResourceRecords {
-						targets[idx] = *rr.Value
-					}
+	name := convertOctalToAscii(wildcardUnescape(*r.Name))
 
-					ep := endpoint.NewEndpointWithTTL(name, string(r.Type), ttl, targets...)
-					if r.Type == endpoint.RecordTypeCNAME {
-						ep = ep.WithProviderSpecific(providerSpecificAlias, "false")
-					}
-					newEndpoints = append(newEndpoints, ep)
-				}
+	var ttl endpoint.TTL
+	if r.TTL != nil {
+		ttl = endpoint.TTL(*r.TTL)
+	}
 
-				if r.AliasTarget != nil {
-					// Alias records don't have TTLs so provide the default to match the TXT generation
-					if ttl == 0 {
-						ttl = defaultTTL
-					}
-					ep := endpoint.
-						NewEndpointWithTTL(name, string(r.Type), ttl, *r.AliasTarget.DNSName).
-						WithProviderSpecific(providerSpecificEvaluateTargetHealth, fmt.Sprintf("%t", r.AliasTarget.EvaluateTargetHealth)).
-						WithProviderSpecific(providerSpecificAlias, "true")
-					newEndpoints = append(newEndpoints, ep)
-				}
+	if len(r.ResourceRecords) > 0 {
+		targets := make([]string, len(r.ResourceRecords))
+		for idx, rr := range r.ResourceRecords {
+			targets[idx] = *rr.Value
+		}
 
-				for _, ep := range newEndpoints {
-					if r.SetIdentifier != nil {
-						ep.SetIdentifier = *r.SetIdentifier
-						switch {
-						case r.Weight != nil:
-							ep.WithProviderSpecific(providerSpecificWeight, fmt.Sprintf("%d", *r.Weight))
-						case r.Region != "":
-							ep.WithProviderSpecific(providerSpecificRegion, string(r.Region))
-						case r.Failover != "":
-							ep.WithProviderSpecific(providerSpecificFailover, string(r.Failover))
-						case r.MultiValueAnswer != nil && *r.MultiValueAnswer:
-							ep.WithProviderSpecific(providerSpecificMultiValueAnswer, "")
-						case r.GeoLocation != nil:
-							if r.GeoLocation.ContinentCode != nil {
-								ep.WithProviderSpecific(providerSpecificGeolocationContinentCode, *r.GeoLocation.ContinentCode)
-							} else {
-								if r.GeoLocation.CountryCode != nil {
-									ep.WithProviderSpecific(providerSpecificGeolocationCountryCode, *r.GeoLocation.CountryCode)
-								}
-								if r.GeoLocation.SubdivisionCode != nil {
-									ep.WithProviderSpecific(providerSpecificGeolocationSubdivisionCode, *r.GeoLocation.SubdivisionCode)
-								}
-							}
-						case r.GeoProximityLocation != nil:
-							handleGeoProximityLocationRecord(&r, ep)
-						default:
-							// one of the above needs to be set, otherwise SetIdentifier doesn't make sense
-						}
-					}
+		ep := endpoint.NewEndpointWithTTL(name, string(r.Type), ttl, targets...)
+		if r.Type == endpoint.RecordTypeCNAME {
+			ep = ep.WithProviderSpecific(providerSpecificAlias, "false")
+		}
+		if r.Type == route53types.RRTypeSvcb || r.Type == route53types.RRTypeHttps {
+			ep.Targets = ep.Targets.NormalizeSVCBRecords()
+		}
+		newEndpoints = append(newEndpoints, ep)
+	}
 
-					if r.HealthCheckId != nil {
-						ep.WithProviderSpecific(providerSpecificHealthCheckID, *r.HealthCheckId)
+	if r.AliasTarget != nil {
+		// Alias records don't have TTLs so provide the default to match the TXT generation
+		if ttl == 0 {
+			ttl = defaultTTL
+		}
+		ep := endpoint.
+			NewEndpointWithTTL(name, string(r.Type), ttl, *r.AliasTarget.DNSName).
+			WithProviderSpecific(providerSpecificEvaluateTargetHealth, fmt.Sprintf("%t", r.AliasTarget.EvaluateTargetHealth)).
+			WithProviderSpecific(providerSpecificAlias, "true")
+		// the target hosted zone is only reported when it cannot be derived from the target, i.e. for cross-zone aliases
+		if hostedZoneID := cleanZoneID(aws.ToString(r.AliasTarget.HostedZoneId)); hostedZoneID != cleanZoneID(zoneID) && hostedZoneID != canonicalHostedZone(ep.Targets[0]) {
+			ep.WithProviderSpecific(providerSpecificTargetHostedZone, hostedZoneID)
+		}
+		newEndpoints = append(newEndpoints, ep)
+	}
+
+	for _, ep := range newEndpoints {
+		if r.SetIdentifier != nil {
+			ep.SetIdentifier = *r.SetIdentifier
+			switch {
+			case r.Weight != nil:
+				ep.WithProviderSpecific(providerSpecificWeight, fmt.Sprintf("%d", *r.Weight))
+			case r.Region != "":
+				ep.WithProviderSpecific(providerSpecificRegion, string(r.Region))
+			case r.Failover != "":
+				ep.WithProviderSpecific(providerSpecificFailover, string(r.Failover))
+			case r.MultiValueAnswer != nil && *r.MultiValueAnswer:
+				ep.WithProviderSpecific(providerSpecificMultiValueAnswer, "")
+			case r.GeoLocation != nil:
+				if r.GeoLocation.ContinentCode != nil {
+					ep.WithProviderSpecific(providerSpecificGeolocationContinentCode, *r.GeoLocation.ContinentCode)
+				} else {
+					if r.GeoLocation.CountryCode != nil {
+						ep.WithProviderSpecific(providerSpecificGeolocationCountryCode, *r.GeoLocation.CountryCode)
+					}
+					if r.GeoLocation.SubdivisionCode != nil {
+						ep.WithProviderSpecific(providerSpecificGeolocationSubdivisionCode, *r.GeoLocation.SubdivisionCode)
 					}
-
-					endpoints = append(endpoints, ep)
 				}
+			case r.GeoProximityLocation != nil:
+				handleGeoProximityLocationRecord(r, ep)
+			default:
+				// one of the above needs to be set, otherwise SetIdentifier doesn't make sense
 			}
 		}
+
+		if r.HealthCheckId != nil {
+			ep.WithProviderSpecific(providerSpecificHealthCheckID, *r.HealthCheckId)
+		}
 	}
 
-	return endpoints, nil
+	return newEndpoints
 }
 
 func handleGeoProximityLocationRecord(r *route53types.ResourceRecordSet, ep *endpoint.Endpoint) {
@@ -821,9 +842,17 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	// hard coded to 'A' type aliases but we also need their 'AAAA' counterparts.
 	var aliasCnameAaaaEndpoints []*endpoint.Endpoint
 
+	provider.NormalizeWeights(endpoints, providerSpecificWeight, maxWeight)
+
 	for _, ep := range endpoints {
 		alias := false
 
+		if ep.RecordType == endpoint.RecordTypeSVCB || ep.RecordType == endpoint.RecordTypeHTTPS {
+			ep.Targets = ep.Targets.NormalizeSVCBRecords()
+		}
+
+		adjustAliasPropertiesEndpoint(ep, p.zonesCache.zones)
+
 		if aliasString, ok := ep.GetProviderSpecificProperty(providerSpecificAlias); ok {
 			alias = aliasString == "true"
 			if alias {
@@ -840,7 +869,7 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 				}
 			}
 		} else if ep.RecordType == endpoint.RecordTypeCNAME {
-			alias = useAlias(ep, p.preferCNAME)
+			alias = useAlias(ep, p.preferCNAMEFor(ep))
 			log.Debugf("Modifying endpoint: %v, setting %s=%v", ep, providerSpecificAlias, alias)
 			ep.SetProviderSpecificProperty(providerSpecificAlias, strconv.FormatBool(alias))
 		}
@@ -874,6 +903,7 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 			}
 		} else {
 			ep.DeleteProviderSpecificProperty(providerSpecificEvaluateTargetHealth)
+			ep.DeleteProviderSpecificProperty(providerSpecificTargetHostedZone)
 		}
 
 		adjustGeoProximityLocationEndpoint(ep)
@@ -883,6 +913,35 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	return endpoints, nil
 }
 
+// adjustAliasPropertiesEndpoint renames the aws- annotated alias properties to the ones of the provider
+// and only keeps the target hosted zone when it differs from the canonical hosted zone of the target
+// and from the zones of the endpoint, matching the records read from Route53.
+func adjustAliasPropertiesEndpoint(ep *endpoint.Endpoint, zones map[string]*profiledZone) {
+	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificAWSAlias); ok {
+		ep.DeleteProviderSpecificProperty(providerSpecificAWSAlias)
+		if _, ok := ep.GetProviderSpecificProperty(providerSpecificAlias); !ok {
+			ep.SetProviderSpecificProperty(providerSpecificAlias, prop)
+		}
+	}
+	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificTargetHostedZoneID); ok {
+		ep.DeleteProviderSpecificProperty(providerSpecificTargetHostedZoneID)
+		if _, ok := ep.GetProviderSpecificProperty(providerSpecificTargetHostedZone); !ok {
+			ep.SetProviderSpecificProperty(providerSpecificTargetHostedZone, prop)
+		}
+	}
+	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificTargetHostedZone); ok {
+		hostedZoneID := cleanZoneID(prop)
+		ownZone := slices.ContainsFunc(suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones), func(z *profiledZone) bool {
+			return cleanZoneID(*z.zone.Id) == hostedZoneID
+		})
+		if ownZone || len(ep.Targets) > 0 && hostedZoneID == canonicalHostedZone(ep.Targets[0]) {
+			ep.DeleteProviderSpecificProperty(providerSpecificTargetHostedZone)
+		} else if hostedZoneID != prop {
+			ep.SetProviderSpecificProperty(providerSpecificTargetHostedZone, hostedZoneID)
+		}
+	}
+}
+
 // if the endpoint is using geoproximity, set the bias to 0 if not set
 // this is needed to avoid unnecessary Upserts if the desired endpoint doesn't specify a bias
 func adjustGeoProximityLocationEndpoint(ep *endpoint.Endpoint) {
@@ -1328,6 +1387,15 @@ func suitableZones(hostname string, zones map[string]*profiledZone) []*profiledZ
 	return matchingZones
 }
 
+// preferCNAMEFor determines if CNAME should be preferred over ALIAS for the given endpoint,
+// either globally or because it belongs to one of the configured domains.
+func (p *AWSProvider) preferCNAMEFor(ep *endpoint.Endpoint) bool {
+	if p.preferCNAME {
+		return true
+	}
+	return p.preferCNAMEDomains != nil && p.preferCNAMEDomains.IsConfigured() && p.preferCNAMEDomains.Match(ep.DNSName)
+}
+
 // useAlias determines if AWS ALIAS should be used.
 func useAlias(ep *endpoint.Endpoint, preferCNAME bool) bool {
 	if preferCNAME {
@@ -1394,7 +1462,7 @@ func cleanZoneID(id string) string {
 
 func (p *AWSProvider) SupportedRecordType(recordType route53types.RRType) bool {
 	switch recordType {
-	case route53types.RRTypeMx:
+	case route53types.RRTypeMx, route53types.RRTypeSvcb, route53types.RRTypeHttps:
 		return true
 	default:
 		return provider.SupportedRecordType(string(recordType))
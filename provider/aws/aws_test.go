@@ -23,6 +23,7 @@ import (
 	"math"
 	"net"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -643,6 +644,12 @@ func TestAWSRecords(t *testing.T) {
 			TTL:             aws.Int64(defaultTTL),
 			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("10 mailhost1.example.com")}, {Value: aws.String("20 mailhost2.example.com")}},
 		},
+		{
+			Name:            aws.String("https.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeHttps,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String(`1 . alpn="h3,h2" port=443`)}},
+		},
 	})
 
 	records, err := provider.Records(context.Background())
@@ -678,6 +685,7 @@ func TestAWSRecords(t *testing.T) {
 		endpoint.NewEndpointWithTTL("healthcheck-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, endpoint.TTL(defaultTTL), "foo.example.com").WithSetIdentifier("test-set-1").WithProviderSpecific(providerSpecificWeight, "10").WithProviderSpecific(providerSpecificHealthCheckID, "foo-bar-healthcheck-id").WithProviderSpecific(providerSpecificAlias, "false"),
 		endpoint.NewEndpointWithTTL("healthcheck-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("test-set-2").WithProviderSpecific(providerSpecificWeight, "20").WithProviderSpecific(providerSpecificHealthCheckID, "abc-def-healthcheck-id"),
 		endpoint.NewEndpointWithTTL("mail.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeMX, endpoint.TTL(defaultTTL), "10 mailhost1.example.com", "20 mailhost2.example.com"),
+		endpoint.NewEndpointWithTTL("https.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeHTTPS, endpoint.TTL(defaultTTL), "1 . alpn=h3,h2 port=443"),
 	})
 }
 
@@ -709,7 +717,9 @@ func TestAWSAdjustEndpoints(t *testing.T) {
 		endpoint.NewEndpoint("cname-test-elb-no-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificAlias, "false"),
 		endpoint.NewEndpoint("cname-test-elb-no-eth.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false"), // eth = evaluate target health
 		endpoint.NewEndpoint("cname-test-elb-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificAlias, "true").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
+		endpoint.NewEndpoint("a-test-elb-hosted-zone.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificAWSAlias, "true").WithProviderSpecific(providerSpecificTargetHostedZoneID, "Z215JYRZR1TBD5"),
 		endpoint.NewEndpoint("a-test-geoproximity-no-bias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("test-set-1").WithProviderSpecific(providerSpecificGeoProximityLocationAWSRegion, "us-west-2"),
+		endpoint.NewEndpoint("https-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeHTTPS, `1 . port="443" alpn=h2`),
 	}
 
 	records, err := provider.AdjustEndpoints(records)
@@ -727,10 +737,87 @@ func TestAWSAdjustEndpoints(t *testing.T) {
 		endpoint.NewEndpoint("cname-test-elb-no-eth.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificAlias, "true").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false"), // eth = evaluate target health
 		endpoint.NewEndpoint("cname-test-elb-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificAlias, "true").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
 		endpoint.NewEndpoint("cname-test-elb-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificAlias, "true").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
+		endpoint.NewEndpoint("a-test-elb-hosted-zone.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificAlias, "true").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
 		endpoint.NewEndpoint("a-test-geoproximity-no-bias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("test-set-1").WithProviderSpecific(providerSpecificGeoProximityLocationAWSRegion, "us-west-2").WithProviderSpecific(providerSpecificGeoProximityLocationBias, "0"),
+		endpoint.NewEndpoint("https-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeHTTPS, "1 . alpn=h2 port=443"),
 	})
 }
 
+func TestAWSAdjustEndpointsWeights(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	records, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("a").WithProviderSpecific(providerSpecificWeight, "1000"),
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.4.4").WithSetIdentifier("b").WithProviderSpecific(providerSpecificWeight, "3000"),
+		endpoint.NewEndpoint("in-range.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("a").WithProviderSpecific(providerSpecificWeight, "1"),
+		endpoint.NewEndpoint("in-range.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.4.4").WithSetIdentifier("b").WithProviderSpecific(providerSpecificWeight, "3"),
+	})
+	require.NoError(t, err)
+
+	validateEndpoints(t, provider, records, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("a").WithProviderSpecific(providerSpecificWeight, "85"),
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.4.4").WithSetIdentifier("b").WithProviderSpecific(providerSpecificWeight, "255"),
+		endpoint.NewEndpoint("in-range.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("a").WithProviderSpecific(providerSpecificWeight, "1"),
+		endpoint.NewEndpoint("in-range.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.4.4").WithSetIdentifier("b").WithProviderSpecific(providerSpecificWeight, "3"),
+	})
+}
+
+func TestAWSAdjustEndpointsPreferCNAME(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		preferCNAME        bool
+		preferCNAMEDomains []string
+		expectedAlias      map[string]bool
+	}{
+		{
+			name: "alias by default",
+			expectedAlias: map[string]bool{
+				"elb.zone-1.ext-dns-test-2.teapot.zalan.do": true,
+				"elb.zone-2.ext-dns-test-2.teapot.zalan.do": true,
+			},
+		},
+		{
+			name:        "prefer cname globally",
+			preferCNAME: true,
+			expectedAlias: map[string]bool{
+				"elb.zone-1.ext-dns-test-2.teapot.zalan.do": false,
+				"elb.zone-2.ext-dns-test-2.teapot.zalan.do": false,
+			},
+		},
+		{
+			name:               "prefer cname per domain",
+			preferCNAMEDomains: []string{"zone-2.ext-dns-test-2.teapot.zalan.do"},
+			expectedAlias: map[string]bool{
+				"elb.zone-1.ext-dns-test-2.teapot.zalan.do": true,
+				"elb.zone-2.ext-dns-test-2.teapot.zalan.do": false,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+			provider.preferCNAME = tc.preferCNAME
+			provider.preferCNAMEDomains = endpoint.NewDomainFilter(tc.preferCNAMEDomains)
+
+			records, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("elb.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "foo.eu-central-1.elb.amazonaws.com"),
+				endpoint.NewEndpoint("elb.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "foo.eu-central-1.elb.amazonaws.com"),
+			})
+			require.NoError(t, err)
+
+			for _, r := range records {
+				alias, ok := r.GetProviderSpecificProperty(providerSpecificAlias)
+				require.True(t, ok)
+				assert.Equal(t, strconv.FormatBool(tc.expectedAlias[r.DNSName]), alias, r.DNSName)
+				if tc.expectedAlias[r.DNSName] {
+					assert.Contains(t, []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA}, r.RecordType)
+				} else {
+					assert.Equal(t, endpoint.RecordTypeCNAME, r.RecordType)
+				}
+			}
+		})
+	}
+}
+
 func TestAWSApplyChanges(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -2141,6 +2228,85 @@ func TestAWSCreateRecordsWithALIAS(t *testing.T) {
 	}
 }
 
+func TestAWSCreateRecordsWithCrossZoneALIAS(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("cross-zone-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "alias-target.zone-2.ext-dns-test-2.teapot.zalan.do").
+			WithProviderSpecific(providerSpecificAWSAlias, "true").
+			WithProviderSpecific(providerSpecificTargetHostedZoneID, "/hostedzone/zone-2.ext-dns-test-2.teapot.zalan.do."),
+	}
+	adjusted, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+	validateEndpoints(t, provider, adjusted, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("cross-zone-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "alias-target.zone-2.ext-dns-test-2.teapot.zalan.do").
+			WithProviderSpecific(providerSpecificAlias, "true").
+			WithProviderSpecific(providerSpecificTargetHostedZone, "zone-2.ext-dns-test-2.teapot.zalan.do.").
+			WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
+	})
+
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: adjusted,
+	}))
+
+	recordSets := listAWSRecords(t, provider.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.")
+	validateRecords(t, recordSets, []route53types.ResourceRecordSet{
+		{
+			AliasTarget: &route53types.AliasTarget{
+				DNSName:              aws.String("alias-target.zone-2.ext-dns-test-2.teapot.zalan.do."),
+				EvaluateTargetHealth: true,
+				HostedZoneId:         aws.String("zone-2.ext-dns-test-2.teapot.zalan.do."),
+			},
+			Name: aws.String("cross-zone-alias.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type: route53types.RRTypeA,
+		},
+	})
+
+	// the target hosted zone is read back, so the alias is not updated again
+	current, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	changes := (&plan.Plan{
+		Current:        current,
+		Desired:        adjusted,
+		ManagedRecords: []string{endpoint.RecordTypeA},
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+}
+
+func TestAWSCreateRecordsWithSameZoneALIASTargetHostedZone(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	// the zones are listed by Records before the endpoints are adjusted
+	_, err := provider.Records(context.Background())
+	require.NoError(t, err)
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("same-zone-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "alias-target.zone-1.ext-dns-test-2.teapot.zalan.do").
+			WithProviderSpecific(providerSpecificAWSAlias, "true").
+			WithProviderSpecific(providerSpecificTargetHostedZoneID, "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."),
+	}
+	adjusted, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+	validateEndpoints(t, provider, adjusted, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("same-zone-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "alias-target.zone-1.ext-dns-test-2.teapot.zalan.do").
+			WithProviderSpecific(providerSpecificAlias, "true").
+			WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
+	})
+
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: adjusted,
+	}))
+
+	// the target hosted zone is the zone of the record, which isn't read back, so the alias is not updated again
+	current, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	changes := (&plan.Plan{
+		Current:        current,
+		Desired:        adjusted,
+		ManagedRecords: []string{endpoint.RecordTypeA},
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+}
+
 func TestAWSisLoadBalancer(t *testing.T) {
 	for _, tc := range []struct {
 		target      string
@@ -2207,6 +2373,69 @@ func TestAWSCanonicalHostedZoneNotExist(t *testing.T) {
 	assert.Containsf(t, buf.String(), "Could not find canonical hosted zone for domain", host)
 }
 
+// pagedRoute53API serves a large synthetic zone of A record sets, one page at a time.
+type pagedRoute53API struct {
+	Route53API
+	pages        int
+	fetchedPages int
+}
+
+func (r *pagedRoute53API) ListResourceRecordSets(_ context.Context, input *route53.ListResourceRecordSetsInput, _ ...func(options *route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	page := 0
+	if input.StartRecordName != nil {
+		page, _ = strconv.Atoi(strings.TrimPrefix(*input.StartRecordName, "page-"))
+	}
+	r.fetchedPages++
+
+	output := &route53.ListResourceRecordSetsOutput{}
+	for i := range int(*input.MaxItems) {
+		output.ResourceRecordSets = append(output.ResourceRecordSets, route53types.ResourceRecordSet{
+			Name:            aws.String(fmt.Sprintf("record-%d-%d.large.example.com.", page, i)),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("192.0.2.1")}},
+		})
+	}
+	if page+1 < r.pages {
+		output.IsTruncated = true
+		output.NextRecordName = aws.String(fmt.Sprintf("page-%d", page+1))
+		output.NextRecordType = route53types.RRTypeA
+	}
+	return output, nil
+}
+
+func newPagedAWSProvider(pages int) (*AWSProvider, *pagedRoute53API, map[string]*profiledZone) {
+	client := &pagedRoute53API{pages: pages}
+	p := &AWSProvider{clients: map[string]Route53API{defaultAWSProfile: client}}
+	zones := map[string]*profiledZone{
+		"/hostedzone/large": {
+			profile: defaultAWSProfile,
+			zone:    &route53types.HostedZone{Id: aws.String("/hostedzone/large"), Name: aws.String("large.example.com.")},
+		},
+	}
+	return p, client, zones
+}
+
+func TestAWSRecordsLargeZone(t *testing.T) {
+	const pages = 100
+	p, client, zones := newPagedAWSProvider(pages)
+
+	endpoints, err := p.records(context.Background(), zones)
+	require.NoError(t, err)
+	assert.Len(t, endpoints, pages*int(route53PageSize))
+	assert.Equal(t, pages, client.fetchedPages)
+}
+
+func BenchmarkAWSRecordsLargeZone(b *testing.B) {
+	p, _, zones := newPagedAWSProvider(100)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := p.records(context.Background(), zones); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkTestAWSCanonicalHostedZone(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		for suffix := range canonicalHostedZones {
@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -47,6 +48,9 @@ const (
 	sdInstanceAttrIPV6  = "AWS_INSTANCE_IPV6"
 	sdInstanceAttrCname = "AWS_INSTANCE_CNAME"
 	sdInstanceAttrAlias = "AWS_ALIAS_DNS_NAME"
+
+	// sdProviderSpecificRoutingPolicy overrides the routing policy of the service of an A or AAAA endpoint
+	sdProviderSpecificRoutingPolicy = "aws/sd-routing-policy"
 )
 
 var (
@@ -85,10 +89,20 @@ type AWSSDProvider struct {
 	ownerID string
 	// tags to be added to the service
 	tags []sdtypes.Tag
+	// DNS TTL of the services of the endpoints without TTL
+	serviceTTL int64
+	// routing policy of the services of the A and AAAA endpoints, derived from the record type when empty
+	routingPolicy sdtypes.RoutingPolicy
+	// routing policies of the annotated endpoints, applied when their service is created
+	routingPolicies map[string]sdtypes.RoutingPolicy
 }
 
 // NewAWSSDProvider initializes a new AWS Cloud Map based Provider.
-func NewAWSSDProvider(domainFilter *endpoint.DomainFilter, namespaceType string, dryRun, cleanEmptyService bool, ownerID string, tags map[string]string, client AWSSDClient) (*AWSSDProvider, error) {
+func NewAWSSDProvider(domainFilter *endpoint.DomainFilter, namespaceType string, dryRun, cleanEmptyService bool, ownerID string, tags map[string]string, serviceTTL int64, routingPolicy string, client AWSSDClient) (*AWSSDProvider, error) {
+	if routingPolicy != "" && !slices.Contains(sdtypes.RoutingPolicy("").Values(), sdtypes.RoutingPolicy(routingPolicy)) {
+		return nil, fmt.Errorf("invalid AWS Cloud Map routing policy %q", routingPolicy)
+	}
+
 	p := &AWSSDProvider{
 		client:              client,
 		dryRun:              dryRun,
@@ -97,6 +111,8 @@ func NewAWSSDProvider(domainFilter *endpoint.DomainFilter, namespaceType string,
 		cleanEmptyService:   cleanEmptyService,
 		ownerID:             ownerID,
 		tags:                awsTags(tags),
+		serviceTTL:          serviceTTL,
+		routingPolicy:       sdtypes.RoutingPolicy(routingPolicy),
 	}
 
 	return p, nil
@@ -215,6 +231,25 @@ func (p *AWSSDProvider) instancesToEndpoint(ns *sdtypes.NamespaceSummary, srv *s
 	return newEndpoint
 }
 
+// AdjustEndpoints sets the service TTL on the endpoints without TTL, so that changes are reconciled.
+// The routing policy can't be changed once the service is created, so it is removed from the endpoints
+// to not be compared with the existing services, and only kept to create their missing services.
+func (p *AWSSDProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	p.routingPolicies = make(map[string]sdtypes.RoutingPolicy)
+	for _, ep := range endpoints {
+		if !ep.RecordTTL.IsConfigured() {
+			ep.RecordTTL = endpoint.TTL(p.ttlFromEndpoint(ep))
+		}
+
+		if _, ok := ep.GetProviderSpecificProperty(sdProviderSpecificRoutingPolicy); ok {
+			p.routingPolicies[ep.DNSName] = p.routingPolicyFromEndpoint(ep)
+			ep.DeleteProviderSpecificProperty(sdProviderSpecificRoutingPolicy)
+		}
+	}
+
+	return endpoints, nil
+}
+
 // ApplyChanges applies Kubernetes changes in endpoints to AWS API
 func (p *AWSSDProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	// return early if there is nothing to change
@@ -303,7 +338,7 @@ func (p *AWSSDProvider) submitCreates(ctx context.Context, namespaces []*sdtypes
 				}
 				// update a local list of services
 				services[*srv.Name] = srv
-			} else if ch.RecordTTL.IsConfigured() && *srv.DnsConfig.DnsRecords[0].TTL != int64(ch.RecordTTL) {
+			} else if *srv.DnsConfig.DnsRecords[0].TTL != p.ttlFromEndpoint(ch) {
 				// update service when TTL differ
 				err = p.UpdateService(ctx, srv, ch)
 				if err != nil {
@@ -420,10 +455,7 @@ func (p *AWSSDProvider) CreateService(ctx context.Context, namespaceID *string,
 	srvType := p.serviceTypeFromEndpoint(ep)
 	routingPolicy := p.routingPolicyFromEndpoint(ep)
 
-	ttl := int64(defaultTTL)
-	if ep.RecordTTL.IsConfigured() {
-		ttl = int64(ep.RecordTTL)
-	}
+	ttl := p.ttlFromEndpoint(ep)
 
 	if p.dryRun {
 		// return a mock service summary in case of a dry run
@@ -456,10 +488,7 @@ func (p *AWSSDProvider) UpdateService(ctx context.Context, service *sdtypes.Serv
 
 	srvType := p.serviceTypeFromEndpoint(ep)
 
-	ttl := int64(defaultTTL)
-	if ep.RecordTTL.IsConfigured() {
-		ttl = int64(ep.RecordTTL)
-	}
+	ttl := p.ttlFromEndpoint(ep)
 
 	if p.dryRun {
 		return nil
@@ -628,8 +657,34 @@ func (p *AWSSDProvider) parseHostname(hostname string) (string, string) {
 	return strings.Join(parts[1:], "."), parts[0]
 }
 
-// determine service routing policy based on endpoint type
+// determine service routing policy based on the endpoint annotation, the configured routing policy and the endpoint type
 func (p *AWSSDProvider) routingPolicyFromEndpoint(ep *endpoint.Endpoint) sdtypes.RoutingPolicy {
+	// CNAME and ALIAS records only support the weighted routing policy
+	if ep.RecordType != endpoint.RecordTypeA && ep.RecordType != endpoint.RecordTypeAAAA {
+		return sdtypes.RoutingPolicyWeighted
+	}
+
+	if value, ok := ep.GetProviderSpecificProperty(sdProviderSpecificRoutingPolicy); ok {
+		routingPolicy := sdtypes.RoutingPolicy(strings.ToUpper(value))
+		if slices.Contains(routingPolicy.Values(), routingPolicy) {
+			return routingPolicy
+		}
+		log.Warnf("Ignoring invalid routing policy %q of endpoint %s", value, ep.DNSName)
+	}
+
+	if routingPolicy, ok := p.routingPolicies[ep.DNSName]; ok {
+		return routingPolicy
+	}
+
+	if p.routingPolicy != "" {
+		return p.routingPolicy
+	}
+
+	return defaultRoutingPolicy(ep)
+}
+
+// determine the default service routing policy based on endpoint type
+func defaultRoutingPolicy(ep *endpoint.Endpoint) sdtypes.RoutingPolicy {
 	if ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA {
 		return sdtypes.RoutingPolicyMultivalue
 	}
@@ -637,6 +692,18 @@ func (p *AWSSDProvider) routingPolicyFromEndpoint(ep *endpoint.Endpoint) sdtypes
 	return sdtypes.RoutingPolicyWeighted
 }
 
+// determine the service TTL based on the endpoint TTL and the configured service TTL
+func (p *AWSSDProvider) ttlFromEndpoint(ep *endpoint.Endpoint) int64 {
+	if ep.RecordTTL.IsConfigured() {
+		return int64(ep.RecordTTL)
+	}
+	if p.serviceTTL > 0 {
+		return p.serviceTTL
+	}
+
+	return defaultTTL
+}
+
 // determine the service type (A, AAAA, CNAME) from a given endpoint
 func (p *AWSSDProvider) serviceTypeFromEndpoint(ep *endpoint.Endpoint) sdtypes.RecordType {
 	switch ep.RecordType {
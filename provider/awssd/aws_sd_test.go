@@ -1041,3 +1041,176 @@ func TestAWSSDProvider_awsTags(t *testing.T) {
 		require.ElementsMatch(t, test.Expectation, awsTags(test.Input))
 	}
 }
+
+func TestNewAWSSDProvider_InvalidRoutingPolicy(t *testing.T) {
+	_, err := NewAWSSDProvider(endpoint.NewDomainFilter([]string{}), "", false, false, "", nil, 300, "ROUND_ROBIN", &AWSSDClientStub{})
+	require.Error(t, err)
+
+	provider, err := NewAWSSDProvider(endpoint.NewDomainFilter([]string{}), "", false, false, "", nil, 300, "WEIGHTED", &AWSSDClientStub{})
+	require.NoError(t, err)
+	assert.Equal(t, sdtypes.RoutingPolicyWeighted, provider.routingPolicy)
+}
+
+func TestAWSSDProvider_CreateService_DNSConfig(t *testing.T) {
+	api := &AWSSDClientStub{
+		services: make(map[string]map[string]*sdtypes.Service),
+	}
+
+	provider := newTestAWSSDProvider(api, endpoint.NewDomainFilter([]string{}), "", "")
+	provider.serviceTTL = 30
+	provider.routingPolicy = sdtypes.RoutingPolicyWeighted
+
+	for _, tc := range []struct {
+		name          string
+		ep            *endpoint.Endpoint
+		routingPolicy sdtypes.RoutingPolicy
+		ttl           int64
+	}{
+		{
+			name:          "A-srv",
+			ep:            endpoint.NewEndpoint("", endpoint.RecordTypeA, "1.2.3.4"),
+			routingPolicy: sdtypes.RoutingPolicyWeighted,
+			ttl:           30,
+		},
+		{
+			name:          "A-annotated-srv",
+			ep:            endpoint.NewEndpointWithTTL("", endpoint.RecordTypeA, 60, "1.2.3.4").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "multivalue"),
+			routingPolicy: sdtypes.RoutingPolicyMultivalue,
+			ttl:           60,
+		},
+		{
+			name:          "A-invalid-srv",
+			ep:            endpoint.NewEndpoint("", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "invalid"),
+			routingPolicy: sdtypes.RoutingPolicyWeighted,
+			ttl:           30,
+		},
+		{
+			name:          "CNAME-srv",
+			ep:            endpoint.NewEndpoint("", endpoint.RecordTypeCNAME, "cname.target.com").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "MULTIVALUE"),
+			routingPolicy: sdtypes.RoutingPolicyWeighted,
+			ttl:           30,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, err := provider.CreateService(context.Background(), aws.String("private"), aws.String(tc.name), tc.ep)
+			require.NoError(t, err)
+			assert.Equal(t, tc.routingPolicy, srv.DnsConfig.RoutingPolicy)
+			assert.Equal(t, tc.ttl, *srv.DnsConfig.DnsRecords[0].TTL)
+		})
+	}
+}
+
+func TestAWSSDProvider_AdjustEndpoints(t *testing.T) {
+	provider := newTestAWSSDProvider(&AWSSDClientStub{}, endpoint.NewDomainFilter([]string{}), "", "")
+	provider.serviceTTL = 30
+
+	endpoints, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.private.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("weighted.private.com", endpoint.RecordTypeA, 60, "1.2.3.4").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "weighted"),
+		endpoint.NewEndpoint("multivalue.private.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "MULTIVALUE"),
+		endpoint.NewEndpoint("cname.private.com", endpoint.RecordTypeCNAME, "cname.target.com").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "MULTIVALUE"),
+	})
+	require.NoError(t, err)
+
+	for i, ttl := range []endpoint.TTL{30, 60, 30, 30} {
+		assert.Equal(t, ttl, endpoints[i].RecordTTL, endpoints[i].DNSName)
+		// the routing policy is not compared with the existing services
+		_, ok := endpoints[i].GetProviderSpecificProperty(sdProviderSpecificRoutingPolicy)
+		assert.False(t, ok, endpoints[i].DNSName)
+	}
+	assert.Equal(t, map[string]sdtypes.RoutingPolicy{
+		"weighted.private.com":   sdtypes.RoutingPolicyWeighted,
+		"multivalue.private.com": sdtypes.RoutingPolicyMultivalue,
+		"cname.private.com":      sdtypes.RoutingPolicyWeighted,
+	}, provider.routingPolicies)
+}
+
+func TestAWSSDProvider_ApplyChanges_RoutingPolicy(t *testing.T) {
+	namespaces := map[string]*sdtypes.Namespace{
+		"private": {
+			Id:   aws.String("private"),
+			Name: aws.String("private.com"),
+			Type: sdtypes.NamespaceTypeDnsPrivate,
+		},
+	}
+
+	api := &AWSSDClientStub{
+		namespaces: namespaces,
+		services:   make(map[string]map[string]*sdtypes.Service),
+		instances:  make(map[string]map[string]*sdtypes.Instance),
+	}
+
+	provider := newTestAWSSDProvider(api, endpoint.NewDomainFilter([]string{}), "", "")
+
+	ctx := context.Background()
+
+	// the routing policy is applied when the service is created
+	desired, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("service1.private.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "WEIGHTED"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Create: desired}))
+	assert.Equal(t, sdtypes.RoutingPolicyWeighted, api.services["private"]["service1"].DnsConfig.RoutingPolicy)
+
+	// a changed routing policy doesn't change the existing service
+	current, err := provider.Records(ctx)
+	require.NoError(t, err)
+
+	desired, err = provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("service1.private.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(sdProviderSpecificRoutingPolicy, "MULTIVALUE"),
+	})
+	require.NoError(t, err)
+
+	changes := (&plan.Plan{
+		Current:        current,
+		Desired:        desired,
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+}
+
+func TestAWSSDProvider_ApplyChanges_ServiceTTL(t *testing.T) {
+	namespaces := map[string]*sdtypes.Namespace{
+		"private": {
+			Id:   aws.String("private"),
+			Name: aws.String("private.com"),
+			Type: sdtypes.NamespaceTypeDnsPrivate,
+		},
+	}
+
+	api := &AWSSDClientStub{
+		namespaces: namespaces,
+		services:   make(map[string]map[string]*sdtypes.Service),
+		instances:  make(map[string]map[string]*sdtypes.Instance),
+	}
+
+	provider := newTestAWSSDProvider(api, endpoint.NewDomainFilter([]string{}), "", "")
+
+	ctx := context.Background()
+
+	desired, err := provider.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("service1.private.com", endpoint.RecordTypeA, "1.2.3.4")})
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Create: desired}))
+	assert.Equal(t, int64(defaultTTL), *api.services["private"]["service1"].DnsConfig.DnsRecords[0].TTL)
+
+	// the service TTL is reconciled when the configured one changes
+	current, err := provider.Records(ctx)
+	require.NoError(t, err)
+
+	provider.serviceTTL = 60
+	desired, err = provider.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("service1.private.com", endpoint.RecordTypeA, "1.2.3.4")})
+	require.NoError(t, err)
+
+	changes := (&plan.Plan{
+		Current:        current,
+		Desired:        desired,
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+	}).Calculate().Changes
+	require.Len(t, changes.UpdateNew, 1)
+
+	require.NoError(t, provider.ApplyChanges(ctx, changes))
+	assert.Equal(t, int64(60), *api.services["private"]["service1"].DnsConfig.DnsRecords[0].TTL)
+	assert.Empty(t, api.deregistered)
+}
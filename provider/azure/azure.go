@@ -20,6 +20,8 @@ package azure
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,6 +38,9 @@ import (
 
 const (
 	defaultTTL = 300
+	// metadataPrefix prefixes the provider-specific properties holding the metadata of the record sets,
+	// set with the external-dns.alpha.kubernetes.io/azure-metadata-<key> annotations.
+	metadataPrefix = "azure/metadata-"
 )
 
 // ZonesClient is an interface of dns.ZoneClient that can be stubbed for testing.
@@ -46,6 +51,8 @@ type ZonesClient interface {
 // RecordSetsClient is an interface of dns.RecordSetsClient that can be stubbed for testing.
 type RecordSetsClient interface {
 	NewListAllByDNSZonePager(resourceGroupName string, zoneName string, options *dns.RecordSetsClientListAllByDNSZoneOptions) *azcoreruntime.Pager[dns.RecordSetsClientListAllByDNSZoneResponse]
+	NewListByTypePager(resourceGroupName string, zoneName string, recordType dns.RecordType, options *dns.RecordSetsClientListByTypeOptions) *azcoreruntime.Pager[dns.RecordSetsClientListByTypeResponse]
+	Get(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType dns.RecordType, options *dns.RecordSetsClientGetOptions) (dns.RecordSetsClientGetResponse, error)
 	Delete(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType dns.RecordType, options *dns.RecordSetsClientDeleteOptions) (dns.RecordSetsClientDeleteResponse, error)
 	CreateOrUpdate(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType dns.RecordType, parameters dns.RecordSet, options *dns.RecordSetsClientCreateOrUpdateOptions) (dns.RecordSetsClientCreateOrUpdateResponse, error)
 }
@@ -64,6 +71,8 @@ type AzureProvider struct {
 	zonesCache                   *zonesCache[dns.Zone]
 	recordSetsClient             RecordSetsClient
 	maxRetriesCount              int
+	// etags holds the etags of the record sets last read, to apply the changes conditionally.
+	etags map[string]string
 }
 
 // NewAzureProvider creates a new Azure provider.
@@ -113,6 +122,7 @@ func (p *AzureProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, erro
 	}
 
 	endpoints := make([]*endpoint.Endpoint, 0)
+	etags := map[string]string{}
 
 	for _, zone := range zones {
 		pager := p.recordSetsClient.NewListAllByDNSZonePager(p.resourceGroup, *zone.Name, &dns.RecordSetsClientListAllByDNSZoneOptions{Top: nil})
@@ -122,42 +132,129 @@ func (p *AzureProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, erro
 				return nil, provider.NewSoftError(fmt.Errorf("failed to fetch dns records: %w", err))
 			}
 			for _, recordSet := range nextResult.Value {
-				if recordSet.Name == nil || recordSet.Type == nil {
-					log.Error("Skipping invalid record set with nil name or type.")
-					continue
+				if ep := p.recordSetEndpoint(*zone.Name, recordSet, etags); ep != nil {
+					endpoints = append(endpoints, ep)
 				}
-				recordType := strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/dnszones/")
-				if !p.SupportedRecordType(recordType) {
-					continue
-				}
-				name := formatAzureDNSName(*recordSet.Name, *zone.Name)
-				if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
-					log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
-					continue
-				}
-				targets := extractAzureTargets(recordSet)
-				if len(targets) == 0 {
-					log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
-					continue
+			}
+		}
+	}
+	p.etags = etags
+	return endpoints, nil
+}
+
+// RecordsExcludingType gets the current records of all the record types but the given one, listing
+// each of the other record types. The record sets looked up then by RecordsByName are added to their etags.
+func (p *AzureProvider) RecordsExcludingType(ctx context.Context, recordType string) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*endpoint.Endpoint, 0)
+	etags := map[string]string{}
+
+	for _, zone := range zones {
+		for _, t := range dns.PossibleRecordTypeValues() {
+			if string(t) == recordType || !p.SupportedRecordType(string(t)) {
+				continue
+			}
+			pager := p.recordSetsClient.NewListByTypePager(p.resourceGroup, *zone.Name, t, &dns.RecordSetsClientListByTypeOptions{Top: nil})
+			for pager.More() {
+				nextResult, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, provider.NewSoftError(fmt.Errorf("failed to fetch dns records: %w", err))
 				}
-				var ttl endpoint.TTL
-				if recordSet.Properties.TTL != nil {
-					ttl = endpoint.TTL(*recordSet.Properties.TTL)
+				for _, recordSet := range nextResult.Value {
+					if ep := p.recordSetEndpoint(*zone.Name, recordSet, etags); ep != nil {
+						endpoints = append(endpoints, ep)
+					}
 				}
-				ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
-				log.Debugf(
-					"Found %s record for '%s' with target '%s'.",
-					ep.RecordType,
-					ep.DNSName,
-					ep.Targets,
-				)
-				endpoints = append(endpoints, ep)
 			}
 		}
 	}
+	p.etags = etags
+	return endpoints, nil
+}
+
+// RecordsByName gets the current records of the given record type with one of the given names,
+// getting each record set by name.
+func (p *AzureProvider) RecordsByName(ctx context.Context, recordType string, names []string) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, z := range zones {
+		if z.Name != nil {
+			zoneNameIDMapper.Add(*z.Name, *z.Name)
+		}
+	}
+
+	endpoints := make([]*endpoint.Endpoint, 0)
+	if p.etags == nil {
+		p.etags = map[string]string{}
+	}
+
+	for _, name := range names {
+		zone, _ := zoneNameIDMapper.FindZone(name)
+		if zone == "" {
+			continue
+		}
+		resp, err := p.recordSetsClient.Get(ctx, p.resourceGroup, zone, p.recordSetNameForZone(zone, &endpoint.Endpoint{DNSName: name}), dns.RecordType(recordType), nil)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, provider.NewSoftError(fmt.Errorf("failed to fetch dns records: %w", err))
+		}
+		if ep := p.recordSetEndpoint(zone, &resp.RecordSet, p.etags); ep != nil {
+			endpoints = append(endpoints, ep)
+		}
+	}
 	return endpoints, nil
 }
 
+// recordSetEndpoint returns the endpoint of a record set of the given zone, adding its etag to the given ones,
+// or nil if the record set is not supported or filtered out.
+func (p *AzureProvider) recordSetEndpoint(zone string, recordSet *dns.RecordSet, etags map[string]string) *endpoint.Endpoint {
+	if recordSet.Name == nil || recordSet.Type == nil {
+		log.Error("Skipping invalid record set with nil name or type.")
+		return nil
+	}
+	recordType := strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/dnszones/")
+	if !p.SupportedRecordType(recordType) {
+		return nil
+	}
+	etags[recordSetKey(zone, *recordSet.Name, recordType)] = etagOrAny(recordSet.Etag)
+	name := formatAzureDNSName(*recordSet.Name, zone)
+	if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
+		log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
+		return nil
+	}
+	targets := extractAzureTargets(recordSet)
+	if len(targets) == 0 {
+		log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
+		return nil
+	}
+	var ttl endpoint.TTL
+	if recordSet.Properties.TTL != nil {
+		ttl = endpoint.TTL(*recordSet.Properties.TTL)
+	}
+	ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
+	for _, key := range slices.Sorted(maps.Keys(recordSet.Properties.Metadata)) {
+		if value := recordSet.Properties.Metadata[key]; value != nil {
+			ep.WithProviderSpecific(metadataPrefix+key, *value)
+		}
+	}
+	log.Debugf(
+		"Found %s record for '%s' with target '%s'.",
+		ep.RecordType,
+		ep.DNSName,
+		ep.Targets,
+	)
+	return ep
+}
+
 // ApplyChanges applies the given changes.
 //
 // Returns nil if the operation was successful or an error if the operation failed.
@@ -168,9 +265,9 @@ func (p *AzureProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	}
 
 	deleted, updated := p.mapChanges(zones, changes)
-	p.deleteRecords(ctx, deleted)
-	p.updateRecords(ctx, updated)
-	return nil
+	conflicts := p.deleteRecords(ctx, deleted)
+	conflicts = append(conflicts, p.updateRecords(ctx, updated)...)
+	return conflictsError(conflicts)
 }
 
 func (p *AzureProvider) zones(ctx context.Context) ([]dns.Zone, error) {
@@ -248,7 +345,8 @@ func (p *AzureProvider) mapChanges(zones []dns.Zone, changes *plan.Changes) (azu
 	return deleted, updated
 }
 
-func (p *AzureProvider) deleteRecords(ctx context.Context, deleted azureChangeMap) {
+func (p *AzureProvider) deleteRecords(ctx context.Context, deleted azureChangeMap) []error {
+	var conflicts []error
 	// Delete records first
 	for zone, endpoints := range deleted {
 		for _, ep := range endpoints {
@@ -261,7 +359,15 @@ func (p *AzureProvider) deleteRecords(ctx context.Context, deleted azureChangeMa
 				log.Infof("Would delete %s record named '%s' for Azure DNS zone '%s'.", ep.RecordType, name, zone)
 			} else {
 				log.Infof("Deleting %s record named '%s' for Azure DNS zone '%s'.", ep.RecordType, name, zone)
-				if _, err := p.recordSetsClient.Delete(ctx, p.resourceGroup, zone, name, dns.RecordType(ep.RecordType), nil); err != nil {
+				var options *dns.RecordSetsClientDeleteOptions
+				if etag := p.etags[recordSetKey(zone, name, ep.RecordType)]; etag != "" {
+					options = &dns.RecordSetsClientDeleteOptions{IfMatch: to.Ptr(etag)}
+				}
+				_, err := p.recordSetsClient.Delete(ctx, p.resourceGroup, zone, name, dns.RecordType(ep.RecordType), options)
+				if isPreconditionFailed(err) {
+					conflicts = append(conflicts, fmt.Errorf("%s record named '%s' for Azure DNS zone '%s': %w", ep.RecordType, name, zone, err))
+				}
+				if err != nil {
 					log.Errorf(
 						"Failed to delete %s record named '%s' for Azure DNS zone '%s': %v",
 						ep.RecordType,
@@ -273,9 +379,11 @@ func (p *AzureProvider) deleteRecords(ctx context.Context, deleted azureChangeMa
 			}
 		}
 	}
+	return conflicts
 }
 
-func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMap) {
+func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMap) []error {
+	var conflicts []error
 	for zone, endpoints := range updated {
 		for _, ep := range endpoints {
 			name := p.recordSetNameForZone(zone, ep)
@@ -304,15 +412,15 @@ func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMa
 
 			recordSet, err := p.newRecordSet(ep)
 			if err == nil {
-				_, err = p.recordSetsClient.CreateOrUpdate(
-					ctx,
-					p.resourceGroup,
-					zone,
-					name,
-					dns.RecordType(ep.RecordType),
-					recordSet,
-					nil,
-				)
+				// Without a last-seen etag, the record set is created only if it doesn't exist yet.
+				options := &dns.RecordSetsClientCreateOrUpdateOptions{IfNoneMatch: to.Ptr("*")}
+				if etag := p.etags[recordSetKey(zone, name, ep.RecordType)]; etag != "" {
+					options = &dns.RecordSetsClientCreateOrUpdateOptions{IfMatch: to.Ptr(etag)}
+				}
+				_, err = p.recordSetsClient.CreateOrUpdate(ctx, p.resourceGroup, zone, name, dns.RecordType(ep.RecordType), recordSet, options)
+				if isPreconditionFailed(err) {
+					conflicts = append(conflicts, fmt.Errorf("%s record named '%s' for Azure DNS zone '%s': %w", ep.RecordType, name, zone, err))
+				}
 			}
 			if err != nil {
 				log.Errorf(
@@ -326,6 +434,7 @@ func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMa
 			}
 		}
 	}
+	return conflicts
 }
 
 func (p *AzureProvider) recordSetNameForZone(zone string, endpoint *endpoint.Endpoint) string {
@@ -346,6 +455,7 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 	if endpoint.RecordTTL.IsConfigured() {
 		ttl = int64(endpoint.RecordTTL)
 	}
+	metadata := recordSetMetadata(endpoint)
 	switch dns.RecordType(endpoint.RecordType) {
 	case dns.RecordTypeA:
 		aRecords := make([]*dns.ARecord, len(endpoint.Targets))
@@ -357,6 +467,7 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
 				TTL:      to.Ptr(ttl),
+				Metadata: metadata,
 				ARecords: aRecords,
 			},
 		}, nil
@@ -370,13 +481,15 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
 				TTL:         to.Ptr(ttl),
+				Metadata:    metadata,
 				AaaaRecords: aaaaRecords,
 			},
 		}, nil
 	case dns.RecordTypeCNAME:
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
-				TTL: to.Ptr(ttl),
+				TTL:      to.Ptr(ttl),
+				Metadata: metadata,
 				CnameRecord: &dns.CnameRecord{
 					Cname: to.Ptr(endpoint.Targets[0]),
 				},
@@ -394,6 +507,7 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
 				TTL:       to.Ptr(ttl),
+				Metadata:  metadata,
 				MxRecords: mxRecords,
 			},
 		}, nil
@@ -407,13 +521,15 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
 				TTL:       to.Ptr(ttl),
+				Metadata:  metadata,
 				NsRecords: nsRecords,
 			},
 		}, nil
 	case dns.RecordTypeTXT:
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
-				TTL: to.Ptr(ttl),
+				TTL:      to.Ptr(ttl),
+				Metadata: metadata,
 				TxtRecords: []*dns.TxtRecord{
 					{
 						Value: []*string{
@@ -427,6 +543,22 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 	return dns.RecordSet{}, fmt.Errorf("unsupported record type '%s'", endpoint.RecordType)
 }
 
+// recordSetMetadata returns the metadata of the record set of the endpoint, from its provider-specific properties.
+func recordSetMetadata(ep *endpoint.Endpoint) map[string]*string {
+	var metadata map[string]*string
+	for _, property := range ep.ProviderSpecific {
+		key, ok := strings.CutPrefix(property.Name, metadataPrefix)
+		if !ok || key == "" {
+			continue
+		}
+		if metadata == nil {
+			metadata = map[string]*string{}
+		}
+		metadata[key] = to.Ptr(property.Value)
+	}
+	return metadata
+}
+
 // Helper function (shared with test code)
 func formatAzureDNSName(recordName, zoneName string) string {
 	if recordName == "@" {
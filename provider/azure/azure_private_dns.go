@@ -59,6 +59,8 @@ type AzurePrivateDNSProvider struct {
 	zonesCache                   *zonesCache[privatedns.PrivateZone]
 	recordSetsClient             PrivateRecordSetsClient
 	maxRetriesCount              int
+	// etags holds the etags of the record sets last read, to apply the changes conditionally.
+	etags map[string]string
 }
 
 // NewAzurePrivateDNSProvider creates a new Azure Private DNS provider.
@@ -110,6 +112,7 @@ func (p *AzurePrivateDNSProvider) Records(ctx context.Context) ([]*endpoint.Endp
 	log.Debugf("Retrieving Azure Private DNS Records for resource group '%s'", p.resourceGroup)
 
 	endpoints := make([]*endpoint.Endpoint, 0)
+	etags := map[string]string{}
 	for _, zone := range zones {
 		pager := p.recordSetsClient.NewListPager(p.resourceGroup, *zone.Name, &privatedns.RecordSetsClientListOptions{Top: nil})
 		for pager.More() {
@@ -131,6 +134,7 @@ func (p *AzurePrivateDNSProvider) Records(ctx context.Context) ([]*endpoint.Endp
 					log.Debugf("Skipping invalid record set with missing name.")
 					continue
 				}
+				etags[recordSetKey(*zone.Name, *recordSet.Name, recordType)] = etagOrAny(recordSet.Etag)
 				name = formatAzureDNSName(*recordSet.Name, *zone.Name)
 
 				if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
@@ -162,6 +166,8 @@ func (p *AzurePrivateDNSProvider) Records(ctx context.Context) ([]*endpoint.Endp
 
 	log.Debugf("Returning %d Azure Private DNS Records for resource group '%s'", len(endpoints), p.resourceGroup)
 
+	p.etags = etags
+
 	return endpoints, nil
 }
 
@@ -177,9 +183,9 @@ func (p *AzurePrivateDNSProvider) ApplyChanges(ctx context.Context, changes *pla
 	}
 
 	deleted, updated := p.mapChanges(zones, changes)
-	p.deleteRecords(ctx, deleted)
-	p.updateRecords(ctx, updated)
-	return nil
+	conflicts := p.deleteRecords(ctx, deleted)
+	conflicts = append(conflicts, p.updateRecords(ctx, updated)...)
+	return conflictsError(conflicts)
 }
 
 func (p *AzurePrivateDNSProvider) zones(ctx context.Context) ([]privatedns.PrivateZone, error) {
@@ -252,7 +258,8 @@ func (p *AzurePrivateDNSProvider) mapChanges(zones []privatedns.PrivateZone, cha
 	return deleted, updated
 }
 
-func (p *AzurePrivateDNSProvider) deleteRecords(ctx context.Context, deleted azurePrivateDNSChangeMap) {
+func (p *AzurePrivateDNSProvider) deleteRecords(ctx context.Context, deleted azurePrivateDNSChangeMap) []error {
+	var conflicts []error
 	log.Debugf("Records to be deleted: %d", len(deleted))
 	// Delete records first
 	for zone, endpoints := range deleted {
@@ -266,7 +273,15 @@ func (p *AzurePrivateDNSProvider) deleteRecords(ctx context.Context, deleted azu
 				log.Infof("Would delete %s record named '%s' for Azure Private DNS zone '%s'.", ep.RecordType, name, zone)
 			} else {
 				log.Infof("Deleting %s record named '%s' for Azure Private DNS zone '%s'.", ep.RecordType, name, zone)
-				if _, err := p.recordSetsClient.Delete(ctx, p.resourceGroup, zone, privatedns.RecordType(ep.RecordType), name, nil); err != nil {
+				var options *privatedns.RecordSetsClientDeleteOptions
+				if etag := p.etags[recordSetKey(zone, name, ep.RecordType)]; etag != "" {
+					options = &privatedns.RecordSetsClientDeleteOptions{IfMatch: to.Ptr(etag)}
+				}
+				_, err := p.recordSetsClient.Delete(ctx, p.resourceGroup, zone, privatedns.RecordType(ep.RecordType), name, options)
+				if isPreconditionFailed(err) {
+					conflicts = append(conflicts, fmt.Errorf("%s record named '%s' for Azure Private DNS zone '%s': %w", ep.RecordType, name, zone, err))
+				}
+				if err != nil {
 					log.Errorf(
 						"Failed to delete %s record named '%s' for Azure Private DNS zone '%s': %v",
 						ep.RecordType,
@@ -278,9 +293,11 @@ func (p *AzurePrivateDNSProvider) deleteRecords(ctx context.Context, deleted azu
 			}
 		}
 	}
+	return conflicts
 }
 
-func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azurePrivateDNSChangeMap) {
+func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azurePrivateDNSChangeMap) []error {
+	var conflicts []error
 	log.Debugf("Records to be updated: %d", len(updated))
 	for zone, endpoints := range updated {
 		for _, ep := range endpoints {
@@ -310,15 +327,15 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 
 			recordSet, err := p.newRecordSet(ep)
 			if err == nil {
-				_, err = p.recordSetsClient.CreateOrUpdate(
-					ctx,
-					p.resourceGroup,
-					zone,
-					privatedns.RecordType(ep.RecordType),
-					name,
-					recordSet,
-					nil,
-				)
+				// Without a last-seen etag, the record set is created only if it doesn't exist yet.
+				options := &privatedns.RecordSetsClientCreateOrUpdateOptions{IfNoneMatch: to.Ptr("*")}
+				if etag := p.etags[recordSetKey(zone, name, ep.RecordType)]; etag != "" {
+					options = &privatedns.RecordSetsClientCreateOrUpdateOptions{IfMatch: to.Ptr(etag)}
+				}
+				_, err = p.recordSetsClient.CreateOrUpdate(ctx, p.resourceGroup, zone, privatedns.RecordType(ep.RecordType), name, recordSet, options)
+				if isPreconditionFailed(err) {
+					conflicts = append(conflicts, fmt.Errorf("%s record named '%s' for Azure Private DNS zone '%s': %w", ep.RecordType, name, zone, err))
+				}
 			}
 			if err != nil {
 				log.Errorf(
@@ -332,6 +349,7 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 			}
 		}
 	}
+	return conflicts
 }
 
 func (p *AzurePrivateDNSProvider) recordSetNameForZone(zone string, endpoint *endpoint.Endpoint) string {
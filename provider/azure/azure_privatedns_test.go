@@ -18,8 +18,12 @@ package azure
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"reflect"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
@@ -556,3 +560,51 @@ func testAzurePrivateDNSApplyChangesInternalZoneName(t *testing.T, dryRun bool,
 		t.Fatal(err)
 	}
 }
+
+// conflictingPrivateRecordSetsClient is a mockPrivateRecordSetsClient which rejects the updates conditioned on
+// an outdated etag, as Azure Private DNS does for the record sets modified concurrently.
+type conflictingPrivateRecordSetsClient struct {
+	mockPrivateRecordSetsClient
+	etag    string
+	ifMatch []string
+}
+
+func (client *conflictingPrivateRecordSetsClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, privateZoneName string, recordType privatedns.RecordType, relativeRecordSetName string, parameters privatedns.RecordSet, options *privatedns.RecordSetsClientCreateOrUpdateOptions) (privatedns.RecordSetsClientCreateOrUpdateResponse, error) {
+	client.ifMatch = append(client.ifMatch, *options.IfMatch)
+	if *options.IfMatch != client.etag {
+		return privatedns.RecordSetsClientCreateOrUpdateResponse{}, &azcore.ResponseError{StatusCode: http.StatusPreconditionFailed}
+	}
+	return client.mockPrivateRecordSetsClient.CreateOrUpdate(ctx, resourceGroupName, privateZoneName, recordType, relativeRecordSetName, parameters, options)
+}
+
+func TestAzurePrivateDNSApplyChangesConcurrentModification(t *testing.T) {
+	zonesClient := newMockPrivateZonesClient([]*privatedns.PrivateZone{createMockPrivateZone("example.com", "/privateDnsZones/example.com")})
+	recordSet := createPrivateMockRecordSet("updated", endpoint.RecordTypeA, "1.2.3.4")
+	recordSet.Etag = to.Ptr("1")
+	client := &conflictingPrivateRecordSetsClient{
+		mockPrivateRecordSetsClient: newMockPrivateRecordSectsClient([]*privatedns.RecordSet{recordSet}),
+		etag:                        "1",
+	}
+	p := newAzurePrivateDNSProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), false, "group", &zonesClient, client, 3)
+
+	if _, err := p.Records(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another instance modifies the record set after it was read.
+	client.etag = "2"
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "1.2.3.5")},
+	})
+	if !errors.Is(err, provider.SoftError) {
+		t.Fatalf("expected a soft error, got %v", err)
+	}
+
+	// The record set modified concurrently is left as is, to be planned again from its current version.
+	if !reflect.DeepEqual(client.ifMatch, []string{"1"}) {
+		t.Errorf("expected the update to be conditioned on the etag [1] only, got %v", client.ifMatch)
+	}
+	validateAzureEndpoints(t, client.updatedEndpoints, []*endpoint.Endpoint{})
+}
@@ -18,17 +18,25 @@ package azure
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
 )
 
 // mockZonesClient implements the methods of the Azure DNS Zones Client which are used in the Azure Provider
@@ -63,8 +71,13 @@ func (client *mockZonesClient) NewListByResourceGroupPager(resourceGroupName str
 // and returns static results which are defined per test
 type mockRecordSetsClient struct {
 	pagingHandler    azcoreruntime.PagingHandler[dns.RecordSetsClientListAllByDNSZoneResponse]
+	recordSets       []*dns.RecordSet
 	deletedEndpoints []*endpoint.Endpoint
 	updatedEndpoints []*endpoint.Endpoint
+	// the calls listing all the record sets, listing them by type and getting them by name
+	listings     int
+	typeListings []dns.RecordType
+	gets         []string
 }
 
 func newMockRecordSetsClient(recordSets []*dns.RecordSet) mockRecordSetsClient {
@@ -82,13 +95,47 @@ func newMockRecordSetsClient(recordSets []*dns.RecordSet) mockRecordSetsClient {
 	}
 	return mockRecordSetsClient{
 		pagingHandler: pagingHandler,
+		recordSets:    recordSets,
 	}
 }
 
 func (client *mockRecordSetsClient) NewListAllByDNSZonePager(resourceGroupName string, zoneName string, options *dns.RecordSetsClientListAllByDNSZoneOptions) *azcoreruntime.Pager[dns.RecordSetsClientListAllByDNSZoneResponse] {
+	client.listings++
 	return azcoreruntime.NewPager(client.pagingHandler)
 }
 
+func (client *mockRecordSetsClient) NewListByTypePager(resourceGroupName string, zoneName string, recordType dns.RecordType, options *dns.RecordSetsClientListByTypeOptions) *azcoreruntime.Pager[dns.RecordSetsClientListByTypeResponse] {
+	client.typeListings = append(client.typeListings, recordType)
+	var recordSets []*dns.RecordSet
+	for _, recordSet := range client.recordSets {
+		if *recordSet.Type == "Microsoft.Network/dnszones/"+string(recordType) {
+			recordSets = append(recordSets, recordSet)
+		}
+	}
+	return azcoreruntime.NewPager(azcoreruntime.PagingHandler[dns.RecordSetsClientListByTypeResponse]{
+		More: func(resp dns.RecordSetsClientListByTypeResponse) bool {
+			return false
+		},
+		Fetcher: func(context.Context, *dns.RecordSetsClientListByTypeResponse) (dns.RecordSetsClientListByTypeResponse, error) {
+			return dns.RecordSetsClientListByTypeResponse{
+				RecordSetListResult: dns.RecordSetListResult{
+					Value: recordSets,
+				},
+			}, nil
+		},
+	})
+}
+
+func (client *mockRecordSetsClient) Get(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType dns.RecordType, options *dns.RecordSetsClientGetOptions) (dns.RecordSetsClientGetResponse, error) {
+	client.gets = append(client.gets, relativeRecordSetName+"/"+string(recordType))
+	for _, recordSet := range client.recordSets {
+		if *recordSet.Name == relativeRecordSetName && *recordSet.Type == "Microsoft.Network/dnszones/"+string(recordType) {
+			return dns.RecordSetsClientGetResponse{RecordSet: *recordSet}, nil
+		}
+	}
+	return dns.RecordSetsClientGetResponse{}, &azcore.ResponseError{StatusCode: http.StatusNotFound}
+}
+
 func (client *mockRecordSetsClient) Delete(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType dns.RecordType, options *dns.RecordSetsClientDeleteOptions) (dns.RecordSetsClientDeleteResponse, error) {
 	client.deletedEndpoints = append(
 		client.deletedEndpoints,
@@ -106,15 +153,16 @@ func (client *mockRecordSetsClient) CreateOrUpdate(ctx context.Context, resource
 	if parameters.Properties.TTL != nil {
 		ttl = endpoint.TTL(*parameters.Properties.TTL)
 	}
-	client.updatedEndpoints = append(
-		client.updatedEndpoints,
-		endpoint.NewEndpointWithTTL(
-			formatAzureDNSName(relativeRecordSetName, zoneName),
-			string(recordType),
-			ttl,
-			extractAzureTargets(&parameters)...,
-		),
+	ep := endpoint.NewEndpointWithTTL(
+		formatAzureDNSName(relativeRecordSetName, zoneName),
+		string(recordType),
+		ttl,
+		extractAzureTargets(&parameters)...,
 	)
+	for _, key := range slices.Sorted(maps.Keys(parameters.Properties.Metadata)) {
+		ep.WithProviderSpecific(metadataPrefix+key, *parameters.Properties.Metadata[key])
+	}
+	client.updatedEndpoints = append(client.updatedEndpoints, ep)
 	return dns.RecordSetsClientCreateOrUpdateResponse{}, nil
 }
 
@@ -308,6 +356,58 @@ func TestAzureRecord(t *testing.T) {
 	validateAzureEndpoints(t, actual, expected)
 }
 
+func TestAzureRecordMetadata(t *testing.T) {
+	recordSet := createMockRecordSet("tagged", endpoint.RecordTypeA, "123.123.123.123")
+	recordSet.Properties.Metadata = map[string]*string{"team": to.Ptr("dns"), "cost-center": to.Ptr("1234")}
+	provider, err := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), true, "k8s", "", "",
+		[]*dns.Zone{
+			createMockZone("example.com", "/dnszones/example.com"),
+		},
+		[]*dns.RecordSet{
+			recordSet,
+			createMockRecordSet("untagged", endpoint.RecordTypeA, "123.123.123.124"),
+		}, 3)
+	require.NoError(t, err)
+
+	actual, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	validateAzureEndpoints(t, actual, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("tagged.example.com", endpoint.RecordTypeA, "123.123.123.123").
+			WithProviderSpecific("azure/metadata-cost-center", "1234").
+			WithProviderSpecific("azure/metadata-team", "dns"),
+		endpoint.NewEndpoint("untagged.example.com", endpoint.RecordTypeA, "123.123.123.124"),
+	})
+}
+
+func TestAzureApplyChangesMetadata(t *testing.T) {
+	recordsClient := newMockRecordSetsClient(nil)
+	zonesClient := newMockZonesClient([]*dns.Zone{createMockZone("example.com", "/dnszones/example.com")})
+	provider := newAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", &zonesClient, &recordsClient, 3)
+
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("created.example.com", endpoint.RecordTypeCNAME, "other.com").
+				WithProviderSpecific("azure/metadata-team", "dns").
+				WithProviderSpecific("aws/weight", "10"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "1.2.3.4").
+				WithProviderSpecific("azure/metadata-team", "dns"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "1.2.3.4").
+				WithProviderSpecific("azure/metadata-team", "network"),
+		},
+	}))
+
+	validateAzureEndpoints(t, recordsClient.updatedEndpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("created.example.com", endpoint.RecordTypeCNAME, defaultTTL, "other.com").
+			WithProviderSpecific("azure/metadata-team", "dns"),
+		endpoint.NewEndpointWithTTL("updated.example.com", endpoint.RecordTypeA, defaultTTL, "1.2.3.4").
+			WithProviderSpecific("azure/metadata-team", "network"),
+	})
+}
+
 func TestAzureMultiRecord(t *testing.T) {
 	provider, err := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), true, "k8s", "", "",
 		[]*dns.Zone{
@@ -612,3 +712,131 @@ func testAzureApplyChangesInternalZoneName(t *testing.T, dryRun bool, client Rec
 		t.Fatal(err)
 	}
 }
+
+// conflictingRecordSetsClient is a mockRecordSetsClient which rejects the changes conditioned on
+// an outdated etag, as Azure DNS does for the record sets modified concurrently.
+type conflictingRecordSetsClient struct {
+	mockRecordSetsClient
+	// etags holds the current etags of the record sets by name and type.
+	etags      map[string]string
+	conditions []string
+}
+
+func (client *conflictingRecordSetsClient) checkCondition(method, name string, recordType dns.RecordType, ifMatch, ifNoneMatch *string) error {
+	condition := method + " " + name
+	if ifMatch != nil {
+		condition += " If-Match=" + *ifMatch
+	}
+	if ifNoneMatch != nil {
+		condition += " If-None-Match=" + *ifNoneMatch
+	}
+	client.conditions = append(client.conditions, condition)
+
+	etag, exists := client.etags[name+"/"+string(recordType)]
+	if ifMatch != nil && (!exists || *ifMatch != "*" && *ifMatch != etag) || ifNoneMatch != nil && exists {
+		return &azcore.ResponseError{StatusCode: http.StatusPreconditionFailed}
+	}
+	return nil
+}
+
+func (client *conflictingRecordSetsClient) Delete(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType dns.RecordType, options *dns.RecordSetsClientDeleteOptions) (dns.RecordSetsClientDeleteResponse, error) {
+	var ifMatch *string
+	if options != nil {
+		ifMatch = options.IfMatch
+	}
+	if err := client.checkCondition("Delete", relativeRecordSetName, recordType, ifMatch, nil); err != nil {
+		return dns.RecordSetsClientDeleteResponse{}, err
+	}
+	delete(client.etags, relativeRecordSetName+"/"+string(recordType))
+	return client.mockRecordSetsClient.Delete(ctx, resourceGroupName, zoneName, relativeRecordSetName, recordType, options)
+}
+
+func (client *conflictingRecordSetsClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType dns.RecordType, parameters dns.RecordSet, options *dns.RecordSetsClientCreateOrUpdateOptions) (dns.RecordSetsClientCreateOrUpdateResponse, error) {
+	if err := client.checkCondition("CreateOrUpdate", relativeRecordSetName, recordType, options.IfMatch, options.IfNoneMatch); err != nil {
+		return dns.RecordSetsClientCreateOrUpdateResponse{}, err
+	}
+	client.etags[relativeRecordSetName+"/"+string(recordType)] += "+"
+	return client.mockRecordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zoneName, relativeRecordSetName, recordType, parameters, options)
+}
+
+func TestAzureApplyChangesConcurrentModification(t *testing.T) {
+	zonesClient := newMockZonesClient([]*dns.Zone{createMockZone("example.com", "/dnszones/example.com")})
+	recordSets := []*dns.RecordSet{
+		createMockRecordSet("updated", endpoint.RecordTypeA, "1.2.3.4"),
+		createMockRecordSet("deleted", endpoint.RecordTypeA, "1.2.3.5"),
+		createMockRecordSet("unmodified", endpoint.RecordTypeA, "1.2.3.8"),
+	}
+	for _, recordSet := range recordSets {
+		recordSet.Etag = to.Ptr("1")
+	}
+	client := &conflictingRecordSetsClient{
+		mockRecordSetsClient: newMockRecordSetsClient(recordSets),
+		etags:                map[string]string{"updated/A": "1", "deleted/A": "1", "unmodified/A": "1"},
+	}
+	p := newAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", &zonesClient, client, 3)
+
+	_, err := p.Records(context.Background())
+	assert.NoError(t, err)
+
+	// Another instance modifies the record sets after they were read.
+	client.etags["updated/A"] = "2"
+	client.etags["deleted/A"] = "2"
+	client.etags["created/A"] = "1"
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("created.example.com", endpoint.RecordTypeA, "1.2.3.6")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "1.2.3.4"), endpoint.NewEndpoint("unmodified.example.com", endpoint.RecordTypeA, "1.2.3.8")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "1.2.3.7"), endpoint.NewEndpoint("unmodified.example.com", endpoint.RecordTypeA, "1.2.3.9")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("deleted.example.com", endpoint.RecordTypeA, "1.2.3.5")},
+	})
+	assert.ErrorIs(t, err, provider.SoftError)
+
+	// Each change is attempted once, and only the record set which was not modified concurrently is changed.
+	assert.Equal(t, []string{
+		"Delete deleted If-Match=1",
+		"CreateOrUpdate created If-None-Match=*",
+		"CreateOrUpdate updated If-Match=1",
+		"CreateOrUpdate unmodified If-Match=1",
+	}, client.conditions)
+	assert.Empty(t, client.deletedEndpoints)
+	validateAzureEndpoints(t, client.updatedEndpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("unmodified.example.com", endpoint.RecordTypeA, recordTTL, "1.2.3.9"),
+	})
+}
+
+func TestAzureTXTRegistryRecordsByName(t *testing.T) {
+	zonesClient := newMockZonesClient([]*dns.Zone{createMockZone("example.com", "/dnszones/example.com")})
+	recordSetsClient := newMockRecordSetsClient([]*dns.RecordSet{
+		createMockRecordSet("foo", endpoint.RecordTypeA, "1.2.3.4"),
+		createMockRecordSet("a-foo", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=owner"),
+		createMockRecordSet("bar", endpoint.RecordTypeCNAME, "other.com"),
+		createMockRecordSet("cname-bar", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=other"),
+		createMockRecordSet("a-orphan", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=owner"),
+	})
+	azureProvider := newAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", &zonesClient, &recordSetsClient, 3)
+	reloadable := provider.NewReloadableProvider(provider.NewCachedProvider(azureProvider, time.Hour), func() (provider.Provider, error) {
+		return nil, errors.New("not reloaded")
+	})
+
+	r, err := registry.NewTXTRegistry(reloadable, "", "", "owner", 0, "", []string{}, []string{}, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	for range 2 {
+		records, err := r.Records(context.Background())
+		require.NoError(t, err)
+		assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner"),
+			endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeCNAME, "other.com").WithLabel(endpoint.OwnerLabelKey, "other"),
+		}), "unexpected records %v", records)
+	}
+
+	// the record sets of the other types are listed by type, and the TXT records are only read by name, once
+	assert.Zero(t, recordSetsClient.listings)
+	assert.NotContains(t, recordSetsClient.typeListings, dns.RecordTypeTXT)
+	assert.Contains(t, recordSetsClient.typeListings, dns.RecordTypeA)
+	assert.Contains(t, recordSetsClient.typeListings, dns.RecordTypeCNAME)
+	assert.ElementsMatch(t, []string{"a-foo/TXT", "foo/TXT", "cname-bar/TXT", "bar/TXT"}, recordSetsClient.gets)
+
+	// the etags of the record sets read by name are kept, to change them conditionally
+	assert.Equal(t, "*", azureProvider.etags[recordSetKey("example.com", "a-foo", endpoint.RecordTypeTXT)])
+}
@@ -18,15 +18,55 @@ limitations under the License.
 package azure
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+
+	"sigs.k8s.io/external-dns/provider"
 )
 
+// recordSetKey returns the key of a record set in the etags of a provider.
+func recordSetKey(zone, name, recordType string) string {
+	return zone + "/" + name + "/" + recordType
+}
+
+// conflictsError returns a soft error for the changes rejected because their record sets were
+// modified concurrently, so that the next reconciliation plans them again from the current
+// record sets, or nil if no change was rejected.
+func conflictsError(conflicts []error) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return provider.NewSoftError(fmt.Errorf("failed to apply changes to concurrently modified record sets: %w", errors.Join(conflicts...)))
+}
+
+// isPreconditionFailed returns true if the error is the rejection of a conditional request.
+func isPreconditionFailed(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// isNotFound returns true if the error is caused by a missing resource.
+func isNotFound(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// etagOrAny returns the etag, or the wildcard matching any existing resource when the etag is unknown.
+func etagOrAny(etag *string) string {
+	if etag == nil || *etag == "" {
+		return "*"
+	}
+	return *etag
+}
+
 // Helper function (shared with test code)
 func parseMxTarget[T dns.MxRecord | privatedns.MxRecord](mxTarget string) (T, error) {
 	targetParts := strings.SplitN(mxTarget, " ", 2)
@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -58,6 +59,10 @@ type CachedProvider struct {
 	RefreshDelay time.Duration
 	lastRead     time.Time
 	cache        []*endpoint.Endpoint
+	// lookedUp holds the names of the records of the excluded type looked up since the last refresh,
+	// when the cache only holds these records and the ones of the other types, and is nil otherwise.
+	lookedUp     map[string]struct{}
+	excludedType string
 }
 
 func NewCachedProvider(provider Provider, refreshDelay time.Duration) *CachedProvider {
@@ -68,7 +73,7 @@ func NewCachedProvider(provider Provider, refreshDelay time.Duration) *CachedPro
 }
 
 func (c *CachedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	if c.needRefresh() {
+	if c.needRefresh() || c.lookedUp != nil {
 		log.Info("Records cache provider: refreshing records list cache")
 		records, err := c.Provider.Records(ctx)
 		if err != nil {
@@ -77,6 +82,7 @@ func (c *CachedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 		}
 		c.cache = records
 		c.lastRead = time.Now()
+		c.lookedUp = nil
 		cachedRecordsCallsTotal.CounterVec.WithLabelValues("false").Inc()
 	} else {
 		log.Debug("Records cache provider: using records list from cache")
@@ -84,6 +90,63 @@ func (c *CachedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 	}
 	return c.cache, nil
 }
+
+// RecordsExcludingType returns the cached records but the ones of the given type. When the cache is refreshed,
+// only the records of the other types are read, the ones of the given type being then looked up by name.
+func (c *CachedProvider) RecordsExcludingType(ctx context.Context, recordType string) ([]*endpoint.Endpoint, error) {
+	if _, ok := AsRecordsByNameProvider(c.Provider); !ok {
+		return recordsExcludingType(ctx, c, recordType)
+	}
+	if c.needRefresh() || c.lookedUp != nil && c.excludedType != recordType {
+		log.Infof("Records cache provider: refreshing records list cache, except the %s records", recordType)
+		records, err := recordsExcludingType(ctx, c.Provider, recordType)
+		if err != nil {
+			c.cache = nil
+			return nil, err
+		}
+		c.cache = records
+		c.lastRead = time.Now()
+		c.lookedUp = map[string]struct{}{}
+		c.excludedType = recordType
+		cachedRecordsCallsTotal.CounterVec.WithLabelValues("false").Inc()
+	} else {
+		log.Debug("Records cache provider: using records list from cache")
+		cachedRecordsCallsTotal.CounterVec.WithLabelValues("true").Inc()
+	}
+	return filterRecords(c.cache, func(r *endpoint.Endpoint) bool { return r.RecordType != recordType }), nil
+}
+
+// RecordsByName returns the cached records of the given type with one of the given names.
+// The names of the excluded type which were not looked up since the last refresh are looked up and cached.
+func (c *CachedProvider) RecordsByName(ctx context.Context, recordType string, names []string) ([]*endpoint.Endpoint, error) {
+	if _, ok := AsRecordsByNameProvider(c.Provider); !ok {
+		return recordsByName(ctx, c, recordType, names)
+	}
+	if c.needRefresh() {
+		return recordsByName(ctx, c.Provider, recordType, names)
+	}
+	if c.lookedUp != nil && recordType == c.excludedType {
+		missing := slices.DeleteFunc(slices.Clone(names), func(name string) bool {
+			_, ok := c.lookedUp[name]
+			return ok
+		})
+		if len(missing) > 0 {
+			records, err := recordsByName(ctx, c.Provider, recordType, missing)
+			if err != nil {
+				return nil, err
+			}
+			c.cache = append(c.cache, records...)
+			for _, name := range missing {
+				c.lookedUp[name] = struct{}{}
+			}
+		}
+	}
+	return filterRecords(c.cache, hasTypeAndName(recordType, names)), nil
+}
+
+func (c *CachedProvider) wrapped() Provider {
+	return c.Provider
+}
 func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	if !changes.HasChanges() {
 		log.Info("Records cache provider: no changes to be applied")
@@ -97,6 +160,7 @@ func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 func (c *CachedProvider) Reset() {
 	c.cache = nil
 	c.lastRead = time.Time{}
+	c.lookedUp = nil
 }
 
 func (c *CachedProvider) needRefresh() bool {
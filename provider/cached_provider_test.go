@@ -55,6 +55,14 @@ func (p *testProviderFunc) GetDomainFilter() endpoint.DomainFilterInterface {
 	return p.getDomainFilter()
 }
 
+func (p *testProviderFunc) SupportedRecordTypes() []string {
+	return nil
+}
+
+func (p *testProviderFunc) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return 0, 0
+}
+
 func recordsNotCalled(t *testing.T) func(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	return func(ctx context.Context) ([]*endpoint.Endpoint, error) {
 		t.Errorf("unexpected call to Records")
@@ -204,3 +212,81 @@ func TestCachedProviderForcesCacheRefreshOnUpdate(t *testing.T) {
 		})
 	})
 }
+
+// testRecordsByNameProvider is a provider able to query its records by name, recording the queried names.
+type testRecordsByNameProvider struct {
+	testProviderFunc
+	records  []*endpoint.Endpoint
+	listings int
+	lookups  [][]string
+}
+
+func (p *testRecordsByNameProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	p.listings++
+	return p.records, nil
+}
+
+func (p *testRecordsByNameProvider) RecordsExcludingType(_ context.Context, recordType string) ([]*endpoint.Endpoint, error) {
+	p.lookups = append(p.lookups, nil)
+	return filterRecords(p.records, func(r *endpoint.Endpoint) bool { return r.RecordType != recordType }), nil
+}
+
+func (p *testRecordsByNameProvider) RecordsByName(_ context.Context, recordType string, names []string) ([]*endpoint.Endpoint, error) {
+	p.lookups = append(p.lookups, names)
+	return filterRecords(p.records, hasTypeAndName(recordType, names)), nil
+}
+
+func TestAsRecordsByNameProvider(t *testing.T) {
+	byName := &testRecordsByNameProvider{}
+	for _, tc := range []struct {
+		name     string
+		provider Provider
+		expected bool
+	}{
+		{name: "provider", provider: &testProviderFunc{}},
+		{name: "provider querying by name", provider: byName, expected: true},
+		{name: "cached provider", provider: NewCachedProvider(&testProviderFunc{}, time.Minute)},
+		{name: "cached provider querying by name", provider: NewCachedProvider(byName, time.Minute), expected: true},
+		{name: "reloadable cached provider", provider: NewReloadableProvider(NewCachedProvider(&testProviderFunc{}, time.Minute), nil)},
+		{name: "reloadable cached provider querying by name", provider: NewReloadableProvider(NewCachedProvider(byName, time.Minute), nil), expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok := AsRecordsByNameProvider(tc.provider)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
+func TestCachedProviderRecordsByName(t *testing.T) {
+	testProvider := &testRecordsByNameProvider{records: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("a-foo.example.org", endpoint.RecordTypeTXT, "foo"),
+		endpoint.NewEndpoint("a-bar.example.org", endpoint.RecordTypeTXT, "bar"),
+	}}
+	p := NewCachedProvider(testProvider, time.Minute)
+
+	for range 2 {
+		records, err := p.RecordsExcludingType(t.Context(), endpoint.RecordTypeTXT)
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+		records, err = p.RecordsByName(t.Context(), endpoint.RecordTypeTXT, []string{"a-foo.example.org"})
+		require.NoError(t, err)
+		assert.Equal(t, []*endpoint.Endpoint{testProvider.records[1]}, records)
+	}
+
+	// only the names not looked up yet are queried
+	records, err := p.RecordsByName(t.Context(), endpoint.RecordTypeTXT, []string{"a-foo.example.org", "a-bar.example.org"})
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, [][]string{nil, {"a-foo.example.org"}, {"a-bar.example.org"}}, testProvider.lookups)
+
+	// the records partially cached are all read again when listed
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, 1, testProvider.listings)
+	records, err = p.RecordsByName(t.Context(), endpoint.RecordTypeTXT, []string{"a-bar.example.org"})
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Len(t, testProvider.lookups, 3)
+}
@@ -27,7 +27,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cenkalti/backoff/v5"
 	"github.com/cloudflare/cloudflare-go"
 	cloudflarev4 "github.com/cloudflare/cloudflare-go/v4"
 	"github.com/cloudflare/cloudflare-go/v4/addressing"
@@ -52,10 +54,20 @@ const (
 	cloudFlareUpdate
 	// defaultTTL 1 = automatic
 	defaultTTL = 1
+	// maxTTL is the highest TTL accepted by Cloudflare https://developers.cloudflare.com/dns/manage-dns-records/reference/ttl/
+	maxTTL = 86400
 
 	// Cloudflare tier limitations https://developers.cloudflare.com/dns/manage-dns-records/reference/record-attributes/#availability
 	freeZoneMaxCommentLength = 100
 	paidZoneMaxCommentLength = 500
+
+	// defaultMaxAttempts is the number of times a record change is attempted on transient errors
+	defaultMaxAttempts = 5
+	// defaultRetryBaseDelay is the initial delay of the exponential backoff between attempts
+	defaultRetryBaseDelay = time.Second
+
+	// MaxCommentLength is the maximum length of the comments of the records in all the zones.
+	MaxCommentLength = freeZoneMaxCommentLength
 )
 
 var changeActionNames = map[changeAction]string{
@@ -84,12 +96,14 @@ type CustomHostnameIndex struct {
 type CustomHostnamesMap map[CustomHostnameIndex]cloudflare.CustomHostname
 
 var recordTypeProxyNotSupported = map[string]bool{
-	"LOC": true,
-	"MX":  true,
-	"NS":  true,
-	"SPF": true,
-	"TXT": true,
-	"SRV": true,
+	"LOC":   true,
+	"MX":    true,
+	"NS":    true,
+	"SPF":   true,
+	"TXT":   true,
+	"SRV":   true,
+	"SVCB":  true,
+	"HTTPS": true,
 }
 
 type CustomHostnamesConfig struct {
@@ -119,6 +133,10 @@ type cloudFlareDNS interface {
 	CustomHostnames(ctx context.Context, zoneID string, page int, filter cloudflare.CustomHostname) ([]cloudflare.CustomHostname, cloudflare.ResultInfo, error)
 	DeleteCustomHostname(ctx context.Context, zoneID string, customHostnameID string) error
 	CreateCustomHostname(ctx context.Context, zoneID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error)
+	ListLoadBalancers(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerParams) ([]cloudflare.LoadBalancer, error)
+	CreateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerParams) (cloudflare.LoadBalancer, error)
+	UpdateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerParams) (cloudflare.LoadBalancer, error)
+	DeleteLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, loadBalancerID string) error
 }
 
 type zoneService struct {
@@ -170,6 +188,8 @@ func (z zoneService) CreateCustomHostname(ctx context.Context, zoneID string, ch
 type DNSRecordsConfig struct {
 	PerPage int
 	Comment string
+	// Provenance, when enabled, is used as the comment of the records without comment nor description annotation.
+	Provenance provider.ProvenanceConfig
 }
 
 func (c *DNSRecordsConfig) trimAndValidateComment(dnsName, comment string, paidZone func(string) bool) string {
@@ -223,6 +243,11 @@ type CloudFlareProvider struct {
 	CustomHostnamesConfig  CustomHostnamesConfig
 	DNSRecordsConfig       DNSRecordsConfig
 	RegionalServicesConfig RegionalServicesConfig
+	LoadBalancersConfig    LoadBalancersConfig
+	// maxAttempts is the number of times a record change is attempted on rate limits and server errors
+	maxAttempts int
+	// retryBaseDelay is the initial delay of the exponential backoff between attempts
+	retryBaseDelay time.Duration
 }
 
 // cloudFlareChange differentiates between ChangeActions
@@ -247,9 +272,14 @@ func updateDNSRecordParam(cfc cloudFlareChange) cloudflare.UpdateDNSRecordParams
 		Proxied:  cfc.ResourceRecord.Proxied,
 		Type:     cfc.ResourceRecord.Type,
 		Content:  cfc.ResourceRecord.Content,
+		Data:     cfc.ResourceRecord.Data,
 		Priority: cfc.ResourceRecord.Priority,
 		Comment:  cloudflare.StringPtr(cfc.ResourceRecord.Comment),
 	}
+	if params.Data != nil {
+		// the content of the records with data is computed by Cloudflare
+		params.Content = ""
+	}
 
 	return params
 }
@@ -262,9 +292,14 @@ func getCreateDNSRecordParam(cfc cloudFlareChange) cloudflare.CreateDNSRecordPar
 		Proxied:  cfc.ResourceRecord.Proxied,
 		Type:     cfc.ResourceRecord.Type,
 		Content:  cfc.ResourceRecord.Content,
+		Data:     cfc.ResourceRecord.Data,
 		Priority: cfc.ResourceRecord.Priority,
 		Comment:  cfc.ResourceRecord.Comment,
 	}
+	if params.Data != nil {
+		// the content of the records with data is computed by Cloudflare
+		params.Content = ""
+	}
 
 	return params
 }
@@ -286,6 +321,34 @@ func convertCloudflareError(err error) error {
 	return err
 }
 
+// withRetry calls fn and retries it with exponential backoff while it fails with a rate limit
+// or a server error, up to maxAttempts times. The other errors are returned immediately.
+func (p *CloudFlareProvider) withRetry(ctx context.Context, logFields log.Fields, fn func() error) error {
+	maxAttempts := max(p.maxAttempts, 1)
+	attempt := 0
+
+	b := backoff.NewExponentialBackOff()
+	if p.retryBaseDelay > 0 {
+		b.InitialInterval = p.retryBaseDelay
+	}
+
+	_, err := backoff.Retry(ctx, func() (struct{}, error) {
+		attempt++
+		err := fn()
+		if err == nil {
+			return struct{}{}, nil
+		}
+		if !errors.Is(convertCloudflareError(err), provider.SoftError) {
+			return struct{}{}, backoff.Permanent(err)
+		}
+		if attempt < maxAttempts {
+			log.WithFields(logFields).Debugf("Transient error (attempt %d/%d), retrying: %v", attempt, maxAttempts, err)
+		}
+		return struct{}{}, err
+	}, backoff.WithBackOff(b), backoff.WithMaxTries(uint(maxAttempts)))
+	return err
+}
+
 // NewCloudFlareProvider initializes a new CloudFlare DNS based Provider.
 func NewCloudFlareProvider(
 	domainFilter *endpoint.DomainFilter,
@@ -295,6 +358,7 @@ func NewCloudFlareProvider(
 	regionalServicesConfig RegionalServicesConfig,
 	customHostnamesConfig CustomHostnamesConfig,
 	dnsRecordsConfig DNSRecordsConfig,
+	loadBalancersConfig LoadBalancersConfig,
 ) (*CloudFlareProvider, error) {
 	// initialize via chosen auth method and returns new API object
 	var (
@@ -339,6 +403,9 @@ func NewCloudFlareProvider(
 		DryRun:                 dryRun,
 		RegionalServicesConfig: regionalServicesConfig,
 		DNSRecordsConfig:       dnsRecordsConfig,
+		LoadBalancersConfig:    loadBalancersConfig,
+		maxAttempts:            defaultMaxAttempts,
+		retryBaseDelay:         defaultRetryBaseDelay,
 	}, nil
 }
 
@@ -413,7 +480,13 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 			return nil, err
 		}
 
+		lbEndpoints, err := p.loadBalancerEndpoints(ctx, zone.ID)
+		if err != nil {
+			return nil, err
+		}
+
 		endpoints = append(endpoints, zoneEndpoints...)
+		endpoints = append(endpoints, lbEndpoints...)
 	}
 
 	return endpoints, nil
@@ -423,6 +496,9 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	var cloudflareChanges []*cloudFlareChange
 
+	// endpoints bound to load balancer pools are published as load balancers instead of DNS records
+	lbChanges, changes := p.loadBalancerChanges(changes)
+
 	// if custom hostnames are enabled, deleting first allows to avoid conflicts with the new ones
 	if p.CustomHostnamesConfig.Enabled {
 		for _, e := range changes.Delete {
@@ -495,7 +571,11 @@ func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Cha
 		}
 	}
 
-	return p.submitChanges(ctx, cloudflareChanges)
+	// load balancers are deleted before and created after the DNS records to avoid hostname conflicts
+	lbDeletes, lbUpserts := splitLoadBalancerDeletes(lbChanges)
+	lbDeleteErr := p.submitLoadBalancerChanges(ctx, lbDeletes)
+	recordsErr := p.submitChanges(ctx, cloudflareChanges)
+	return errors.Join(lbDeleteErr, recordsErr, p.submitLoadBalancerChanges(ctx, lbUpserts))
 }
 
 // submitCustomHostnameChanges implements Custom Hostname functionality for the Change, returns false if it fails
@@ -588,7 +668,7 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 	// separate into per-zone change sets to be passed to the API.
 	changesByZone := p.changesByZone(zones, changes)
 
-	var failedZones []string
+	var failedZones, failedRecords []string
 	for zoneID, zoneChanges := range changesByZone {
 		var failedChange bool
 		resourceContainer := cloudflare.ZoneIdentifier(zoneID)
@@ -627,9 +707,12 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 				}
 				recordParam := updateDNSRecordParam(*change)
 				recordParam.ID = recordID
-				err := p.Client.UpdateDNSRecord(ctx, resourceContainer, recordParam)
+				err := p.withRetry(ctx, logFields, func() error {
+					return p.Client.UpdateDNSRecord(ctx, resourceContainer, recordParam)
+				})
 				if err != nil {
 					failedChange = true
+					failedRecords = append(failedRecords, failedRecord(change))
 					log.WithFields(logFields).Errorf("failed to update record: %v", err)
 				}
 			} else if change.Action == cloudFlareDelete {
@@ -638,9 +721,12 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 					log.WithFields(logFields).Errorf("failed to find previous record: %v", change.ResourceRecord)
 					continue
 				}
-				err := p.Client.DeleteDNSRecord(ctx, resourceContainer, recordID)
+				err := p.withRetry(ctx, logFields, func() error {
+					return p.Client.DeleteDNSRecord(ctx, resourceContainer, recordID)
+				})
 				if err != nil {
 					failedChange = true
+					failedRecords = append(failedRecords, failedRecord(change))
 					log.WithFields(logFields).Errorf("failed to delete record: %v", err)
 				}
 				if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
@@ -648,9 +734,13 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 				}
 			} else if change.Action == cloudFlareCreate {
 				recordParam := getCreateDNSRecordParam(*change)
-				_, err := p.Client.CreateDNSRecord(ctx, resourceContainer, recordParam)
+				err := p.withRetry(ctx, logFields, func() error {
+					_, err := p.Client.CreateDNSRecord(ctx, resourceContainer, recordParam)
+					return err
+				})
 				if err != nil {
 					failedChange = true
+					failedRecords = append(failedRecords, failedRecord(change))
 					log.WithFields(logFields).Errorf("failed to create record: %v", err)
 				}
 				if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
@@ -681,6 +771,10 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 		}
 	}
 
+	if len(failedRecords) > 0 {
+		sort.Strings(failedRecords)
+		return fmt.Errorf("failed to submit the changes of the following records: %q", failedRecords)
+	}
 	if len(failedZones) > 0 {
 		return fmt.Errorf("failed to submit all changes for the following zones: %q", failedZones)
 	}
@@ -688,10 +782,44 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 	return nil
 }
 
+// failedRecord describes the failed change in the error returned by submitChanges.
+func failedRecord(change *cloudFlareChange) string {
+	return fmt.Sprintf("%s %s %s", change.Action, change.ResourceRecord.Type, change.ResourceRecord.Name)
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	var adjustedEndpoints []*endpoint.Endpoint
+	loadBalancerNames := map[string]bool{}
 	for _, e := range endpoints {
+		isLoadBalancer := p.LoadBalancersConfig.Enabled && p.isLoadBalancerEndpoint(e)
+		if _, ok := e.GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey); !ok && !isLoadBalancer {
+			if description := e.Labels[endpoint.DescriptionLabelKey]; description != "" {
+				e.SetProviderSpecificProperty(annotations.CloudflareRecordCommentKey, description)
+			} else if provenance := p.DNSRecordsConfig.Provenance.Provenance(e); provenance != "" {
+				e.SetProviderSpecificProperty(annotations.CloudflareRecordCommentKey, provenance)
+			}
+		}
+
+		if p.LoadBalancersConfig.Enabled {
+			if isLoadBalancer {
+				// a single load balancer is published per hostname, whatever the record types of the source
+				if loadBalancerNames[e.DNSName] {
+					log.Debugf("Skipping endpoint %s/%s, a load balancer is already published for this hostname", e.DNSName, e.RecordType)
+					continue
+				}
+				loadBalancerNames[e.DNSName] = true
+				adjustLoadBalancerEndpoint(e)
+			}
+		} else {
+			// ignore load balancer pools annotations if not enabled
+			e.DeleteProviderSpecificProperty(annotations.CloudflareLoadBalancerPoolKey)
+		}
+
+		if e.RecordType == endpoint.RecordTypeSVCB || e.RecordType == endpoint.RecordTypeHTTPS {
+			e.Targets = e.Targets.NormalizeSVCBRecords()
+		}
+
 		proxied := shouldBeProxied(e, p.proxiedByDefault)
 		if proxied {
 			e.RecordTTL = 0
@@ -799,6 +927,7 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 	}
 
 	priority := (*uint16)(nil)
+	var data interface{}
 	if ep.RecordType == "MX" {
 		mxRecord, err := endpoint.NewMXRecord(target)
 		if err != nil {
@@ -808,6 +937,19 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 			target = *mxRecord.GetHost()
 		}
 	}
+	if ep.RecordType == endpoint.RecordTypeSVCB || ep.RecordType == endpoint.RecordTypeHTTPS {
+		svcbRecord, err := endpoint.NewSVCBRecord(target)
+		if err != nil {
+			return &cloudFlareChange{}, fmt.Errorf("failed to parse %s record target %q: %w", ep.RecordType, target, err)
+		}
+		// the content is kept to find the record, but Cloudflare only accepts the data of these records
+		target = svcbRecord.String()
+		data = map[string]interface{}{
+			"priority": *svcbRecord.GetPriority(),
+			"target":   *svcbRecord.GetTarget(),
+			"value":    svcbRecord.GetParamsString(),
+		}
+	}
 
 	return &cloudFlareChange{
 		Action: action,
@@ -819,6 +961,7 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 			Proxied:  &proxied,
 			Type:     ep.RecordType,
 			Content:  target,
+			Data:     data,
 			Comment:  comment,
 			Priority: priority,
 		},
@@ -845,6 +988,12 @@ func (p *CloudFlareProvider) listDNSRecordsWithAutoPagination(ctx context.Contex
 		}
 
 		for _, r := range pageRecords {
+			if r.Type == endpoint.RecordTypeSVCB || r.Type == endpoint.RecordTypeHTTPS {
+				// Cloudflare quotes the parameter values, use the same representation as the endpoints
+				if svcbRecord, err := endpoint.NewSVCBRecord(r.Content); err == nil {
+					r.Content = svcbRecord.String()
+				}
+			}
 			records[newDNSRecordIndex(r)] = r
 		}
 		params.ResultInfo = resultInfo.Next()
@@ -1001,10 +1150,16 @@ func (p *CloudFlareProvider) groupByNameAndTypeWithCustomHostnames(records DNSRe
 	return endpoints
 }
 
+// TTLLimits returns the highest TTL accepted by Cloudflare. The lowest one depends on the plan of the zone,
+// and 1 means automatic.
+func (p *CloudFlareProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return 0, maxTTL
+}
+
 // SupportedRecordType returns true if the record type is supported by the provider
 func (p *CloudFlareProvider) SupportedAdditionalRecordTypes(recordType string) bool {
 	switch recordType {
-	case endpoint.RecordTypeMX:
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSVCB, endpoint.RecordTypeHTTPS:
 		return true
 	default:
 		return provider.SupportedRecordType(recordType)
@@ -0,0 +1,318 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+type LoadBalancersConfig struct {
+	Enabled bool
+}
+
+type loadBalancerChange struct {
+	action       changeAction
+	loadBalancer cloudflare.LoadBalancer
+}
+
+// loadBalancersMap is a map of load balancers keyed by hostname.
+type loadBalancersMap map[string]cloudflare.LoadBalancer
+
+func (z zoneService) ListLoadBalancers(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerParams) ([]cloudflare.LoadBalancer, error) {
+	return z.service.ListLoadBalancers(ctx, rc, params)
+}
+
+func (z zoneService) CreateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	return z.service.CreateLoadBalancer(ctx, rc, params)
+}
+
+func (z zoneService) UpdateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	return z.service.UpdateLoadBalancer(ctx, rc, params)
+}
+
+func (z zoneService) DeleteLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, loadBalancerID string) error {
+	return z.service.DeleteLoadBalancer(ctx, rc, loadBalancerID)
+}
+
+// getEndpointLoadBalancerPools returns the load balancer pool IDs the endpoint is bound to, in annotation order.
+func getEndpointLoadBalancerPools(ep *endpoint.Endpoint) []string {
+	value, ok := ep.GetProviderSpecificProperty(annotations.CloudflareLoadBalancerPoolKey)
+	if !ok {
+		return nil
+	}
+	var pools []string
+	for _, pool := range strings.Split(value, ",") {
+		if pool = strings.TrimSpace(pool); pool != "" {
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
+
+// isLoadBalancerEndpoint returns true if the endpoint must be published as a Cloudflare load balancer.
+func (p *CloudFlareProvider) isLoadBalancerEndpoint(ep *endpoint.Endpoint) bool {
+	return p.LoadBalancersConfig.Enabled && len(getEndpointLoadBalancerPools(ep)) > 0
+}
+
+// adjustLoadBalancerEndpoint normalizes an endpoint bound to load balancer pools so that it matches
+// the endpoint returned by Records for the load balancer: a CNAME whose targets are the pool IDs.
+func adjustLoadBalancerEndpoint(ep *endpoint.Endpoint) {
+	pools := getEndpointLoadBalancerPools(ep)
+	ep.RecordType = endpoint.RecordTypeCNAME
+	ep.Targets = pools
+	ep.SetProviderSpecificProperty(annotations.CloudflareLoadBalancerPoolKey, strings.Join(pools, ","))
+}
+
+// newLoadBalancer returns the load balancer publishing the given endpoint.
+//
+// The first pool is used as the fallback pool. The TTL is only set for non-proxied load balancers.
+func (p *CloudFlareProvider) newLoadBalancer(ep *endpoint.Endpoint) cloudflare.LoadBalancer {
+	pools := getEndpointLoadBalancerPools(ep)
+	proxied := shouldBeProxied(ep, p.proxiedByDefault)
+	lb := cloudflare.LoadBalancer{
+		Name:         ep.DNSName,
+		DefaultPools: pools,
+		FallbackPool: pools[0],
+		Proxied:      proxied,
+	}
+	if !proxied && ep.RecordTTL.IsConfigured() {
+		lb.TTL = int(ep.RecordTTL)
+	}
+	return lb
+}
+
+// newLoadBalancerEndpoint returns the endpoint representing an existing load balancer.
+func newLoadBalancerEndpoint(lb cloudflare.LoadBalancer) *endpoint.Endpoint {
+	e := endpoint.NewEndpointWithTTL(lb.Name, endpoint.RecordTypeCNAME, endpoint.TTL(lb.TTL), lb.DefaultPools...)
+	if e == nil {
+		return nil
+	}
+	return e.
+		WithProviderSpecific(annotations.CloudflareProxiedKey, strconv.FormatBool(lb.Proxied)).
+		WithProviderSpecific(annotations.CloudflareLoadBalancerPoolKey, strings.Join(lb.DefaultPools, ","))
+}
+
+// loadBalancerChanges extracts the changes for endpoints bound to load balancer pools from the plan changes.
+//
+// It returns the load balancer changes and the remaining changes, which are published as plain DNS records.
+// An update between a plain record and a load balancer is split into a delete of the former and a create of the latter.
+func (p *CloudFlareProvider) loadBalancerChanges(changes *plan.Changes) ([]loadBalancerChange, *plan.Changes) {
+	if !p.LoadBalancersConfig.Enabled {
+		return nil, changes
+	}
+
+	var lbChanges []loadBalancerChange
+	recordChanges := &plan.Changes{}
+
+	for _, e := range changes.Create {
+		if p.isLoadBalancerEndpoint(e) {
+			lbChanges = append(lbChanges, loadBalancerChange{action: cloudFlareCreate, loadBalancer: p.newLoadBalancer(e)})
+			continue
+		}
+		recordChanges.Create = append(recordChanges.Create, e)
+	}
+
+	for i, desired := range changes.UpdateNew {
+		current := changes.UpdateOld[i]
+		currentIsLB, desiredIsLB := p.isLoadBalancerEndpoint(current), p.isLoadBalancerEndpoint(desired)
+		switch {
+		case currentIsLB && desiredIsLB:
+			lbChanges = append(lbChanges, loadBalancerChange{action: cloudFlareUpdate, loadBalancer: p.newLoadBalancer(desired)})
+		case currentIsLB:
+			lbChanges = append(lbChanges, loadBalancerChange{action: cloudFlareDelete, loadBalancer: p.newLoadBalancer(current)})
+			recordChanges.Create = append(recordChanges.Create, desired)
+		case desiredIsLB:
+			recordChanges.Delete = append(recordChanges.Delete, current)
+			lbChanges = append(lbChanges, loadBalancerChange{action: cloudFlareCreate, loadBalancer: p.newLoadBalancer(desired)})
+		default:
+			recordChanges.UpdateOld = append(recordChanges.UpdateOld, current)
+			recordChanges.UpdateNew = append(recordChanges.UpdateNew, desired)
+		}
+	}
+
+	for _, e := range changes.Delete {
+		if p.isLoadBalancerEndpoint(e) {
+			lbChanges = append(lbChanges, loadBalancerChange{action: cloudFlareDelete, loadBalancer: p.newLoadBalancer(e)})
+			continue
+		}
+		recordChanges.Delete = append(recordChanges.Delete, e)
+	}
+
+	return lbChanges, recordChanges
+}
+
+// splitLoadBalancerDeletes separates the load balancer deletions from the creations and updates.
+func splitLoadBalancerDeletes(lbChanges []loadBalancerChange) ([]loadBalancerChange, []loadBalancerChange) {
+	var deletes, upserts []loadBalancerChange
+	for _, lbChange := range lbChanges {
+		if lbChange.action == cloudFlareDelete {
+			deletes = append(deletes, lbChange)
+		} else {
+			upserts = append(upserts, lbChange)
+		}
+	}
+	return deletes, upserts
+}
+
+// listLoadBalancers fetches the current load balancers for the given zone ID.
+//
+// It returns a map of hostnames to load balancers, or an error if the request fails.
+func (p *CloudFlareProvider) listLoadBalancers(ctx context.Context, zoneID string) (loadBalancersMap, error) {
+	lbs, err := p.Client.ListLoadBalancers(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListLoadBalancerParams{})
+	if err != nil {
+		return nil, convertCloudflareError(err)
+	}
+	lbsMap := make(loadBalancersMap, len(lbs))
+	for _, lb := range lbs {
+		lbsMap[lb.Name] = lb
+	}
+	return lbsMap, nil
+}
+
+// loadBalancerEndpoints returns the endpoints representing the load balancers of the given zone.
+//
+// Do nothing if the load balancers feature is not enabled.
+func (p *CloudFlareProvider) loadBalancerEndpoints(ctx context.Context, zoneID string) ([]*endpoint.Endpoint, error) {
+	if !p.LoadBalancersConfig.Enabled {
+		return nil, nil
+	}
+	lbs, err := p.listLoadBalancers(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []*endpoint.Endpoint
+	for _, lb := range lbs {
+		if e := newLoadBalancerEndpoint(lb); e != nil {
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints, nil
+}
+
+// submitLoadBalancerChanges applies a set of load balancer changes, grouped by zone.
+func (p *CloudFlareProvider) submitLoadBalancerChanges(ctx context.Context, lbChanges []loadBalancerChange) error {
+	if len(lbChanges) == 0 {
+		return nil
+	}
+
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, z := range zones {
+		zoneNameIDMapper.Add(z.ID, z.Name)
+	}
+
+	changesByZone := make(map[string][]loadBalancerChange)
+	var zoneIDs []string
+	for _, lbChange := range lbChanges {
+		zoneID, _ := zoneNameIDMapper.FindZone(lbChange.loadBalancer.Name)
+		if zoneID == "" {
+			log.Debugf("Skipping load balancer %q because no hosted zone matching its name was detected", lbChange.loadBalancer.Name)
+			continue
+		}
+		if _, ok := changesByZone[zoneID]; !ok {
+			zoneIDs = append(zoneIDs, zoneID)
+		}
+		changesByZone[zoneID] = append(changesByZone[zoneID], lbChange)
+	}
+
+	var failedZones []string
+	for _, zoneID := range zoneIDs {
+		if !p.submitZoneLoadBalancerChanges(ctx, zoneID, changesByZone[zoneID]) {
+			failedZones = append(failedZones, zoneID)
+		}
+	}
+
+	if len(failedZones) > 0 {
+		return fmt.Errorf("failed to submit all load balancer changes for the following zones: %q", failedZones)
+	}
+	return nil
+}
+
+// submitZoneLoadBalancerChanges applies the load balancer changes of a single zone, returns false if at least one fails
+func (p *CloudFlareProvider) submitZoneLoadBalancerChanges(ctx context.Context, zoneID string, lbChanges []loadBalancerChange) bool {
+	var current loadBalancersMap
+	if !p.DryRun {
+		var err error
+		current, err = p.listLoadBalancers(ctx, zoneID)
+		if err != nil {
+			log.Errorf("could not fetch load balancers from zone %q, %v", zoneID, err)
+			return false
+		}
+	}
+
+	resourceContainer := cloudflare.ZoneIdentifier(zoneID)
+	failedChange := false
+	for _, lbChange := range lbChanges {
+		changeLog := log.WithFields(log.Fields{
+			"loadBalancer": lbChange.loadBalancer.Name,
+			"pools":        strings.Join(lbChange.loadBalancer.DefaultPools, ","),
+			"action":       lbChange.action.String(),
+			"zone":         zoneID,
+		})
+		changeLog.Info("Changing load balancer.")
+
+		if p.DryRun {
+			continue
+		}
+
+		switch lbChange.action {
+		case cloudFlareCreate:
+			if _, err := p.Client.CreateLoadBalancer(ctx, resourceContainer, cloudflare.CreateLoadBalancerParams{LoadBalancer: lbChange.loadBalancer}); err != nil {
+				failedChange = true
+				changeLog.Errorf("failed to create load balancer: %v", err)
+			}
+		case cloudFlareUpdate:
+			existing, ok := current[lbChange.loadBalancer.Name]
+			if !ok {
+				failedChange = true
+				changeLog.Errorf("failed to find previous load balancer")
+				continue
+			}
+			lb := lbChange.loadBalancer
+			lb.ID = existing.ID
+			if _, err := p.Client.UpdateLoadBalancer(ctx, resourceContainer, cloudflare.UpdateLoadBalancerParams{LoadBalancer: lb}); err != nil {
+				failedChange = true
+				changeLog.Errorf("failed to update load balancer: %v", err)
+			}
+		case cloudFlareDelete:
+			existing, ok := current[lbChange.loadBalancer.Name]
+			if !ok {
+				changeLog.Warnf("failed to find load balancer to delete")
+				continue
+			}
+			if err := p.Client.DeleteLoadBalancer(ctx, resourceContainer, existing.ID); err != nil {
+				failedChange = true
+				changeLog.Errorf("failed to delete load balancer: %v", err)
+			}
+		}
+	}
+	return !failedChange
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func (m *mockCloudFlareClient) ListLoadBalancers(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerParams) ([]cloudflare.LoadBalancer, error) {
+	if strings.Contains(rc.Identifier, "lberror") {
+		return nil, fmt.Errorf("failed to list load balancers")
+	}
+	return m.loadBalancers[rc.Identifier], nil
+}
+
+func (m *mockCloudFlareClient) CreateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	if strings.Contains(params.LoadBalancer.Name, "lberror") {
+		return cloudflare.LoadBalancer{}, fmt.Errorf("failed to create load balancer")
+	}
+	m.Actions = append(m.Actions, MockAction{
+		Name:         "CreateLoadBalancer",
+		ZoneId:       rc.Identifier,
+		LoadBalancer: params.LoadBalancer,
+	})
+	return params.LoadBalancer, nil
+}
+
+func (m *mockCloudFlareClient) UpdateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	if strings.Contains(params.LoadBalancer.Name, "lberror") {
+		return cloudflare.LoadBalancer{}, fmt.Errorf("failed to update load balancer")
+	}
+	m.Actions = append(m.Actions, MockAction{
+		Name:         "UpdateLoadBalancer",
+		ZoneId:       rc.Identifier,
+		LoadBalancer: params.LoadBalancer,
+	})
+	return params.LoadBalancer, nil
+}
+
+func (m *mockCloudFlareClient) DeleteLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, loadBalancerID string) error {
+	if strings.Contains(loadBalancerID, "lberror") {
+		return fmt.Errorf("failed to delete load balancer")
+	}
+	m.Actions = append(m.Actions, MockAction{
+		Name:         "DeleteLoadBalancer",
+		ZoneId:       rc.Identifier,
+		LoadBalancer: cloudflare.LoadBalancer{ID: loadBalancerID},
+	})
+	return nil
+}
+
+func TestCloudflareLoadBalancerActions(t *testing.T) {
+	tests := []struct {
+		name          string
+		records       map[string]cloudflare.DNSRecord
+		loadBalancers []cloudflare.LoadBalancer
+		endpoints     []*endpoint.Endpoint
+		want          []MockAction
+	}{
+		{
+			name:          "create",
+			records:       map[string]cloudflare.DNSRecord{},
+			loadBalancers: []cloudflare.LoadBalancer{},
+			endpoints: []*endpoint.Endpoint{
+				{
+					RecordType: "A",
+					DNSName:    "create.bar.com",
+					Targets:    endpoint.Targets{"127.0.0.1"},
+					ProviderSpecific: endpoint.ProviderSpecific{
+						{
+							Name:  "external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool",
+							Value: "pool-1, pool-2",
+						},
+					},
+				},
+				{
+					RecordType: "AAAA",
+					DNSName:    "create.bar.com",
+					Targets:    endpoint.Targets{"::1"},
+					ProviderSpecific: endpoint.ProviderSpecific{
+						{
+							Name:  "external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool",
+							Value: "pool-1, pool-2",
+						},
+					},
+				},
+			},
+			want: []MockAction{
+				{
+					Name:   "CreateLoadBalancer",
+					ZoneId: "001",
+					LoadBalancer: cloudflare.LoadBalancer{
+						Name:         "create.bar.com",
+						DefaultPools: []string{"pool-1", "pool-2"},
+						FallbackPool: "pool-1",
+						Proxied:      false,
+					},
+				},
+			},
+		},
+		{
+			name:    "update",
+			records: map[string]cloudflare.DNSRecord{},
+			loadBalancers: []cloudflare.LoadBalancer{
+				{
+					ID:           "lb-update",
+					Name:         "update.bar.com",
+					DefaultPools: []string{"pool-1"},
+					FallbackPool: "pool-1",
+					TTL:          1,
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				{
+					RecordType: "A",
+					DNSName:    "update.bar.com",
+					Targets:    endpoint.Targets{"127.0.0.1"},
+					ProviderSpecific: endpoint.ProviderSpecific{
+						{
+							Name:  "external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool",
+							Value: "pool-2,pool-1",
+						},
+					},
+				},
+			},
+			want: []MockAction{
+				{
+					Name:   "UpdateLoadBalancer",
+					ZoneId: "001",
+					LoadBalancer: cloudflare.LoadBalancer{
+						ID:           "lb-update",
+						Name:         "update.bar.com",
+						DefaultPools: []string{"pool-2", "pool-1"},
+						FallbackPool: "pool-2",
+						Proxied:      false,
+					},
+				},
+			},
+		},
+		{
+			name:    "delete",
+			records: map[string]cloudflare.DNSRecord{},
+			loadBalancers: []cloudflare.LoadBalancer{
+				{
+					ID:           "lb-delete",
+					Name:         "delete.bar.com",
+					DefaultPools: []string{"pool-1"},
+					FallbackPool: "pool-1",
+					TTL:          1,
+				},
+			},
+			endpoints: []*endpoint.Endpoint{},
+			want: []MockAction{
+				{
+					Name:         "DeleteLoadBalancer",
+					ZoneId:       "001",
+					LoadBalancer: cloudflare.LoadBalancer{ID: "lb-delete"},
+				},
+			},
+		},
+		{
+			name:    "no change",
+			records: map[string]cloudflare.DNSRecord{},
+			loadBalancers: []cloudflare.LoadBalancer{
+				{
+					ID:           "lb-nochange",
+					Name:         "nochange.bar.com",
+					DefaultPools: []string{"pool-1", "pool-2"},
+					FallbackPool: "pool-1",
+					Proxied:      true,
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				{
+					RecordType: "A",
+					DNSName:    "nochange.bar.com",
+					Targets:    endpoint.Targets{"127.0.0.1"},
+					ProviderSpecific: endpoint.ProviderSpecific{
+						{
+							Name:  "external-dns.alpha.kubernetes.io/cloudflare-proxied",
+							Value: "true",
+						},
+						{
+							Name:  "external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool",
+							Value: "pool-1,pool-2",
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "record replaced by load balancer",
+			records: map[string]cloudflare.DNSRecord{
+				"replace.bar.com": {
+					ID:      generateDNSRecordID("CNAME", "replace.bar.com", "pool-1"),
+					Type:    "CNAME",
+					Name:    "replace.bar.com",
+					Content: "pool-1",
+					TTL:     1,
+					Proxied: proxyDisabled,
+				},
+			},
+			loadBalancers: []cloudflare.LoadBalancer{},
+			endpoints: []*endpoint.Endpoint{
+				{
+					RecordType: "A",
+					DNSName:    "replace.bar.com",
+					Targets:    endpoint.Targets{"127.0.0.1"},
+					ProviderSpecific: endpoint.ProviderSpecific{
+						{
+							Name:  "external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool",
+							Value: "pool-1",
+						},
+					},
+				},
+			},
+			want: []MockAction{
+				{
+					Name:       "Delete",
+					ZoneId:     "001",
+					RecordId:   generateDNSRecordID("CNAME", "replace.bar.com", "pool-1"),
+					RecordData: cloudflare.DNSRecord{},
+				},
+				{
+					Name:   "CreateLoadBalancer",
+					ZoneId: "001",
+					LoadBalancer: cloudflare.LoadBalancer{
+						Name:         "replace.bar.com",
+						DefaultPools: []string{"pool-1"},
+						FallbackPool: "pool-1",
+						Proxied:      false,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &CloudFlareProvider{
+				LoadBalancersConfig: LoadBalancersConfig{Enabled: true},
+				Client: &mockCloudFlareClient{
+					Zones: map[string]string{
+						"001": "bar.com",
+					},
+					Records: map[string]map[string]cloudflare.DNSRecord{
+						"001": tt.records,
+					},
+					loadBalancers: map[string][]cloudflare.LoadBalancer{
+						"001": tt.loadBalancers,
+					},
+				},
+			}
+
+			AssertActions(t, provider, tt.endpoints, tt.want, []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME})
+		})
+	}
+}
+
+func TestCloudflareLoadBalancerDisabled(t *testing.T) {
+	provider := &CloudFlareProvider{
+		LoadBalancersConfig: LoadBalancersConfig{Enabled: false},
+	}
+	endpoints := []*endpoint.Endpoint{
+		{
+			RecordType: "A",
+			DNSName:    "disabled.bar.com",
+			Targets:    endpoint.Targets{"127.0.0.1"},
+			ProviderSpecific: endpoint.ProviderSpecific{
+				{
+					Name:  "external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool",
+					Value: "pool-1",
+				},
+			},
+		},
+	}
+
+	AssertActions(t, provider, endpoints, []MockAction{
+		{
+			Name:     "Create",
+			ZoneId:   "001",
+			RecordId: generateDNSRecordID("A", "disabled.bar.com", "127.0.0.1"),
+			RecordData: cloudflare.DNSRecord{
+				ID:      generateDNSRecordID("A", "disabled.bar.com", "127.0.0.1"),
+				Type:    "A",
+				Name:    "disabled.bar.com",
+				Content: "127.0.0.1",
+				TTL:     1,
+				Proxied: proxyDisabled,
+			},
+		},
+	}, []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME})
+}
+
+func TestCloudflareLoadBalancerRecords(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	client.loadBalancers["001"] = []cloudflare.LoadBalancer{
+		{
+			ID:           "lb-1",
+			Name:         "lb.bar.com",
+			DefaultPools: []string{"pool-1", "pool-2"},
+			FallbackPool: "pool-1",
+			TTL:          120,
+		},
+	}
+	provider := &CloudFlareProvider{
+		LoadBalancersConfig: LoadBalancersConfig{Enabled: true},
+		Client:              client,
+	}
+
+	records, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, "lb.bar.com", records[0].DNSName)
+	assert.Equal(t, endpoint.RecordTypeCNAME, records[0].RecordType)
+	assert.Equal(t, endpoint.TTL(120), records[0].RecordTTL)
+	assert.Equal(t, endpoint.Targets{"pool-1", "pool-2"}, records[0].Targets)
+	pools, _ := records[0].GetProviderSpecificProperty("external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool")
+	assert.Equal(t, "pool-1,pool-2", pools)
+
+	provider.LoadBalancersConfig.Enabled = false
+	records, err = provider.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestCloudflareLoadBalancerListError(t *testing.T) {
+	provider := &CloudFlareProvider{
+		LoadBalancersConfig: LoadBalancersConfig{Enabled: true},
+		Client: &mockCloudFlareClient{
+			Zones: map[string]string{
+				"lberror": "bar.com",
+			},
+			Records: map[string]map[string]cloudflare.DNSRecord{
+				"lberror": {},
+			},
+		},
+	}
+
+	_, err := provider.Records(context.Background())
+	assert.Error(t, err)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("lb.bar.com", endpoint.RecordTypeCNAME, "pool-1").
+				WithProviderSpecific("external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool", "pool-1"),
+		},
+	})
+	assert.ErrorContains(t, err, "failed to submit all load balancer changes")
+}
+
+func TestCloudflareLoadBalancerChanges(t *testing.T) {
+	provider := &CloudFlareProvider{
+		LoadBalancersConfig: LoadBalancersConfig{Enabled: true},
+	}
+	lbEndpoint := func(name string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeCNAME, "pool-1").
+			WithProviderSpecific("external-dns.alpha.kubernetes.io/cloudflare-load-balancer-pool", "pool-1")
+	}
+	recordEndpoint := func(name string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeA, "127.0.0.1")
+	}
+
+	lbChanges, changes := provider.loadBalancerChanges(&plan.Changes{
+		Create:    []*endpoint.Endpoint{lbEndpoint("create-lb.bar.com"), recordEndpoint("create.bar.com")},
+		UpdateOld: []*endpoint.Endpoint{lbEndpoint("lb-to-lb.bar.com"), lbEndpoint("lb-to-record.bar.com"), recordEndpoint("record-to-lb.bar.com"), recordEndpoint("record-to-record.bar.com")},
+		UpdateNew: []*endpoint.Endpoint{lbEndpoint("lb-to-lb.bar.com"), recordEndpoint("lb-to-record.bar.com"), lbEndpoint("record-to-lb.bar.com"), recordEndpoint("record-to-record.bar.com")},
+		Delete:    []*endpoint.Endpoint{lbEndpoint("delete-lb.bar.com"), recordEndpoint("delete.bar.com")},
+	})
+
+	type lbAction struct {
+		action changeAction
+		name   string
+	}
+	var got []lbAction
+	for _, c := range lbChanges {
+		got = append(got, lbAction{c.action, c.loadBalancer.Name})
+	}
+	assert.Equal(t, []lbAction{
+		{cloudFlareCreate, "create-lb.bar.com"},
+		{cloudFlareUpdate, "lb-to-lb.bar.com"},
+		{cloudFlareDelete, "lb-to-record.bar.com"},
+		{cloudFlareCreate, "record-to-lb.bar.com"},
+		{cloudFlareDelete, "delete-lb.bar.com"},
+	}, got)
+
+	assert.Equal(t, []*endpoint.Endpoint{recordEndpoint("create.bar.com"), recordEndpoint("lb-to-record.bar.com")}, changes.Create)
+	assert.Equal(t, []*endpoint.Endpoint{recordEndpoint("record-to-record.bar.com")}, changes.UpdateOld)
+	assert.Equal(t, []*endpoint.Endpoint{recordEndpoint("record-to-record.bar.com")}, changes.UpdateNew)
+	assert.Equal(t, []*endpoint.Endpoint{recordEndpoint("record-to-lb.bar.com"), recordEndpoint("delete.bar.com")}, changes.Delete)
+
+	provider.LoadBalancersConfig.Enabled = false
+	lbChanges, changes = provider.loadBalancerChanges(&plan.Changes{
+		Create: []*endpoint.Endpoint{lbEndpoint("create-lb.bar.com")},
+	})
+	assert.Empty(t, lbChanges)
+	assert.Len(t, changes.Create, 1)
+}
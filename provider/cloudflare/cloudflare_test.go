@@ -20,20 +20,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"slices"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/maxatome/go-testdeep/td"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
 	"sigs.k8s.io/external-dns/source/annotations"
 )
 
@@ -49,6 +53,7 @@ type MockAction struct {
 	RecordId         string
 	RecordData       cloudflare.DNSRecord
 	RegionalHostname regionalHostname
+	LoadBalancer     cloudflare.LoadBalancer
 }
 
 type mockCloudFlareClient struct {
@@ -61,6 +66,7 @@ type mockCloudFlareClient struct {
 	dnsRecordsError       error
 	customHostnames       map[string][]cloudflare.CustomHostname
 	regionalHostnames     map[string][]regionalHostname
+	loadBalancers         map[string][]cloudflare.LoadBalancer
 }
 
 var ExampleDomain = []cloudflare.DNSRecord{
@@ -103,6 +109,7 @@ func NewMockCloudFlareClient() *mockCloudFlareClient {
 		},
 		customHostnames:   map[string][]cloudflare.CustomHostname{},
 		regionalHostnames: map[string][]regionalHostname{},
+		loadBalancers:     map[string][]cloudflare.LoadBalancer{},
 	}
 }
 
@@ -130,10 +137,12 @@ func getDNSRecordFromRecordParams(rp any) cloudflare.DNSRecord {
 			Proxied: params.Proxied,
 			Type:    params.Type,
 			Content: params.Content,
+			Comment: params.Comment,
 		}
 		if params.Type == "MX" {
 			record.Priority = params.Priority
 		}
+		setDNSRecordData(&record, params.Data)
 		return record
 	case cloudflare.UpdateDNSRecordParams:
 		record := cloudflare.DNSRecord{
@@ -144,15 +153,36 @@ func getDNSRecordFromRecordParams(rp any) cloudflare.DNSRecord {
 			Type:    params.Type,
 			Content: params.Content,
 		}
+		if params.Comment != nil {
+			record.Comment = *params.Comment
+		}
 		if params.Type == "MX" {
 			record.Priority = params.Priority
 		}
+		setDNSRecordData(&record, params.Data)
 		return record
 	default:
 		return cloudflare.DNSRecord{}
 	}
 }
 
+// setDNSRecordData sets the data of the record and its content, formatted like Cloudflare does.
+func setDNSRecordData(record *cloudflare.DNSRecord, data interface{}) {
+	if data == nil {
+		return
+	}
+	record.Data = data
+	if d, ok := data.(map[string]interface{}); ok {
+		params := strings.Fields(fmt.Sprint(d["value"]))
+		for i, param := range params {
+			if key, value, ok := strings.Cut(param, "="); ok {
+				params[i] = fmt.Sprintf("%s=%q", key, value)
+			}
+		}
+		record.Content = strings.TrimSpace(fmt.Sprintf("%v %v %s", d["priority"], d["target"], strings.Join(params, " ")))
+	}
+}
+
 func generateDNSRecordID(rrtype string, name string, content string) string {
 	return fmt.Sprintf("%s-%s-%s", name, rrtype, content)
 }
@@ -565,6 +595,134 @@ func TestCloudflareMx(t *testing.T) {
 	)
 }
 
+func TestCloudflareHTTPS(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		{
+			RecordType: endpoint.RecordTypeHTTPS,
+			DNSName:    "https.bar.com",
+			Targets:    endpoint.Targets{"1 . port=443 alpn=h3,h2"},
+		},
+	}
+
+	AssertActions(t, &CloudFlareProvider{}, endpoints, []MockAction{
+		{
+			Name:     "Create",
+			ZoneId:   "001",
+			RecordId: generateDNSRecordID(endpoint.RecordTypeHTTPS, "https.bar.com", `1 . alpn="h3,h2" port="443"`),
+			RecordData: cloudflare.DNSRecord{
+				ID:      generateDNSRecordID(endpoint.RecordTypeHTTPS, "https.bar.com", `1 . alpn="h3,h2" port="443"`),
+				Type:    endpoint.RecordTypeHTTPS,
+				Name:    "https.bar.com",
+				Content: `1 . alpn="h3,h2" port="443"`,
+				Data: map[string]interface{}{
+					"priority": uint16(1),
+					"target":   ".",
+					"value":    "alpn=h3,h2 port=443",
+				},
+				TTL:     1,
+				Proxied: proxyDisabled,
+			},
+		},
+	},
+		[]string{endpoint.RecordTypeHTTPS},
+	)
+}
+
+func TestCloudflareHTTPSRecords(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": {
+			{
+				ID:      "1234567890",
+				Name:    "https.bar.com",
+				Type:    endpoint.RecordTypeHTTPS,
+				TTL:     120,
+				Content: `1 . alpn="h3,h2" port="443"`,
+			},
+		},
+	})
+
+	provider := &CloudFlareProvider{Client: client}
+	records, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, endpoint.Targets{"1 . alpn=h3,h2 port=443"}, records[0].Targets)
+
+	// the same record with another representation is left unchanged
+	desired, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("https.bar.com", endpoint.RecordTypeHTTPS, 120, `1 . port=443 alpn="h3,h2"`),
+	})
+	require.NoError(t, err)
+	changes := (&plan.Plan{
+		Current:        records,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeHTTPS},
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+}
+
+func TestCloudflareCommentRegistryOwnership(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": {
+			{ID: "1", Name: "owned.bar.com", Type: endpoint.RecordTypeA, TTL: 120, Content: "1.2.3.4", Comment: "heritage=external-dns,external-dns/owner=owner"},
+			{ID: "2", Name: "foreign.bar.com", Type: endpoint.RecordTypeA, TTL: 120, Content: "1.2.3.4", Comment: "heritage=external-dns,external-dns/owner=other-owner"},
+			{ID: "3", Name: "manual.bar.com", Type: endpoint.RecordTypeA, TTL: 120, Content: "1.2.3.4", Comment: "managed by hand"},
+		},
+	})
+	r, err := registry.NewCommentRegistry(&CloudFlareProvider{Client: client}, "owner", annotations.CloudflareRecordCommentKey, MaxCommentLength)
+	require.NoError(t, err)
+
+	sync := func() *plan.Changes {
+		records, err := r.Records(t.Context())
+		require.NoError(t, err)
+		desired, err := r.AdjustEndpoints([]*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("new.bar.com", endpoint.RecordTypeA, 120, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "ingress/default/new"),
+			endpoint.NewEndpointWithTTL("owned.bar.com", endpoint.RecordTypeA, 120, "2.2.2.2"),
+			endpoint.NewEndpointWithTTL("foreign.bar.com", endpoint.RecordTypeA, 120, "2.2.2.2"),
+			endpoint.NewEndpointWithTTL("manual.bar.com", endpoint.RecordTypeA, 120, "2.2.2.2"),
+		})
+		require.NoError(t, err)
+		changes := (&plan.Plan{
+			Current:        records,
+			Desired:        desired,
+			OwnerID:        "owner",
+			ManagedRecords: []string{endpoint.RecordTypeA},
+		}).Calculate().Changes
+		require.NoError(t, r.ApplyChanges(t.Context(), changes))
+		return changes
+	}
+
+	changes := sync()
+	assert.Len(t, changes.Create, 1)
+	assert.Len(t, changes.UpdateNew, 1)
+
+	records := map[string]cloudflare.DNSRecord{}
+	for _, record := range client.Records["001"] {
+		records[record.Name] = record
+	}
+	assert.Equal(t, "5.6.7.8", records["new.bar.com"].Content)
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/new", records["new.bar.com"].Comment)
+	assert.Equal(t, "2.2.2.2", records["owned.bar.com"].Content)
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=owner", records["owned.bar.com"].Comment)
+	assert.Equal(t, "1.2.3.4", records["foreign.bar.com"].Content)
+	assert.Equal(t, "1.2.3.4", records["manual.bar.com"].Content)
+	assert.Equal(t, "managed by hand", records["manual.bar.com"].Comment)
+
+	// the ownership is read back from the comments
+	owned, err := r.Records(t.Context())
+	require.NoError(t, err)
+	for _, ep := range owned {
+		switch ep.DNSName {
+		case "new.bar.com", "owned.bar.com":
+			assert.Equal(t, "owner", ep.Labels[endpoint.OwnerLabelKey], ep.DNSName)
+		case "foreign.bar.com":
+			assert.Equal(t, "other-owner", ep.Labels[endpoint.OwnerLabelKey], ep.DNSName)
+		default:
+			assert.Empty(t, ep.Labels[endpoint.OwnerLabelKey], ep.DNSName)
+		}
+	}
+	assert.False(t, sync().HasChanges())
+}
+
 func TestCloudflareTxt(t *testing.T) {
 	endpoints := []*endpoint.Endpoint{
 		{
@@ -764,7 +922,7 @@ func TestCloudflareSetProxied(t *testing.T) {
 		{"CNAME", "bar.com", proxied},
 		{"TXT", "bar.com", notProxied},
 		{"MX", "bar.com", notProxied},
-		{"NS", "bar.com", notProxied},
+		{"NS", "sub.bar.com", notProxied},
 		{"SPF", "bar.com", notProxied},
 		{"SRV", "bar.com", notProxied},
 		{"A", "*.bar.com", proxied},
@@ -1053,6 +1211,7 @@ func TestCloudflareProvider(t *testing.T) {
 				RegionalServicesConfig{Enabled: false},
 				CustomHostnamesConfig{Enabled: false},
 				DNSRecordsConfig{PerPage: 5000, Comment: ""},
+				LoadBalancersConfig{Enabled: false},
 			)
 			if err != nil && !tc.ShouldFail {
 				t.Errorf("should not fail, %s", err)
@@ -1174,6 +1333,72 @@ func TestCloudflareApplyChangesError(t *testing.T) {
 	}
 }
 
+// flakyCloudFlareClient fails the creation of the records with the given errors before delegating to the mock.
+type flakyCloudFlareClient struct {
+	*mockCloudFlareClient
+	createErrors map[string][]error
+}
+
+func (c *flakyCloudFlareClient) CreateDNSRecord(ctx context.Context, rc *cloudflare.ResourceContainer, rp cloudflare.CreateDNSRecordParams) (cloudflare.DNSRecord, error) {
+	if errs := c.createErrors[rp.Name]; len(errs) > 0 {
+		c.createErrors[rp.Name] = errs[1:]
+		return cloudflare.DNSRecord{}, errs[0]
+	}
+	return c.mockCloudFlareClient.CreateDNSRecord(ctx, rc, rp)
+}
+
+func TestCloudflareApplyChangesRetry(t *testing.T) {
+	rateLimited := &cloudflare.Error{StatusCode: http.StatusTooManyRequests, Type: cloudflare.ErrorTypeRateLimit}
+	client := &flakyCloudFlareClient{
+		mockCloudFlareClient: NewMockCloudFlareClient(),
+		createErrors: map[string][]error{
+			"retried.bar.com": {rateLimited, &cloudflare.Error{StatusCode: http.StatusServiceUnavailable}},
+			"invalid.bar.com": {&cloudflare.Error{StatusCode: http.StatusBadRequest}, nil},
+		},
+	}
+	provider := &CloudFlareProvider{
+		Client:         client,
+		maxAttempts:    3,
+		retryBaseDelay: time.Millisecond,
+	}
+
+	err := provider.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("retried.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("invalid.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("other.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	})
+	// the permanent error is not retried and does not abort the other changes
+	require.EqualError(t, err, `failed to submit the changes of the following records: ["CREATE A invalid.bar.com"]`)
+	created := map[string]bool{}
+	for _, record := range client.Records["001"] {
+		created[record.Name] = true
+	}
+	assert.Equal(t, map[string]bool{"retried.bar.com": true, "other.bar.com": true}, created)
+}
+
+func TestCloudflareApplyChangesRetryExhausted(t *testing.T) {
+	rateLimited := &cloudflare.Error{StatusCode: http.StatusTooManyRequests, Type: cloudflare.ErrorTypeRateLimit}
+	client := &flakyCloudFlareClient{
+		mockCloudFlareClient: NewMockCloudFlareClient(),
+		createErrors: map[string][]error{
+			"limited.bar.com": {rateLimited, rateLimited, rateLimited},
+		},
+	}
+	provider := &CloudFlareProvider{
+		Client:         client,
+		maxAttempts:    2,
+		retryBaseDelay: time.Millisecond,
+	}
+
+	err := provider.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("limited.bar.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.EqualError(t, err, `failed to submit the changes of the following records: ["CREATE A limited.bar.com"]`)
+	assert.Len(t, client.createErrors["limited.bar.com"], 1, "the change is attempted maxAttempts times")
+}
+
 func TestCloudflareGetRecordID(t *testing.T) {
 	p := &CloudFlareProvider{}
 	recordsMap := DNSRecordsMap{
@@ -1765,6 +1990,7 @@ func TestCloudFlareProvider_Region(t *testing.T) {
 		RegionalServicesConfig{Enabled: false, RegionKey: "us"},
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50, Comment: ""},
+		LoadBalancersConfig{Enabled: false},
 	)
 	assert.NoError(t, err, "should not fail to create provider")
 	assert.True(t, provider.RegionalServicesConfig.Enabled, "expect regional services to be enabled")
@@ -1783,6 +2009,7 @@ func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 		RegionalServicesConfig{Enabled: true, RegionKey: "us"},
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50},
+		LoadBalancersConfig{Enabled: false},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1826,6 +2053,7 @@ func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 		RegionalServicesConfig{Enabled: true, RegionKey: "us"},
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50, Comment: paidValidCommentBuilder.String()},
+		LoadBalancersConfig{Enabled: false},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -2735,6 +2963,12 @@ func TestCloudflareApplyChanges_AllErrorLogPaths(t *testing.T) {
 	}
 }
 
+func TestCloudflareTTLLimits(t *testing.T) {
+	minTTL, maxTTL := (&CloudFlareProvider{}).TTLLimits()
+	assert.Equal(t, endpoint.TTL(0), minTTL)
+	assert.Equal(t, endpoint.TTL(86400), maxTTL)
+}
+
 func TestCloudFlareProvider_SupportedAdditionalRecordTypes(t *testing.T) {
 	provider := &CloudFlareProvider{}
 
@@ -2760,3 +2994,68 @@ func TestCloudFlareProvider_SupportedAdditionalRecordTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudflareProvenanceComment(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{
+		Client: client,
+		DNSRecordsConfig: DNSRecordsConfig{
+			Provenance: provider.ProvenanceConfig{Enabled: true, OwnerID: "default", ClusterName: "production"},
+		},
+	}
+	domainFilter := endpoint.NewDomainFilter([]string{"bar.com"})
+	desired := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			{
+				DNSName:    "new.bar.com",
+				Targets:    endpoint.Targets{"1.2.3.4"},
+				RecordType: endpoint.RecordTypeA,
+				RecordTTL:  endpoint.TTL(defaultTTL),
+				Labels:     endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/nginx"},
+			},
+			{
+				DNSName:          "annotated.bar.com",
+				Targets:          endpoint.Targets{"1.2.3.4"},
+				RecordType:       endpoint.RecordTypeA,
+				RecordTTL:        endpoint.TTL(defaultTTL),
+				Labels:           endpoint.Labels{endpoint.ResourceLabelKey: "service/default/nginx"},
+				ProviderSpecific: endpoint.ProviderSpecific{{Name: annotations.CloudflareRecordCommentKey, Value: "my comment"}},
+			},
+			{
+				DNSName:    "described.bar.com",
+				Targets:    endpoint.Targets{"1.2.3.4"},
+				RecordType: endpoint.RecordTypeA,
+				RecordTTL:  endpoint.TTL(defaultTTL),
+				Labels:     endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/checkout", endpoint.DescriptionLabelKey: "Checkout frontend"},
+			},
+		}
+	}
+	calculate := func() *plan.Changes {
+		records, err := p.Records(context.Background())
+		require.NoError(t, err)
+		endpoints, err := p.AdjustEndpoints(desired())
+		require.NoError(t, err)
+		return (&plan.Plan{
+			Current:        records,
+			Desired:        endpoints,
+			DomainFilter:   endpoint.MatchAllDomainFilters{domainFilter},
+			ManagedRecords: []string{endpoint.RecordTypeA},
+		}).Calculate().Changes
+	}
+
+	require.NoError(t, p.ApplyChanges(context.Background(), calculate()))
+
+	comments := map[string]string{}
+	for _, action := range client.Actions {
+		assert.Equal(t, "Create", action.Name)
+		comments[action.RecordData.Name] = action.RecordData.Comment
+	}
+	assert.Equal(t, map[string]string{
+		"new.bar.com":       "external-dns owner=default source=ingress cluster=production",
+		"annotated.bar.com": "my comment",
+		"described.bar.com": "Checkout frontend",
+	}, comments)
+
+	// the provenance comment doesn't trigger any further update
+	assert.False(t, calculate().HasChanges())
+}
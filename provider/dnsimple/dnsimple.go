@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -39,6 +40,15 @@ const (
 	dnsimpleUpdate = "UPDATE"
 
 	defaultTTL = 3600 // Default TTL of 1 hour if not set (DNSimple's default)
+
+	// dnsimpleRegionsProperty is the provider specific property of the comma separated regions
+	// the record is served from, e.g. SV1,IAD. Records without regions are served globally.
+	dnsimpleRegionsProperty = "dnsimple/regions"
+	dnsimpleGlobalRegion    = "global"
+
+	// recordTypeALIAS is the native record type of DNSimple aliasing the zone apex to a hostname,
+	// where CNAME records are not allowed
+	recordTypeALIAS = "ALIAS"
 )
 
 type dnsimpleIdentityService struct {
@@ -207,6 +217,10 @@ func (p *dnsimpleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, e
 				return nil, err
 			}
 			for _, record := range records.Data {
+				if record.Type == recordTypeALIAS && record.Name == "" {
+					// the apex aliases are managed as the CNAME records of the apex
+					record.Type = endpoint.RecordTypeCNAME
+				}
 				if record.Type != endpoint.RecordTypeA && record.Type != endpoint.RecordTypeCNAME && record.Type != endpoint.RecordTypeTXT {
 					continue
 				}
@@ -216,7 +230,11 @@ func (p *dnsimpleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, e
 				if record.Name == "" {
 					dnsName = record.ZoneID
 				}
-				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(dnsName, record.Type, endpoint.TTL(record.TTL), record.Content))
+				ep := endpoint.NewEndpointWithTTL(dnsName, record.Type, endpoint.TTL(record.TTL), record.Content)
+				if regions := normalizeDnsimpleRegions(record.Regions); len(regions) > 0 {
+					ep.WithProviderSpecific(dnsimpleRegionsProperty, strings.Join(regions, ","))
+				}
+				endpoints = append(endpoints, ep)
 			}
 			page++
 			if page > records.Pagination.TotalPages {
@@ -234,6 +252,12 @@ func newDnsimpleChange(action string, e *endpoint.Endpoint) *dnsimpleChange {
 		ttl = int(e.RecordTTL)
 	}
 
+	regions := dnsimpleRegions(e)
+	if len(regions) == 0 && action == dnsimpleUpdate {
+		// The regions are left unchanged when omitted from an update, reset them explicitly.
+		regions = []string{dnsimpleGlobalRegion}
+	}
+
 	change := &dnsimpleChange{
 		Action: action,
 		ResourceRecordSet: dnsimple.ZoneRecord{
@@ -241,11 +265,52 @@ func newDnsimpleChange(action string, e *endpoint.Endpoint) *dnsimpleChange {
 			Type:    e.RecordType,
 			Content: e.Targets[0],
 			TTL:     ttl,
+			Regions: regions,
 		},
 	}
 	return change
 }
 
+// dnsimpleRegions returns the regions of the regions provider specific property of the endpoint,
+// or nil when the record is served globally.
+func dnsimpleRegions(e *endpoint.Endpoint) []string {
+	value, ok := e.GetProviderSpecificProperty(dnsimpleRegionsProperty)
+	if !ok {
+		return nil
+	}
+	return normalizeDnsimpleRegions(strings.Split(value, ","))
+}
+
+// normalizeDnsimpleRegions returns the sorted and deduplicated regions, or nil when the record is served globally.
+func normalizeDnsimpleRegions(regions []string) []string {
+	var result []string
+	for _, region := range regions {
+		region = strings.TrimSpace(region)
+		if region == "" || region == dnsimpleGlobalRegion {
+			continue
+		}
+		result = append(result, region)
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// AdjustEndpoints normalizes the regions of the endpoints as they are returned by Records,
+// so that the plan only updates the records whose regions have actually changed.
+func (p *dnsimpleProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, e := range endpoints {
+		if _, ok := e.GetProviderSpecificProperty(dnsimpleRegionsProperty); !ok {
+			continue
+		}
+		if regions := dnsimpleRegions(e); len(regions) > 0 {
+			e.SetProviderSpecificProperty(dnsimpleRegionsProperty, strings.Join(regions, ","))
+		} else {
+			e.DeleteProviderSpecificProperty(dnsimpleRegionsProperty)
+		}
+	}
+	return endpoints, nil
+}
+
 // newDnsimpleChanges returns a slice of changes based on given action and record
 func newDnsimpleChanges(action string, endpoints []*endpoint.Endpoint) []*dnsimpleChange {
 	changes := make([]*dnsimpleChange, 0, len(endpoints))
@@ -276,6 +341,9 @@ func (p *dnsimpleProvider) submitChanges(ctx context.Context, changes []*dnsimpl
 
 		if change.ResourceRecordSet.Name == zone.Name {
 			change.ResourceRecordSet.Name = "" // Apex records have an empty name
+			if change.ResourceRecordSet.Type == endpoint.RecordTypeCNAME {
+				change.ResourceRecordSet.Type = recordTypeALIAS // CNAME records are not allowed at the apex
+			}
 		} else {
 			change.ResourceRecordSet.Name = strings.TrimSuffix(change.ResourceRecordSet.Name, fmt.Sprintf(".%s", zone.Name))
 		}
@@ -285,6 +353,7 @@ func (p *dnsimpleProvider) submitChanges(ctx context.Context, changes []*dnsimpl
 			Type:    change.ResourceRecordSet.Type,
 			Content: change.ResourceRecordSet.Content,
 			TTL:     change.ResourceRecordSet.TTL,
+			Regions: change.ResourceRecordSet.Regions,
 		}
 
 		if !p.dryRun {
@@ -295,7 +364,7 @@ func (p *dnsimpleProvider) submitChanges(ctx context.Context, changes []*dnsimpl
 					return err
 				}
 			case dnsimpleDelete:
-				recordID, err := p.GetRecordID(ctx, zone.Name, *recordAttributes.Name)
+				recordID, err := p.GetRecordID(ctx, zone.Name, *recordAttributes.Name, recordAttributes.Type)
 				if err != nil {
 					return err
 				}
@@ -304,7 +373,7 @@ func (p *dnsimpleProvider) submitChanges(ctx context.Context, changes []*dnsimpl
 					return err
 				}
 			case dnsimpleUpdate:
-				recordID, err := p.GetRecordID(ctx, zone.Name, *recordAttributes.Name)
+				recordID, err := p.GetRecordID(ctx, zone.Name, *recordAttributes.Name, recordAttributes.Type)
 				if err != nil {
 					return err
 				}
@@ -318,8 +387,8 @@ func (p *dnsimpleProvider) submitChanges(ctx context.Context, changes []*dnsimpl
 	return nil
 }
 
-// GetRecordID returns the record ID for a given record name and zone.
-func (p *dnsimpleProvider) GetRecordID(ctx context.Context, zone string, recordName string) (int64, error) {
+// GetRecordID returns the record ID for a given record name, record type and zone.
+func (p *dnsimpleProvider) GetRecordID(ctx context.Context, zone string, recordName string, recordType string) (int64, error) {
 	page := 1
 	listOptions := &dnsimple.ZoneRecordListOptions{Name: &recordName}
 	for {
@@ -330,7 +399,7 @@ func (p *dnsimpleProvider) GetRecordID(ctx context.Context, zone string, recordN
 		}
 
 		for _, record := range records.Data {
-			if record.Name == recordName {
+			if record.Name == recordName && record.Type == recordType {
 				return record.ID, nil
 			}
 		}
@@ -138,7 +138,9 @@ func TestDnsimpleServices(t *testing.T) {
 		mockDNS.On("ListRecords", context.Background(), "1", record.ZoneID, &dnsimple.ZoneRecordListOptions{Name: &recordName, ListOptions: dnsimple.ListOptions{Page: dnsimple.Int(1)}}).Return(&dnsimpleRecordResponse, nil)
 		mockDNS.On("CreateRecord", context.Background(), "1", record.ZoneID, simpleRecord).Return(&dnsimple.ZoneRecordResponse{}, nil)
 		mockDNS.On("DeleteRecord", context.Background(), "1", record.ZoneID, record.ID).Return(&dnsimple.ZoneRecordResponse{}, nil)
-		mockDNS.On("UpdateRecord", context.Background(), "1", record.ZoneID, record.ID, simpleRecord).Return(&dnsimple.ZoneRecordResponse{}, nil)
+		updatedRecord := simpleRecord
+		updatedRecord.Regions = []string{dnsimpleGlobalRegion}
+		mockDNS.On("UpdateRecord", context.Background(), "1", record.ZoneID, record.ID, updatedRecord).Return(&dnsimple.ZoneRecordResponse{}, nil)
 	}
 
 	mockProvider = dnsimpleProvider{client: mockDNS}
@@ -270,13 +272,143 @@ func testDnsimpleGetRecordID(t *testing.T) {
 	var err error
 
 	mockProvider.accountID = "1"
-	result, err = mockProvider.GetRecordID(context.Background(), "example.com", "example")
+	result, err = mockProvider.GetRecordID(context.Background(), "example.com", "example", "CNAME")
 	assert.NoError(t, err)
 	assert.Equal(t, int64(2), result)
 
-	result, err = mockProvider.GetRecordID(context.Background(), "example.com", "example-beta")
+	result, err = mockProvider.GetRecordID(context.Background(), "example.com", "example-beta", "A")
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), result)
+
+	_, err = mockProvider.GetRecordID(context.Background(), "example.com", "example-beta", "TXT")
+	assert.Error(t, err)
+}
+
+func TestDnsimpleRegions(t *testing.T) {
+	zonesResponse := &dnsimple.ZonesResponse{
+		Response: dnsimple.Response{Pagination: &dnsimple.Pagination{}},
+		Data:     []dnsimple.Zone{{ID: 1, AccountID: 12345, Name: "example.com"}},
+	}
+	regional := dnsimple.ZoneRecord{ID: 1, ZoneID: "example.com", Name: "regional", Content: "192.0.2.1", TTL: 3600, Type: "A", Regions: []string{"SV1", "IAD"}}
+	global := dnsimple.ZoneRecord{ID: 2, ZoneID: "example.com", Name: "global", Content: "192.0.2.2", TTL: 3600, Type: "A", Regions: []string{"global"}}
+	recordsResponse := func(records ...dnsimple.ZoneRecord) *dnsimple.ZoneRecordsResponse {
+		return &dnsimple.ZoneRecordsResponse{Response: dnsimple.Response{Pagination: &dnsimple.Pagination{}}, Data: records}
+	}
+
+	mockDNS := &mockDnsimpleZoneServiceInterface{}
+	mockDNS.On("ListZones", context.Background(), "1", &dnsimple.ZoneListOptions{ListOptions: dnsimple.ListOptions{Page: dnsimple.Int(1)}}).Return(zonesResponse, nil)
+	mockDNS.On("ListRecords", context.Background(), "1", "example.com", &dnsimple.ZoneRecordListOptions{ListOptions: dnsimple.ListOptions{Page: dnsimple.Int(1)}}).Return(recordsResponse(regional, global), nil)
+	mockDNS.On("ListRecords", context.Background(), "1", "example.com", &dnsimple.ZoneRecordListOptions{Name: &regional.Name, ListOptions: dnsimple.ListOptions{Page: dnsimple.Int(1)}}).Return(recordsResponse(regional), nil)
+	p := &dnsimpleProvider{client: mockDNS, accountID: "1"}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, record := range records {
+		regions, ok := record.GetProviderSpecificProperty(dnsimpleRegionsProperty)
+		switch record.DNSName {
+		case "regional.example.com":
+			assert.True(t, ok)
+			assert.Equal(t, "IAD,SV1", regions)
+		case "global.example.com":
+			assert.False(t, ok, "global records have no regions")
+		}
+	}
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("regional.example.com", endpoint.RecordTypeA, "192.0.2.1").WithProviderSpecific(dnsimpleRegionsProperty, "SV1, IAD,SV1"),
+		endpoint.NewEndpoint("global.example.com", endpoint.RecordTypeA, "192.0.2.2").WithProviderSpecific(dnsimpleRegionsProperty, "global"),
+	})
+	require.NoError(t, err)
+	regions, _ := adjusted[0].GetProviderSpecificProperty(dnsimpleRegionsProperty)
+	assert.Equal(t, "IAD,SV1", regions)
+	_, ok := adjusted[1].GetProviderSpecificProperty(dnsimpleRegionsProperty)
+	assert.False(t, ok)
+
+	created, regional2 := "created", "regional"
+	mockDNS.On("CreateRecord", context.Background(), "1", "example.com", dnsimple.ZoneRecordAttributes{
+		Name: &created, Type: "A", Content: "192.0.2.3", TTL: defaultTTL, Regions: []string{"AMS", "SV1"},
+	}).Return(&dnsimple.ZoneRecordResponse{}, nil).Once()
+	mockDNS.On("UpdateRecord", context.Background(), "1", "example.com", regional.ID, dnsimple.ZoneRecordAttributes{
+		Name: &regional2, Type: "A", Content: "192.0.2.1", TTL: defaultTTL, Regions: []string{dnsimpleGlobalRegion},
+	}).Return(&dnsimple.ZoneRecordResponse{}, nil).Once()
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("created.example.com", endpoint.RecordTypeA, "192.0.2.3").WithProviderSpecific(dnsimpleRegionsProperty, "SV1,AMS"),
+		},
+		// the regions of the record are removed
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("regional.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		},
+	})
+	require.NoError(t, err)
+	mockDNS.AssertExpectations(t)
+}
+
+func TestDnsimpleApexAlias(t *testing.T) {
+	zonesResponse := &dnsimple.ZonesResponse{
+		Response: dnsimple.Response{Pagination: &dnsimple.Pagination{}},
+		Data:     []dnsimple.Zone{{ID: 1, AccountID: 12345, Name: "example.com"}},
+	}
+	apex := dnsimple.ZoneRecord{ID: 1, ZoneID: "example.com", Name: "", Content: "lb.example.net", TTL: 3600, Type: "ALIAS"}
+	other := dnsimple.ZoneRecord{ID: 2, ZoneID: "example.com", Name: "www", Content: "lb.example.net", TTL: 3600, Type: "ALIAS"}
+
+	mockDNS := &mockDnsimpleZoneServiceInterface{}
+	mockDNS.On("ListZones", context.Background(), "1", &dnsimple.ZoneListOptions{ListOptions: dnsimple.ListOptions{Page: dnsimple.Int(1)}}).Return(zonesResponse, nil)
+	mockDNS.On("ListRecords", context.Background(), "1", "example.com", &dnsimple.ZoneRecordListOptions{ListOptions: dnsimple.ListOptions{Page: dnsimple.Int(1)}}).Return(&dnsimple.ZoneRecordsResponse{
+		Response: dnsimple.Response{Pagination: &dnsimple.Pagination{}},
+		Data:     []dnsimple.ZoneRecord{apex, other},
+	}, nil)
+	p := &dnsimpleProvider{client: mockDNS, accountID: "1"}
+
+	// the apex alias is read as the CNAME record of the apex, the other aliases are not managed
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "example.com", records[0].DNSName)
+		assert.Equal(t, endpoint.RecordTypeCNAME, records[0].RecordType)
+		assert.Equal(t, endpoint.Targets{"lb.example.net"}, records[0].Targets)
+	}
+
+	// the CNAME record of the apex is created as an alias
+	apexName, wwwName := "", "www"
+	mockDNS.On("CreateRecord", context.Background(), "1", "example.com", dnsimple.ZoneRecordAttributes{
+		Name: &apexName, Type: "ALIAS", Content: "lb.example.net", TTL: defaultTTL,
+	}).Return(&dnsimple.ZoneRecordResponse{}, nil).Once()
+	mockDNS.On("CreateRecord", context.Background(), "1", "example.com", dnsimple.ZoneRecordAttributes{
+		Name: &wwwName, Type: "CNAME", Content: "lb.example.net", TTL: defaultTTL,
+	}).Return(&dnsimple.ZoneRecordResponse{}, nil).Once()
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+		},
+	})
+	require.NoError(t, err)
+
+	// the alias and the TXT record of the apex are updated by their own record ID
+	txt := dnsimple.ZoneRecord{ID: 3, ZoneID: "example.com", Name: "", Content: "\"heritage=external-dns\"", TTL: 3600, Type: "TXT"}
+	mockDNS.On("ListRecords", context.Background(), "1", "example.com", &dnsimple.ZoneRecordListOptions{Name: &apexName, ListOptions: dnsimple.ListOptions{Page: dnsimple.Int(1)}}).Return(&dnsimple.ZoneRecordsResponse{
+		Response: dnsimple.Response{Pagination: &dnsimple.Pagination{}},
+		Data:     []dnsimple.ZoneRecord{txt, apex},
+	}, nil)
+	mockDNS.On("UpdateRecord", context.Background(), "1", "example.com", apex.ID, dnsimple.ZoneRecordAttributes{
+		Name: &apexName, Type: "ALIAS", Content: "lb2.example.net", TTL: defaultTTL, Regions: []string{dnsimpleGlobalRegion},
+	}).Return(&dnsimple.ZoneRecordResponse{}, nil).Once()
+	mockDNS.On("UpdateRecord", context.Background(), "1", "example.com", txt.ID, dnsimple.ZoneRecordAttributes{
+		Name: &apexName, Type: "TXT", Content: "\"heritage=external-dns,external-dns/owner=default\"", TTL: defaultTTL, Regions: []string{dnsimpleGlobalRegion},
+	}).Return(&dnsimple.ZoneRecordResponse{}, nil).Once()
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb2.example.net"),
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=default\""),
+		},
+	})
+	require.NoError(t, err)
+	mockDNS.AssertExpectations(t)
 }
 
 func validateDnsimpleZones(t *testing.T, zones map[string]dnsimple.Zone, expected []dnsimple.Zone) {
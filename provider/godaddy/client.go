@@ -39,6 +39,9 @@ const (
 
 	// DefaultTimeout api requests after
 	DefaultTimeout = 180 * time.Second
+
+	// DefaultRateLimit is the number of requests per minute allowed by GoDaddy
+	DefaultRateLimit = 60
 )
 
 // Errors
@@ -80,7 +83,7 @@ type Client struct {
 	// Client is the underlying HTTP client used to run the requests. It may be overloaded but a default one is instanciated in ``NewClient`` by default.
 	Client *http.Client
 
-	// GoDaddy limits to 60 requests per minute
+	// GoDaddy limits to 60 requests per minute by default
 	Ratelimiter *rate.Limiter
 
 	// Logger is used to log HTTP requests and responses.
@@ -112,10 +115,14 @@ func (r GDErrorResponse) String() string {
 	return "<error>"
 }
 
-// NewClient represents a new client to call the API
-func NewClient(useOTE bool, apiKey, apiSecret string) (*Client, error) {
+// NewClient represents a new client to call the API, sending at most rateLimit requests per minute
+func NewClient(useOTE bool, apiKey, apiSecret string, rateLimit int) (*Client, error) {
 	var endpoint string
 
+	if rateLimit <= 0 {
+		rateLimit = DefaultRateLimit
+	}
+
 	if useOTE {
 		endpoint = "https://api.ote-godaddy.com"
 	} else {
@@ -127,8 +134,8 @@ func NewClient(useOTE bool, apiKey, apiSecret string) (*Client, error) {
 		APISecret:   apiSecret,
 		APIEndPoint: endpoint,
 		Client:      &http.Client{},
-		// Add one token every second
-		Ratelimiter: rate.NewLimiter(rate.Every(time.Second), 60),
+		// Spread the requests over the minute, allowing a burst of a full minute
+		Ratelimiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(rateLimit)), rateLimit),
 		Timeout:     DefaultTimeout,
 	}
 
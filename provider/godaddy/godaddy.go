@@ -95,6 +95,8 @@ type gdRecords struct {
 	records []gdRecordField
 	changed bool
 	zone    string
+	// pending holds the records to add to the zone in a single PATCH call
+	pending []gdRecordField
 }
 
 type gdZone struct {
@@ -137,8 +139,8 @@ func (z gdZoneIDName) findZoneRecord(hostname string) (string, *gdRecords) {
 }
 
 // NewGoDaddyProvider initializes a new GoDaddy DNS based Provider.
-func NewGoDaddyProvider(_ context.Context, domainFilter *endpoint.DomainFilter, ttl int64, apiKey, apiSecret string, useOTE, dryRun bool) (*GDProvider, error) {
-	client, err := NewClient(useOTE, apiKey, apiSecret)
+func NewGoDaddyProvider(_ context.Context, domainFilter *endpoint.DomainFilter, ttl int64, apiKey, apiSecret string, apiRateLimit int, useOTE, dryRun bool) (*GDProvider, error) {
+	client, err := NewClient(useOTE, apiKey, apiSecret, apiRateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -365,6 +367,12 @@ func (p *GDProvider) changeAllRecords(endpoints []gdEndpoint, zoneRecords []*gdR
 		}
 	}
 
+	for _, zoneRecord := range zoneRecords {
+		if err := zoneRecord.addPendingRecords(p.client, p.DryRun); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -439,8 +447,8 @@ func (p *GDProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) er
 	return nil
 }
 
-func (p *gdRecords) addRecord(client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
-	var response GDErrorResponse
+// Queue the records to add, they are sent at once by addPendingRecords
+func (p *gdRecords) addRecord(_ gdClient, endpoint endpoint.Endpoint, dnsName string, _ bool) error {
 	for _, target := range endpoint.Targets {
 		change := gdRecordField{
 			Type: endpoint.RecordType,
@@ -450,16 +458,37 @@ func (p *gdRecords) addRecord(client gdClient, endpoint endpoint.Endpoint, dnsNa
 		}
 
 		p.records = append(p.records, change)
+		p.pending = append(p.pending, change)
 		p.changed = true
 
 		log.Debugf("GoDaddy: Add an entry %s to zone %s", change.String(), p.zone)
-		if dryRun {
-			log.Infof("[DryRun] - Add record %s.%s of type %s %s", change.Name, p.zone, change.Type, toString(change))
-		} else if err := client.Patch(fmt.Sprintf("/v1/domains/%s/records", p.zone), []gdRecordField{change}, &response); err != nil {
-			log.Errorf("Add record %s.%s of type %s failed: %s", change.Name, p.zone, change.Type, response)
+	}
 
-			return err
+	return nil
+}
+
+// Add all the queued records of the zone with a single PATCH call
+func (p *gdRecords) addPendingRecords(client gdClient, dryRun bool) error {
+	if len(p.pending) == 0 {
+		return nil
+	}
+
+	pending := p.pending
+	p.pending = nil
+
+	if dryRun {
+		for _, change := range pending {
+			log.Infof("[DryRun] - Add record %s.%s of type %s %s", change.Name, p.zone, change.Type, toString(change))
 		}
+
+		return nil
+	}
+
+	var response GDErrorResponse
+	if err := client.Patch(fmt.Sprintf("/v1/domains/%s/records", p.zone), pending, &response); err != nil {
+		log.Errorf("Add %d records to zone %s failed: %s", len(pending), p.zone, response)
+
+		return err
 	}
 
 	return nil
@@ -545,6 +574,22 @@ func (p *gdRecords) deleteRecord(client gdClient, endpoint endpoint.Endpoint, dn
 		}
 	}
 
+	// Only the records matching the targets are removed, the other ones of the same name and type are kept
+	remaining := []gdReplaceRecordField{}
+	for _, record := range p.records {
+		if record.Type == endpoint.RecordType && record.Name == dnsName {
+			remaining = append(remaining, gdReplaceRecordField{
+				Data:     record.Data,
+				TTL:      record.TTL,
+				Port:     record.Port,
+				Priority: record.Priority,
+				Weight:   record.Weight,
+				Protocol: record.Protocol,
+				Service:  record.Service,
+			})
+		}
+	}
+
 	if dryRun {
 		log.Infof("[DryRun] - Delete record %s.%s of type %s %s", dnsName, p.zone, endpoint.RecordType, records)
 
@@ -552,6 +597,17 @@ func (p *gdRecords) deleteRecord(client gdClient, endpoint endpoint.Endpoint, dn
 	}
 
 	var response GDErrorResponse
+	if len(remaining) > 0 {
+		log.Debugf("Delete record %s.%s of type %s %s, keeping %d records", dnsName, p.zone, endpoint.RecordType, records, len(remaining))
+		if err := client.Put(fmt.Sprintf("/v1/domains/%s/records/%s/%s", p.zone, endpoint.RecordType, dnsName), remaining, &response); err != nil {
+			log.Errorf("Delete record %s.%s of type %s failed: %v", dnsName, p.zone, endpoint.RecordType, response)
+
+			return err
+		}
+
+		return nil
+	}
+
 	if err := client.Delete(fmt.Sprintf("/v1/domains/%s/records/%s/%s", p.zone, endpoint.RecordType, dnsName), &response); err != nil {
 		log.Errorf("Delete record %s.%s of type %s failed: %v", dnsName, p.zone, endpoint.RecordType, response)
 
@@ -20,13 +20,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
 )
@@ -390,6 +396,182 @@ func TestGoDaddyChange(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestGoDaddyChangeBatched(t *testing.T) {
+	assert := assert.New(t)
+	client := newMockGoDaddyClient(t)
+	provider := &GDProvider{
+		client: client,
+	}
+
+	changes := plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{
+				DNSName:    "new.example.net",
+				RecordType: "A",
+				RecordTTL:  defaultTTL,
+				Targets: []string{
+					"203.0.113.44",
+					"203.0.113.45",
+				},
+			},
+			{
+				DNSName:    "www.example.net",
+				RecordType: "CNAME",
+				RecordTTL:  defaultTTL,
+				Targets: []string{
+					"example.net",
+				},
+			},
+		},
+		Delete: []*endpoint.Endpoint{
+			{
+				DNSName:    "godaddy.example.net",
+				RecordType: "A",
+				Targets: []string{
+					"203.0.113.43",
+				},
+			},
+		},
+	}
+
+	client.On("Get", domainsURI).Return([]gdZone{
+		{
+			Domain: zoneNameExampleNet,
+		},
+	}, nil).Once()
+
+	client.On("Get", "/v1/domains/example.net/records").Return([]gdRecordField{
+		{
+			Name: "godaddy",
+			Type: "A",
+			TTL:  defaultTTL,
+			Data: "203.0.113.42",
+		},
+		{
+			Name: "godaddy",
+			Type: "A",
+			TTL:  defaultTTL,
+			Data: "203.0.113.43",
+		},
+	}, nil).Once()
+
+	// The remaining target of the name and type is kept
+	client.On("Put", "/v1/domains/example.net/records/A/godaddy", []gdReplaceRecordField{
+		{
+			Data: "203.0.113.42",
+			TTL:  defaultTTL,
+		},
+	}).Return(nil, nil).Once()
+
+	// All the new records are added at once
+	client.On("Patch", "/v1/domains/example.net/records", []gdRecordField{
+		{
+			Name: "new",
+			Type: "A",
+			TTL:  defaultTTL,
+			Data: "203.0.113.44",
+		},
+		{
+			Name: "new",
+			Type: "A",
+			TTL:  defaultTTL,
+			Data: "203.0.113.45",
+		},
+		{
+			Name: "www",
+			Type: "CNAME",
+			TTL:  defaultTTL,
+			Data: "example.net",
+		},
+	}).Return(nil, nil).Once()
+
+	assert.NoError(provider.ApplyChanges(context.TODO(), &changes))
+
+	client.AssertExpectations(t)
+}
+
+func TestGoDaddyChangeAPICalls(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains":
+			_, _ = w.Write([]byte(`[{"domain": "example.net"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains/example.net/records":
+			records := []gdRecordField{}
+			for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+				records = append(records, gdRecordField{Name: name, Type: "A", TTL: defaultTTL, Data: "203.0.113.42"})
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(records))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	provider := &GDProvider{
+		client: &Client{
+			APIEndPoint: server.URL,
+			Client:      &http.Client{},
+			Ratelimiter: rate.NewLimiter(rate.Every(time.Second), DefaultRateLimit),
+			Timeout:     DefaultTimeout,
+		},
+	}
+
+	for _, tc := range []struct {
+		title   string
+		changes *plan.Changes
+		call    string
+	}{
+		{
+			title: "create",
+			changes: &plan.Changes{
+				Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.net", "A", "203.0.113.43")},
+			},
+			call: `PATCH /v1/domains/example.net/records [{"data":"203.0.113.43","name":"new","ttl":600,"type":"A"}]`,
+		},
+		{
+			title: "update",
+			changes: &plan.Changes{
+				UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("c.example.net", "A", "203.0.113.42")},
+				UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("c.example.net", "A", "203.0.113.43")},
+			},
+			call: `PUT /v1/domains/example.net/records/A/c [{"data":"203.0.113.43","ttl":600}]`,
+		},
+		{
+			title: "delete",
+			changes: &plan.Changes{
+				Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("h.example.net", "A", "203.0.113.42")},
+			},
+			call: "DELETE /v1/domains/example.net/records/A/h ",
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			calls = nil
+
+			require.NoError(t, provider.ApplyChanges(context.Background(), tc.changes))
+
+			// Listing the zones and their records, then a single call for the changed record
+			assert.Equal(t, []string{
+				"GET /v1/domains ",
+				"GET /v1/domains/example.net/records ",
+				tc.call,
+			}, calls)
+		})
+	}
+}
+
 const (
 	operationFailedTestErrCode = "GD500"
 	operationFailedTestReason  = "Could not apply request"
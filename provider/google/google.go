@@ -18,6 +18,7 @@ package google
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
@@ -108,6 +109,8 @@ type GoogleProvider struct {
 	batchChangeSize int
 	// Interval between batch updates.
 	batchChangeInterval time.Duration
+	// Retry the changes of a failed batch one record name at a time, continuing past the failed ones.
+	continueOnError bool
 	// only consider hosted zones managing domains ending in this suffix
 	domainFilter *endpoint.DomainFilter
 	// filter for zones based on visibility
@@ -125,7 +128,7 @@ type GoogleProvider struct {
 }
 
 // NewGoogleProvider initializes a new Google CloudDNS based Provider.
-func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool) (*GoogleProvider, error) {
+func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool, continueOnError bool) (*GoogleProvider, error) {
 	gcloud, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, err
@@ -154,6 +157,7 @@ func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoi
 		dryRun:                   dryRun,
 		batchChangeSize:          batchChangeSize,
 		batchChangeInterval:      batchChangeInterval,
+		continueOnError:          continueOnError,
 		domainFilter:             domainFilter,
 		zoneTypeFilter:           zoneTypeFilter,
 		zoneIDFilter:             zoneIDFilter,
@@ -241,7 +245,21 @@ func (p *GoogleProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 
 	change.Deletions = append(change.Deletions, p.newFilteredRecords(changes.Delete)...)
 
-	return p.submitChange(ctx, change)
+	return p.submitChange(ctx, change, ownedRecordNames(changes))
+}
+
+// ownedRecordNames returns the names of the records owned by the TXT registry records of the changes,
+// by the names of these TXT records, so that a record and its ownership record are changed together.
+func ownedRecordNames(changes *plan.Changes) map[string]string {
+	owned := map[string]string{}
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew, changes.UpdateOld, changes.Delete} {
+		for _, ep := range endpoints {
+			if ownedRecord, ok := ep.Labels[endpoint.OwnedRecordLabelKey]; ok && ownedRecord != "" {
+				owned[provider.EnsureTrailingDot(ep.DNSName)] = provider.EnsureTrailingDot(ownedRecord)
+			}
+		}
+	}
+	return owned
 }
 
 // SupportedRecordType returns true if the record type is supported by the provider
@@ -268,7 +286,7 @@ func (p *GoogleProvider) newFilteredRecords(endpoints []*endpoint.Endpoint) []*d
 }
 
 // submitChange takes a zone and a Change and sends it to Google.
-func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) error {
+func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change, ownedRecords map[string]string) error {
 	if len(change.Additions) == 0 && len(change.Deletions) == 0 {
 		log.Info("All records are already up to date")
 		return nil
@@ -282,6 +300,7 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 	// separate into per-zone change sets to be passed to the API.
 	changes := separateChange(zones, change)
 
+	var errs []error
 	for zone, change := range changes {
 		for batch, c := range batchChange(change, p.batchChangeSize) {
 			log.Infof("Change zone: %v batch #%d", zone, batch)
@@ -297,16 +316,87 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 			}
 
 			if _, err := p.changesClient.Create(p.project, zone, c).Do(); err != nil {
-				return provider.NewSoftError(fmt.Errorf("failed to create changes: %w", err))
+				if !p.continueOnError {
+					return provider.NewSoftError(fmt.Errorf("failed to create changes: %w", err))
+				}
+				log.Warnf("Failed to change zone %v batch #%d, applying its changes one record name at a time: %v", zone, batch, err)
+				errs = append(errs, p.submitChangeByName(zone, c, ownedRecords)...)
 			}
 
 			time.Sleep(p.batchChangeInterval)
 		}
 	}
 
+	if len(errs) > 0 {
+		return provider.NewSoftError(fmt.Errorf("failed to create changes: %w", errors.Join(errs...)))
+	}
 	return nil
 }
 
+// submitChangeByName submits the changes of each record name of a zone in its own transaction,
+// keeping the additions and deletions of a name and of its ownership records together, and returns
+// the errors of the failed ones. The transactions are spaced by the batch change interval.
+func (p *GoogleProvider) submitChangeByName(zone string, change *dns.Change, ownedRecords map[string]string) []error {
+	var errs []error
+	for i, c := range splitChangeByName(change, ownedRecords) {
+		if i > 0 {
+			time.Sleep(p.batchChangeInterval)
+		}
+		name := ""
+		if len(c.Additions) > 0 {
+			name = c.Additions[0].Name
+		} else {
+			name = c.Deletions[0].Name
+		}
+		if owned, ok := ownedRecords[name]; ok {
+			name = owned
+		}
+		if _, err := p.changesClient.Create(p.project, zone, c).Do(); err != nil {
+			log.Errorf("Failed to change records %s in zone %v: %v", name, zone, err)
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// splitChangeByName separates a change in one change per record name, sorted by name.
+// The ownership records are included in the change of the record they own.
+func splitChangeByName(change *dns.Change, ownedRecords map[string]string) []*dns.Change {
+	changesByName := map[string]*dns.Change{}
+	nameOf := func(r *dns.ResourceRecordSet) string {
+		if owned, ok := ownedRecords[r.Name]; ok {
+			return owned
+		}
+		return r.Name
+	}
+	for _, a := range change.Additions {
+		name := nameOf(a)
+		if _, ok := changesByName[name]; !ok {
+			changesByName[name] = &dns.Change{}
+		}
+		changesByName[name].Additions = append(changesByName[name].Additions, a)
+	}
+	for _, d := range change.Deletions {
+		name := nameOf(d)
+		if _, ok := changesByName[name]; !ok {
+			changesByName[name] = &dns.Change{}
+		}
+		changesByName[name].Deletions = append(changesByName[name].Deletions, d)
+	}
+
+	names := make([]string, 0, len(changesByName))
+	for name := range changesByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changes := make([]*dns.Change, 0, len(names))
+	for _, name := range names {
+		changes = append(changes, changesByName[name])
+	}
+	return changes
+}
+
 // batchChange separates a zone in multiple transaction.
 func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 	var changes []*dns.Change
@@ -127,9 +127,10 @@ func (m *mockResourceRecordSetsClient) List(project string, managedZone string)
 }
 
 type mockChangesCreateCall struct {
-	project     string
-	managedZone string
-	change      *dns.Change
+	project        string
+	managedZone    string
+	change         *dns.Change
+	failedDeletion string
 }
 
 func (m *mockChangesCreateCall) Do(opts ...googleapi.CallOption) (*dns.Change, error) {
@@ -143,6 +144,15 @@ func (m *mockChangesCreateCall) Do(opts ...googleapi.CallOption) (*dns.Change, e
 		testRecords[zoneKey] = make(map[string]*dns.ResourceRecordSet)
 	}
 
+	for _, del := range m.change.Deletions {
+		if del.Name == m.failedDeletion {
+			return nil, &googleapi.Error{
+				Code:    http.StatusPreconditionFailed,
+				Message: fmt.Sprintf("deletion does not match: %v", del),
+			}
+		}
+	}
+
 	for _, c := range append(m.change.Additions, m.change.Deletions...) {
 		if !isValidRecordSet(c) {
 			return nil, &googleapi.Error{
@@ -165,10 +175,13 @@ func (m *mockChangesCreateCall) Do(opts ...googleapi.CallOption) (*dns.Change, e
 	return m.change, nil
 }
 
-type mockChangesClient struct{}
+type mockChangesClient struct {
+	// the changes deleting the records of this name fail
+	failedDeletion string
+}
 
 func (m *mockChangesClient) Create(project string, managedZone string, change *dns.Change) changesCreateCallInterface {
-	return &mockChangesCreateCall{project: project, managedZone: managedZone, change: change}
+	return &mockChangesCreateCall{project: project, managedZone: managedZone, change: change, failedDeletion: m.failedDeletion}
 }
 
 func zoneKey(project, zoneName string) string {
@@ -403,6 +416,65 @@ func TestGoogleApplyChanges(t *testing.T) {
 	})
 }
 
+func TestGoogleApplyChangesContinueOnError(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		continueOnError bool
+		expected        []*endpoint.Endpoint
+	}{
+		{
+			name: "the batch fails",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("delete-1.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+				endpoint.NewEndpointWithTTL("delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+				endpoint.NewEndpointWithTTL("a-delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeTXT, defaultTTL, "heritage=external-dns"),
+				endpoint.NewEndpointWithTTL("delete-3.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+				endpoint.NewEndpointWithTTL("update.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+			},
+		},
+		{
+			name:            "the other changes are applied, keeping the failed ones along with their ownership records",
+			continueOnError: true,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+				endpoint.NewEndpointWithTTL("a-delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeTXT, defaultTTL, "heritage=external-dns"),
+				endpoint.NewEndpointWithTTL("update.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+				endpoint.NewEndpointWithTTL("create.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{""}), false, []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("delete-1.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+				endpoint.NewEndpointWithTTL("delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+				endpoint.NewEndpointWithTTL("a-delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeTXT, defaultTTL, "heritage=external-dns"),
+				endpoint.NewEndpointWithTTL("delete-3.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+				endpoint.NewEndpointWithTTL("update.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+			}, nil, nil)
+			p.continueOnError = tc.continueOnError
+			p.changesClient = &mockChangesClient{failedDeletion: "delete-2.zone-1.ext-dns-test-2.gcp.zalan.do."}
+
+			err := p.ApplyChanges(context.Background(), &plan.Changes{
+				Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("create.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8")},
+				UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("update.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8")},
+				UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("update.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "1.2.3.4")},
+				Delete: []*endpoint.Endpoint{
+					endpoint.NewEndpoint("delete-1.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
+					endpoint.NewEndpoint("delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
+					endpoint.NewEndpoint("a-delete-2.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeTXT, "heritage=external-dns").WithLabel(endpoint.OwnedRecordLabelKey, "delete-2.zone-1.ext-dns-test-2.gcp.zalan.do"),
+					endpoint.NewEndpoint("delete-3.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
+				},
+			})
+			require.ErrorIs(t, err, provider.SoftError)
+			assert.Contains(t, err.Error(), "delete-2.zone-1.ext-dns-test-2.gcp.zalan.do.")
+
+			records, err := p.Records(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, records, tc.expected)
+		})
+	}
+}
+
 func TestGoogleApplyChangesDryRun(t *testing.T) {
 	originalEndpoints := []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("update-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
@@ -823,7 +895,7 @@ func clearGoogleRecords(t *testing.T, provider *GoogleProvider, zone string) {
 	provider.resourceRecordSetsClient.List(provider.project, zone).Pages(context.Background(), func(resp *dns.ResourceRecordSetsListResponse) error {
 		for _, r := range resp.Rrsets {
 			switch r.Type {
-			case endpoint.RecordTypeA, endpoint.RecordTypeCNAME:
+			case endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT:
 				recordSets = append(recordSets, r)
 			}
 		}
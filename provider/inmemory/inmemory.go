@@ -49,6 +49,9 @@ type InMemoryProvider struct {
 	domain         endpoint.DomainFilterInterface
 	client         *inMemoryClient
 	filter         *filter
+	defaultTTL     endpoint.TTL
+	minTTL         endpoint.TTL
+	maxTTL         endpoint.TTL
 	OnApplyChanges func(ctx context.Context, changes *plan.Changes)
 	OnRecords      func()
 }
@@ -83,6 +86,21 @@ func InMemoryWithDomain(domainFilter *endpoint.DomainFilter) InMemoryOption {
 	}
 }
 
+// InMemoryWithDefaultTTL sets the TTL of the records created or updated without TTL, like the default TTL of the real providers
+func InMemoryWithDefaultTTL(ttl endpoint.TTL) InMemoryOption {
+	return func(p *InMemoryProvider) {
+		p.defaultTTL = ttl
+	}
+}
+
+// InMemoryWithTTLLimits clamps the TTLs of the records to the given limits, 0 meaning no limit
+func InMemoryWithTTLLimits(minTTL, maxTTL endpoint.TTL) InMemoryOption {
+	return func(p *InMemoryProvider) {
+		p.minTTL = minTTL
+		p.maxTTL = maxTTL
+	}
+}
+
 // InMemoryInitZones pre-seeds the InMemoryProvider with given zones
 func InMemoryInitZones(zones []string) InMemoryOption {
 	return func(p *InMemoryProvider) {
@@ -159,14 +177,14 @@ func (im *InMemoryProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 		if zoneID == "" {
 			continue
 		}
-		perZoneChanges[zoneID].Create = append(perZoneChanges[zoneID].Create, ep)
+		perZoneChanges[zoneID].Create = append(perZoneChanges[zoneID].Create, im.withTTL(ep))
 	}
 	for _, ep := range changes.UpdateNew {
 		zoneID := im.filter.EndpointZoneID(ep, zones)
 		if zoneID == "" {
 			continue
 		}
-		perZoneChanges[zoneID].UpdateNew = append(perZoneChanges[zoneID].UpdateNew, ep)
+		perZoneChanges[zoneID].UpdateNew = append(perZoneChanges[zoneID].UpdateNew, im.withTTL(ep))
 	}
 	for _, ep := range changes.UpdateOld {
 		zoneID := im.filter.EndpointZoneID(ep, zones)
@@ -199,6 +217,33 @@ func (im *InMemoryProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 	return nil
 }
 
+// TTLLimits returns the TTL limits set with InMemoryWithTTLLimits
+func (im *InMemoryProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return im.minTTL, im.maxTTL
+}
+
+// withTTL returns the endpoint with the default TTL when it has none, and its TTL clamped to the limits
+func (im *InMemoryProvider) withTTL(ep *endpoint.Endpoint) *endpoint.Endpoint {
+	ttl := ep.RecordTTL
+	if !ttl.IsConfigured() {
+		ttl = im.defaultTTL
+	}
+	if ttl.IsConfigured() {
+		if im.minTTL > 0 && ttl < im.minTTL {
+			ttl = im.minTTL
+		}
+		if im.maxTTL > 0 && ttl > im.maxTTL {
+			ttl = im.maxTTL
+		}
+	}
+	if ttl == ep.RecordTTL {
+		return ep
+	}
+	ep = ep.DeepCopy()
+	ep.RecordTTL = ttl
+	return ep
+}
+
 func copyEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
 	records := make([]*endpoint.Endpoint, 0, len(endpoints))
 	for _, ep := range endpoints {
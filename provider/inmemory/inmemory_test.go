@@ -36,6 +36,7 @@ func TestInMemoryProvider(t *testing.T) {
 	t.Run("ApplyChanges", testInMemoryApplyChanges)
 	t.Run("NewInMemoryProvider", testNewInMemoryProvider)
 	t.Run("CreateZone", testInMemoryCreateZone)
+	t.Run("TTL", testInMemoryTTL)
 }
 
 func testInMemoryRecords(t *testing.T) {
@@ -573,6 +574,48 @@ func testInMemoryCreateZone(t *testing.T) {
 	require.EqualError(t, err, ErrZoneAlreadyExists.Error())
 }
 
+func testInMemoryTTL(t *testing.T) {
+	im := NewInMemoryProvider(InMemoryInitZones([]string{"org"}), InMemoryWithDefaultTTL(300), InMemoryWithTTLLimits(60, 86400))
+	minTTL, maxTTL := im.TTLLimits()
+	assert.Equal(t, endpoint.TTL(60), minTTL)
+	assert.Equal(t, endpoint.TTL(86400), maxTTL)
+
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("default.org", endpoint.RecordTypeA, "8.8.8.8"),
+			endpoint.NewEndpointWithTTL("low.org", endpoint.RecordTypeA, 10, "8.8.8.8"),
+			endpoint.NewEndpointWithTTL("high.org", endpoint.RecordTypeA, 172800, "8.8.8.8"),
+			endpoint.NewEndpointWithTTL("valid.org", endpoint.RecordTypeA, 3600, "8.8.8.8"),
+		},
+	}))
+	records, err := im.Records(context.Background())
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("default.org", endpoint.RecordTypeA, 300, "8.8.8.8"),
+		endpoint.NewEndpointWithTTL("low.org", endpoint.RecordTypeA, 60, "8.8.8.8"),
+		endpoint.NewEndpointWithTTL("high.org", endpoint.RecordTypeA, 86400, "8.8.8.8"),
+		endpoint.NewEndpointWithTTL("valid.org", endpoint.RecordTypeA, 3600, "8.8.8.8"),
+	}))
+
+	// the updates without TTL get the default TTL too
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("valid.org", endpoint.RecordTypeA, 3600, "8.8.8.8")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("valid.org", endpoint.RecordTypeA, "8.8.4.4")},
+	}))
+	records, err = im.Records(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, records, endpoint.NewEndpointWithTTL("valid.org", endpoint.RecordTypeA, 300, "8.8.4.4"))
+
+	// the TTLs are kept as is without default TTL nor limits
+	im = NewInMemoryProvider(InMemoryInitZones([]string{"org"}))
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("default.org", endpoint.RecordTypeA, "8.8.8.8")},
+	}))
+	records, err = im.Records(context.Background())
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{endpoint.NewEndpoint("default.org", endpoint.RecordTypeA, "8.8.8.8")}))
+}
+
 func makeZone(s ...string) map[endpoint.EndpointKey]*endpoint.Endpoint {
 	if len(s)%3 != 0 {
 		panic("makeZone arguments must be multiple of 3")
@@ -77,7 +77,15 @@ type OVHProvider struct {
 	// your refresh rate/number of records is too big, which might cause issue with the
 	// provider.
 	// Default value: true
-	UseCache       bool
+	UseCache bool
+
+	// UseZoneExport controls if the OVHProvider reads the records of a zone from its
+	// export in a single call, instead of fetching each record from the OVHcloud API.
+	// The export doesn't contain the record IDs, they are only fetched for the records
+	// to update or delete.
+	// Default value: false
+	UseZoneExport bool
+
 	lastRunRecords []ovhRecord
 	lastRunZones   []string
 
@@ -123,7 +131,7 @@ type ovhChange struct {
 }
 
 // NewOVHProvider initializes a new OVH DNS based Provider.
-func NewOVHProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, endpoint string, apiRateLimit int, enableCNAMERelative, dryRun bool) (*OVHProvider, error) {
+func NewOVHProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, endpoint string, apiRateLimit int, enableCNAMERelative, useZoneExport, dryRun bool) (*OVHProvider, error) {
 	client, err := ovh.NewEndpointClient(endpoint)
 	if err != nil {
 		return nil, err
@@ -140,6 +148,7 @@ func NewOVHProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, en
 		dnsClient:                 new(dns.Client),
 		UseCache:                  true,
 		EnableCNAMERelativeTarget: enableCNAMERelative,
+		UseZoneExport:             useZoneExport,
 	}, nil
 }
 
@@ -215,6 +224,16 @@ func (p *OVHProvider) computeSingleZoneChanges(_ context.Context, zoneName strin
 }
 
 func (p *OVHProvider) handleSingleZoneUpdate(ctx context.Context, zoneName string, existingRecords []ovhRecord, changes *plan.Changes) error {
+	// records read from the zone export have no ID, fetch the ones of the records to mutate
+	if p.UseZoneExport && (len(changes.Delete) > 0 || len(changes.UpdateOld) > 0) {
+		var err error
+		existingRecords, err = p.recordsToMutate(ctx, zoneName, changes)
+		if err != nil {
+			p.invalidateCache(zoneName)
+			return err
+		}
+	}
+
 	allChanges, err := p.computeSingleZoneChanges(ctx, zoneName, existingRecords, changes)
 	if err != nil {
 		return err
@@ -423,19 +442,27 @@ func (p *OVHProvider) records(ctx context.Context, zone *string, records chan<-
 		}
 	}
 
-	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(*zone)), &recordsIds); err != nil {
-		return err
-	}
-	chRecords := make(chan ovhRecord, len(recordsIds))
-	for _, id := range recordsIds {
-		eg.Go(func() error { return p.record(ctxErrGroup, zone, id, chRecords) })
-	}
-	if err := eg.Wait(); err != nil {
-		return err
-	}
-	close(chRecords)
-	for record := range chRecords {
-		ovhRecords = append(ovhRecords, record)
+	if p.UseZoneExport {
+		var err error
+		ovhRecords, err = p.exportedRecords(ctx, *zone)
+		if err != nil {
+			return err
+		}
+	} else {
+		if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(*zone)), &recordsIds); err != nil {
+			return err
+		}
+		chRecords := make(chan ovhRecord, len(recordsIds))
+		for _, id := range recordsIds {
+			eg.Go(func() error { return p.record(ctxErrGroup, zone, id, chRecords) })
+		}
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+		close(chRecords)
+		for record := range chRecords {
+			ovhRecords = append(ovhRecords, record)
+		}
 	}
 
 	if p.UseCache {
@@ -463,6 +490,87 @@ func (p *OVHProvider) record(ctx context.Context, zone *string, id uint64, recor
 	return nil
 }
 
+// exportedRecords reads the records of a zone from its export in BIND format.
+func (p *OVHProvider) exportedRecords(ctx context.Context, zone string) ([]ovhRecord, error) {
+	var zoneFile string
+	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/export", url.PathEscape(zone)), &zoneFile); err != nil {
+		return nil, err
+	}
+
+	var records []ovhRecord
+	origin := dns.Fqdn(zone)
+	zp := dns.NewZoneParser(strings.NewReader(zoneFile), origin, "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		hdr := rr.Header()
+		fieldType := dns.TypeToString[hdr.Rrtype]
+		if !provider.SupportedRecordType(fieldType) {
+			continue
+		}
+		target := strings.TrimPrefix(rr.String(), hdr.String())
+		if fieldType == endpoint.RecordTypeCNAME && p.EnableCNAMERelativeTarget {
+			// the export contains absolute targets, while relative ones are expected
+			target = strings.TrimSuffix(target, "."+origin)
+		}
+		records = append(records, ovhRecord{
+			Zone: zone,
+			ovhRecordFields: ovhRecordFields{
+				FieldType: fieldType,
+				ovhRecordFieldUpdate: ovhRecordFieldUpdate{
+					SubDomain: convertDNSNameIntoSubDomain(strings.TrimSuffix(hdr.Name, "."), zone),
+					TTL:       int64(hdr.Ttl),
+					Target:    target,
+				},
+			},
+		})
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse the export of zone %s: %w", zone, err)
+	}
+	log.Debugf("OVH: zone %s: %d records read from the export", zone, len(records))
+	return records, nil
+}
+
+// recordsToMutate fetches the records, with their IDs, matching the names and types of the records to update or delete.
+func (p *OVHProvider) recordsToMutate(ctx context.Context, zone string, changes *plan.Changes) ([]ovhRecord, error) {
+	type nameAndType struct {
+		subDomain string
+		fieldType string
+	}
+	var toMutate []nameAndType
+	for _, e := range slices.Concat(changes.Delete, changes.UpdateOld) {
+		key := nameAndType{subDomain: convertDNSNameIntoSubDomain(e.DNSName, zone), fieldType: e.RecordType}
+		if !slices.Contains(toMutate, key) {
+			toMutate = append(toMutate, key)
+		}
+	}
+
+	var recordsIds []uint64
+	for _, key := range toMutate {
+		var ids []uint64
+		p.apiRateLimiter.Take()
+		query := url.Values{"fieldType": {key.fieldType}, "subDomain": {key.subDomain}}
+		if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record?%s", url.PathEscape(zone), query.Encode()), &ids); err != nil {
+			return nil, err
+		}
+		recordsIds = append(recordsIds, ids...)
+	}
+
+	chRecords := make(chan ovhRecord, len(recordsIds))
+	eg, ctxErrGroup := errgroup.WithContext(ctx)
+	for _, id := range recordsIds {
+		eg.Go(func() error { return p.record(ctxErrGroup, &zone, id, chRecords) })
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	close(chRecords)
+	var records []ovhRecord
+	for record := range chRecords {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 func ovhGroupByNameAndType(records []ovhRecord) []*endpoint.Endpoint {
 	endpoints := []*endpoint.Endpoint{}
 
@@ -585,6 +585,143 @@ func TestOvhApplyChanges(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestOvhApplyChangesRefreshOncePerZone(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	changes := plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42", "203.0.113.43"}},
+			{DNSName: "www.example.net", RecordType: "CNAME", RecordTTL: 10, Targets: []string{"example.net"}},
+			{DNSName: "ovh.example.org", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.44"}},
+		},
+		Delete: []*endpoint.Endpoint{
+			{DNSName: "old.example.org", RecordType: "A", Targets: []string{"203.0.113.45"}},
+		},
+	}
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net", "example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record").Return([]uint64{}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{42}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(ovhRecord{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "old", TTL: 10, Target: "203.0.113.45"}}}, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 10, Target: "203.0.113.42"}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 10, Target: "203.0.113.43"}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/record", ovhRecordFields{FieldType: "CNAME", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "www", TTL: 10, Target: "example.net."}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.44"}}).Return(nil, nil).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.org/record/42").Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/refresh", nil).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/refresh", nil).Return(nil, nil).Once()
+
+	_, err := provider.Records(t.Context())
+	td.CmpNoError(t, err)
+	td.CmpNoError(t, provider.ApplyChanges(t.Context(), &changes))
+	client.AssertExpectations(t)
+	client.AssertNumberOfCalls(t, "PostWithContext", 6)
+}
+
+func TestOvhRecordsZoneExport(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), UseZoneExport: true}
+
+	zoneFile := `$TTL 3600
+@	IN SOA dns11.ovh.net. tech.ovh.net. (2022090901 86400 3600 3600000 60)
+	IN NS	dns11.ovh.net.
+	IN NS	ns11.ovh.net.
+	10 IN A	203.0.113.42
+www	10 IN CNAME	example.org.
+ovh	10 IN A	203.0.113.42
+ovh	10 IN A	203.0.113.43
+txt	10 IN TXT	"heritage=external-dns,external-dns/owner=default"
+`
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/export").Return(zoneFile, nil).Once()
+
+	endpoints, err := provider.Records(t.Context())
+	td.CmpNoError(t, err)
+	for _, endpoint := range endpoints {
+		sort.Strings(endpoint.Targets)
+	}
+	assert.ElementsMatch(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "example.org", RecordType: "NS", RecordTTL: 3600, Labels: endpoint.NewLabels(), Targets: []string{"dns11.ovh.net", "ns11.ovh.net"}},
+		{DNSName: "example.org", RecordType: "A", RecordTTL: 10, Labels: endpoint.NewLabels(), Targets: []string{"203.0.113.42"}},
+		{DNSName: "www.example.org", RecordType: "CNAME", RecordTTL: 10, Labels: endpoint.NewLabels(), Targets: []string{"example.org"}},
+		{DNSName: "ovh.example.org", RecordType: "A", RecordTTL: 10, Labels: endpoint.NewLabels(), Targets: []string{"203.0.113.42", "203.0.113.43"}},
+		{DNSName: "txt.example.org", RecordType: "TXT", RecordTTL: 10, Labels: endpoint.NewLabels(), Targets: []string{"\"heritage=external-dns,external-dns/owner=default\""}},
+	})
+	client.AssertExpectations(t)
+	client.AssertNumberOfCalls(t, "GetWithContext", 2)
+
+	// Relative CNAME targets, as set by the provider in the zone
+	client = new(mockOvhClient)
+	provider.client = client
+	provider.EnableCNAMERelativeTarget = true
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/export").Return("www 10 IN CNAME ovh.example.org.\n", nil).Once()
+	endpoints, err = provider.Records(t.Context())
+	td.CmpNoError(t, err)
+	assert.ElementsMatch(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: "CNAME", RecordTTL: 10, Labels: endpoint.NewLabels(), Targets: []string{"ovh"}},
+	})
+	client.AssertExpectations(t)
+
+	// Error getting the export
+	client = new(mockOvhClient)
+	provider.client = client
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/export").Return(nil, ovh.ErrAPIDown).Once()
+	endpoints, err = provider.Records(t.Context())
+	td.CmpError(t, err)
+	td.CmpNil(t, endpoints)
+	client.AssertExpectations(t)
+
+	// Invalid export
+	client = new(mockOvhClient)
+	provider.client = client
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/export").Return("www 10 IN A not-an-ip\n", nil).Once()
+	endpoints, err = provider.Records(t.Context())
+	td.CmpError(t, err)
+	td.CmpNil(t, endpoints)
+	client.AssertExpectations(t)
+}
+
+func TestOvhApplyChangesZoneExport(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), UseZoneExport: true}
+	changes := plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
+		},
+		Delete: []*endpoint.Endpoint{
+			{DNSName: "ovh.example.net", RecordType: "A", Targets: []string{"203.0.113.43"}},
+		},
+	}
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/export").Return("ovh 10 IN A 203.0.113.43\n", nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record?fieldType=A&subDomain=ovh").Return([]uint64{42}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record/42").Return(ovhRecord{ID: 42, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.43"}}}, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 10, Target: "203.0.113.42"}}).Return(nil, nil).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.net/record/42").Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/refresh", nil).Return(nil, nil).Once()
+
+	_, err := provider.Records(t.Context())
+	td.CmpNoError(t, err)
+	td.CmpNoError(t, provider.ApplyChanges(t.Context(), &changes))
+	client.AssertExpectations(t)
+
+	// Error fetching the records to delete
+	client = new(mockOvhClient)
+	provider.client = client
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/export").Return("ovh 10 IN A 203.0.113.43\n", nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record?fieldType=A&subDomain=ovh").Return(nil, ovh.ErrAPIDown).Once()
+
+	_, err = provider.Records(t.Context())
+	td.CmpNoError(t, err)
+	td.CmpError(t, provider.ApplyChanges(t.Context(), &changes))
+	client.AssertExpectations(t)
+}
+
 func TestOvhChange(t *testing.T) {
 	assert := assert.New(t)
 	client := new(mockOvhClient)
@@ -622,13 +759,13 @@ func TestOvhRecordString(t *testing.T) {
 
 func TestNewOvhProvider(t *testing.T) {
 	domainFilter := &endpoint.DomainFilter{}
-	_, err := NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true)
+	_, err := NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, false, true)
 	td.CmpError(t, err)
 
 	t.Setenv("OVH_APPLICATION_KEY", "aaaaaa")
 	t.Setenv("OVH_APPLICATION_SECRET", "bbbbbb")
 	t.Setenv("OVH_CONSUMER_KEY", "cccccc")
 
-	_, err = NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true)
+	_, err = NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, false, true)
 	td.CmpNoError(t, err)
 }
@@ -23,9 +23,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net"
 	"net/http"
+	"net/url"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -68,6 +71,13 @@ type PDNSConfig struct {
 	ServerID     string
 	APIKey       string
 	TLSConfig    TLSConfig
+	Provenance   provider.ProvenanceConfig
+	// CreateZones enables the creation of the zones of the domain filter missing from the server
+	CreateZones bool
+	// ZoneNameservers are the nameservers of the created zones
+	ZoneNameservers []string
+	// ZoneSOA is the content of the SOA record of the created zones, the server default when empty
+	ZoneSOA string
 }
 
 // TLSConfig is comprised of the TLS-related fields necessary to create a new PDNSProvider
@@ -131,6 +141,7 @@ type PDNSAPIProvider interface {
 	PartitionZones(zones []pgo.Zone) ([]pgo.Zone, []pgo.Zone)
 	ListZone(zoneID string) (pgo.Zone, *http.Response, error)
 	PatchZone(zoneID string, zoneStruct pgo.Zone) (*http.Response, error)
+	CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error)
 }
 
 // PDNSAPIClient : Struct that encapsulates all the PowerDNS specific implementation details
@@ -139,6 +150,7 @@ type PDNSAPIClient struct {
 	serverID     string
 	authCtx      context.Context
 	client       *pgo.APIClient
+	config       *pgo.Configuration
 	domainFilter *endpoint.DomainFilter
 }
 
@@ -217,10 +229,69 @@ func (c *PDNSAPIClient) PatchZone(zoneID string, zoneStruct pgo.Zone) (*http.Res
 	return resp, provider.NewSoftErrorf("unable to patch zone: %v", err)
 }
 
+// CreateZone : Method used to create a new zone in PowerDNS
+// ref: https://doc.powerdns.com/authoritative/http-api/zone.html#post--servers-server_id-zones
+func (c *PDNSAPIClient) CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error) {
+	body, err := json.Marshal(zoneStruct)
+	if err != nil {
+		return pgo.Zone{}, nil, err
+	}
+	var zone pgo.Zone
+	var resp *http.Response
+	for i := 0; i < retryLimit; i++ {
+		zone, resp, err = c.postZone(body)
+		if err != nil {
+			log.Debugf("Unable to create zone %v", err)
+			log.Debugf("Retrying CreateZone() ... %d", i)
+			time.Sleep(retryAfterTime * (1 << uint(i)))
+			continue
+		}
+		return zone, resp, err
+	}
+
+	return zone, resp, provider.NewSoftErrorf("unable to create zone: %v", err)
+}
+
+// postZone sends the zone creation request, the generated client not sending the zone in its body
+func (c *PDNSAPIClient) postZone(body []byte) (pgo.Zone, *http.Response, error) {
+	req, err := http.NewRequestWithContext(c.authCtx, http.MethodPost, c.config.BasePath+"/servers/"+url.PathEscape(c.serverID)+"/zones", bytes.NewReader(body))
+	if err != nil {
+		return pgo.Zone{}, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if apiKey, ok := c.authCtx.Value(pgo.ContextAPIKey).(pgo.APIKey); ok {
+		req.Header.Set("X-API-Key", apiKey.Key)
+	}
+
+	httpClient := c.config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return pgo.Zone{}, resp, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(resp.Body)
+		return pgo.Zone{}, resp, fmt.Errorf("status: %v, body: %s", resp.Status, respBody)
+	}
+
+	var zone pgo.Zone
+	err = json.NewDecoder(resp.Body).Decode(&zone)
+	return zone, resp, err
+}
+
 // PDNSProvider is an implementation of the Provider interface for PowerDNS
 type PDNSProvider struct {
 	provider.BaseProvider
-	client PDNSAPIProvider
+	client          PDNSAPIProvider
+	provenance      provider.ProvenanceConfig
+	domainFilter    *endpoint.DomainFilter
+	createZones     bool
+	zoneNameservers []string
+	zoneSOA         string
 }
 
 // NewPDNSProvider initializes a new PowerDNS based Provider.
@@ -253,8 +324,14 @@ func NewPDNSProvider(ctx context.Context, config PDNSConfig) (*PDNSProvider, err
 			serverID:     config.ServerID,
 			authCtx:      context.WithValue(ctx, pgo.ContextAPIKey, pgo.APIKey{Key: config.APIKey}),
 			client:       pgo.NewAPIClient(pdnsClientConfig),
+			config:       pdnsClientConfig,
 			domainFilter: config.DomainFilter,
 		},
+		provenance:      config.Provenance,
+		domainFilter:    config.DomainFilter,
+		createZones:     config.CreateZones,
+		zoneNameservers: config.ZoneNameservers,
+		zoneSOA:         config.ZoneSOA,
 	}
 	return provider, nil
 }
@@ -349,6 +426,11 @@ func (p *PDNSProvider) ConvertEndpointsToZones(eps []*endpoint.Endpoint, changet
 					} else {
 						rrset.Ttl = int32(ep.RecordTTL)
 					}
+					if description := ep.Labels[endpoint.DescriptionLabelKey]; description != "" {
+						rrset.Comments = []pgo.Comment{{Content: description, Account: "external-dns"}}
+					} else if provenance := p.provenance.Provenance(ep); provenance != "" {
+						rrset.Comments = []pgo.Comment{{Content: provenance, Account: "external-dns"}}
+					}
 				}
 
 				zone.Rrsets = append(zone.Rrsets, rrset)
@@ -391,6 +473,52 @@ func (p *PDNSProvider) ConvertEndpointsToZones(eps []*endpoint.Endpoint, changet
 	return zoneList, nil
 }
 
+// createMissingZones creates the zones of the domain filter which are neither on the server
+// nor a subdomain of one of its zones, whose records are managed in the parent zone.
+// The filters starting with a dot only match subdomains and are ignored
+func (p *PDNSProvider) createMissingZones() error {
+	if p.domainFilter == nil {
+		return nil
+	}
+	zones, _, err := p.client.ListZones()
+	if err != nil {
+		return err
+	}
+
+	for _, filter := range p.domainFilter.Filters {
+		if filter == "" || strings.HasPrefix(filter, ".") {
+			continue
+		}
+		zoneName := provider.EnsureTrailingDot(filter)
+		if slices.ContainsFunc(zones, func(zone pgo.Zone) bool {
+			return zoneName == zone.Name || strings.HasSuffix(zoneName, "."+zone.Name)
+		}) {
+			continue
+		}
+
+		zone := pgo.Zone{
+			Name:        zoneName,
+			Kind:        "Native",
+			Nameservers: p.zoneNameservers,
+		}
+		if p.zoneSOA != "" {
+			zone.Rrsets = []pgo.RrSet{{
+				Name:    zoneName,
+				Type_:   "SOA",
+				Ttl:     defaultTTL,
+				Records: []pgo.Record{{Content: p.zoneSOA}},
+			}}
+		}
+		log.Infof("CREATE ZONE: %s", zoneName)
+		created, _, err := p.client.CreateZone(zone)
+		if err != nil {
+			return fmt.Errorf("creating zone %s: %w", zoneName, err)
+		}
+		zones = append(zones, created)
+	}
+	return nil
+}
+
 // mutateRecords takes a list of endpoints and creates, replaces or deletes them based on the changetype
 func (p *PDNSProvider) mutateRecords(endpoints []*endpoint.Endpoint, changetype pdnsChangeType) error {
 	zonelist, err := p.ConvertEndpointsToZones(endpoints, changetype)
@@ -460,6 +588,12 @@ func (p *PDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 func (p *PDNSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	startTime := time.Now()
 
+	if p.createZones {
+		if err := p.createMissingZones(); err != nil {
+			return err
+		}
+	}
+
 	// Create
 	for _, change := range changes.Create {
 		log.Infof("CREATE: %+v", change)
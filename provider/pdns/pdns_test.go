@@ -18,8 +18,10 @@ package pdns
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
@@ -28,6 +30,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -672,6 +675,10 @@ func (c *PDNSAPIClientStub) PatchZone(zoneID string, zoneStruct pgo.Zone) (*http
 	return &http.Response{}, nil
 }
 
+func (c *PDNSAPIClientStub) CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error) {
+	return zoneStruct, &http.Response{}, nil
+}
+
 /******************************************************************************/
 // API that returns a zones with no records
 type PDNSAPIClientStubEmptyZones struct {
@@ -703,6 +710,10 @@ func (c *PDNSAPIClientStubEmptyZones) PatchZone(zoneID string, zoneStruct pgo.Zo
 	return &http.Response{}, nil
 }
 
+func (c *PDNSAPIClientStubEmptyZones) CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error) {
+	return zoneStruct, &http.Response{}, nil
+}
+
 /******************************************************************************/
 // API that returns error on PatchZone()
 type PDNSAPIClientStubPatchZoneFailure struct {
@@ -987,6 +998,44 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSConvertEndpointsToZones() {
 	suite.Equal([]pgo.Zone{ZoneEmptyToApexPatch}, zlist)
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSConvertEndpointsToZonesProvenance() {
+	p := &PDNSProvider{
+		client:     &PDNSAPIClientStubEmptyZones{},
+		provenance: provider.ProvenanceConfig{Enabled: true, OwnerID: "tower-pdns", ClusterName: "production"},
+	}
+	ep := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8")
+	ep.Labels[endpoint.ResourceLabelKey] = "service/default/nginx"
+
+	// Check the provenance is written in the comments of the replaced records
+	zlist, err := p.ConvertEndpointsToZones([]*endpoint.Endpoint{ep}, PdnsReplace)
+	suite.NoError(err)
+	suite.Len(zlist, 1)
+	suite.Len(zlist[0].Rrsets, 1)
+	suite.Equal([]pgo.Comment{{Content: "external-dns owner=tower-pdns source=service cluster=production", Account: "external-dns"}}, zlist[0].Rrsets[0].Comments)
+
+	// Check no comment is sent when deleting records
+	zlist, err = p.ConvertEndpointsToZones([]*endpoint.Endpoint{ep}, PdnsDelete)
+	suite.NoError(err)
+	suite.Len(zlist, 1)
+	suite.Nil(zlist[0].Rrsets[0].Comments)
+}
+
+func (suite *NewPDNSProviderTestSuite) TestPDNSConvertEndpointsToZonesDescription() {
+	p := &PDNSProvider{
+		client:     &PDNSAPIClientStubEmptyZones{},
+		provenance: provider.ProvenanceConfig{Enabled: true, OwnerID: "tower-pdns"},
+	}
+	ep := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8")
+	ep.Labels[endpoint.DescriptionLabelKey] = "Checkout frontend"
+
+	// Check the description is written in the comments of the replaced records instead of the provenance
+	zlist, err := p.ConvertEndpointsToZones([]*endpoint.Endpoint{ep}, PdnsReplace)
+	suite.NoError(err)
+	suite.Len(zlist, 1)
+	suite.Len(zlist[0].Rrsets, 1)
+	suite.Equal([]pgo.Comment{{Content: "Checkout frontend", Account: "external-dns"}}, zlist[0].Rrsets[0].Comments)
+}
+
 func (suite *NewPDNSProviderTestSuite) TestPDNSConvertEndpointsToZonesPartitionZones() {
 	// Test DomainFilters
 	p := &PDNSProvider{
@@ -1158,6 +1207,77 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSAdjustEndpoints() {
 	}
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSApplyChangesCreateZones() {
+	zones := []pgo.Zone{{Id: "example.com.", Name: "example.com."}}
+	var created []pgo.Zone
+	patched := map[string][]pgo.RrSet{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("secret", r.Header.Get("X-API-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		switch path := r.URL.Path; {
+		case path == "/api/v1/servers/localhost/zones" && r.Method == http.MethodGet:
+			suite.NoError(json.NewEncoder(w).Encode(zones))
+		case path == "/api/v1/servers/localhost/zones" && r.Method == http.MethodPost:
+			var zone pgo.Zone
+			suite.NoError(json.NewDecoder(r.Body).Decode(&zone))
+			created = append(created, zone)
+			zone.Id = zone.Name
+			zones = append(zones, zone)
+			w.WriteHeader(http.StatusCreated)
+			suite.NoError(json.NewEncoder(w).Encode(zone))
+		case strings.HasPrefix(path, "/api/v1/servers/localhost/zones/") && r.Method == http.MethodPatch:
+			var zone pgo.Zone
+			suite.NoError(json.NewDecoder(r.Body).Decode(&zone))
+			patched[strings.TrimPrefix(path, "/api/v1/servers/localhost/zones/")] = zone.Rrsets
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			suite.Failf("unexpected request", "%s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p, err := NewPDNSProvider(
+		context.Background(),
+		PDNSConfig{
+			Server:          server.URL,
+			ServerID:        "localhost",
+			APIKey:          "secret",
+			DomainFilter:    endpoint.NewDomainFilter([]string{"example.com", "sub.example.com", "mock.test"}),
+			CreateZones:     true,
+			ZoneNameservers: []string{"ns1.mock.test.", "ns2.mock.test."},
+			ZoneSOA:         "ns1.mock.test. hostmaster.mock.test. 1 10800 3600 604800 3600",
+		})
+	suite.NoError(err)
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.mock.test", endpoint.RecordTypeA, "8.8.8.8")},
+	})
+	suite.NoError(err)
+
+	// the subdomain of an existing zone is not created
+	suite.Equal([]pgo.Zone{{
+		Name:        "mock.test.",
+		Kind:        "Native",
+		Nameservers: []string{"ns1.mock.test.", "ns2.mock.test."},
+		Rrsets: []pgo.RrSet{{
+			Name:    "mock.test.",
+			Type_:   "SOA",
+			Ttl:     300,
+			Records: []pgo.Record{{Content: "ns1.mock.test. hostmaster.mock.test. 1 10800 3600 604800 3600"}},
+		}},
+	}}, created)
+	suite.Equal(map[string][]pgo.RrSet{
+		"mock.test.": {{
+			Name:       "www.mock.test.",
+			Type_:      "A",
+			Ttl:        300,
+			Changetype: "REPLACE",
+			Records:    []pgo.Record{{Content: "8.8.8.8"}},
+		}},
+	}, patched)
+}
+
 func TestNewPDNSProviderTestSuite(t *testing.T) {
 	suite.Run(t, new(NewPDNSProviderTestSuite))
 }
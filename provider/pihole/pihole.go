@@ -96,6 +96,11 @@ func (p *PiholeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 	return append(aRecords, cnameRecords...), nil
 }
 
+// SupportedRecordTypes implements Provider, Pi-hole Local DNS only has A, AAAA and CNAME records.
+func (p *PiholeProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+}
+
 // ApplyChanges implements Provider, syncing desired state with the Pi-hole server Local DNS.
 func (p *PiholeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	// Handle pure deletes first.
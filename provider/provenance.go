@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const provenanceHeritage = "external-dns"
+
+// ProvenanceConfig configures the provenance metadata embedded by the providers
+// in the comment, or similar, fields of the records they create.
+type ProvenanceConfig struct {
+	Enabled     bool
+	OwnerID     string
+	ClusterName string
+}
+
+// Provenance returns the provenance metadata of an endpoint, identifying the
+// instance of ExternalDNS and the source which created it, e.g.
+// "external-dns owner=default source=service cluster=production".
+// It returns an empty string when provenance is disabled.
+func (c ProvenanceConfig) Provenance(ep *endpoint.Endpoint) string {
+	if !c.Enabled {
+		return ""
+	}
+
+	parts := []string{provenanceHeritage}
+	if c.OwnerID != "" {
+		parts = append(parts, "owner="+c.OwnerID)
+	}
+	// the resource label has the format <source>/<namespace>/<name>
+	if resource, ok := ep.Labels[endpoint.ResourceLabelKey]; ok && resource != "" {
+		parts = append(parts, "source="+strings.SplitN(resource, "/", 2)[0])
+	}
+	if c.ClusterName != "" {
+		parts = append(parts, "cluster="+c.ClusterName)
+	}
+	return strings.Join(parts, " ")
+}
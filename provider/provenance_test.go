@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestProvenance(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		config   ProvenanceConfig
+		labels   endpoint.Labels
+		expected string
+	}{
+		{
+			name:     "disabled",
+			config:   ProvenanceConfig{OwnerID: "default", ClusterName: "production"},
+			labels:   endpoint.Labels{endpoint.ResourceLabelKey: "service/default/nginx"},
+			expected: "",
+		},
+		{
+			name:     "all metadata",
+			config:   ProvenanceConfig{Enabled: true, OwnerID: "default", ClusterName: "production"},
+			labels:   endpoint.Labels{endpoint.ResourceLabelKey: "service/default/nginx"},
+			expected: "external-dns owner=default source=service cluster=production",
+		},
+		{
+			name:     "no cluster name",
+			config:   ProvenanceConfig{Enabled: true, OwnerID: "default"},
+			labels:   endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/nginx"},
+			expected: "external-dns owner=default source=ingress",
+		},
+		{
+			name:     "no resource label",
+			config:   ProvenanceConfig{Enabled: true, OwnerID: "default", ClusterName: "production"},
+			labels:   endpoint.Labels{},
+			expected: "external-dns owner=default cluster=production",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ep := &endpoint.Endpoint{DNSName: "example.org", Labels: tc.labels}
+			assert.Equal(t, tc.expected, tc.config.Provenance(ep))
+		})
+	}
+}
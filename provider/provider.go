@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -55,6 +56,84 @@ type Provider interface {
 	// Endpoints. It is permitted to modify the supplied endpoints.
 	AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error)
 	GetDomainFilter() endpoint.DomainFilterInterface
+	// SupportedRecordTypes returns the DNS record types the provider can manage,
+	// or nil when it supports all of them.
+	SupportedRecordTypes() []string
+	// TTLLimits returns the lowest and highest TTLs the provider accepts, zero when not limited.
+	TTLLimits() (minTTL, maxTTL endpoint.TTL)
+}
+
+// RecordsByNameProvider is implemented by the providers able to query their records by name,
+// which lets the TXT registry fetch only the ownership records of the other records.
+type RecordsByNameProvider interface {
+	// RecordsExcludingType returns the current records of all the record types but the given one.
+	RecordsExcludingType(ctx context.Context, recordType string) ([]*endpoint.Endpoint, error)
+	// RecordsByName returns the current records of the given record type with one of the given names.
+	RecordsByName(ctx context.Context, recordType string, names []string) ([]*endpoint.Endpoint, error)
+}
+
+// recordsByNameWrapper is implemented by the providers wrapping another one, which can only query
+// their records by name when the wrapped provider does.
+type recordsByNameWrapper interface {
+	RecordsByNameProvider
+	wrapped() Provider
+}
+
+// AsRecordsByNameProvider returns the provider as a RecordsByNameProvider, if it or the providers
+// it wraps can query their records by name.
+func AsRecordsByNameProvider(p Provider) (RecordsByNameProvider, bool) {
+	if w, ok := p.(recordsByNameWrapper); ok {
+		if _, ok := AsRecordsByNameProvider(w.wrapped()); !ok {
+			return nil, false
+		}
+		return w, true
+	}
+	lookup, ok := p.(RecordsByNameProvider)
+	return lookup, ok
+}
+
+// recordsExcludingType returns the current records of the provider but the ones of the given type,
+// only querying the other types when the provider can query its records by name.
+func recordsExcludingType(ctx context.Context, p Provider, recordType string) ([]*endpoint.Endpoint, error) {
+	if lookup, ok := AsRecordsByNameProvider(p); ok {
+		return lookup.RecordsExcludingType(ctx, recordType)
+	}
+	records, err := p.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, func(r *endpoint.Endpoint) bool { return r.RecordType != recordType }), nil
+}
+
+// recordsByName returns the current records of the provider of the given type with one of the given names,
+// only querying these names when the provider can query its records by name.
+func recordsByName(ctx context.Context, p Provider, recordType string, names []string) ([]*endpoint.Endpoint, error) {
+	if lookup, ok := AsRecordsByNameProvider(p); ok {
+		return lookup.RecordsByName(ctx, recordType, names)
+	}
+	records, err := p.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, hasTypeAndName(recordType, names)), nil
+}
+
+// hasTypeAndName returns a function matching the records of the given type with one of the given names.
+func hasTypeAndName(recordType string, names []string) func(*endpoint.Endpoint) bool {
+	return func(r *endpoint.Endpoint) bool {
+		return r.RecordType == recordType && slices.Contains(names, r.DNSName)
+	}
+}
+
+// filterRecords returns the records matching the given function.
+func filterRecords(records []*endpoint.Endpoint, match func(*endpoint.Endpoint) bool) []*endpoint.Endpoint {
+	result := make([]*endpoint.Endpoint, 0, len(records))
+	for _, r := range records {
+		if match(r) {
+			result = append(result, r)
+		}
+	}
+	return result
 }
 
 type BaseProvider struct{}
@@ -67,6 +146,14 @@ func (b BaseProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return &endpoint.DomainFilter{}
 }
 
+func (b BaseProvider) SupportedRecordTypes() []string {
+	return nil
+}
+
+func (b BaseProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return 0, 0
+}
+
 type contextKey struct {
 	name string
 }
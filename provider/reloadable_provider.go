@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// ReloadableProvider is a Provider which can be rebuilt at runtime, e.g. to use rotated credentials.
+type ReloadableProvider struct {
+	build    func() (Provider, error)
+	mutex    sync.RWMutex
+	provider Provider
+}
+
+// NewReloadableProvider returns a ReloadableProvider delegating to the given provider,
+// and replacing it by the result of build on each reload.
+func NewReloadableProvider(provider Provider, build func() (Provider, error)) *ReloadableProvider {
+	return &ReloadableProvider{
+		build:    build,
+		provider: provider,
+	}
+}
+
+// Reload rebuilds the provider. The current provider is kept when it fails.
+func (r *ReloadableProvider) Reload() error {
+	provider, err := r.build()
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.provider = provider
+	log.Info("Reloaded the provider")
+	return nil
+}
+
+func (r *ReloadableProvider) current() Provider {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.provider
+}
+
+func (r *ReloadableProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return r.current().Records(ctx)
+}
+
+func (r *ReloadableProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	return r.current().ApplyChanges(ctx, changes)
+}
+
+func (r *ReloadableProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return r.current().AdjustEndpoints(endpoints)
+}
+
+func (r *ReloadableProvider) GetDomainFilter() endpoint.DomainFilterInterface {
+	return r.current().GetDomainFilter()
+}
+
+func (r *ReloadableProvider) SupportedRecordTypes() []string {
+	return r.current().SupportedRecordTypes()
+}
+
+func (r *ReloadableProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return r.current().TTLLimits()
+}
+
+func (r *ReloadableProvider) RecordsExcludingType(ctx context.Context, recordType string) ([]*endpoint.Endpoint, error) {
+	return recordsExcludingType(ctx, r.current(), recordType)
+}
+
+func (r *ReloadableProvider) RecordsByName(ctx context.Context, recordType string, names []string) ([]*endpoint.Endpoint, error) {
+	return recordsByName(ctx, r.current(), recordType, names)
+}
+
+func (r *ReloadableProvider) wrapped() Provider {
+	return r.current()
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// newTestRecordsProvider returns a provider with a single record targeting the given credentials.
+func newTestRecordsProvider(credentials string) Provider {
+	return &testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			return []*endpoint.Endpoint{endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, credentials)}, nil
+		},
+	}
+}
+
+func TestReloadableProvider(t *testing.T) {
+	var buildErr error
+	credentials := "new"
+	p := NewReloadableProvider(newTestRecordsProvider("old"), func() (Provider, error) {
+		if buildErr != nil {
+			return nil, buildErr
+		}
+		return newTestRecordsProvider(credentials), nil
+	})
+
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"old"}, records[0].Targets)
+
+	require.NoError(t, p.Reload())
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"new"}, records[0].Targets)
+
+	// the current provider is kept when the reload fails
+	buildErr = errors.New("invalid credentials")
+	credentials = "invalid"
+	require.ErrorIs(t, p.Reload(), buildErr)
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"new"}, records[0].Targets)
+}
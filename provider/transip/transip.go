@@ -35,6 +35,9 @@ const (
 	// 60 seconds is the current minimal TTL for TransIP and will replace unconfigured
 	// TTL's for Endpoints
 	defaultTTL = 60
+	// recordTypeALIAS is the native record type of TransIP aliasing the zone apex to a hostname,
+	// where CNAME records are not allowed
+	recordTypeALIAS = "ALIAS"
 )
 
 // TransIPProvider is an implementation of Provider for TransIP.
@@ -273,12 +276,17 @@ func (p *TransIPProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, er
 		}
 
 		for _, r := range entries {
-			if !provider.SupportedRecordType(r.Type) {
+			recordType := r.Type
+			if recordType == recordTypeALIAS && r.Name == "@" {
+				// the apex aliases are managed as the CNAME records of the apex
+				recordType = endpoint.RecordTypeCNAME
+			}
+			if !provider.SupportedRecordType(recordType) {
 				continue
 			}
 
 			name := endpointNameForRecord(r, zone.Name)
-			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, r.Type, endpoint.TTL(r.Expire), r.Content))
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, recordType, endpoint.TTL(r.Expire), r.Content))
 		}
 	}
 
@@ -297,9 +305,10 @@ func (p *TransIPProvider) entriesForEndpoint(ep *endpoint.Endpoint) (string, []d
 		return zoneName, nil, err
 	}
 
+	entryType := dnsEntryType(ep, zoneName)
 	matches := []domain.DNSEntry{}
 	for _, entry := range dnsEntries {
-		if ep.RecordType != entry.Type {
+		if entryType != entry.Type {
 			continue
 		}
 
@@ -335,6 +344,15 @@ func recordNameForEndpoint(ep *endpoint.Endpoint, zoneName string) string {
 	return strings.TrimSuffix(ep.DNSName, "."+zoneName)
 }
 
+// dnsEntryType returns the type of the DNS entries of the endpoint: the CNAME records of the zone apex
+// are ALIAS entries, CNAME entries not being allowed at the apex.
+func dnsEntryType(ep *endpoint.Endpoint, zoneName string) string {
+	if ep.RecordType == endpoint.RecordTypeCNAME && ep.DNSName == zoneName {
+		return recordTypeALIAS
+	}
+	return ep.RecordType
+}
+
 // getMinimalValidTTL returns max between given Endpoint's RecordTTL and
 // defaultTTL
 func getMinimalValidTTL(ep *endpoint.Endpoint) int {
@@ -383,18 +401,19 @@ func dnsEntriesAreEqual(a, b []domain.DNSEntry) bool {
 // resulting DNS entry set
 func dnsEntriesForEndpoint(ep *endpoint.Endpoint, zoneName string) []domain.DNSEntry {
 	ttl := getMinimalValidTTL(ep)
+	entryType := dnsEntryType(ep, zoneName)
 
 	entries := []domain.DNSEntry{}
 	for _, target := range ep.Targets {
 		// external hostnames require a trailing dot in TransIP API
-		if ep.RecordType == "CNAME" {
+		if entryType == "CNAME" || entryType == recordTypeALIAS {
 			target = provider.EnsureTrailingDot(target)
 		}
 
 		entries = append(entries, domain.DNSEntry{
 			Name:    recordNameForEndpoint(ep, zoneName),
 			Expire:  ttl,
-			Type:    ep.RecordType,
+			Type:    entryType,
 			Content: target,
 		})
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/transip/gotransip/v6/rest"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -172,6 +173,15 @@ func TestTransIPAddEndpointToEntries(t *testing.T) {
 		assert.Equal(t, "CNAME", result[0].Type)
 		assert.Equal(t, "foo.bar.", result[0].Content)
 	}
+
+	// try again with a CNAME at the zone apex
+	ep.DNSName = "example.org"
+	result = dnsEntriesForEndpoint(ep, zone.Name)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "@", result[0].Name)
+		assert.Equal(t, "ALIAS", result[0].Type)
+		assert.Equal(t, "foo.bar.", result[0].Content)
+	}
 }
 
 func TestZoneNameForDNSName(t *testing.T) {
@@ -191,7 +201,8 @@ func TestZoneNameForDNSName(t *testing.T) {
 
 // fakeClient mocks the REST API client
 type fakeClient struct {
-	getFunc func(rest.Request, interface{}) error
+	getFunc  func(rest.Request, interface{}) error
+	postFunc func(rest.Request) error
 }
 
 func (f *fakeClient) Get(request rest.Request, dest interface{}) error {
@@ -207,7 +218,11 @@ func (f *fakeClient) Put(request rest.Request) error {
 }
 
 func (f *fakeClient) Post(request rest.Request) error {
-	return errors.New("POST not implemented")
+	if f.postFunc == nil {
+		return errors.New("POST not implemented")
+	}
+
+	return f.postFunc(request)
 }
 
 func (f *fakeClient) Delete(request rest.Request) error {
@@ -348,3 +363,56 @@ func TestProviderEntriesForEndpoint(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderApexAlias(t *testing.T) {
+	// set up the fake REST client, with an ALIAS entry at the apex of example.org
+	var posted []domain.DNSEntry
+	client := &fakeClient{}
+	client.getFunc = func(req rest.Request, dest interface{}) error {
+		var data []byte
+		switch {
+		case req.Endpoint == "/domains":
+			data = []byte(`{"domains":[{"name":"example.org"}, {"name":"example.com"}]}`)
+		case req.Endpoint == "/domains/example.org/dns":
+			data = []byte(`{"dnsEntries":[{"name":"@", "expire":3600, "type":"ALIAS", "content":"lb.example.net."},{"name":"www", "expire":3600, "type":"ALIAS", "content":"lb.example.net."}]}`)
+		default:
+			data = []byte(`{"dnsEntries":[]}`)
+		}
+		return json.Unmarshal(data, &dest)
+	}
+	client.postFunc = func(req rest.Request) error {
+		var v struct {
+			DNSEntry domain.DNSEntry `json:"dnsEntry"`
+		}
+		data, err := json.Marshal(req.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &v))
+		posted = append(posted, v.DNSEntry)
+		return nil
+	}
+
+	p := newProvider()
+	p.domainRepo = domain.Repository{Client: client}
+
+	// the apex alias is read as the CNAME record of the apex, the other aliases are not managed
+	endpoints, err := p.Records(context.TODO())
+	require.NoError(t, err)
+	if assert.Len(t, endpoints, 1) {
+		assert.Equal(t, "example.org", endpoints[0].DNSName)
+		assert.Equal(t, endpoint.RecordTypeCNAME, endpoints[0].RecordType)
+		assert.Equal(t, endpoint.Targets{"lb.example.net"}, endpoints[0].Targets)
+	}
+
+	// the CNAME record of the apex is created as an alias
+	err = p.ApplyChanges(context.TODO(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DNSEntry{
+		{Name: "@", Expire: defaultTTL, Type: "ALIAS", Content: "lb.example.net."},
+		{Name: "www", Expire: defaultTTL, Type: "CNAME", Content: "lb.example.net."},
+	}, posted)
+}
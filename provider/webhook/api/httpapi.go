@@ -36,6 +36,7 @@ const (
 	UrlAdjustEndpoints        = "/adjustendpoints"
 	UrlApplyChanges           = "/applychanges"
 	UrlRecords                = "/records"
+	SupportedRecordTypesKey   = "supportedRecordTypes"
 )
 
 type WebhookServer struct {
@@ -105,18 +106,44 @@ func (p *WebhookServer) AdjustEndpointsHandler(w http.ResponseWriter, req *http.
 }
 
 func (p *WebhookServer) NegotiateHandler(w http.ResponseWriter, _ *http.Request) {
-	w.Header().Set(ContentTypeHeader, MediaTypeFormatAndVersion)
-	err := json.NewEncoder(w).Encode(p.Provider.GetDomainFilter())
+	body, err := NegotiationBody(p.Provider.GetDomainFilter(), p.Provider.SupportedRecordTypes())
 	if err != nil {
+		log.Errorf("Failed to encode the negotiation: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(ContentTypeHeader, MediaTypeFormatAndVersion)
+	_, _ = w.Write(body)
+}
+
+// NegotiationBody returns the body of the negotiation: the serialized domain filter, along with the
+// record types supported by the provider under the SupportedRecordTypesKey when they are limited.
+// Older clients ignore the supported record types.
+func NegotiationBody(domainFilter endpoint.DomainFilterInterface, supportedRecordTypes []string) ([]byte, error) {
+	b, err := json.Marshal(domainFilter)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = map[string]json.RawMessage{}
+	}
+	if len(supportedRecordTypes) > 0 {
+		if fields[SupportedRecordTypesKey], err = json.Marshal(supportedRecordTypes); err != nil {
+			return nil, err
+		}
 	}
+	return json.Marshal(fields)
 }
 
 // StartHTTPApi starts a HTTP server given any provider.
 // the function takes an optional channel as input which is used to signal that the server has started.
 // The server will listen on port `providerPort`.
 // The server will respond to the following endpoints:
-// - / (GET): initialization, negotiates headers and returns the domain filter
+// - / (GET): initialization, negotiates headers and returns the domain filter and the supported record types
 // - /records (GET): returns the current records
 // - /records (POST): applies the changes
 // - /adjustendpoints (POST): executes the AdjustEndpoints method
@@ -38,9 +38,10 @@ import (
 var records []*endpoint.Endpoint
 
 type FakeWebhookProvider struct {
-	err           error
-	domainFilter  *endpoint.DomainFilter
-	assertChanges func(*plan.Changes)
+	err                  error
+	domainFilter         *endpoint.DomainFilter
+	supportedRecordTypes []string
+	assertChanges        func(*plan.Changes)
 }
 
 func (p FakeWebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
@@ -73,6 +74,14 @@ func (p FakeWebhookProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return p.domainFilter
 }
 
+func (p FakeWebhookProvider) SupportedRecordTypes() []string {
+	return p.supportedRecordTypes
+}
+
+func (p FakeWebhookProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return 0, 0
+}
+
 func TestMain(m *testing.M) {
 	records = []*endpoint.Endpoint{
 		{
@@ -341,6 +350,30 @@ func TestNegotiateHandler_Success(t *testing.T) {
 	require.Equal(t, provider.domainFilter, df)
 }
 
+func TestNegotiateHandler_SupportedRecordTypes(t *testing.T) {
+	provider := &FakeWebhookProvider{
+		domainFilter:         endpoint.NewDomainFilter([]string{"foo.bar.com"}),
+		supportedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}
+	server := &WebhookServer{Provider: provider}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	server.NegotiateHandler(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"include":["foo.bar.com"],"supportedRecordTypes":["A","CNAME"]}`, string(body))
+
+	// The domain filter is still readable by the clients ignoring the supported record types.
+	df := &endpoint.DomainFilter{}
+	require.NoError(t, df.UnmarshalJSON(body))
+	require.Equal(t, provider.domainFilter, df)
+}
+
 func TestNegotiateHandler_FiltersWithSpecialEncodings(t *testing.T) {
 	provider := &FakeWebhookProvider{
 		domainFilter: endpoint.NewDomainFilter([]string{"\\u001a", "\\Xfoo.\\u2028, \\u0000.com", "<invalid json>"}),
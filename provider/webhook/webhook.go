@@ -21,8 +21,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/metrics"
@@ -89,6 +91,12 @@ type WebhookProvider struct {
 	client          *http.Client
 	remoteServerURL *url.URL
 	DomainFilter    *endpoint.DomainFilter
+	// supportedRecordTypes are the record types advertised by the server, nil when it supports all of them
+	supportedRecordTypes []string
+	// maxAttempts is the number of times idempotent requests are attempted before giving up
+	maxAttempts int
+	// retryBaseDelay is the initial delay of the exponential backoff between attempts
+	retryBaseDelay time.Duration
 }
 
 func init() {
@@ -100,7 +108,7 @@ func init() {
 	metrics.RegisterMetric.MustRegister(adjustEndpointsRequestsGauge)
 }
 
-func NewWebhookProvider(u string) (*WebhookProvider, error) {
+func NewWebhookProvider(u string, maxAttempts int, retryBaseDelay time.Duration) (*WebhookProvider, error) {
 	parsedURL, err := url.Parse(u)
 	if err != nil {
 		return nil, err
@@ -126,15 +134,35 @@ func NewWebhookProvider(u string) (*WebhookProvider, error) {
 		return nil, fmt.Errorf("wrong content type returned from server: %s", ct)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the negotiation response body: %w", err)
+	}
+
 	df := &endpoint.DomainFilter{}
-	if err := json.NewDecoder(resp.Body).Decode(df); err != nil {
+	if err := json.Unmarshal(body, df); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body of DomainFilter: %w", err)
 	}
 
+	// the record types are only advertised by the servers supporting a limited set of them
+	var capabilities map[string]json.RawMessage
+	if err := json.Unmarshal(body, &capabilities); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body of negotiation: %w", err)
+	}
+	var supportedRecordTypes []string
+	if raw, ok := capabilities[webhookapi.SupportedRecordTypesKey]; ok {
+		if err := json.Unmarshal(raw, &supportedRecordTypes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the supported record types: %w", err)
+		}
+	}
+
 	return &WebhookProvider{
-		client:          client,
-		remoteServerURL: parsedURL,
-		DomainFilter:    df,
+		client:               client,
+		remoteServerURL:      parsedURL,
+		DomainFilter:         df,
+		supportedRecordTypes: supportedRecordTypes,
+		maxAttempts:          maxAttempts,
+		retryBaseDelay:       retryBaseDelay,
 	}, nil
 }
 
@@ -154,19 +182,53 @@ func requestWithRetry(client *http.Client, req *http.Request) (*http.Response, e
 	return resp, err
 }
 
+// doWithRetry performs the request returned by newRequest and retries it with exponential backoff
+// on connection errors and retryable status codes, up to maxAttempts times.
+// A new request is built for every attempt so that request bodies can be sent again.
+// Client errors are not retried, and the response of the last attempt is returned as is.
+func (p WebhookProvider) doWithRetry(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	maxAttempts := max(p.maxAttempts, 1)
+	attempt := 0
+
+	b := backoff.NewExponentialBackOff()
+	if p.retryBaseDelay > 0 {
+		b.InitialInterval = p.retryBaseDelay
+	}
+
+	return backoff.Retry(ctx, func() (*http.Response, error) {
+		attempt++
+		req, err := newRequest()
+		if err != nil {
+			return nil, backoff.Permanent(err)
+		}
+		resp, err := p.client.Do(req)
+		if err != nil {
+			log.Debugf("Failed to perform request (attempt %d/%d): %s", attempt, maxAttempts, err.Error())
+			return nil, err
+		}
+		if isRetryableError(resp.StatusCode) && attempt < maxAttempts {
+			resp.Body.Close()
+			log.Debugf("Request to %s failed with code %d (attempt %d/%d), retrying", req.URL.Path, resp.StatusCode, attempt, maxAttempts)
+			return nil, fmt.Errorf("request failed with code %d", resp.StatusCode)
+		}
+		return resp, nil
+	}, backoff.WithBackOff(b), backoff.WithMaxTries(uint(maxAttempts)))
+}
+
 // Records will make a GET call to remoteServerURL/records and return the results
 func (p WebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	recordsRequestsGauge.Gauge.Inc()
 	u := p.remoteServerURL.JoinPath("records").String()
 
-	req, err := http.NewRequest(http.MethodGet, u, nil)
-	if err != nil {
-		recordsErrorsGauge.Gauge.Inc()
-		log.Debugf("Failed to create request: %s", err.Error())
-		return nil, err
-	}
-	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
-	resp, err := p.client.Do(req)
+	resp, err := p.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			log.Debugf("Failed to create request: %s", err.Error())
+			return nil, err
+		}
+		req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
+		return req, nil
+	})
 	if err != nil {
 		recordsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to perform request: %s", err.Error())
@@ -255,17 +317,17 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u, b)
-	if err != nil {
-		adjustEndpointsErrorsGauge.Gauge.Inc()
-		log.Debugf("Failed to create new HTTP request, %s", err)
-		return nil, err
-	}
-
-	req.Header.Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
-	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
-
-	resp, err := p.client.Do(req)
+	body := b.Bytes()
+	resp, err := p.doWithRetry(context.Background(), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+		if err != nil {
+			log.Debugf("Failed to create new HTTP request, %s", err)
+			return nil, err
+		}
+		req.Header.Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+		req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
+		return req, nil
+	})
 	if err != nil {
 		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed executing http request, %s", err)
@@ -297,6 +359,16 @@ func (p WebhookProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return p.DomainFilter
 }
 
+// SupportedRecordTypes returns the record types advertised by the server during the negotiation
+func (p WebhookProvider) SupportedRecordTypes() []string {
+	return p.supportedRecordTypes
+}
+
+// TTLLimits returns no limits, the TTLs are validated by the server
+func (p WebhookProvider) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return 0, 0
+}
+
 // isRetryableError returns true for HTTP status codes between 500 and 510 (inclusive)
 func isRetryableError(statusCode int) bool {
 	return statusCode >= http.StatusInternalServerError && statusCode <= http.StatusNotExtended
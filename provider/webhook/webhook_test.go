@@ -36,12 +36,12 @@ import (
 )
 
 func TestNewWebhookProvider_InvalidURL(t *testing.T) {
-	_, err := NewWebhookProvider("://invalid-url")
+	_, err := NewWebhookProvider("://invalid-url", 1, 0)
 	require.Error(t, err)
 }
 
 func TestNewWebhookProvider_HTTPRequestFailure(t *testing.T) {
-	_, err := NewWebhookProvider("http://nonexistent.url")
+	_, err := NewWebhookProvider("http://nonexistent.url", 1, 0)
 	require.Error(t, err)
 }
 
@@ -53,7 +53,7 @@ func TestNewWebhookProvider_InvalidResponseBody(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := NewWebhookProvider(svr.URL)
+	_, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "failed to unmarshal response body of DomainFilter")
 }
@@ -64,7 +64,7 @@ func TestNewWebhookProvider_Non2XXStatusCode(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := NewWebhookProvider(svr.URL)
+	_, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "status code < 500")
 }
@@ -79,7 +79,7 @@ func TestNewWebhookProvider_WrongContentTypeHeader(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := NewWebhookProvider(svr.URL)
+	_, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "wrong content type returned from server")
 }
@@ -97,7 +97,7 @@ func TestInvalidDomainFilter(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := NewWebhookProvider(svr.URL)
+	_, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.Error(t, err)
 }
 
@@ -113,11 +113,54 @@ func TestValidDomainfilter(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := NewWebhookProvider(svr.URL)
+	p, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 	require.Equal(t, p.GetDomainFilter(), endpoint.NewDomainFilter([]string{"example.com"}))
 }
 
+func TestSupportedRecordTypes(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		body     string
+		expected []string
+	}{
+		{
+			title: "not advertised",
+			body:  `{"include":["example.com"]}`,
+		},
+		{
+			title:    "limited record types",
+			body:     `{"include":["example.com"],"supportedRecordTypes":["A","AAAA","CNAME"]}`,
+			expected: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+				w.Write([]byte(tc.body))
+			}))
+			defer svr.Close()
+
+			p, err := NewWebhookProvider(svr.URL, 1, 0)
+			require.NoError(t, err)
+			require.Equal(t, endpoint.NewDomainFilter([]string{"example.com"}), p.GetDomainFilter())
+			require.Equal(t, tc.expected, p.SupportedRecordTypes())
+		})
+	}
+}
+
+func TestSupportedRecordTypesInvalid(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+		w.Write([]byte(`{"supportedRecordTypes":"A"}`))
+	}))
+	defer svr.Close()
+
+	_, err := NewWebhookProvider(svr.URL, 1, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to unmarshal the supported record types")
+}
+
 func TestRecords(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
@@ -132,7 +175,7 @@ func TestRecords(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	provider, err := NewWebhookProvider(svr.URL)
+	provider, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 	endpoints, err := provider.Records(context.TODO())
 	require.NoError(t, err)
@@ -154,7 +197,7 @@ func TestRecordsWithErrors(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := NewWebhookProvider(svr.URL)
+	p, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 	_, err = p.Records(context.Background())
 	require.Error(t, err)
@@ -240,7 +283,7 @@ func TestApplyChanges(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := NewWebhookProvider(svr.URL)
+	p, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 	err = p.ApplyChanges(context.TODO(), nil)
 	require.NoError(t, err)
@@ -286,7 +329,7 @@ func TestApplyChanges_StatusCodeError(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := NewWebhookProvider(svr.URL)
+	p, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 
 	err = p.ApplyChanges(context.TODO(), nil)
@@ -323,7 +366,7 @@ func TestAdjustEndpoints(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	provider, err := NewWebhookProvider(svr.URL)
+	provider, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 	endpoints := []*endpoint.Endpoint{
 		{
@@ -359,7 +402,7 @@ func TestAdjustendpointsWithError(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := NewWebhookProvider(svr.URL)
+	p, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 	endpoints := []*endpoint.Endpoint{
 		{
@@ -403,7 +446,7 @@ func TestApplyChangesWithProviderSpecificProperty(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := NewWebhookProvider(svr.URL)
+	p, err := NewWebhookProvider(svr.URL, 1, 0)
 	require.NoError(t, err)
 	e := &endpoint.Endpoint{
 		DNSName:    "test.example.com",
@@ -531,3 +574,115 @@ func TestRequestWithRetry_NonRetriableStatus(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, resp)
 }
+
+func TestRecords_RetriesTransientErrors(t *testing.T) {
+	attempts := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+			w.Write([]byte(`{}`))
+			return
+		}
+		assert.Equal(t, "/records", r.URL.Path)
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[{
+			"dnsName" : "test.example.com"
+		}]`))
+	}))
+	defer svr.Close()
+
+	p, err := NewWebhookProvider(svr.URL, 3, time.Millisecond)
+	require.NoError(t, err)
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, []*endpoint.Endpoint{{
+		DNSName: "test.example.com",
+	}}, endpoints)
+}
+
+func TestRecords_RetriesExhausted(t *testing.T) {
+	attempts := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+			w.Write([]byte(`{}`))
+			return
+		}
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer svr.Close()
+
+	p, err := NewWebhookProvider(svr.URL, 2, time.Millisecond)
+	require.NoError(t, err)
+	_, err = p.Records(context.Background())
+	require.Error(t, err)
+	require.ErrorIs(t, err, provider.SoftError)
+	require.Contains(t, err.Error(), "failed to get records with code 503")
+	require.Equal(t, 2, attempts)
+}
+
+func TestRecords_ClientErrorIsNotRetried(t *testing.T) {
+	attempts := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+			w.Write([]byte(`{}`))
+			return
+		}
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+
+	p, err := NewWebhookProvider(svr.URL, 5, time.Millisecond)
+	require.NoError(t, err)
+	_, err = p.Records(context.Background())
+	require.Error(t, err)
+	require.NotErrorIs(t, err, provider.SoftError)
+	require.Equal(t, 1, attempts)
+}
+
+func TestAdjustEndpoints_RetriesTransientErrors(t *testing.T) {
+	attempts := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+			w.Write([]byte(`{}`))
+			return
+		}
+		assert.Equal(t, webhookapi.UrlAdjustEndpoints, r.URL.Path)
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var endpoints []*endpoint.Endpoint
+		defer r.Body.Close()
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &endpoints))
+		w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+		require.NoError(t, json.NewEncoder(w).Encode(&endpoints))
+	}))
+	defer svr.Close()
+
+	p, err := NewWebhookProvider(svr.URL, 3, time.Millisecond)
+	require.NoError(t, err)
+	endpoints := []*endpoint.Endpoint{
+		{
+			DNSName:    "test.example.com",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"192.0.2.1"},
+		},
+	}
+	adjusted, err := p.AdjustEndpoints(endpoints)
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, endpoints, adjusted)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// NormalizeWeights scales the weights of the weighted endpoints, set in the given provider specific property,
+// into the range from 0 to maxWeight accepted by the provider. The weights of a DNS name and record type
+// are scaled down together when one of them exceeds maxWeight, keeping their ratios: the largest becomes maxWeight.
+// The weights in range are left unchanged, the negative weights become 0, and the positive weights stay positive
+// so that no endpoint stops receiving traffic. It is meant to be called by AdjustEndpoints.
+func NormalizeWeights(endpoints []*endpoint.Endpoint, property string, maxWeight int64) {
+	type weightKey struct {
+		dnsName    string
+		recordType string
+	}
+	keyOf := func(ep *endpoint.Endpoint) weightKey {
+		return weightKey{dnsName: strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")), recordType: ep.RecordType}
+	}
+
+	largest := map[weightKey]int64{}
+	for _, ep := range endpoints {
+		if weight, ok := endpointWeight(ep, property); ok {
+			largest[keyOf(ep)] = max(largest[keyOf(ep)], weight)
+		}
+	}
+
+	for _, ep := range endpoints {
+		weight, ok := endpointWeight(ep, property)
+		if !ok {
+			continue
+		}
+		normalized := max(weight, 0)
+		if top := largest[keyOf(ep)]; top > maxWeight {
+			normalized = int64(math.Round(float64(normalized) * float64(maxWeight) / float64(top)))
+			if normalized == 0 && weight > 0 {
+				normalized = 1
+			}
+		}
+		if normalized != weight {
+			log.Debugf("Modifying endpoint: %v, setting %s=%d", ep, property, normalized)
+			ep.SetProviderSpecificProperty(property, strconv.FormatInt(normalized, 10))
+		}
+	}
+}
+
+// endpointWeight returns the weight of the endpoint, if it has a valid one.
+func endpointWeight(ep *endpoint.Endpoint, property string) (int64, bool) {
+	value, ok := ep.GetProviderSpecificProperty(property)
+	if !ok {
+		return 0, false
+	}
+	weight, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return weight, true
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestNormalizeWeights(t *testing.T) {
+	const property = "test/weight"
+
+	for _, tc := range []struct {
+		name      string
+		weights   []string
+		maxWeight int64
+		expected  []string
+	}{
+		{
+			name:      "weights in range",
+			weights:   []string{"1", "3"},
+			maxWeight: 255,
+			expected:  []string{"1", "3"},
+		},
+		{
+			name:      "weights scaled into range",
+			weights:   []string{"1", "3"},
+			maxWeight: 2,
+			expected:  []string{"1", "2"},
+		},
+		{
+			name:      "large weights keep their ratio",
+			weights:   []string{"1000", "3000"},
+			maxWeight: 255,
+			expected:  []string{"85", "255"},
+		},
+		{
+			name:      "positive weights stay positive",
+			weights:   []string{"1", "1000", "0"},
+			maxWeight: 255,
+			expected:  []string{"1", "255", "0"},
+		},
+		{
+			name:      "negative weights",
+			weights:   []string{"-1", "3"},
+			maxWeight: 255,
+			expected:  []string{"0", "3"},
+		},
+		{
+			name:      "invalid and missing weights are left unchanged",
+			weights:   []string{"heavy", "", "300"},
+			maxWeight: 255,
+			expected:  []string{"heavy", "", "255"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var endpoints []*endpoint.Endpoint
+			for i, weight := range tc.weights {
+				ep := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1").WithSetIdentifier(string(rune('a' + i)))
+				if weight != "" {
+					ep.WithProviderSpecific(property, weight)
+				}
+				endpoints = append(endpoints, ep)
+			}
+
+			NormalizeWeights(endpoints, property, tc.maxWeight)
+
+			var weights []string
+			for _, ep := range endpoints {
+				weight, _ := ep.GetProviderSpecificProperty(property)
+				weights = append(weights, weight)
+			}
+			assert.Equal(t, tc.expected, weights)
+		})
+	}
+}
+
+func TestNormalizeWeightsPerName(t *testing.T) {
+	const property = "test/weight"
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1").WithSetIdentifier("a").WithProviderSpecific(property, "100"),
+		endpoint.NewEndpoint("App.example.org.", endpoint.RecordTypeA, "192.0.2.2").WithSetIdentifier("b").WithProviderSpecific(property, "300"),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeAAAA, "2001:db8::1").WithSetIdentifier("a").WithProviderSpecific(property, "100"),
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "192.0.2.3").WithSetIdentifier("a").WithProviderSpecific(property, "100"),
+	}
+
+	NormalizeWeights(endpoints, property, 255)
+
+	// only the weights of the name and record type exceeding the range are scaled
+	var weights []string
+	for _, ep := range endpoints {
+		weight, _ := ep.GetProviderSpecificProperty(property)
+		weights = append(weights, weight)
+	}
+	assert.Equal(t, []string{"85", "255", "100", "100"}, weights)
+}
@@ -46,6 +46,14 @@ func (sdr *AWSSDRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return sdr.provider.GetDomainFilter()
 }
 
+func (sdr *AWSSDRegistry) SupportedRecordTypes() []string {
+	return sdr.provider.SupportedRecordTypes()
+}
+
+func (sdr *AWSSDRegistry) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return sdr.provider.TTLLimits()
+}
+
 func (im *AWSSDRegistry) OwnerID() string {
 	return im.ownerID
 }
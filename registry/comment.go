@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// CommentRegistry implements registry interface with ownership information stored in the comment of the records,
+// instead of separate TXT records. The provider reports and sets the comment as the commentKey provider specific property.
+type CommentRegistry struct {
+	provider   provider.Provider
+	ownerID    string
+	commentKey string
+	// maxLength is the maximum length of the comments, the resource label is not stored when exceeding it.
+	maxLength int
+}
+
+// NewCommentRegistry returns implementation of registry storing the ownership in the comment of the records
+func NewCommentRegistry(provider provider.Provider, ownerID, commentKey string, maxLength int) (*CommentRegistry, error) {
+	if ownerID == "" {
+		return nil, errors.New("owner id cannot be empty")
+	}
+	if commentKey == "" {
+		return nil, errors.New("comment key cannot be empty")
+	}
+	return &CommentRegistry{
+		provider:   provider,
+		ownerID:    ownerID,
+		commentKey: commentKey,
+		maxLength:  maxLength,
+	}, nil
+}
+
+func (cr *CommentRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return cr.provider.GetDomainFilter()
+}
+
+func (cr *CommentRegistry) SupportedRecordTypes() []string {
+	return cr.provider.SupportedRecordTypes()
+}
+
+func (cr *CommentRegistry) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return cr.provider.TTLLimits()
+}
+
+func (cr *CommentRegistry) OwnerID() string {
+	return cr.ownerID
+}
+
+// Records returns the records of the provider with the labels serialized in their comment.
+// The records without comment, or with a comment not written by External DNS, are not owned.
+func (cr *CommentRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := cr.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		comment, ok := record.GetProviderSpecificProperty(cr.commentKey)
+		if !ok {
+			continue
+		}
+		labels, err := endpoint.NewLabelsFromStringPlain(comment)
+		if err != nil {
+			// the comment is not managed by External DNS and is left as is
+			continue
+		}
+		if record.Labels == nil {
+			record.Labels = endpoint.NewLabels()
+		}
+		for key, value := range labels {
+			record.Labels[key] = value
+		}
+		// the comment is managed by the registry and never compared with the desired endpoints
+		record.DeleteProviderSpecificProperty(cr.commentKey)
+	}
+
+	return records, nil
+}
+
+// ApplyChanges filters out records not owned the External-DNS, additionally it serializes the labels
+// of the records in their comment
+func (cr *CommentRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	filteredChanges := &plan.Changes{
+		Create:    changes.Create,
+		UpdateNew: endpoint.FilterEndpointsByOwnerID(cr.ownerID, changes.UpdateNew),
+		UpdateOld: endpoint.FilterEndpointsByOwnerID(cr.ownerID, changes.UpdateOld),
+		Delete:    endpoint.FilterEndpointsByOwnerID(cr.ownerID, changes.Delete),
+	}
+
+	cr.updateComments(filteredChanges.Create)
+	cr.updateComments(filteredChanges.UpdateNew)
+
+	return cr.provider.ApplyChanges(ctx, filteredChanges)
+}
+
+func (cr *CommentRegistry) updateComments(endpoints []*endpoint.Endpoint) {
+	for _, ep := range endpoints {
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
+		}
+		ep.Labels[endpoint.OwnerLabelKey] = cr.ownerID
+		comment := ep.Labels.SerializePlain(false)
+		if cr.maxLength > 0 && len(comment) > cr.maxLength {
+			log.Debugf("Not storing the resource of %s in its comment longer than %d chars", ep.DNSName, cr.maxLength)
+			comment = endpoint.Labels{endpoint.OwnerLabelKey: cr.ownerID}.SerializePlain(false)
+		}
+		ep.SetProviderSpecificProperty(cr.commentKey, comment)
+	}
+}
+
+// AdjustEndpoints modifies the endpoints as needed by the specific provider, the comments of the desired
+// endpoints are dropped as the comment of the records stores their ownership.
+func (cr *CommentRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	adjusted, err := cr.provider.AdjustEndpoints(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	for _, ep := range adjusted {
+		ep.DeleteProviderSpecificProperty(cr.commentKey)
+	}
+	return adjusted, nil
+}
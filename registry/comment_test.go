@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const testCommentKey = "external-dns.alpha.kubernetes.io/test-comment"
+
+var _ Registry = &CommentRegistry{}
+
+func TestNewCommentRegistry(t *testing.T) {
+	p := newInMemoryProvider(nil, nil)
+	_, err := NewCommentRegistry(p, "", testCommentKey, 0)
+	require.Error(t, err)
+
+	_, err = NewCommentRegistry(p, "owner", "", 0)
+	require.Error(t, err)
+
+	r, err := NewCommentRegistry(p, "owner", testCommentKey, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "owner", r.OwnerID())
+}
+
+func TestCommentRegistryRecords(t *testing.T) {
+	p := newInMemoryProvider([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("owned.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(testCommentKey, "heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/owned"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(testCommentKey, "heritage=external-dns,external-dns/owner=other-owner"),
+		endpoint.NewEndpoint("commented.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(testCommentKey, "managed by hand"),
+		endpoint.NewEndpoint("unowned.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+	}, nil)
+	r, err := NewCommentRegistry(p, "owner", testCommentKey, 0)
+	require.NoError(t, err)
+
+	records, err := r.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	assert.Equal(t, endpoint.Labels{endpoint.OwnerLabelKey: "owner", endpoint.ResourceLabelKey: "ingress/default/owned"}, records[0].Labels)
+	assert.Empty(t, records[0].ProviderSpecific)
+	assert.Equal(t, endpoint.Labels{endpoint.OwnerLabelKey: "other-owner"}, records[1].Labels)
+	assert.Empty(t, records[2].Labels)
+	comment, ok := records[2].GetProviderSpecificProperty(testCommentKey)
+	assert.True(t, ok)
+	assert.Equal(t, "managed by hand", comment)
+	assert.Empty(t, records[3].Labels)
+}
+
+func TestCommentRegistryApplyChanges(t *testing.T) {
+	var got *plan.Changes
+	p := newInMemoryProvider(nil, func(changes *plan.Changes) {
+		got = changes
+	})
+	r, err := NewCommentRegistry(p, "owner", testCommentKey, 100)
+	require.NoError(t, err)
+
+	err = r.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithLabel(endpoint.ResourceLabelKey, "ingress/default/new"),
+			endpoint.NewEndpoint("long.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithLabel(endpoint.ResourceLabelKey, "ingress/default/a-very-long-ingress-name"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			newEndpointWithOwner("updated.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			newEndpointWithOwner("updated.example.org", "5.6.7.8", endpoint.RecordTypeA, "owner"),
+		},
+		Delete: []*endpoint.Endpoint{
+			newEndpointWithOwner("deleted.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+			newEndpointWithOwner("other.example.org", "1.2.3.4", endpoint.RecordTypeA, "other-owner"),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, got.Create, 2)
+	comment, _ := got.Create[0].GetProviderSpecificProperty(testCommentKey)
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/new", comment)
+	comment, _ = got.Create[1].GetProviderSpecificProperty(testCommentKey)
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=owner", comment)
+	require.Len(t, got.UpdateNew, 1)
+	comment, _ = got.UpdateNew[0].GetProviderSpecificProperty(testCommentKey)
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=owner", comment)
+	require.Len(t, got.Delete, 1)
+	assert.Equal(t, "deleted.example.org", got.Delete[0].DNSName)
+}
+
+func TestCommentRegistryAdjustEndpoints(t *testing.T) {
+	r, err := NewCommentRegistry(newInMemoryProvider(nil, nil), "owner", testCommentKey, 0)
+	require.NoError(t, err)
+
+	endpoints, err := r.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(testCommentKey, "from annotation"),
+	})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	_, ok := endpoints[0].GetProviderSpecificProperty(testCommentKey)
+	assert.False(t, ok)
+}
@@ -52,11 +52,10 @@ type DynamoDBRegistry struct {
 	table       string
 
 	// For migration from TXT registry
-	mapper              nameMapper
-	wildcardReplacement string
-	managedRecordTypes  []string
-	excludeRecordTypes  []string
-	txtEncryptAESKey    []byte
+	mapper             nameMapper
+	managedRecordTypes []string
+	excludeRecordTypes []string
+	txtEncryptAESKey   []byte
 
 	// cache the dynamodb records owned by us.
 	labels         map[endpoint.EndpointKey]endpoint.Labels
@@ -97,16 +96,15 @@ func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI
 	mapper := newaffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement)
 
 	return &DynamoDBRegistry{
-		provider:            provider,
-		ownerID:             ownerID,
-		dynamodbAPI:         dynamodbAPI,
-		table:               table,
-		mapper:              mapper,
-		wildcardReplacement: txtWildcardReplacement,
-		managedRecordTypes:  managedRecordTypes,
-		excludeRecordTypes:  excludeRecordTypes,
-		txtEncryptAESKey:    txtEncryptAESKey,
-		cacheInterval:       cacheInterval,
+		provider:           provider,
+		ownerID:            ownerID,
+		dynamodbAPI:        dynamodbAPI,
+		table:              table,
+		mapper:             mapper,
+		managedRecordTypes: managedRecordTypes,
+		excludeRecordTypes: excludeRecordTypes,
+		txtEncryptAESKey:   txtEncryptAESKey,
+		cacheInterval:      cacheInterval,
 	}, nil
 }
 
@@ -114,6 +112,14 @@ func (im *DynamoDBRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
 }
 
+func (im *DynamoDBRegistry) SupportedRecordTypes() []string {
+	return im.provider.SupportedRecordTypes()
+}
+
+func (im *DynamoDBRegistry) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return im.provider.TTLLimits()
+}
+
 func (im *DynamoDBRegistry) OwnerID() string {
 	return im.ownerID
 }
@@ -180,14 +186,9 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 				continue
 			}
 
-			dnsNameSplit := strings.Split(ep.DNSName, ".")
-			// If specified, replace a leading asterisk in the generated txt record name with some other string
-			if im.wildcardReplacement != "" && dnsNameSplit[0] == "*" {
-				dnsNameSplit[0] = im.wildcardReplacement
-			}
-			dnsName := strings.Join(dnsNameSplit, ".")
+			// the name mapper restores the leading asterisk of wildcard records replaced in the TXT record names
 			key := endpoint.EndpointKey{
-				DNSName:       dnsName,
+				DNSName:       ep.DNSName,
 				SetIdentifier: ep.SetIdentifier,
 			}
 			if ep.RecordType == endpoint.RecordTypeAAAA {
@@ -40,6 +40,14 @@ func (im *NoopRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
 }
 
+func (im *NoopRegistry) SupportedRecordTypes() []string {
+	return im.provider.SupportedRecordTypes()
+}
+
+func (im *NoopRegistry) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return im.provider.TTLLimits()
+}
+
 func (im *NoopRegistry) OwnerID() string {
 	return ""
 }
@@ -32,5 +32,7 @@ type Registry interface {
 	ApplyChanges(ctx context.Context, changes *plan.Changes) error
 	AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error)
 	GetDomainFilter() endpoint.DomainFilterInterface
+	SupportedRecordTypes() []string
+	TTLLimits() (minTTL, maxTTL endpoint.TTL)
 	OwnerID() string
 }
@@ -19,6 +19,7 @@ package registry
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
@@ -34,6 +35,8 @@ import (
 const (
 	recordTemplate              = "%{record_type}"
 	providerSpecificForceUpdate = "txt/force-update"
+	// the number of TXT record names queried at once from the providers able to query their records by name
+	txtLookupChunkSize = 100
 )
 
 // TXTRegistry implements registry interface with ownership implemented via associated TXT records
@@ -42,34 +45,50 @@ type TXTRegistry struct {
 	ownerID  string // refers to the owner id of the current instance
 	mapper   nameMapper
 
+	// the records owned by these owner ids are taken over by the current instance
+	adoptedOwnerIDs []string
+	// the previous owner id of the adopted records, until their TXT records are rewritten
+	adoptedRecords map[endpoint.EndpointKey]string
+
+	// rewrite the malformed TXT records of the current instance in the canonical format
+	repairOwnership bool
+	// the malformed value of the TXT records to repair, by TXT record name
+	repairedTXTRecords map[string]string
+
+	// no TXT records are created for these record types, their records are considered owned by the current instance
+	skipRecordTypes []string
+
 	// cache the records in memory and update on an interval instead.
 	recordsCache            []*endpoint.Endpoint
 	recordsCacheRefreshTime time.Time
 	cacheInterval           time.Duration
 
-	// optional string to use to replace the asterisk in wildcard entries - without using this,
-	// registry TXT records corresponding to wildcard records will be invalid (and rejected by most providers), due to
-	// having a '*' appear (not as the first character) - see https://tools.ietf.org/html/rfc1034#section-4.3.3
-	wildcardReplacement string
-
 	managedRecordTypes []string
 	excludeRecordTypes []string
+	// the names holding TXT records not written by a registry, e.g. by another controller, are left alone:
+	// no TXT records are written at these names and the records whose TXT records would be there are not created
+	ignoreForeignRecords bool
+	// the lowercase names of the foreign TXT records
+	foreignTXTNames map[string]struct{}
 
 	// encrypt text records
 	txtEncryptEnabled bool
 	txtEncryptAESKey  []byte
 }
 
-// NewTXTRegistry returns a new TXTRegistry object. When newFormatOnly is true, it will only
-// generate new format TXT records, otherwise it generates both old and new formats for
-// backwards compatibility.
+// NewTXTRegistry returns a new TXTRegistry object. It only generates new format TXT records,
+// and reads both the old and the new formats.
 func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID string,
 	cacheInterval time.Duration, txtWildcardReplacement string,
 	managedRecordTypes, excludeRecordTypes []string,
-	txtEncryptEnabled bool, txtEncryptAESKey []byte) (*TXTRegistry, error) {
+	txtEncryptEnabled bool, txtEncryptAESKey []byte, adoptedOwnerIDs []string, repairOwnership bool, skipRecordTypes []string,
+	ignoreForeignRecords bool) (*TXTRegistry, error) {
 	if ownerID == "" {
 		return nil, errors.New("owner id cannot be empty")
 	}
+	if slices.Contains(adoptedOwnerIDs, ownerID) || slices.Contains(adoptedOwnerIDs, "") {
+		return nil, errors.New("adopted owner ids cannot be empty or the owner id")
+	}
 
 	if len(txtEncryptAESKey) == 0 {
 		txtEncryptAESKey = nil
@@ -90,16 +109,28 @@ func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID st
 
 	mapper := newaffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement)
 
+	// the record types of the caller are left untouched
+	upperSkipRecordTypes := make([]string, 0, len(skipRecordTypes))
+	for _, recordType := range skipRecordTypes {
+		upperSkipRecordTypes = append(upperSkipRecordTypes, strings.ToUpper(recordType))
+	}
+
 	return &TXTRegistry{
-		provider:            provider,
-		ownerID:             ownerID,
-		mapper:              mapper,
-		cacheInterval:       cacheInterval,
-		wildcardReplacement: txtWildcardReplacement,
-		managedRecordTypes:  managedRecordTypes,
-		excludeRecordTypes:  excludeRecordTypes,
-		txtEncryptEnabled:   txtEncryptEnabled,
-		txtEncryptAESKey:    txtEncryptAESKey,
+		provider:             provider,
+		ownerID:              ownerID,
+		mapper:               mapper,
+		cacheInterval:        cacheInterval,
+		managedRecordTypes:   managedRecordTypes,
+		excludeRecordTypes:   excludeRecordTypes,
+		ignoreForeignRecords: ignoreForeignRecords,
+		foreignTXTNames:      map[string]struct{}{},
+		txtEncryptEnabled:    txtEncryptEnabled,
+		txtEncryptAESKey:     txtEncryptAESKey,
+		adoptedOwnerIDs:      adoptedOwnerIDs,
+		adoptedRecords:       map[endpoint.EndpointKey]string{},
+		repairOwnership:      repairOwnership,
+		repairedTXTRecords:   map[string]string{},
+		skipRecordTypes:      upperSkipRecordTypes,
 	}, nil
 }
 
@@ -111,6 +142,14 @@ func (im *TXTRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
 }
 
+func (im *TXTRegistry) SupportedRecordTypes() []string {
+	return im.provider.SupportedRecordTypes()
+}
+
+func (im *TXTRegistry) TTLLimits() (endpoint.TTL, endpoint.TTL) {
+	return im.provider.TTLLimits()
+}
+
 func (im *TXTRegistry) OwnerID() string {
 	return im.ownerID
 }
@@ -126,12 +165,15 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		return im.recordsCache, nil
 	}
 
-	records, err := im.provider.Records(ctx)
+	records, err := im.records(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	endpoints := []*endpoint.Endpoint{}
+	adoptedRecords := map[endpoint.EndpointKey]string{}
+	repairedTXTRecords := map[string]string{}
+	foreignTXTNames := map[string]struct{}{}
 
 	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
 	txtRecordsMap := map[string]struct{}{}
@@ -148,10 +190,19 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			continue
 		}
 		labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
+		if errors.Is(err, endpoint.ErrInvalidHeritage) && im.repairOwnership {
+			// a malformed TXT record of this instance is recovered and rewritten in the canonical format
+			if recovered, recoverErr := endpoint.RecoverLabelsFromString(record.Targets[0]); recoverErr == nil && recovered[endpoint.OwnerLabelKey] == im.ownerID {
+				log.Infof("Repairing malformed TXT record %s: %s", record.DNSName, record.Targets[0])
+				labels, err = recovered, nil
+				repairedTXTRecords[record.DNSName] = record.Targets[0]
+			}
+		}
 		if errors.Is(err, endpoint.ErrInvalidHeritage) {
 			// if no heritage is found or it is invalid
 			// case when value of txt record cannot be identified
 			// record will not be removed as it will have empty owner
+			foreignTXTNames[strings.ToLower(record.DNSName)] = struct{}{}
 			endpoints = append(endpoints, record)
 			continue
 		}
@@ -173,14 +224,9 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		if ep.Labels == nil {
 			ep.Labels = endpoint.NewLabels()
 		}
-		dnsNameSplit := strings.Split(ep.DNSName, ".")
-		// If specified, replace a leading asterisk in the generated txt record name with some other string
-		if im.wildcardReplacement != "" && dnsNameSplit[0] == "*" {
-			dnsNameSplit[0] = im.wildcardReplacement
-		}
-		dnsName := strings.Join(dnsNameSplit, ".")
+		// the name mapper restores the leading asterisk of wildcard records replaced in the TXT record names
 		key := endpoint.EndpointKey{
-			DNSName:       dnsName,
+			DNSName:       ep.DNSName,
 			RecordType:    ep.RecordType,
 			SetIdentifier: ep.SetIdentifier,
 		}
@@ -202,6 +248,31 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			}
 		}
 
+		// Take over the records of the adopted owner ids, their TXT records are rewritten with the owner id of this instance.
+		if owner := ep.Labels[endpoint.OwnerLabelKey]; owner != "" && slices.Contains(im.adoptedOwnerIDs, owner) {
+			log.Debugf("Adopting record %s/%s owned by %q", ep.DNSName, ep.RecordType, owner)
+			adoptedRecords[ep.Key()] = owner
+			ep.Labels[endpoint.OwnerLabelKey] = im.ownerID
+			ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
+		}
+
+		// Rewrite the repaired TXT records of this instance.
+		if len(repairedTXTRecords) > 0 && labelsExist && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID {
+			for _, txt := range im.generateTXTRecord(ep) {
+				if _, ok := repairedTXTRecords[txt.DNSName]; ok {
+					ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
+				}
+			}
+		}
+
+		// The records of the skipped record types have no TXT records and are owned by this instance.
+		if slices.Contains(im.skipRecordTypes, ep.RecordType) {
+			if ep.Labels[endpoint.OwnerLabelKey] == "" {
+				ep.Labels[endpoint.OwnerLabelKey] = im.ownerID
+			}
+			continue
+		}
+
 		// Handle the migration of TXT records created before the new format (introduced in v0.12.0).
 		// The migration is done for the TXT records owned by this instance only.
 		if len(txtRecordsMap) > 0 && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID {
@@ -217,6 +288,10 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 	}
 
+	im.adoptedRecords = adoptedRecords
+	im.repairedTXTRecords = repairedTXTRecords
+	im.foreignTXTNames = foreignTXTNames
+
 	// Update the cache.
 	if im.cacheInterval > 0 {
 		im.recordsCache = endpoints
@@ -226,19 +301,82 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 	return endpoints, nil
 }
 
-// generateTXTRecord generates TXT records in either both formats (old and new) or new format only,
-// depending on the newFormatOnly configuration. The old format is maintained for backwards
-// compatibility but can be disabled to reduce the number of DNS records.
+// hasForeignTXT returns whether a TXT record of the endpoint would be written at a name holding
+// foreign TXT records, when they are left alone.
+func (im *TXTRegistry) hasForeignTXT(r *endpoint.Endpoint) bool {
+	if !im.ignoreForeignRecords {
+		return false
+	}
+	for _, txt := range im.generateTXTRecord(r) {
+		if _, ok := im.foreignTXTNames[strings.ToLower(txt.DNSName)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// records returns the current records of the provider. When the provider can query its records by name
+// and the TXT records are not managed, only the TXT records named after the other records are fetched,
+// looked up in chunks, instead of listing all of them.
+func (im *TXTRegistry) records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	lookup, ok := provider.AsRecordsByNameProvider(im.provider)
+	if !ok || plan.IsManagedRecord(endpoint.RecordTypeTXT, im.managedRecordTypes, im.excludeRecordTypes) {
+		return im.provider.Records(ctx)
+	}
+
+	records, err := lookup.RecordsExcludingType(ctx, endpoint.RecordTypeTXT)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	seen := map[string]struct{}{}
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeTXT {
+			continue
+		}
+		txtNames := []string{im.mapper.toTXTName(record.DNSName, txtRecordType(record))}
+		// AAAA records have no TXT records of the old format
+		if record.RecordType != endpoint.RecordTypeAAAA {
+			txtNames = append(txtNames, im.mapper.toOldTXTName(record.DNSName))
+		}
+		for _, name := range txtNames {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+
+	for chunk := range slices.Chunk(names, txtLookupChunkSize) {
+		txtRecords, err := lookup.RecordsByName(ctx, endpoint.RecordTypeTXT, chunk)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, txtRecords...)
+	}
+	return records, nil
+}
+
+// txtRecordType returns the record type in the name of the TXT record of an endpoint.
+func txtRecordType(r *endpoint.Endpoint) string {
+	// AWS Alias records are encoded as type "cname"
+	if isAlias, found := r.GetProviderSpecificProperty("alias"); found && isAlias == "true" && r.RecordType == endpoint.RecordTypeA {
+		return endpoint.RecordTypeCNAME
+	}
+	return r.RecordType
+}
+
+// generateTXTRecord generates the TXT records of the new format, prefixed with the record type.
+// Old format TXT records are still read, but no longer generated.
 func (im *TXTRegistry) generateTXTRecord(r *endpoint.Endpoint) []*endpoint.Endpoint {
 	endpoints := make([]*endpoint.Endpoint, 0)
+	if slices.Contains(im.skipRecordTypes, r.RecordType) {
+		return endpoints
+	}
 
 	// Always create new format record
-	recordType := r.RecordType
-	// AWS Alias records are encoded as type "cname"
-	if isAlias, found := r.GetProviderSpecificProperty("alias"); found && isAlias == "true" && recordType == endpoint.RecordTypeA {
-		recordType = endpoint.RecordTypeCNAME
-	}
-	txtNew := endpoint.NewEndpoint(im.mapper.toTXTName(r.DNSName, recordType), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
+	txtNew := endpoint.NewEndpoint(im.mapper.toTXTName(r.DNSName, txtRecordType(r)), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
 	if txtNew != nil {
 		txtNew.WithSetIdentifier(r.SetIdentifier)
 		txtNew.Labels[endpoint.OwnedRecordLabelKey] = r.DNSName
@@ -248,11 +386,54 @@ func (im *TXTRegistry) generateTXTRecord(r *endpoint.Endpoint) []*endpoint.Endpo
 	return endpoints
 }
 
+// withAdoptedOwner returns the endpoint with the owner id of its TXT records in the provider:
+// the previous owner id for the adopted records, which are no longer tracked afterwards.
+func (im *TXTRegistry) withAdoptedOwner(r *endpoint.Endpoint) *endpoint.Endpoint {
+	owner, ok := im.adoptedRecords[r.Key()]
+	if !ok {
+		return r
+	}
+	delete(im.adoptedRecords, r.Key())
+
+	adopted := r.DeepCopy()
+	adopted.Labels[endpoint.OwnerLabelKey] = owner
+	return adopted
+}
+
+// withRepairedTXT returns the TXT records with their malformed value in the provider
+// for the repaired records, which are no longer tracked afterwards.
+func (im *TXTRegistry) withRepairedTXT(txts []*endpoint.Endpoint) []*endpoint.Endpoint {
+	for _, txt := range txts {
+		if value, ok := im.repairedTXTRecords[txt.DNSName]; ok {
+			delete(im.repairedTXTRecords, txt.DNSName)
+			txt.Targets = endpoint.Targets{value}
+		}
+	}
+	return txts
+}
+
+// withoutForeignTXTCreates returns the created records whose TXT records don't conflict with foreign TXT records.
+// The other records are not created, as they could not be owned without overwriting the foreign TXT records.
+func (im *TXTRegistry) withoutForeignTXTCreates(creates []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if !im.ignoreForeignRecords || len(im.foreignTXTNames) == 0 {
+		return creates
+	}
+	filtered := make([]*endpoint.Endpoint, 0, len(creates))
+	for _, r := range creates {
+		if im.hasForeignTXT(r) {
+			log.Warnf("Not creating record %s/%s, its TXT record name holds TXT records not written by external-dns", r.DNSName, r.RecordType)
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
 // ApplyChanges updates dns provider with the changes
 // for each created/deleted record it will also take into account TXT records for creation/deletion
 func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	filteredChanges := &plan.Changes{
-		Create:    changes.Create,
+		Create:    im.withoutForeignTXTCreates(changes.Create),
 		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
 		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
 		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
@@ -274,7 +455,7 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		// when we delete TXT records for which value has changed (due to new label) this would still work because
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
 		// !!! After migration to the new TXT registry format we can drop records in old format here!!!
-		filteredChanges.Delete = append(filteredChanges.Delete, im.generateTXTRecord(r)...)
+		filteredChanges.Delete = append(filteredChanges.Delete, im.withRepairedTXT(im.generateTXTRecord(im.withAdoptedOwner(r)))...)
 
 		if im.cacheInterval > 0 {
 			im.removeFromCache(r)
@@ -285,7 +466,7 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	for _, r := range filteredChanges.UpdateOld {
 		// when we updateOld TXT records for which value has changed (due to new label) this would still work because
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
-		filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
+		filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.withRepairedTXT(im.generateTXTRecord(im.withAdoptedOwner(r)))...)
 		// remove old version of record from cache
 		if im.cacheInterval > 0 {
 			im.removeFromCache(r)
@@ -321,12 +502,16 @@ func (im *TXTRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 type nameMapper interface {
 	toEndpointName(string) (endpointName string, recordType string)
 	toTXTName(string, string) string
+	toOldTXTName(string) string
 	recordTypeInAffix() bool
 }
 
 type affixNameMapper struct {
-	prefix              string
-	suffix              string
+	prefix string
+	suffix string
+	// optional string to use to replace the asterisk in wildcard entries - without using this,
+	// registry TXT records corresponding to wildcard records will be invalid (and rejected by most providers), due to
+	// having a '*' appear (not as the first character) - see https://tools.ietf.org/html/rfc1034#section-4.3.3
 	wildcardReplacement string
 }
 
@@ -402,7 +587,8 @@ func (pr affixNameMapper) toEndpointName(txtDNSName string) (string, string) {
 
 	// drop prefix
 	if pr.isPrefix() {
-		return pr.dropAffixExtractType(lowerDNSName)
+		r, rType := pr.dropAffixExtractType(lowerDNSName)
+		return pr.restoreWildcard(r), rType
 	}
 
 	// drop suffix
@@ -413,13 +599,27 @@ func (pr affixNameMapper) toEndpointName(txtDNSName string) (string, string) {
 
 		r, rType := pr.dropAffixExtractType(domainWithSuffix)
 		if !strings.Contains(lowerDNSName, ".") {
-			return r, rType
+			return pr.restoreWildcard(r), rType
 		}
-		return r + "." + DNSName[1+dc], rType
+		return pr.restoreWildcard(r + "." + DNSName[1+dc]), rType
 	}
 	return "", ""
 }
 
+// restoreWildcard replaces a leading wildcard replacement in the endpoint name with an asterisk,
+// reversing the replacement done by toTXTName. The replacement is matched case-insensitively.
+func (pr affixNameMapper) restoreWildcard(endpointName string) string {
+	if pr.wildcardReplacement == "" {
+		return endpointName
+	}
+	DNSName := strings.SplitN(endpointName, ".", 2)
+	if !strings.EqualFold(DNSName[0], pr.wildcardReplacement) {
+		return endpointName
+	}
+	DNSName[0] = "*"
+	return strings.Join(DNSName, ".")
+}
+
 func (pr affixNameMapper) recordTypeInAffix() bool {
 	if strings.Contains(pr.prefix, recordTemplate) {
 		return true
@@ -461,6 +661,24 @@ func (pr affixNameMapper) toTXTName(endpointDNSName, recordType string) string {
 	return prefix + DNSName[0] + suffix + "." + DNSName[1]
 }
 
+// toOldTXTName returns the name of the TXT record of the old format, without record type, of an endpoint.
+func (pr affixNameMapper) toOldTXTName(endpointDNSName string) string {
+	DNSName := strings.SplitN(endpointDNSName, ".", 2)
+
+	prefix := pr.dropAffixTemplate(pr.prefix)
+	suffix := pr.dropAffixTemplate(pr.suffix)
+
+	if pr.wildcardReplacement != "" && DNSName[0] == "*" {
+		DNSName[0] = pr.wildcardReplacement
+	}
+
+	if len(DNSName) < 2 {
+		return prefix + DNSName[0] + suffix
+	}
+
+	return prefix + DNSName[0] + suffix + "." + DNSName[1]
+}
+
 func (im *TXTRegistry) addToCache(ep *endpoint.Endpoint) {
 	if im.recordsCache != nil {
 		im.recordsCache = append(im.recordsCache, ep)
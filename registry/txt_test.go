@@ -18,7 +18,9 @@ package registry
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -48,20 +50,20 @@ func TestTXTRegistry(t *testing.T) {
 
 func testTXTRegistryNew(t *testing.T) {
 	p := inmemory.NewInMemoryProvider()
-	_, err := NewTXTRegistry(p, "txt", "", "", time.Hour, "", []string{}, []string{}, false, nil)
+	_, err := NewTXTRegistry(p, "txt", "", "", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	require.Error(t, err)
 
-	_, err = NewTXTRegistry(p, "", "txt", "", time.Hour, "", []string{}, []string{}, false, nil)
+	_, err = NewTXTRegistry(p, "", "txt", "", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	require.Error(t, err)
 
-	r, err := NewTXTRegistry(p, "txt", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, err := NewTXTRegistry(p, "txt", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	require.NoError(t, err)
 	assert.Equal(t, p, r.provider)
 
-	r, err = NewTXTRegistry(p, "", "txt", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, err = NewTXTRegistry(p, "", "txt", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	require.NoError(t, err)
 
-	_, err = NewTXTRegistry(p, "txt", "txt", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	_, err = NewTXTRegistry(p, "txt", "txt", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	require.Error(t, err)
 
 	_, ok := r.mapper.(affixNameMapper)
@@ -70,16 +72,16 @@ func testTXTRegistryNew(t *testing.T) {
 	assert.Equal(t, p, r.provider)
 
 	aesKey := []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^")
-	_, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	_, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	require.NoError(t, err)
 
-	_, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, aesKey)
+	_, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, aesKey, nil, false, nil, false)
 	require.NoError(t, err)
 
-	_, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, true, nil)
+	_, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, true, nil, nil, false, nil, false)
 	require.Error(t, err)
 
-	r, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, true, aesKey)
+	r, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, true, aesKey, nil, false, nil, false)
 	require.NoError(t, err)
 
 	_, ok = r.mapper.(affixNameMapper)
@@ -227,13 +229,13 @@ func testTXTRegistryRecordsPrefixed(t *testing.T) {
 		},
 	}
 
-	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
 
 	// Ensure prefix is case-insensitive
-	r, _ = NewTXTRegistry(p, "TxT.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+	r, _ = NewTXTRegistry(p, "TxT.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ = r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
@@ -362,13 +364,13 @@ func testTXTRegistryRecordsSuffixed(t *testing.T) {
 		},
 	}
 
-	r, _ := NewTXTRegistry(p, "", "-txt", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "-txt", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
 
 	// Ensure prefix is case-insensitive
-	r, _ = NewTXTRegistry(p, "", "-TxT", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ = NewTXTRegistry(p, "", "-TxT", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ = r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpointLabels(records, expectedRecords))
@@ -489,7 +491,7 @@ func testTXTRegistryRecordsNoPrefix(t *testing.T) {
 		},
 	}
 
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
@@ -526,12 +528,12 @@ func testTXTRegistryRecordsPrefixedTemplated(t *testing.T) {
 		},
 	}
 
-	r, _ := NewTXTRegistry(p, "txt-%{record_type}.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "txt-%{record_type}.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
 
-	r, _ = NewTXTRegistry(p, "TxT-%{record_type}.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+	r, _ = NewTXTRegistry(p, "TxT-%{record_type}.", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ = r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
@@ -568,12 +570,12 @@ func testTXTRegistryRecordsSuffixedTemplated(t *testing.T) {
 		},
 	}
 
-	r, _ := NewTXTRegistry(p, "", "txt%{record_type}", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "txt%{record_type}", "owner", time.Hour, "wc", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
 
-	r, _ = NewTXTRegistry(p, "", "TxT%{record_type}", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+	r, _ = NewTXTRegistry(p, "", "TxT%{record_type}", "owner", time.Hour, "wc", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ = r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
@@ -616,7 +618,7 @@ func testTXTRegistryApplyChangesWithPrefix(t *testing.T) {
 			newEndpointWithOwner("txt.cname-multiple.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, "").WithSetIdentifier("test-set-2"),
 		},
 	})
-	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 
 	changes := &plan.Changes{
 		Create: []*endpoint.Endpoint{
@@ -697,7 +699,7 @@ func testTXTRegistryApplyChangesWithTemplatedPrefix(t *testing.T) {
 	p.ApplyChanges(ctx, &plan.Changes{
 		Create: []*endpoint.Endpoint{},
 	})
-	r, _ := NewTXTRegistry(p, "prefix%{record_type}.", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "prefix%{record_type}.", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	changes := &plan.Changes{
 		Create: []*endpoint.Endpoint{
 			newEndpointWithOwnerResource("new-record-1.test-zone.example.org", "new-loadbalancer-1.lb.com", endpoint.RecordTypeCNAME, "", "ingress/default/my-ingress"),
@@ -740,7 +742,7 @@ func testTXTRegistryApplyChangesWithTemplatedSuffix(t *testing.T) {
 	p.OnApplyChanges = func(ctx context.Context, got *plan.Changes) {
 		assert.Equal(t, ctxEndpoints, ctx.Value(provider.RecordsContextKey))
 	}
-	r, _ := NewTXTRegistry(p, "", "-%{record_type}suffix", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "-%{record_type}suffix", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	changes := &plan.Changes{
 		Create: []*endpoint.Endpoint{
 			newEndpointWithOwnerResource("new-record-1.test-zone.example.org", "new-loadbalancer-1.lb.com", endpoint.RecordTypeCNAME, "", "ingress/default/my-ingress"),
@@ -805,7 +807,7 @@ func testTXTRegistryApplyChangesWithSuffix(t *testing.T) {
 			newEndpointWithOwner("cname-multiple-txt.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, "").WithSetIdentifier("test-set-2"),
 		},
 	})
-	r, _ := NewTXTRegistry(p, "", "-txt", "owner", time.Hour, "wildcard", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "-txt", "owner", time.Hour, "wildcard", []string{}, []string{}, false, nil, nil, false, nil, false)
 
 	changes := &plan.Changes{
 		Create: []*endpoint.Endpoint{
@@ -899,7 +901,7 @@ func testTXTRegistryApplyChangesNoPrefix(t *testing.T) {
 			newEndpointWithOwner("cname-foobar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
 		},
 	})
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 
 	changes := &plan.Changes{
 		Create: []*endpoint.Endpoint{
@@ -1057,7 +1059,7 @@ func testTXTRegistryMissingRecordsNoPrefix(t *testing.T) {
 		},
 	}
 
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "wc", []string{endpoint.RecordTypeCNAME, endpoint.RecordTypeA, endpoint.RecordTypeNS}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "wc", []string{endpoint.RecordTypeCNAME, endpoint.RecordTypeA, endpoint.RecordTypeNS}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
@@ -1167,7 +1169,7 @@ func testTXTRegistryMissingRecordsWithPrefix(t *testing.T) {
 		},
 	}
 
-	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "wc", []string{endpoint.RecordTypeCNAME, endpoint.RecordTypeA, endpoint.RecordTypeNS, endpoint.RecordTypeTXT}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "wc", []string{endpoint.RecordTypeCNAME, endpoint.RecordTypeA, endpoint.RecordTypeNS, endpoint.RecordTypeTXT}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
@@ -1427,6 +1429,34 @@ func TestToEndpointNameNewTXT(t *testing.T) {
 			recordType: "A",
 			txtDomain:  "example.fooa.bar.com",
 		},
+		{
+			name:       "prefix with wildcard replacement",
+			mapper:     newaffixNameMapper("foo.", "", "star"),
+			domain:     "*.example.com",
+			recordType: "CNAME",
+			txtDomain:  "foo.cname-star.example.com",
+		},
+		{
+			name:       "suffix with wildcard replacement",
+			mapper:     newaffixNameMapper("", "-foo", "Star"),
+			domain:     "*.example.com",
+			recordType: "A",
+			txtDomain:  "a-star-foo.example.com",
+		},
+		{
+			name:       "templated prefix with wildcard replacement",
+			mapper:     newaffixNameMapper("%{record_type}-", "", "star"),
+			domain:     "*.example.com",
+			recordType: "AAAA",
+			txtDomain:  "aaaa-star.example.com",
+		},
+		{
+			name:       "wildcard replacement not matching the first label",
+			mapper:     newaffixNameMapper("foo.", "", "star"),
+			domain:     "stars.example.com",
+			recordType: "A",
+			txtDomain:  "foo.a-stars.example.com",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1440,6 +1470,367 @@ func TestToEndpointNameNewTXT(t *testing.T) {
 	}
 }
 
+func TestRestoreWildcard(t *testing.T) {
+	mapper := affixNameMapper{wildcardReplacement: "Star"}
+	assert.Equal(t, "*.example.com", mapper.restoreWildcard("star.example.com"))
+	assert.Equal(t, "*.example.com", mapper.restoreWildcard("STAR.example.com"))
+	assert.Equal(t, "stars.example.com", mapper.restoreWildcard("stars.example.com"))
+	assert.Equal(t, "star.example.com", affixNameMapper{}.restoreWildcard("star.example.com"))
+}
+
+func TestTXTRegistryWildcardReplacementRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("*.wildcard.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-star.wildcard.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			// a legitimate subdomain is not mistaken for the wildcard record
+			newEndpointWithOwner("wc.wildcard.test-zone.example.org", "wc.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+		},
+	})
+	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "Star", []string{}, []string{}, false, nil, nil, false, nil, false)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	expectedRecords := []*endpoint.Endpoint{
+		{
+			DNSName:    "*.wildcard.test-zone.example.org",
+			Targets:    endpoint.Targets{"foo.loadbalancer.com"},
+			RecordType: endpoint.RecordTypeCNAME,
+			Labels: map[string]string{
+				endpoint.OwnerLabelKey: "owner",
+			},
+		},
+		{
+			DNSName:    "wc.wildcard.test-zone.example.org",
+			Targets:    endpoint.Targets{"wc.loadbalancer.com"},
+			RecordType: endpoint.RecordTypeCNAME,
+			Labels: map[string]string{
+				endpoint.OwnerLabelKey: "",
+			},
+		},
+	}
+	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
+
+	err = r.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			newEndpointWithOwner("*.wildcard.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "owner"),
+		},
+	})
+	require.NoError(t, err)
+
+	remaining, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(remaining, []*endpoint.Endpoint{
+		newEndpointWithOwner("wc.wildcard.test-zone.example.org", "wc.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+	}))
+}
+
+func TestTXTRegistryAdoptedOwnerIDs(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=legacy-1,external-dns/resource=ingress/default/foo\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("txt.a-bar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=legacy-2,external-dns/resource=ingress/default/bar\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("baz.test-zone.example.org", "baz.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-baz.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=other,external-dns/resource=ingress/default/baz\"", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	_, err := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, []string{"owner"}, false, nil, false)
+	require.Error(t, err)
+
+	r, err := NewTXTRegistry(p, "txt.", "", "owner", 0, "", []string{}, []string{}, false, nil, []string{"legacy-1", "legacy-2"}, false, nil, false)
+	require.NoError(t, err)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwnerResource("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "owner", "ingress/default/foo").WithProviderSpecific(providerSpecificForceUpdate, "true"),
+		newEndpointWithOwnerResource("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner", "ingress/default/bar").WithProviderSpecific(providerSpecificForceUpdate, "true"),
+		newEndpointWithOwnerResource("baz.test-zone.example.org", "baz.loadbalancer.com", endpoint.RecordTypeCNAME, "other", "ingress/default/baz"),
+	}))
+
+	desired := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			newEndpointWithOwnerResource("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "", "ingress/default/foo"),
+			newEndpointWithOwnerResource("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", "ingress/default/bar"),
+			newEndpointWithOwnerResource("baz.test-zone.example.org", "baz.loadbalancer.com", endpoint.RecordTypeCNAME, "", "ingress/default/baz"),
+		}
+	}
+	changes := (&plan.Plan{
+		Current:        records,
+		Desired:        desired(),
+		DomainFilter:   endpoint.MatchAllDomainFilters{&endpoint.DomainFilter{}},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		OwnerID:        r.OwnerID(),
+	}).Calculate().Changes
+	require.NoError(t, r.ApplyChanges(ctx, changes))
+
+	// the TXT records of the adopted records are rewritten with the owner id of this instance
+	txtRecords := map[string]string{}
+	providerRecords, err := p.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range providerRecords {
+		if record.RecordType == endpoint.RecordTypeTXT {
+			txtRecords[record.DNSName] = record.Targets[0]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"txt.cname-foo.test-zone.example.org": "\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/foo\"",
+		"txt.a-bar.test-zone.example.org":     "\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/bar\"",
+		"txt.cname-baz.test-zone.example.org": "\"heritage=external-dns,external-dns/owner=other,external-dns/resource=ingress/default/baz\"",
+	}, txtRecords)
+
+	// the adopted records are no longer updated
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	changes = (&plan.Plan{
+		Current:        records,
+		Desired:        desired(),
+		DomainFilter:   endpoint.MatchAllDomainFilters{&endpoint.DomainFilter{}},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		OwnerID:        r.OwnerID(),
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+}
+
+func TestTXTRegistryRepairOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-foo.test-zone.example.org", "heritage=external-dns;external-dns/owner=owner;external-dns/resource=ingress/default/foo", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("txt.a-bar.test-zone.example.org", "\"Heritage=External-DNS, external-dns/owner=owner, external-dns/resource=ingress/default/bar\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("qux.test-zone.example.org", "5.6.7.8", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("txt.a-qux.test-zone.example.org", "external-dns/owner=owner,external-dns/resource=ingress/default/qux", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("baz.test-zone.example.org", "baz.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-baz.test-zone.example.org", "heritage=external-dns;external-dns/owner=other;external-dns/resource=ingress/default/baz", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("mate.test-zone.example.org", "mate.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-mate.test-zone.example.org", "heritage=mate;external-dns/owner=owner", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	// without repair the malformed TXT records are not recognized
+	r, err := NewTXTRegistry(p, "txt.", "", "owner", 0, "", []string{}, []string{}, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		if record.RecordType != endpoint.RecordTypeTXT {
+			assert.Empty(t, record.Labels[endpoint.OwnerLabelKey], record.DNSName)
+		}
+	}
+
+	r, err = NewTXTRegistry(p, "txt.", "", "owner", 0, "", []string{}, []string{}, false, nil, nil, true, nil, false)
+	require.NoError(t, err)
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwnerResource("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "owner", "ingress/default/foo").WithProviderSpecific(providerSpecificForceUpdate, "true"),
+		newEndpointWithOwnerResource("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner", "ingress/default/bar").WithProviderSpecific(providerSpecificForceUpdate, "true"),
+		newEndpointWithOwnerResource("qux.test-zone.example.org", "5.6.7.8", endpoint.RecordTypeA, "owner", "ingress/default/qux").WithProviderSpecific(providerSpecificForceUpdate, "true"),
+		newEndpointWithOwner("baz.test-zone.example.org", "baz.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+		newEndpointWithOwner("txt.cname-baz.test-zone.example.org", "heritage=external-dns;external-dns/owner=other;external-dns/resource=ingress/default/baz", endpoint.RecordTypeTXT, ""),
+		newEndpointWithOwner("mate.test-zone.example.org", "mate.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+		newEndpointWithOwner("txt.cname-mate.test-zone.example.org", "heritage=mate;external-dns/owner=owner", endpoint.RecordTypeTXT, ""),
+	}))
+
+	changes := (&plan.Plan{
+		Current: records,
+		Desired: []*endpoint.Endpoint{
+			newEndpointWithOwnerResource("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "", "ingress/default/foo"),
+			newEndpointWithOwnerResource("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", "ingress/default/bar"),
+			newEndpointWithOwnerResource("qux.test-zone.example.org", "5.6.7.8", endpoint.RecordTypeA, "", "ingress/default/qux"),
+		},
+		DomainFilter:   endpoint.MatchAllDomainFilters{&endpoint.DomainFilter{}},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		OwnerID:        r.OwnerID(),
+	}).Calculate().Changes
+	assert.Empty(t, changes.Delete)
+	require.NoError(t, r.ApplyChanges(ctx, changes))
+
+	// the repaired TXT records are rewritten in the canonical format, the data records are kept
+	providerRecords, err := p.Records(ctx)
+	require.NoError(t, err)
+	txtRecords := map[string]string{}
+	for _, record := range providerRecords {
+		if record.RecordType == endpoint.RecordTypeTXT {
+			txtRecords[record.DNSName] = record.Targets[0]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"txt.cname-foo.test-zone.example.org":  "\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/foo\"",
+		"txt.a-bar.test-zone.example.org":      "\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/bar\"",
+		"txt.a-qux.test-zone.example.org":      "\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/qux\"",
+		"txt.cname-baz.test-zone.example.org":  "heritage=external-dns;external-dns/owner=other;external-dns/resource=ingress/default/baz",
+		"txt.cname-mate.test-zone.example.org": "heritage=mate;external-dns/owner=owner",
+	}, txtRecords)
+	assert.Len(t, providerRecords, 10)
+
+	// the repaired records are no longer updated
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		_, forced := record.GetProviderSpecificProperty(providerSpecificForceUpdate)
+		assert.False(t, forced, record.DNSName)
+	}
+}
+
+func TestTXTRegistrySkipRecordTypes(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("sub.test-zone.example.org", "ns1.example.com", endpoint.RecordTypeNS, ""),
+			newEndpointWithOwner("old.test-zone.example.org", "ns2.example.com", endpoint.RecordTypeNS, ""),
+			newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	skipRecordTypes := []string{"ns"}
+	r, err := NewTXTRegistry(p, "txt.", "", "owner", 0, "", []string{}, []string{}, false, nil, nil, false, skipRecordTypes, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ns"}, skipRecordTypes)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwner("sub.test-zone.example.org", "ns1.example.com", endpoint.RecordTypeNS, "owner"),
+		newEndpointWithOwner("old.test-zone.example.org", "ns2.example.com", endpoint.RecordTypeNS, "owner"),
+		newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "owner"),
+	}))
+
+	changes := (&plan.Plan{
+		Current: records,
+		Desired: []*endpoint.Endpoint{
+			newEndpointWithOwner("sub.test-zone.example.org", "ns3.example.com", endpoint.RecordTypeNS, ""),
+			newEndpointWithOwner("new.test-zone.example.org", "ns1.example.com", endpoint.RecordTypeNS, ""),
+			newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", "bar.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+		},
+		DomainFilter:   endpoint.MatchAllDomainFilters{&endpoint.DomainFilter{}},
+		ManagedRecords: []string{endpoint.RecordTypeNS, endpoint.RecordTypeCNAME},
+		OwnerID:        r.OwnerID(),
+	}).Calculate().Changes
+	require.NoError(t, r.ApplyChanges(ctx, changes))
+
+	// the NS records are managed without TXT records
+	providerRecords, err := p.Records(ctx)
+	require.NoError(t, err)
+	got := []string{}
+	for _, record := range providerRecords {
+		got = append(got, record.DNSName+" "+record.RecordType+" "+record.Targets[0])
+	}
+	assert.ElementsMatch(t, []string{
+		"sub.test-zone.example.org NS ns3.example.com",
+		"new.test-zone.example.org NS ns1.example.com",
+		"foo.test-zone.example.org CNAME foo.loadbalancer.com",
+		"txt.cname-foo.test-zone.example.org TXT \"heritage=external-dns,external-dns/owner=owner\"",
+		"bar.test-zone.example.org CNAME bar.loadbalancer.com",
+		"txt.cname-bar.test-zone.example.org TXT \"heritage=external-dns,external-dns/owner=owner\"",
+	}, got)
+
+	// no update is forced for the missing TXT records of the skipped record types
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		_, forced := record.GetProviderSpecificProperty(providerSpecificForceUpdate)
+		assert.False(t, forced, record.DNSName)
+	}
+}
+
+func TestTXTRegistryIgnoreForeignRecords(t *testing.T) {
+	for _, tc := range []struct {
+		title          string
+		ignoreForeign  bool
+		expectedRecord []string
+	}{
+		{
+			title: "registry TXT record conflicting with a foreign TXT record",
+		},
+		{
+			title:         "foreign TXT records left alone",
+			ignoreForeign: true,
+			expectedRecord: []string{
+				"a-baz.test-zone.example.org TXT \"site-verification=abc\"",
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			ctx := context.Background()
+			p := inmemory.NewInMemoryProvider()
+			p.CreateZone(testZone)
+			p.ApplyChanges(ctx, &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+					newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+					// the TXT records of another controller, the second one at the name of a registry TXT record
+					newEndpointWithOwner("foo.test-zone.example.org", "\"v=spf1 -all\"", endpoint.RecordTypeTXT, ""),
+					newEndpointWithOwner("a-baz.test-zone.example.org", "\"site-verification=abc\"", endpoint.RecordTypeTXT, ""),
+				},
+			})
+
+			r, err := NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, nil, false, nil, tc.ignoreForeign)
+			require.NoError(t, err)
+
+			// the foreign TXT records are still read
+			records, err := r.Records(ctx)
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+				newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+				newEndpointWithOwner("foo.test-zone.example.org", "\"v=spf1 -all\"", endpoint.RecordTypeTXT, ""),
+				newEndpointWithOwner("a-baz.test-zone.example.org", "\"site-verification=abc\"", endpoint.RecordTypeTXT, ""),
+			}))
+
+			changes := (&plan.Plan{
+				Current: records,
+				Desired: []*endpoint.Endpoint{
+					newEndpointWithOwner("foo.test-zone.example.org", "5.6.7.8", endpoint.RecordTypeA, ""),
+					newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+					newEndpointWithOwner("baz.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+				},
+				DomainFilter:   endpoint.MatchAllDomainFilters{&endpoint.DomainFilter{}},
+				ManagedRecords: []string{endpoint.RecordTypeA},
+				OwnerID:        r.OwnerID(),
+			}).Calculate().Changes
+			if !tc.ignoreForeign {
+				require.Error(t, r.ApplyChanges(ctx, changes))
+				return
+			}
+			require.NoError(t, r.ApplyChanges(ctx, changes))
+
+			// the A records are managed, the TXT records of the other controller are left unchanged
+			// and the record whose registry TXT record would overwrite one of them is not created
+			providerRecords, err := p.Records(ctx)
+			require.NoError(t, err)
+			got := []string{}
+			for _, record := range providerRecords {
+				got = append(got, record.DNSName+" "+record.RecordType+" "+record.Targets[0])
+			}
+			assert.ElementsMatch(t, append([]string{
+				"foo.test-zone.example.org A 5.6.7.8",
+				"a-foo.test-zone.example.org TXT \"heritage=external-dns,external-dns/owner=owner\"",
+				"foo.test-zone.example.org TXT \"v=spf1 -all\"",
+				"bar.test-zone.example.org A 1.2.3.4",
+				"a-bar.test-zone.example.org TXT \"heritage=external-dns,external-dns/owner=owner\"",
+			}, tc.expectedRecord...), got)
+		})
+	}
+}
+
 func TestNewTXTScheme(t *testing.T) {
 	p := inmemory.NewInMemoryProvider()
 	p.CreateZone(testZone)
@@ -1462,7 +1853,7 @@ func TestNewTXTScheme(t *testing.T) {
 			newEndpointWithOwner("cname-foobar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
 		},
 	})
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 
 	changes := &plan.Changes{
 		Create: []*endpoint.Endpoint{
@@ -1527,7 +1918,7 @@ func TestGenerateTXT(t *testing.T) {
 	}
 	p := inmemory.NewInMemoryProvider()
 	p.CreateZone(testZone)
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	gotTXT := r.generateTXTRecord(record)
 	assert.Equal(t, expectedTXT, gotTXT)
 }
@@ -1546,7 +1937,7 @@ func TestGenerateTXTForAAAA(t *testing.T) {
 	}
 	p := inmemory.NewInMemoryProvider()
 	p.CreateZone(testZone)
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	gotTXT := r.generateTXTRecord(record)
 	assert.Equal(t, expectedTXT, gotTXT)
 }
@@ -1563,7 +1954,7 @@ func TestFailGenerateTXT(t *testing.T) {
 	expectedTXT := []*endpoint.Endpoint{}
 	p := inmemory.NewInMemoryProvider()
 	p.CreateZone(testZone)
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	gotTXT := r.generateTXTRecord(cnameRecord)
 	assert.Equal(t, expectedTXT, gotTXT)
 }
@@ -1581,7 +1972,7 @@ func TestTXTRegistryApplyChangesEncrypt(t *testing.T) {
 		},
 	})
 
-	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "", []string{}, []string{}, true, []byte("12345678901234567890123456789012"))
+	r, _ := NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "", []string{}, []string{}, true, []byte("12345678901234567890123456789012"), nil, false, nil, false)
 	records, _ := r.Records(ctx)
 	changes := &plan.Changes{
 		Delete: records,
@@ -1627,7 +2018,7 @@ func TestMultiClusterDifferentRecordTypeOwnership(t *testing.T) {
 		},
 	})
 
-	r, _ := NewTXTRegistry(p, "_owner.", "", "bar", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "_owner.", "", "bar", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	records, _ := r.Records(ctx)
 
 	// new cluster has same ingress host as other cluster and uses CNAME ingress address
@@ -1712,7 +2103,7 @@ func TestGenerateTXTRecordWithNewFormatOnly(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+			r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 			records := r.generateTXTRecord(tc.endpoint)
 
 			assert.Len(t, records, tc.expectedRecords, tc.description)
@@ -1741,7 +2132,7 @@ func TestApplyChangesWithNewFormatOnly(t *testing.T) {
 	p.CreateZone(testZone)
 	ctx := context.Background()
 
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 
 	changes := &plan.Changes{
 		Create: []*endpoint.Endpoint{
@@ -1789,7 +2180,7 @@ func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 		},
 	})
 
-	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, nil, false, nil, false)
 	hook := testutils.LogsUnderTestWithLogLevel(log.ErrorLevel, t)
 	records, err := r.Records(ctx)
 	require.NoError(t, err)
@@ -1807,3 +2198,133 @@ func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 
 	testutils.TestHelperLogContains("TXT record has no targets empty-targets.test-zone.example.org", hook, t)
 }
+
+// recordsByNameProvider is an inmemory provider able to query its records by name, recording the calls.
+type recordsByNameProvider struct {
+	*inmemory.InMemoryProvider
+	listings int
+	lookups  [][]string
+}
+
+func (p *recordsByNameProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	p.listings++
+	return p.InMemoryProvider.Records(ctx)
+}
+
+func (p *recordsByNameProvider) RecordsExcludingType(ctx context.Context, recordType string) ([]*endpoint.Endpoint, error) {
+	records, err := p.InMemoryProvider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := []*endpoint.Endpoint{}
+	for _, record := range records {
+		if record.RecordType != recordType {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+func (p *recordsByNameProvider) RecordsByName(ctx context.Context, recordType string, names []string) ([]*endpoint.Endpoint, error) {
+	p.lookups = append(p.lookups, names)
+	records, err := p.InMemoryProvider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := []*endpoint.Endpoint{}
+	for _, record := range records {
+		if record.RecordType == recordType && slices.Contains(names, record.DNSName) {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+func TestTXTRegistryRecordsByName(t *testing.T) {
+	ctx := context.Background()
+	p := &recordsByNameProvider{InMemoryProvider: inmemory.NewInMemoryProvider()}
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("txt.bar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=otherowner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("baz.test-zone.example.org", "2001:db8::1", endpoint.RecordTypeAAAA, ""),
+			newEndpointWithOwner("txt.aaaa-baz.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("txt.a-orphan.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	r, err := NewTXTRegistry(p, "txt.", "", "owner", 0, "", []string{}, []string{}, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "owner"),
+		newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "otherowner"),
+		newEndpointWithOwner("baz.test-zone.example.org", "2001:db8::1", endpoint.RecordTypeAAAA, "owner"),
+	}))
+
+	// only the TXT records of the new and the old formats of the other records are looked up
+	assert.Zero(t, p.listings)
+	require.Len(t, p.lookups, 1)
+	assert.ElementsMatch(t, []string{
+		"txt.cname-foo.test-zone.example.org",
+		"txt.foo.test-zone.example.org",
+		"txt.a-bar.test-zone.example.org",
+		"txt.bar.test-zone.example.org",
+		"txt.aaaa-baz.test-zone.example.org",
+	}, p.lookups[0])
+}
+
+func TestTXTRegistryRecordsByNameChunks(t *testing.T) {
+	ctx := context.Background()
+	p := &recordsByNameProvider{InMemoryProvider: inmemory.NewInMemoryProvider()}
+	p.CreateZone(testZone)
+	created := []*endpoint.Endpoint{}
+	for i := range 150 {
+		created = append(created, newEndpointWithOwner(fmt.Sprintf("foo-%d.test-zone.example.org", i), "1.2.3.4", endpoint.RecordTypeA, ""))
+	}
+	p.ApplyChanges(ctx, &plan.Changes{Create: created})
+
+	r, err := NewTXTRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 150)
+
+	// the 300 names of the TXT records of the new and the old formats are looked up in chunks
+	require.Len(t, p.lookups, 3)
+	for _, lookup := range p.lookups {
+		assert.Len(t, lookup, txtLookupChunkSize)
+	}
+}
+
+func TestTXTRegistryRecordsByNameManagedTXT(t *testing.T) {
+	ctx := context.Background()
+	p := &recordsByNameProvider{InMemoryProvider: inmemory.NewInMemoryProvider()}
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("text.test-zone.example.org", "\"some text\"", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	r, err := NewTXTRegistry(p, "txt.", "", "owner", 0, "", []string{endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT}, []string{}, false, nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	// all the records are listed to find the managed TXT records
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "owner"),
+		{DNSName: "text.test-zone.example.org", RecordType: endpoint.RecordTypeTXT, Targets: endpoint.Targets{"\"some text\""}, Labels: endpoint.Labels{}},
+	}))
+	assert.Equal(t, 1, p.listings)
+	assert.Empty(t, p.lookups)
+}
@@ -169,6 +169,9 @@ func (sc *ambassadorHostSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			log.Warningf("Could not get endpoints for Host %s", err)
 			continue
 		}
+
+		hostEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(hostEndpoints, host.Annotations), host.Annotations)
+
 		if len(hostEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from Host %s", fullname)
 			continue
@@ -27,11 +27,15 @@ const (
 	CloudflareCustomHostnameKey = AnnotationKeyPrefix + "cloudflare-custom-hostname"
 	CloudflareRegionKey         = AnnotationKeyPrefix + "cloudflare-region-key"
 	CloudflareRecordCommentKey  = AnnotationKeyPrefix + "cloudflare-record-comment"
+	// CloudflareLoadBalancerPoolKey The annotation used for binding a hostname to existing Cloudflare load balancer pools
+	CloudflareLoadBalancerPoolKey = AnnotationKeyPrefix + "cloudflare-load-balancer-pool"
 
 	AWSPrefix        = AnnotationKeyPrefix + "aws-"
 	SCWPrefix        = AnnotationKeyPrefix + "scw-"
+	AzurePrefix      = AnnotationKeyPrefix + "azure-"
 	WebhookPrefix    = AnnotationKeyPrefix + "webhook-"
 	CloudflarePrefix = AnnotationKeyPrefix + "cloudflare-"
+	DNSimplePrefix   = AnnotationKeyPrefix + "dnsimple-"
 
 	TtlKey     = AnnotationKeyPrefix + "ttl"
 	ttlMinimum = 1
@@ -40,6 +44,9 @@ const (
 	SetIdentifierKey = AnnotationKeyPrefix + "set-identifier"
 	AliasKey         = AnnotationKeyPrefix + "alias"
 	TargetKey        = AnnotationKeyPrefix + "target"
+	// The annotations used for specifying explicit IPv4 and IPv6 targets
+	TargetIPv4Key = AnnotationKeyPrefix + "target-ipv4"
+	TargetIPv6Key = AnnotationKeyPrefix + "target-ipv6"
 	// The annotation used for figuring out which controller is responsible
 	ControllerKey = AnnotationKeyPrefix + "controller"
 	// The annotation used for defining the desired hostname
@@ -51,8 +58,23 @@ const (
 	// The annotation used to determine the source of hostnames for ingresses.  This is an optional field - all
 	// available hostname sources are used if not specified.
 	IngressHostnameSourceKey = AnnotationKeyPrefix + "ingress-hostname-source"
+	// The annotation used for restricting the hostnames of the rules and the TLS section of an ingress to the listed
+	// primary hostnames. This is an optional field - all the hostnames are used if not specified.
+	IngressPrimaryHostsKey = AnnotationKeyPrefix + "ingress-primary-hosts"
 	// The value of the controller annotation so that we feel responsible
 	ControllerValue = "dns-controller"
 	// The annotation used for defining the desired hostname
 	InternalHostnameKey = AnnotationKeyPrefix + "internal-hostname"
+	// The annotation used for suppressing the listed record types for a single object
+	RecordTypeExcludeKey = AnnotationKeyPrefix + "record-type-exclude"
+	// The annotation used for defining alias hostnames published as CNAME records to the canonical hostname
+	HostnameAliasesKey = AnnotationKeyPrefix + "hostname-aliases"
+	// The annotation used for routing the endpoints of an object to one of several providers
+	ProviderKey = AnnotationKeyPrefix + "provider"
+	// The annotation used for leaving the records of an object untouched: they are neither created, updated nor deleted
+	ExcludeKey = AnnotationKeyPrefix + "exclude"
+	// The annotation used for protecting the records of an object from the deletion, e.g. once the object is deleted
+	DeleteProtectionKey = AnnotationKeyPrefix + "delete-protection"
+	// The annotation used for describing the records of an object in the comments of the providers supporting them
+	DescriptionKey = AnnotationKeyPrefix + "description"
 )
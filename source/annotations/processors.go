@@ -14,6 +14,8 @@ limitations under the License.
 package annotations
 
 import (
+	"net/netip"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -43,7 +45,7 @@ func TTLFromAnnotations(annotations map[string]string, resource string) endpoint
 		return ttlNotConfigured
 	}
 	if ttlValue < ttlMinimum || ttlValue > ttlMaximum {
-		log.Warnf("TTL value %q must be between [%d, %d]", ttlValue, ttlMinimum, ttlMaximum)
+		log.Warnf("%s: TTL value %d must be between [%d, %d]", resource, ttlValue, ttlMinimum, ttlMaximum)
 		return ttlNotConfigured
 	}
 	return endpoint.TTL(ttlValue)
@@ -95,13 +97,62 @@ func TargetsFromTargetAnnotation(annotations map[string]string) endpoint.Targets
 			targets = append(targets, targetHostname)
 		}
 	}
+	targets = append(targets, TypedTargetsFromAnnotations(annotations)...)
+	return targets
+}
+
+// TypedTargetsFromAnnotations gets the IP addresses from the optional "target-ipv4" and "target-ipv6" annotations.
+// Addresses that do not match the family of their annotation are logged and ignored.
+func TypedTargetsFromAnnotations(annotations map[string]string) endpoint.Targets {
+	var targets endpoint.Targets
+	for _, typed := range []struct {
+		key  string
+		isV4 bool
+	}{
+		{key: TargetIPv4Key, isV4: true},
+		{key: TargetIPv6Key, isV4: false},
+	} {
+		annotation, ok := annotations[typed.key]
+		if !ok || annotation == "" {
+			continue
+		}
+		for _, target := range strings.Split(annotation, ",") {
+			target = strings.TrimSpace(target)
+			if target == "" {
+				continue
+			}
+			addr, err := netip.ParseAddr(target)
+			if err != nil || addr.Is4() != typed.isV4 || addr.Is4In6() {
+				log.Warnf("Ignoring invalid target %q in %s annotation", target, typed.key)
+				continue
+			}
+			targets = append(targets, target)
+		}
+	}
 	return targets
 }
 
 // HostnamesFromAnnotations extracts the hostnames from the given annotations map.
 // It returns a slice of hostnames if the HostnameKey annotation is present, otherwise it returns nil.
 func HostnamesFromAnnotations(input map[string]string) []string {
-	return extractHostnamesFromAnnotations(input, HostnameKey)
+	annotation, ok := input[HostnameKey]
+	if !ok {
+		return nil
+	}
+	hostnames, _ := splitHostnameTargetsAnnotation(annotation)
+	return hostnames
+}
+
+// HostnameTargetsFromAnnotations extracts the hostnames mapped to their own targets in the HostnameKey
+// annotation, e.g. "a.example.com=1.2.3.4;b.example.com=5.6.7.8".
+// It returns nil if the annotation is not present or does not use the mapping format.
+func HostnameTargetsFromAnnotations(input map[string]string) map[string]endpoint.Targets {
+	annotation, ok := input[HostnameKey]
+	if !ok {
+		return nil
+	}
+	_, hostTargets := splitHostnameTargetsAnnotation(annotation)
+	return hostTargets
 }
 
 // InternalHostnamesFromAnnotations extracts the internal hostnames from the given annotations map.
@@ -110,12 +161,118 @@ func InternalHostnamesFromAnnotations(input map[string]string) []string {
 	return extractHostnamesFromAnnotations(input, InternalHostnameKey)
 }
 
+// HostnameAliasesFromAnnotations extracts the alias hostnames from the given annotations map.
+// It returns a slice of alias hostnames if the HostnameAliasesKey annotation is present, otherwise it returns nil.
+func HostnameAliasesFromAnnotations(input map[string]string) []string {
+	return extractHostnamesFromAnnotations(input, HostnameAliasesKey)
+}
+
+// IngressPrimaryHostsFromAnnotations extracts the primary hostnames from the given annotations map.
+// It returns a slice of primary hostnames if the IngressPrimaryHostsKey annotation is present, otherwise it returns nil.
+func IngressPrimaryHostsFromAnnotations(input map[string]string) []string {
+	return extractHostnamesFromAnnotations(input, IngressPrimaryHostsKey)
+}
+
 // SplitHostnameAnnotation splits a comma-separated hostname annotation string into a slice of hostnames.
 // It trims any leading or trailing whitespace and removes any spaces within the anno
 func SplitHostnameAnnotation(input string) []string {
 	return strings.Split(strings.TrimSpace(strings.ReplaceAll(input, " ", "")), ",")
 }
 
+// splitHostnameTargetsAnnotation splits a hostname annotation string into its hostnames and the targets of
+// the hostnames mapped to their own targets. Mapping entries are separated by semicolons and map a hostname
+// to comma-separated targets, entries without targets are comma-separated hostnames using the object targets.
+func splitHostnameTargetsAnnotation(input string) ([]string, map[string]endpoint.Targets) {
+	input = strings.TrimSpace(strings.ReplaceAll(input, " ", ""))
+	if !strings.Contains(input, "=") {
+		return SplitHostnameAnnotation(input), nil
+	}
+
+	var hostnames []string
+	hostTargets := map[string]endpoint.Targets{}
+	for _, entry := range strings.Split(input, ";") {
+		hostname, value, mapped := strings.Cut(entry, "=")
+		if !mapped {
+			if hostname != "" {
+				hostnames = append(hostnames, SplitHostnameAnnotation(hostname)...)
+			}
+			continue
+		}
+		if hostname == "" || value == "" {
+			log.Warnf("Ignoring invalid entry %q in %s annotation", entry, HostnameKey)
+			continue
+		}
+		hostnames = append(hostnames, hostname)
+		hostname = strings.TrimSuffix(hostname, ".")
+		for _, target := range strings.Split(value, ",") {
+			target = strings.TrimSuffix(target, ".")
+			if target != "" {
+				hostTargets[hostname] = append(hostTargets[hostname], target)
+			}
+		}
+	}
+	return hostnames, hostTargets
+}
+
+// ExcludedRecordTypesFromAnnotations extracts the record types listed in the RecordTypeExcludeKey annotation.
+// Record types are upper-cased, so "aaaa" and "AAAA" are equivalent. Unknown record types are logged and ignored.
+// It returns nil if the annotation is not present.
+func ExcludedRecordTypesFromAnnotations(input map[string]string) []string {
+	annotation, ok := input[RecordTypeExcludeKey]
+	if !ok || annotation == "" {
+		return nil
+	}
+	var recordTypes []string
+	for _, recordType := range strings.Split(annotation, ",") {
+		recordType = strings.ToUpper(strings.TrimSpace(recordType))
+		if recordType == "" {
+			continue
+		}
+		if recordType != endpoint.RecordTypeCNAME && !slices.Contains(endpoint.KnownRecordTypes, recordType) {
+			log.Warnf("Ignoring unknown record type %q in %s annotation", recordType, RecordTypeExcludeKey)
+			continue
+		}
+		recordTypes = append(recordTypes, recordType)
+	}
+	return recordTypes
+}
+
+// IsExcludedFromAnnotations returns whether the object is excluded with the exclude annotation.
+// Invalid values are ignored and logged as a warning.
+func IsExcludedFromAnnotations(input map[string]string) bool {
+	annotation, ok := input[ExcludeKey]
+	if !ok {
+		return false
+	}
+	excluded, err := strconv.ParseBool(annotation)
+	if err != nil {
+		log.Warnf("Ignoring invalid value %q of the %s annotation", annotation, ExcludeKey)
+		return false
+	}
+	return excluded
+}
+
+// IsDeleteProtectedFromAnnotations returns whether the records of the object are protected from the deletion
+// with the delete-protection annotation. Invalid values are ignored and logged as a warning.
+func IsDeleteProtectedFromAnnotations(input map[string]string) bool {
+	annotation, ok := input[DeleteProtectionKey]
+	if !ok {
+		return false
+	}
+	protected, err := strconv.ParseBool(annotation)
+	if err != nil {
+		log.Warnf("Ignoring invalid value %q of the %s annotation", annotation, DeleteProtectionKey)
+		return false
+	}
+	return protected
+}
+
+// DescriptionFromAnnotations returns the human description of the records of the object
+// set with the description annotation, empty when not set.
+func DescriptionFromAnnotations(input map[string]string) string {
+	return strings.TrimSpace(input[DescriptionKey])
+}
+
 func extractHostnamesFromAnnotations(input map[string]string, key string) []string {
 	annotation, ok := input[key]
 	if !ok {
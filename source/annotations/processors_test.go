@@ -107,6 +107,15 @@ func TestTargetsFromTargetAnnotation(t *testing.T) {
 			},
 			expected: endpoint.Targets{"example.com", "example.org"},
 		},
+		{
+			name: "target annotation combined with typed target annotations",
+			annotations: map[string]string{
+				TargetKey:     "example.com",
+				TargetIPv4Key: "192.0.2.1",
+				TargetIPv6Key: "2001:db8::1",
+			},
+			expected: endpoint.Targets{"example.com", "192.0.2.1", "2001:db8::1"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -117,6 +126,64 @@ func TestTargetsFromTargetAnnotation(t *testing.T) {
 	}
 }
 
+func TestTypedTargetsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    endpoint.Targets
+	}{
+		{
+			name:        "no typed target annotations",
+			annotations: map[string]string{},
+			expected:    endpoint.Targets(nil),
+		},
+		{
+			name: "ipv4 target annotation only",
+			annotations: map[string]string{
+				TargetIPv4Key: "192.0.2.1, 192.0.2.2",
+			},
+			expected: endpoint.Targets{"192.0.2.1", "192.0.2.2"},
+		},
+		{
+			name: "ipv6 target annotation only",
+			annotations: map[string]string{
+				TargetIPv6Key: "2001:db8::1",
+			},
+			expected: endpoint.Targets{"2001:db8::1"},
+		},
+		{
+			name: "mixed family target annotations",
+			annotations: map[string]string{
+				TargetIPv4Key: "192.0.2.1",
+				TargetIPv6Key: "2001:db8::1,2001:db8::2",
+			},
+			expected: endpoint.Targets{"192.0.2.1", "2001:db8::1", "2001:db8::2"},
+		},
+		{
+			name: "addresses of the wrong family are ignored",
+			annotations: map[string]string{
+				TargetIPv4Key: "2001:db8::1,192.0.2.1",
+				TargetIPv6Key: "192.0.2.2,::ffff:192.0.2.3,2001:db8::2",
+			},
+			expected: endpoint.Targets{"192.0.2.1", "2001:db8::2"},
+		},
+		{
+			name: "hostnames are ignored",
+			annotations: map[string]string{
+				TargetIPv4Key: "example.com",
+			},
+			expected: endpoint.Targets(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TypedTargetsFromAnnotations(tt.annotations)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestTTLFromAnnotations(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -194,6 +261,26 @@ func TestTTLFromAnnotations(t *testing.T) {
 			annotations: map[string]string{TtlKey: "20.5s"},
 			expectedTTL: endpoint.TTL(20),
 		},
+		{
+			name:        "TTL annotation value as integer seconds",
+			annotations: map[string]string{TtlKey: "300"},
+			expectedTTL: endpoint.TTL(300),
+		},
+		{
+			name:        "TTL annotation value as duration in seconds",
+			annotations: map[string]string{TtlKey: "300s"},
+			expectedTTL: endpoint.TTL(300),
+		},
+		{
+			name:        "TTL annotation value as duration in minutes",
+			annotations: map[string]string{TtlKey: "5m"},
+			expectedTTL: endpoint.TTL(300),
+		},
+		{
+			name:        "TTL annotation value is garbage",
+			annotations: map[string]string{TtlKey: "5 minutes"},
+			expectedTTL: endpoint.TTL(0),
+		},
 	}
 
 	for _, tt := range tests {
@@ -267,6 +354,13 @@ func TestHostnamesFromAnnotations(t *testing.T) {
 			},
 			expected: []string{"example.com", "example.org"},
 		},
+		{
+			name: "hostname annotation mapping hostnames to targets",
+			annotations: map[string]string{
+				HostnameKey: "a.example.com=1.2.3.4; b.example.com.=5.6.7.8,lb.example.net;c.example.com,d.example.com",
+			},
+			expected: []string{"a.example.com", "b.example.com.", "c.example.com", "d.example.com"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -277,6 +371,53 @@ func TestHostnamesFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestHostnameTargetsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[string]endpoint.Targets
+	}{
+		{
+			name:        "no hostname annotation",
+			annotations: map[string]string{},
+			expected:    nil,
+		},
+		{
+			name:        "hostnames without targets",
+			annotations: map[string]string{HostnameKey: "a.example.com,b.example.com"},
+			expected:    nil,
+		},
+		{
+			name:        "hostnames mapped to targets",
+			annotations: map[string]string{HostnameKey: "a.example.com=1.2.3.4;b.example.com=5.6.7.8"},
+			expected: map[string]endpoint.Targets{
+				"a.example.com": {"1.2.3.4"},
+				"b.example.com": {"5.6.7.8"},
+			},
+		},
+		{
+			name:        "hostnames mapped to multiple targets with trailing dots",
+			annotations: map[string]string{HostnameKey: " a.example.com. = 1.2.3.4, lb.example.net. ;c.example.com"},
+			expected: map[string]endpoint.Targets{
+				"a.example.com": {"1.2.3.4", "lb.example.net"},
+			},
+		},
+		{
+			name:        "invalid entries are ignored",
+			annotations: map[string]string{HostnameKey: "=1.2.3.4;a.example.com=;b.example.com=5.6.7.8;"},
+			expected: map[string]endpoint.Targets{
+				"b.example.com": {"5.6.7.8"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HostnameTargetsFromAnnotations(tt.annotations))
+		})
+	}
+}
+
 func TestSplitHostnameAnnotation(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -354,3 +495,147 @@ func TestInternalHostnamesFromAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestExcludedRecordTypesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			name:        "no record type exclude annotation",
+			annotations: map[string]string{},
+			expected:    nil,
+		},
+		{
+			name: "empty record type exclude annotation",
+			annotations: map[string]string{
+				RecordTypeExcludeKey: "",
+			},
+			expected: nil,
+		},
+		{
+			name: "single record type",
+			annotations: map[string]string{
+				RecordTypeExcludeKey: "AAAA",
+			},
+			expected: []string{"AAAA"},
+		},
+		{
+			name: "multiple lower-case record types with spaces",
+			annotations: map[string]string{
+				RecordTypeExcludeKey: " aaaa , cname,",
+			},
+			expected: []string{"AAAA", "CNAME"},
+		},
+		{
+			name: "unknown record types are ignored",
+			annotations: map[string]string{
+				RecordTypeExcludeKey: "AAAA,FOO,A A",
+			},
+			expected: []string{"AAAA"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExcludedRecordTypesFromAnnotations(tt.annotations)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestIsExcludedFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:        "no exclude annotation",
+			annotations: map[string]string{},
+			expected:    false,
+		},
+		{
+			name:        "excluded",
+			annotations: map[string]string{ExcludeKey: "true"},
+			expected:    true,
+		},
+		{
+			name:        "not excluded",
+			annotations: map[string]string{ExcludeKey: "false"},
+			expected:    false,
+		},
+		{
+			name:        "invalid value is ignored",
+			annotations: map[string]string{ExcludeKey: "yes please"},
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsExcludedFromAnnotations(tt.annotations))
+		})
+	}
+}
+
+func TestIsDeleteProtectedFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:        "no delete-protection annotation",
+			annotations: map[string]string{},
+			expected:    false,
+		},
+		{
+			name:        "protected",
+			annotations: map[string]string{DeleteProtectionKey: "true"},
+			expected:    true,
+		},
+		{
+			name:        "not protected",
+			annotations: map[string]string{DeleteProtectionKey: "false"},
+			expected:    false,
+		},
+		{
+			name:        "invalid value is ignored",
+			annotations: map[string]string{DeleteProtectionKey: "yes please"},
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsDeleteProtectedFromAnnotations(tt.annotations))
+		})
+	}
+}
+
+func TestDescriptionFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			name:        "no description annotation",
+			annotations: map[string]string{},
+			expected:    "",
+		},
+		{
+			name:        "description",
+			annotations: map[string]string{DescriptionKey: " Checkout frontend, owned by the web team "},
+			expected:    "Checkout frontend, owned by the web team",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DescriptionFromAnnotations(tt.annotations))
+		})
+	}
+}
@@ -33,6 +33,11 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 	for k, v := range annotations {
 		if k == SetIdentifierKey {
 			setIdentifier = v
+		} else if k == ProviderKey {
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  ProviderKey,
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, AWSPrefix) {
 			attr := strings.TrimPrefix(k, AWSPrefix)
 			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
@@ -45,6 +50,18 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 				Name:  fmt.Sprintf("scw/%s", attr),
 				Value: v,
 			})
+		} else if strings.HasPrefix(k, AzurePrefix) {
+			attr := strings.TrimPrefix(k, AzurePrefix)
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("azure/%s", attr),
+				Value: v,
+			})
+		} else if strings.HasPrefix(k, DNSimplePrefix) {
+			attr := strings.TrimPrefix(k, DNSimplePrefix)
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("dnsimple/%s", attr),
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, WebhookPrefix) {
 			// Support for wildcard annotations for webhook providers
 			attr := strings.TrimPrefix(k, WebhookPrefix)
@@ -73,6 +90,11 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 					Name:  CloudflareRecordCommentKey,
 					Value: v,
 				})
+			} else if strings.Contains(k, CloudflareLoadBalancerPoolKey) {
+				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+					Name:  CloudflareLoadBalancerPoolKey,
+					Value: v,
+				})
 			}
 		}
 	}
@@ -64,6 +64,26 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "Azure annotation",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/azure-metadata-team": "dns",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: "azure/metadata-team", Value: "dns"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Provider annotation",
+			annotations: map[string]string{
+				ProviderKey: "coredns",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: ProviderKey, Value: "coredns"},
+			},
+			setIdentifier: "",
+		},
 		{
 			name: "Set identifier annotation",
 			annotations: map[string]string{
@@ -155,6 +175,14 @@ func TestGetProviderSpecificCloudflareAnnotations(t *testing.T) {
 			expectedKey:   CloudflareRecordCommentKey,
 			expectedValue: "comment",
 		},
+		{
+			title: "Cloudflare load balancer pool annotation is set correctly",
+			annotations: map[string]string{
+				CloudflareLoadBalancerPoolKey: "pool-1,pool-2",
+			},
+			expectedKey:   CloudflareLoadBalancerPoolKey,
+			expectedValue: "pool-1,pool-2",
+		},
 	} {
 		t.Run(tc.title, func(t *testing.T) {
 			providerSpecificAnnotations, _ := ProviderSpecificAnnotations(tc.annotations)
@@ -300,6 +328,15 @@ func TestGetProviderSpecificIdentifierAnnotations(t *testing.T) {
 			},
 			expectedIdentifier: "id1",
 		},
+		{
+			title: "dnsimple- provider specific annotations are set correctly",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/dnsimple-regions": "SV1,IAD",
+			},
+			expectedResult: map[string]string{
+				"dnsimple/regions": "SV1,IAD",
+			},
+		},
 		{
 			title: "webhook- provider specific annotations are set correctly",
 			annotations: map[string]string{
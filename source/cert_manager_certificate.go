@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+var certManagerCertificateGVR = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+// Basic redefinition of the cert-manager Certificate spec:
+// https://github.com/cert-manager/cert-manager/blob/master/pkg/apis/certmanager/v1/types_certificate.go
+type certManagerCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              certManagerCertificateSpec `json:"spec,omitempty"`
+}
+
+type certManagerCertificateSpec struct {
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// certManagerCertificateSource is an implementation of Source for the cert-manager Certificate objects.
+// The hostnames are the DNS names of the certificates, and the targets the configured ones,
+// e.g. the address of a shared ingress, unless the target annotation is set.
+type certManagerCertificateSource struct {
+	namespace        string
+	annotationFilter string
+	labelSelector    labels.Selector
+	targets          endpoint.Targets
+	informer         kubeinformers.GenericInformer
+}
+
+// NewCertManagerCertificateSource creates a new certManagerCertificateSource with the given config.
+func NewCertManagerCertificateSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	targets []string,
+) (Source, error) {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informer := informerFactory.ForResource(certManagerCertificateGVR)
+
+	// Add default resource event handler to properly initialize informer.
+	_, _ = informer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &certManagerCertificateSource{
+		namespace:        namespace,
+		annotationFilter: annotationFilter,
+		labelSelector:    labelSelector,
+		targets:          targets,
+		informer:         informer,
+	}, nil
+}
+
+// Endpoints returns endpoint objects for each DNS name of the Certificates.
+func (cs *certManagerCertificateSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	objects, err := cs.informer.Lister().ByNamespace(cs.namespace).List(cs.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := annotations.ParseFilter(cs.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+
+	for _, obj := range objects {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+		certificate := &certManagerCertificate{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, certificate); err != nil {
+			return nil, err
+		}
+
+		if !selector.Empty() && !selector.Matches(labels.Set(certificate.Annotations)) {
+			continue
+		}
+
+		// Check controller annotation to see if we are responsible.
+		if controller, ok := certificate.Annotations[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping Certificate %s/%s because controller value does not match, found: %s, required: %s",
+				certificate.Namespace, certificate.Name, controller, controllerAnnotationValue)
+			continue
+		}
+
+		resource := fmt.Sprintf("certificate/%s/%s", certificate.Namespace, certificate.Name)
+
+		if len(certificate.Spec.DNSNames) == 0 {
+			log.Debugf("Skipping %s because it has no DNS names", resource)
+			continue
+		}
+
+		targets := annotations.TargetsFromTargetAnnotation(certificate.Annotations)
+		if len(targets) == 0 {
+			targets = cs.targets
+		}
+		if len(targets) == 0 {
+			log.Debugf("Skipping %s because it has no targets", resource)
+			continue
+		}
+
+		ttl := annotations.TTLFromAnnotations(certificate.Annotations, resource)
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(certificate.Annotations)
+
+		var certificateEndpoints []*endpoint.Endpoint
+		for _, hostname := range certificate.Spec.DNSNames {
+			certificateEndpoints = append(certificateEndpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+		endpoints = append(endpoints, applyObjectAnnotations(certificateEndpoints, certificate.Annotations)...)
+	}
+
+	for _, ep := range endpoints {
+		sort.Sort(ep.Targets)
+	}
+
+	return endpoints, nil
+}
+
+func (cs *certManagerCertificateSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for cert-manager Certificate")
+
+	_, _ = cs.informer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestCertManagerCertificateSource(t *testing.T) {
+	for _, tc := range []struct {
+		title            string
+		objects          []*unstructured.Unstructured
+		targets          []string
+		annotationFilter string
+		expected         []*endpoint.Endpoint
+	}{
+		{
+			title:   "multiple DNS names",
+			objects: []*unstructured.Unstructured{newCertManagerCertificate("app", nil, "app.example.org", "www.example.org", "*.app.example.org")},
+			targets: []string{"ingress.example.org"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"ingress.example.org"}},
+				{DNSName: "www.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"ingress.example.org"}},
+				{DNSName: "*.app.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"ingress.example.org"}},
+			},
+		},
+		{
+			title:   "IP targets",
+			objects: []*unstructured.Unstructured{newCertManagerCertificate("app", nil, "app.example.org", "www.example.org")},
+			targets: []string{"192.0.2.2", "192.0.2.1"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2"}},
+				{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2"}},
+			},
+		},
+		{
+			title: "target annotation overrides the configured targets",
+			objects: []*unstructured.Unstructured{newCertManagerCertificate("app",
+				map[string]interface{}{"external-dns.alpha.kubernetes.io/target": "192.0.2.10"}, "app.example.org")},
+			targets: []string{"ingress.example.org"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.10"}},
+			},
+		},
+		{
+			title:    "without targets",
+			objects:  []*unstructured.Unstructured{newCertManagerCertificate("app", nil, "app.example.org")},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title:    "without DNS names",
+			objects:  []*unstructured.Unstructured{newCertManagerCertificate("app", nil)},
+			targets:  []string{"ingress.example.org"},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "annotation filter and controller annotation",
+			objects: []*unstructured.Unstructured{
+				newCertManagerCertificate("public", map[string]interface{}{"dns": "public"}, "public.example.org"),
+				newCertManagerCertificate("private", map[string]interface{}{"dns": "private"}, "private.example.org"),
+				newCertManagerCertificate("other", map[string]interface{}{"dns": "public", controllerAnnotationKey: "other-controller"}, "other.example.org"),
+			},
+			targets:          []string{"ingress.example.org"},
+			annotationFilter: "dns=public",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "public.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"ingress.example.org"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				certManagerCertificateGVR: "CertificateList",
+			})
+			for _, obj := range tc.objects {
+				_, err := dynamicClient.Resource(certManagerCertificateGVR).Namespace(obj.GetNamespace()).Create(t.Context(), obj, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewCertManagerCertificateSource(t.Context(), dynamicClient, "", tc.annotationFilter, labels.Everything(), tc.targets)
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+// newCertManagerCertificate creates a Certificate with the given DNS names.
+func newCertManagerCertificate(name string, annotations map[string]interface{}, dnsNames ...string) *unstructured.Unstructured {
+	names := make([]interface{}, 0, len(dnsNames))
+	for _, dnsName := range dnsNames {
+		names = append(names, dnsName)
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": certManagerCertificateGVR.GroupVersion().String(),
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":        name,
+				"namespace":   "default",
+				"annotations": annotations,
+			},
+			"spec": map[string]interface{}{
+				"secretName": name + "-tls",
+				"dnsNames":   names,
+			},
+		},
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// configMapTTLAnnotationPrefix is the prefix of the annotations setting the TTL of a single
+// entry of a ConfigMap, e.g. ttl.external-dns.alpha.kubernetes.io/www.example.org: "60".
+const configMapTTLAnnotationPrefix = "ttl.external-dns.alpha.kubernetes.io/"
+
+// configMapSource is an implementation of Source that reads a static map of
+// hostnames to targets from the data of ConfigMaps.
+//
+// Each data key is a hostname and its value a comma separated list of targets.
+type configMapSource struct {
+	configMaps         []types.NamespacedName
+	configMapInformers map[string]coreinformers.ConfigMapInformer
+}
+
+// NewConfigMapSource creates a new configMapSource reading the given ConfigMaps,
+// each in the format <namespace>/<name>.
+func NewConfigMapSource(ctx context.Context, kubeClient kubernetes.Interface, configMapNames []string) (Source, error) {
+	configMaps, err := parseConfigMapNames(configMapNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use one informer per namespace so that only the namespaces of the referenced ConfigMaps are cached.
+	// Set resync period to 0, to prevent processing when nothing has changed
+	configMapInformers := map[string]coreinformers.ConfigMapInformer{}
+	for _, cm := range configMaps {
+		if _, ok := configMapInformers[cm.Namespace]; ok {
+			continue
+		}
+		informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(cm.Namespace))
+		configMapInformer := informerFactory.Core().V1().ConfigMaps()
+
+		// Add default resource event handler to properly initialize informer.
+		_, _ = configMapInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+		informerFactory.Start(ctx.Done())
+
+		// wait for the local cache to be populated.
+		if err := informers.WaitForCacheSync(context.Background(), informerFactory); err != nil {
+			return nil, err
+		}
+		configMapInformers[cm.Namespace] = configMapInformer
+	}
+
+	return &configMapSource{
+		configMaps:         configMaps,
+		configMapInformers: configMapInformers,
+	}, nil
+}
+
+// parseConfigMapNames parses a list of <namespace>/<name> references.
+func parseConfigMapNames(names []string) ([]types.NamespacedName, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("configmap source requires at least one ConfigMap, expected format: <namespace>/<name>")
+	}
+	configMaps := make([]types.NamespacedName, 0, len(names))
+	for _, n := range names {
+		namespace, name, _ := strings.Cut(n, "/")
+		if namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid ConfigMap %q, expected format: <namespace>/<name>", n)
+		}
+		configMaps = append(configMaps, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	return configMaps, nil
+}
+
+// Endpoints returns endpoint objects for each entry of the referenced ConfigMaps.
+func (cs *configMapSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints := []*endpoint.Endpoint{}
+
+	for _, ref := range cs.configMaps {
+		cm, err := cs.configMapInformers[ref.Namespace].Lister().ConfigMaps(ref.Namespace).Get(ref.Name)
+		if errors.IsNotFound(err) {
+			log.Warnf("ConfigMap %s not found, skipping", ref)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Check controller annotation to see if we are responsible.
+		if controller, ok := cm.Annotations[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping ConfigMap %s because controller value does not match, found: %s, required: %s",
+				ref, controller, controllerAnnotationValue)
+			continue
+		}
+
+		resource := fmt.Sprintf("configmap/%s/%s", cm.Namespace, cm.Name)
+		defaultTTL := annotations.TTLFromAnnotations(cm.Annotations, resource)
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(cm.Annotations)
+
+		hostnames := make([]string, 0, len(cm.Data))
+		for hostname := range cm.Data {
+			hostnames = append(hostnames, hostname)
+		}
+		sort.Strings(hostnames)
+
+		for _, hostname := range hostnames {
+			targets := endpoint.Targets{}
+			for _, target := range strings.Split(cm.Data[hostname], ",") {
+				if target = strings.TrimSpace(target); target != "" {
+					targets = append(targets, target)
+				}
+			}
+			if len(targets) == 0 {
+				log.Debugf("Skipping entry %s of %s because it has no targets", hostname, resource)
+				continue
+			}
+
+			ttl := defaultTTL
+			if value, ok := cm.Annotations[configMapTTLAnnotationPrefix+hostname]; ok {
+				ttl = annotations.TTLFromAnnotations(map[string]string{annotations.TtlKey: value}, resource)
+			}
+
+			endpoints = append(endpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+	}
+
+	return endpoints, nil
+}
+
+func (cs *configMapSource) AddEventHandler(_ context.Context, handler func()) {
+	for _, configMapInformer := range cs.configMapInformers {
+		_, _ = configMapInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
+}
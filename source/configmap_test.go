@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestNewConfigMapSource(t *testing.T) {
+	for _, tc := range []struct {
+		title          string
+		configMapNames []string
+		expectError    bool
+	}{
+		{
+			title:          "single ConfigMap",
+			configMapNames: []string{"default/records"},
+		},
+		{
+			title:          "ConfigMaps in several namespaces",
+			configMapNames: []string{"default/records", "kube-system/records", "default/more-records"},
+		},
+		{
+			title:       "no ConfigMap",
+			expectError: true,
+		},
+		{
+			title:          "missing namespace",
+			configMapNames: []string{"records"},
+			expectError:    true,
+		},
+		{
+			title:          "missing name",
+			configMapNames: []string{"default/"},
+			expectError:    true,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			_, err := NewConfigMapSource(t.Context(), fake.NewClientset(), tc.configMapNames)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigMapSourceEndpoints(t *testing.T) {
+	for _, tc := range []struct {
+		title          string
+		configMapNames []string
+		configMaps     []*v1.ConfigMap
+		expected       []*endpoint.Endpoint
+	}{
+		{
+			title:          "multiple entries",
+			configMapNames: []string{"default/records"},
+			configMaps: []*v1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "records"},
+				Data: map[string]string{
+					"a.example.org":     "1.2.3.4",
+					"b.example.org":     "1.2.3.4, 5.6.7.8",
+					"c.example.org":     "lb.example.com",
+					"d.example.org":     "2001:db8::1,10.0.0.1",
+					"empty.example.org": " , ",
+				},
+			}},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "b.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4", "5.6.7.8"}},
+				{DNSName: "c.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+				{DNSName: "d.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "d.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:          "ttl annotations",
+			configMapNames: []string{"default/records"},
+			configMaps: []*v1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "records",
+					Annotations: map[string]string{
+						"external-dns.alpha.kubernetes.io/ttl":               "300",
+						"ttl.external-dns.alpha.kubernetes.io/b.example.org": "1m",
+						"ttl.external-dns.alpha.kubernetes.io/c.example.org": "invalid",
+					},
+				},
+				Data: map[string]string{
+					"a.example.org": "1.2.3.4",
+					"b.example.org": "1.2.3.4",
+					"c.example.org": "1.2.3.4",
+				},
+			}},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, RecordTTL: 300},
+				{DNSName: "b.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, RecordTTL: 60},
+				{DNSName: "c.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:          "only referenced ConfigMaps",
+			configMapNames: []string{"default/records", "other/records", "default/missing"},
+			configMaps: []*v1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "records"},
+					Data:       map[string]string{"a.example.org": "1.2.3.4"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unrelated"},
+					Data:       map[string]string{"ca.crt": "certificate"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "records"},
+					Data:       map[string]string{"b.example.org": "5.6.7.8"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "b.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"5.6.7.8"}},
+			},
+		},
+		{
+			title:          "controller annotation mismatch",
+			configMapNames: []string{"default/records"},
+			configMaps: []*v1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "records",
+					Annotations: map[string]string{controllerAnnotationKey: "other-controller"},
+				},
+				Data: map[string]string{"a.example.org": "1.2.3.4"},
+			}},
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			kubeClient := fake.NewClientset()
+			for _, cm := range tc.configMaps {
+				_, err := kubeClient.CoreV1().ConfigMaps(cm.Namespace).Create(t.Context(), cm, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewConfigMapSource(t.Context(), kubeClient, tc.configMapNames)
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestConfigMapSourceResourceLabel(t *testing.T) {
+	kubeClient := fake.NewClientset()
+	_, err := kubeClient.CoreV1().ConfigMaps("default").Create(t.Context(), &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "records"},
+		Data:       map[string]string{"a.example.org": "1.2.3.4"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	src, err := NewConfigMapSource(t.Context(), kubeClient, []string{"default/records"})
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "configmap/default/records", endpoints[0].Labels[endpoint.ResourceLabelKey])
+}
@@ -127,6 +127,12 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 		httpProxies = append(httpProxies, hpConverted)
 	}
 
+	// All the HTTPProxies are kept to follow the includes, even those filtered out by annotations.
+	delegates := make(map[string]*projectcontour.HTTPProxy, len(httpProxies))
+	for _, hp := range httpProxies {
+		delegates[hp.Namespace+"/"+hp.Name] = hp
+	}
+
 	httpProxies, err = sc.filterByAnnotations(httpProxies)
 	if err != nil {
 		return nil, fmt.Errorf("failed to filter HTTPProxies: %w", err)
@@ -143,14 +149,14 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
-		hpEndpoints, err := sc.endpointsFromHTTPProxy(hp)
+		hpEndpoints, err := sc.endpointsFromHTTPProxy(hp, delegates)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get endpoints from HTTPProxy: %w", err)
 		}
 
 		// apply template if fqdn is missing on HTTPProxy
 		if (sc.combineFQDNAnnotation || len(hpEndpoints) == 0) && sc.fqdnTemplate != nil {
-			tmplEndpoints, err := sc.endpointsFromTemplate(hp)
+			tmplEndpoints, err := sc.endpointsFromTemplate(hp, delegates)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get endpoints from template: %w", err)
 			}
@@ -162,6 +168,11 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			}
 		}
 
+		if !sc.ignoreHostnameAnnotation {
+			hpEndpoints = endpointsWithHostnameTargets(hpEndpoints, hp.Annotations)
+		}
+		hpEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(hpEndpoints, hp.Annotations), hp.Annotations)
+
 		if len(hpEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from HTTPProxy %s/%s", hp.Namespace, hp.Name)
 			continue
@@ -178,7 +189,7 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 	return endpoints, nil
 }
 
-func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPProxy) ([]*endpoint.Endpoint, error) {
+func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPProxy, delegates map[string]*projectcontour.HTTPProxy) ([]*endpoint.Endpoint, error) {
 	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, httpProxy)
 	if err != nil {
 		return nil, err
@@ -188,17 +199,7 @@ func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPP
 
 	ttl := annotations.TTLFromAnnotations(httpProxy.Annotations, resource)
 
-	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
-	if len(targets) == 0 {
-		for _, lb := range httpProxy.Status.LoadBalancer.Ingress {
-			if lb.IP != "" {
-				targets = append(targets, lb.IP)
-			}
-			if lb.Hostname != "" {
-				targets = append(targets, lb.Hostname)
-			}
-		}
-	}
+	targets := targetsFromHTTPProxy(httpProxy, delegates)
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(httpProxy.Annotations)
 
@@ -234,23 +235,12 @@ func (sc *httpProxySource) filterByAnnotations(httpProxies []*projectcontour.HTT
 }
 
 // endpointsFromHTTPProxyConfig extracts the endpoints from a Contour HTTPProxy object
-func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy) ([]*endpoint.Endpoint, error) {
+func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy, delegates map[string]*projectcontour.HTTPProxy) ([]*endpoint.Endpoint, error) {
 	resource := fmt.Sprintf("HTTPProxy/%s/%s", httpProxy.Namespace, httpProxy.Name)
 
 	ttl := annotations.TTLFromAnnotations(httpProxy.Annotations, resource)
 
-	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
-
-	if len(targets) == 0 {
-		for _, lb := range httpProxy.Status.LoadBalancer.Ingress {
-			if lb.IP != "" {
-				targets = append(targets, lb.IP)
-			}
-			if lb.Hostname != "" {
-				targets = append(targets, lb.Hostname)
-			}
-		}
-	}
+	targets := targetsFromHTTPProxy(httpProxy, delegates)
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(httpProxy.Annotations)
 
@@ -273,6 +263,53 @@ func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTP
 	return endpoints, nil
 }
 
+// targetsFromHTTPProxy returns the targets of the target annotation, or else the load balancer addresses
+// of the HTTPProxy. When a root HTTPProxy has no load balancer address of its own, the addresses are
+// resolved from the HTTPProxies it delegates to through its includes, following the delegation chain.
+func targetsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy, delegates map[string]*projectcontour.HTTPProxy) endpoint.Targets {
+	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
+	if len(targets) > 0 {
+		return targets
+	}
+
+	visited := map[string]bool{}
+	var resolve func(hp *projectcontour.HTTPProxy) endpoint.Targets
+	resolve = func(hp *projectcontour.HTTPProxy) endpoint.Targets {
+		visited[hp.Namespace+"/"+hp.Name] = true
+
+		var targets endpoint.Targets
+		for _, lb := range hp.Status.LoadBalancer.Ingress {
+			if lb.IP != "" {
+				targets = append(targets, lb.IP)
+			}
+			if lb.Hostname != "" {
+				targets = append(targets, lb.Hostname)
+			}
+		}
+		if len(targets) > 0 {
+			return targets
+		}
+
+		for _, include := range hp.Spec.Includes {
+			namespace := include.Namespace
+			if namespace == "" {
+				namespace = hp.Namespace
+			}
+			key := namespace + "/" + include.Name
+			child, ok := delegates[key]
+			if !ok || visited[key] {
+				continue
+			}
+			if targets = resolve(child); len(targets) > 0 {
+				log.Debugf("Using the load balancer addresses of the HTTPProxy %s included by %s/%s", key, httpProxy.Namespace, httpProxy.Name)
+				return targets
+			}
+		}
+		return nil
+	}
+	return resolve(httpProxy)
+}
+
 func (sc *httpProxySource) AddEventHandler(ctx context.Context, handler func()) {
 	log.Debug("Adding event handler for httpproxy")
 
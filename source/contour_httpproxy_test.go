@@ -136,6 +136,7 @@ func TestHTTPProxy(t *testing.T) {
 	suite.Run(t, new(HTTPProxySuite))
 	t.Run("endpointsFromHTTPProxy", testEndpointsFromHTTPProxy)
 	t.Run("Endpoints", testHTTPProxyEndpoints)
+	t.Run("Delegation", testHTTPProxyDelegationEndpoints)
 }
 
 func TestNewContourHTTPProxySource(t *testing.T) {
@@ -289,7 +290,7 @@ func testEndpointsFromHTTPProxy(t *testing.T) {
 
 			if source, err := newTestHTTPProxySource(); err != nil {
 				require.NoError(t, err)
-			} else if endpoints, err := source.endpointsFromHTTPProxy(ti.httpProxy.HTTPProxy()); err != nil {
+			} else if endpoints, err := source.endpointsFromHTTPProxy(ti.httpProxy.HTTPProxy(), nil); err != nil {
 				require.NoError(t, err)
 			} else {
 				validateEndpoints(t, endpoints, ti.expected)
@@ -1076,6 +1077,136 @@ func testHTTPProxyEndpoints(t *testing.T) {
 	}
 }
 
+func testHTTPProxyDelegationEndpoints(t *testing.T) {
+	t.Parallel()
+
+	namespace := "testing"
+	for _, ti := range []struct {
+		title          string
+		httpProxyItems []fakeHTTPProxy
+		expected       []*endpoint.Endpoint
+	}{
+		{
+			title: "root without load balancer address uses the address of its include",
+			httpProxyItems: []fakeHTTPProxy{
+				{
+					name:      "root",
+					namespace: namespace,
+					host:      "example.org",
+					includes:  []projectcontour.Include{{Name: "child"}},
+				},
+				{
+					name:         "child",
+					namespace:    namespace,
+					delegate:     true,
+					loadBalancer: fakeLoadBalancerService{ips: []string{"8.8.8.8"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"8.8.8.8"},
+				},
+			},
+		},
+		{
+			title: "delegation chain across namespaces",
+			httpProxyItems: []fakeHTTPProxy{
+				{
+					name:      "root",
+					namespace: namespace,
+					host:      "example.org",
+					includes:  []projectcontour.Include{{Name: "missing"}, {Name: "middle", Namespace: "team"}},
+				},
+				{
+					name:      "middle",
+					namespace: "team",
+					delegate:  true,
+					includes:  []projectcontour.Include{{Name: "leaf"}},
+				},
+				{
+					name:         "leaf",
+					namespace:    "team",
+					delegate:     true,
+					loadBalancer: fakeLoadBalancerService{hostnames: []string{"lb.com"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					RecordType: endpoint.RecordTypeCNAME,
+					Targets:    endpoint.Targets{"lb.com"},
+				},
+			},
+		},
+		{
+			title: "root load balancer address takes precedence over its includes",
+			httpProxyItems: []fakeHTTPProxy{
+				{
+					name:         "root",
+					namespace:    namespace,
+					host:         "example.org",
+					includes:     []projectcontour.Include{{Name: "child"}},
+					loadBalancer: fakeLoadBalancerService{ips: []string{"1.2.3.4"}},
+				},
+				{
+					name:         "child",
+					namespace:    namespace,
+					delegate:     true,
+					loadBalancer: fakeLoadBalancerService{ips: []string{"8.8.8.8"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"1.2.3.4"},
+				},
+			},
+		},
+		{
+			title: "include cycle without load balancer address",
+			httpProxyItems: []fakeHTTPProxy{
+				{
+					name:      "root",
+					namespace: namespace,
+					host:      "example.org",
+					includes:  []projectcontour.Include{{Name: "child"}},
+				},
+				{
+					name:      "child",
+					namespace: namespace,
+					delegate:  true,
+					includes:  []projectcontour.Include{{Name: "root"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			t.Parallel()
+
+			fakeDynamicClient, scheme := newDynamicKubernetesClient()
+			for _, item := range ti.httpProxyItems {
+				httpProxy := item.HTTPProxy()
+				converted, err := convertHTTPProxyToUnstructured(httpProxy, scheme)
+				require.NoError(t, err)
+				_, err = fakeDynamicClient.Resource(projectcontour.HTTPProxyGVR).Namespace(httpProxy.Namespace).Create(context.Background(), converted, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			httpProxySource, err := NewContourHTTPProxySource(context.TODO(), fakeDynamicClient, "", "", "", false, false)
+			require.NoError(t, err)
+
+			res, err := httpProxySource.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, res, ti.expected)
+		})
+	}
+}
+
 // httpproxy specific helper functions
 func newTestHTTPProxySource() (*httpProxySource, error) {
 	fakeDynamicClient, _ := newDynamicKubernetesClient()
@@ -1108,6 +1239,7 @@ type fakeHTTPProxy struct {
 
 	host         string
 	delegate     bool
+	includes     []projectcontour.Include
 	loadBalancer fakeLoadBalancerService
 }
 
@@ -1123,6 +1255,8 @@ func (ir fakeHTTPProxy) HTTPProxy() *projectcontour.HTTPProxy {
 		}
 	}
 
+	spec.Includes = ir.includes
+
 	lb := v1.LoadBalancerStatus{
 		Ingress: []v1.LoadBalancerIngress{},
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,6 +43,10 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 )
 
+// crdFinalizer is the finalizer added to the custom resources when the finalizer handling is enabled,
+// removed once the records of a deleted resource are removed.
+const crdFinalizer = "externaldns.k8s.io/records"
+
 // crdSource is an implementation of Source that provides endpoints by listing
 // specified CRD and fetching Endpoints embedded in Spec.
 type crdSource struct {
@@ -52,6 +57,12 @@ type crdSource struct {
 	annotationFilter string
 	labelSelector    labels.Selector
 	informer         cache.SharedInformer
+	// clusterScoped is set when the CRD is cluster-scoped: the resources are read without a namespace
+	clusterScoped bool
+	// finalizer enables the finalizer blocking the deletion of the resources until their records are removed
+	finalizer bool
+	// records tracks the records left by the deleted resources
+	records *resourceRecords
 }
 
 func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) error {
@@ -64,14 +75,14 @@ func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) err
 }
 
 // NewCRDClientForAPIVersionKind return rest client for the given apiVersion and kind of the CRD
-func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiServerURL, apiVersion, kind string) (*rest.RESTClient, *runtime.Scheme, error) {
+func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, kubeContext, apiServerURL, apiVersion, kind string) (*rest.RESTClient, *runtime.Scheme, error) {
 	if kubeConfig == "" {
 		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
 			kubeConfig = clientcmd.RecommendedHomeFile
 		}
 	}
 
-	config, err := clientcmd.BuildConfigFromFlags(apiServerURL, kubeConfig)
+	config, err := buildConfigFromFlags(apiServerURL, kubeConfig, kubeContext)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -111,10 +122,17 @@ func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiS
 }
 
 // NewCRDSource creates a new crdSource with the given config.
-func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool) (Source, error) {
+// The namespace is ignored when the CRD is cluster-scoped.
+func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool, clusterScoped bool, finalizer bool) (Source, error) {
+	if clusterScoped {
+		namespace = ""
+	}
 	sourceCrd := crdSource{
 		crdResource:      strings.ToLower(kind) + "s",
 		namespace:        namespace,
+		clusterScoped:    clusterScoped,
+		finalizer:        finalizer,
+		records:          &resourceRecords{},
 		annotationFilter: annotationFilter,
 		labelSelector:    labelSelector,
 		crdClient:        crdClient,
@@ -179,7 +197,27 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		return nil, err
 	}
 
+	// the endpoints with an illegal target are skipped and reported along with the valid ones
+	var invalid invalidObjects
 	for _, dnsEndpoint := range result.Items {
+		if cs.finalizer && dnsEndpoint.DeletionTimestamp != nil {
+			if slices.Contains(dnsEndpoint.Finalizers, crdFinalizer) {
+				// the records are removed by the reconciliations, the finalizer once they are gone
+				log.Debugf("Skipping the endpoints of the deleted %s", cs.resourceLabel(&dnsEndpoint))
+				if cs.records.removed(ctx, cs.resourceLabel(&dnsEndpoint)) {
+					cs.removeFinalizer(ctx, dnsEndpoint)
+				}
+				continue
+			}
+		} else if cs.finalizer && !slices.Contains(dnsEndpoint.Finalizers, crdFinalizer) {
+			updated, err := cs.addFinalizer(ctx, &dnsEndpoint)
+			if err != nil {
+				log.Warnf("Could not add the finalizer to %s: %v", cs.resourceLabel(&dnsEndpoint), err)
+			} else {
+				dnsEndpoint = *updated
+			}
+		}
+
 		var crdEndpoints []*endpoint.Endpoint
 		for _, ep := range dnsEndpoint.Spec.Endpoints {
 			if (ep.RecordType == endpoint.RecordTypeCNAME || ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA) && len(ep.Targets) < 1 {
@@ -187,20 +225,25 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 			}
 
 			illegalTarget := false
-			for _, target := range ep.Targets {
-				isNAPTR := ep.RecordType == endpoint.RecordTypeNAPTR
-				hasDot := strings.HasSuffix(target, ".")
-				if (isNAPTR && !hasDot) || (!isNAPTR && hasDot) {
-					illegalTarget = true
-					break
+			if ep.RecordType == endpoint.RecordTypeSVCB || ep.RecordType == endpoint.RecordTypeHTTPS {
+				// the target names of SVCB records may be fully qualified, but their parameters must be valid
+				illegalTarget = !ep.CheckEndpoint()
+			} else {
+				for _, target := range ep.Targets {
+					isNAPTR := ep.RecordType == endpoint.RecordTypeNAPTR
+					hasDot := strings.HasSuffix(target, ".")
+					if (isNAPTR && !hasDot) || (!isNAPTR && hasDot) {
+						illegalTarget = true
+						break
+					}
 				}
 			}
 			if illegalTarget {
-				log.Warnf("Endpoint %s/%s with DNSName %s has an illegal target format.", dnsEndpoint.Namespace, dnsEndpoint.Name, ep.DNSName)
+				invalid.add(cs.resourceLabel(&dnsEndpoint), fmt.Errorf("endpoint with DNSName %s has an illegal target format", ep.DNSName))
 				continue
 			}
 
-			ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name))
+			ep.WithLabel(endpoint.ResourceLabelKey, cs.resourceLabel(&dnsEndpoint))
 
 			crdEndpoints = append(crdEndpoints, ep)
 		}
@@ -219,7 +262,35 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 	}
 
-	return endpoints, nil
+	return endpoints, invalid.err()
+}
+
+// resourceLabel returns the resource label of the endpoints of the DNSEndpoint: crd/<namespace>/<name>,
+// or crd/<name> when the CRD is cluster-scoped.
+func (cs *crdSource) resourceLabel(dnsEndpoint *apiv1alpha1.DNSEndpoint) string {
+	if cs.clusterScoped {
+		return fmt.Sprintf("crd/%s", dnsEndpoint.Name)
+	}
+	return fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name)
+}
+
+// addFinalizer adds the finalizer to the DNSEndpoint and returns the updated object.
+func (cs *crdSource) addFinalizer(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) (*apiv1alpha1.DNSEndpoint, error) {
+	dnsEndpoint = dnsEndpoint.DeepCopy()
+	dnsEndpoint.Finalizers = append(dnsEndpoint.Finalizers, crdFinalizer)
+	return cs.Update(ctx, dnsEndpoint)
+}
+
+// removeFinalizer removes the finalizer of the deleted DNSEndpoint, whose records are gone.
+func (cs *crdSource) removeFinalizer(ctx context.Context, dnsEndpoint apiv1alpha1.DNSEndpoint) {
+	dnsEndpoint.Finalizers = slices.DeleteFunc(dnsEndpoint.Finalizers, func(finalizer string) bool {
+		return finalizer == crdFinalizer
+	})
+	if _, err := cs.Update(ctx, &dnsEndpoint); err != nil {
+		log.Warnf("Could not remove the finalizer of %s: %v", cs.resourceLabel(&dnsEndpoint), err)
+		return
+	}
+	log.Infof("Removed the finalizer of %s", cs.resourceLabel(&dnsEndpoint))
 }
 
 func (cs *crdSource) watch(ctx context.Context, opts *metav1.ListOptions) (watch.Interface, error) {
@@ -241,10 +312,21 @@ func (cs *crdSource) List(ctx context.Context, opts *metav1.ListOptions) (*apiv1
 		Into(result)
 }
 
+func (cs *crdSource) Update(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) (*apiv1alpha1.DNSEndpoint, error) {
+	result := &apiv1alpha1.DNSEndpoint{}
+	return result, cs.crdClient.Put().
+		NamespaceIfScoped(dnsEndpoint.Namespace, !cs.clusterScoped).
+		Resource(cs.crdResource).
+		Name(dnsEndpoint.Name).
+		Body(dnsEndpoint).
+		Do(ctx).
+		Into(result)
+}
+
 func (cs *crdSource) UpdateStatus(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) (*apiv1alpha1.DNSEndpoint, error) {
 	result := &apiv1alpha1.DNSEndpoint{}
 	return result, cs.crdClient.Put().
-		Namespace(dnsEndpoint.Namespace).
+		NamespaceIfScoped(dnsEndpoint.Namespace, !cs.clusterScoped).
 		Resource(cs.crdResource).
 		Name(dnsEndpoint.Name).
 		SubResource("status").
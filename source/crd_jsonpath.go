@@ -0,0 +1,265 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// crdJSONPathSource is an implementation of Source that reads the hostnames, targets and TTL
+// of arbitrary namespaced custom resources using JSONPath expressions.
+type crdJSONPathSource struct {
+	kind             string
+	namespace        string
+	annotationFilter string
+	hostnamePath     *jsonpath.JSONPath
+	targetsPath      *jsonpath.JSONPath
+	ttlPath          *jsonpath.JSONPath
+	informer         kubeinformers.GenericInformer
+}
+
+// NewCRDJSONPathSource creates a new crdJSONPathSource for the custom resources of the given
+// apiVersion and kind. The hostname and targets expressions are required, the TTL expression
+// is optional. Resources without targets fall back to the target annotation.
+func NewCRDJSONPathSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
+	namespace, apiVersion, kind string,
+	hostnameJSONPath, targetsJSONPath, ttlJSONPath string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+) (Source, error) {
+	gvr, err := resourceForAPIVersionKind(kubeClient, apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if hostnameJSONPath == "" || targetsJSONPath == "" {
+		return nil, errors.New("crd-jsonpath source requires JSONPath expressions for the hostnames and the targets")
+	}
+	hostnamePath, err := parseJSONPath("hostname", hostnameJSONPath)
+	if err != nil {
+		return nil, err
+	}
+	targetsPath, err := parseJSONPath("targets", targetsJSONPath)
+	if err != nil {
+		return nil, err
+	}
+	var ttlPath *jsonpath.JSONPath
+	if ttlJSONPath != "" {
+		if ttlPath, err = parseJSONPath("ttl", ttlJSONPath); err != nil {
+			return nil, err
+		}
+	}
+
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector.String()
+	})
+	informer := informerFactory.ForResource(gvr)
+
+	// Add default resource event handler to properly initialize informer.
+	_, _ = informer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &crdJSONPathSource{
+		kind:             kind,
+		namespace:        namespace,
+		annotationFilter: annotationFilter,
+		hostnamePath:     hostnamePath,
+		targetsPath:      targetsPath,
+		ttlPath:          ttlPath,
+		informer:         informer,
+	}, nil
+}
+
+// resourceForAPIVersionKind looks up the resource of the given apiVersion and kind using the discovery API.
+func resourceForAPIVersionKind(kubeClient kubernetes.Interface, apiVersion, kind string) (schema.GroupVersionResource, error) {
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	apiResourceList, err := kubeClient.Discovery().ServerResourcesForGroupVersion(groupVersion.String())
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("error listing resources in GroupVersion %q: %w", groupVersion.String(), err)
+	}
+	for _, apiResource := range apiResourceList.APIResources {
+		// Subresources such as <resource>/status share the kind of their resource.
+		if apiResource.Kind == kind && !strings.Contains(apiResource.Name, "/") {
+			return groupVersion.WithResource(apiResource.Name), nil
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("unable to find Resource Kind %q in GroupVersion %q", kind, apiVersion)
+}
+
+// parseJSONPath parses a JSONPath expression as used by kubectl, with or without the enclosing braces.
+func parseJSONPath(name, expression string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	jp := jsonpath.New(name).AllowMissingKeys(true)
+	if err := jp.Parse(expression); err != nil {
+		return nil, fmt.Errorf("invalid %s JSONPath expression %q: %w", name, expression, err)
+	}
+	return jp, nil
+}
+
+// Endpoints returns endpoint objects for each hostname of the custom resources.
+func (cs *crdJSONPathSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	objects, err := cs.informer.Lister().ByNamespace(cs.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := annotations.ParseFilter(cs.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+
+	for _, obj := range objects {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+
+		objAnnotations := u.GetAnnotations()
+		if !selector.Empty() && !selector.Matches(labels.Set(objAnnotations)) {
+			continue
+		}
+
+		// Check controller annotation to see if we are responsible.
+		if controller, ok := objAnnotations[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping %s %s/%s because controller value does not match, found: %s, required: %s",
+				cs.kind, u.GetNamespace(), u.GetName(), controller, controllerAnnotationValue)
+			continue
+		}
+
+		resource := fmt.Sprintf("%s/%s/%s", strings.ToLower(cs.kind), u.GetNamespace(), u.GetName())
+
+		hostnames, err := jsonPathValues(cs.hostnamePath, u.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the hostnames of %s: %w", resource, err)
+		}
+		if len(hostnames) == 0 {
+			log.Debugf("Skipping %s because it has no hostnames", resource)
+			continue
+		}
+
+		targets := annotations.TargetsFromTargetAnnotation(objAnnotations)
+		if len(targets) == 0 {
+			values, err := jsonPathValues(cs.targetsPath, u.Object)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the targets of %s: %w", resource, err)
+			}
+			targets = endpoint.NewTargets(values...)
+		}
+		if len(targets) == 0 {
+			log.Debugf("Skipping %s because it has no targets", resource)
+			continue
+		}
+
+		ttl := annotations.TTLFromAnnotations(objAnnotations, resource)
+		if cs.ttlPath != nil {
+			values, err := jsonPathValues(cs.ttlPath, u.Object)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the TTL of %s: %w", resource, err)
+			}
+			if len(values) > 0 {
+				ttl = annotations.TTLFromAnnotations(map[string]string{annotations.TtlKey: values[0]}, resource)
+			}
+		}
+
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(objAnnotations)
+
+		var objEndpoints []*endpoint.Endpoint
+		for _, hostname := range hostnames {
+			objEndpoints = append(objEndpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+		endpoints = append(endpoints, applyObjectAnnotations(endpointsWithHostnameAliases(objEndpoints, objAnnotations), objAnnotations)...)
+	}
+
+	for _, ep := range endpoints {
+		sort.Sort(ep.Targets)
+	}
+
+	return endpoints, nil
+}
+
+// jsonPathValues returns the non-empty values found by the JSONPath expression.
+// Lists are flattened and all other values are formatted as strings.
+func jsonPathValues(jp *jsonpath.JSONPath, obj map[string]interface{}) ([]string, error) {
+	results, err := jp.FindResults(obj)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	var add func(v interface{})
+	add = func(v interface{}) {
+		switch value := v.(type) {
+		case nil:
+		case []interface{}:
+			for _, item := range value {
+				add(item)
+			}
+		case string:
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		default:
+			values = append(values, fmt.Sprint(value))
+		}
+	}
+	for _, result := range results {
+		for _, v := range result {
+			add(v.Interface())
+		}
+	}
+	return values, nil
+}
+
+func (cs *crdJSONPathSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debugf("Adding event handler for %s", cs.kind)
+
+	_, _ = cs.informer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+var dnsRecordGVR = schema.GroupVersionResource{
+	Group:    "example.com",
+	Version:  "v1alpha1",
+	Resource: "dnsrecords",
+}
+
+// newCRDJSONPathTestSource creates a crd-jsonpath source reading the given objects,
+// with discovery knowing the F5 VirtualServers and the example DNSRecords.
+func newCRDJSONPathTestSource(t *testing.T, apiVersion, kind, hostnamePath, targetsPath, ttlPath string, objects ...*unstructured.Unstructured) (Source, error) {
+	t.Helper()
+
+	kubeClient := fake.NewClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: f5VirtualServerGVR.GroupVersion().String(),
+			APIResources: []metav1.APIResource{
+				{Name: "virtualservers/status", Kind: "VirtualServer", Namespaced: true},
+				{Name: "virtualservers", Kind: "VirtualServer", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: dnsRecordGVR.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: "dnsrecords", Kind: "DNSRecord", Namespaced: true}},
+		},
+	}
+
+	dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		f5VirtualServerGVR: "VirtualServerList",
+		dnsRecordGVR:       "DNSRecordList",
+	})
+	for _, obj := range objects {
+		gvr := dnsRecordGVR
+		if obj.GetKind() == "VirtualServer" {
+			gvr = f5VirtualServerGVR
+		}
+		_, err := dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Create(t.Context(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	return NewCRDJSONPathSource(t.Context(), dynamicClient, kubeClient, "", apiVersion, kind, hostnamePath, targetsPath, ttlPath, "", labels.Everything())
+}
+
+func TestNewCRDJSONPathSource(t *testing.T) {
+	for _, tc := range []struct {
+		title        string
+		apiVersion   string
+		kind         string
+		hostnamePath string
+		targetsPath  string
+		ttlPath      string
+		expectError  bool
+	}{
+		{
+			title:        "valid configuration",
+			apiVersion:   "example.com/v1alpha1",
+			kind:         "DNSRecord",
+			hostnamePath: "{.spec.hostnames[*]}",
+			targetsPath:  ".status.addresses[*].ip",
+			ttlPath:      "{.spec.ttl}",
+		},
+		{
+			title:        "unknown kind",
+			apiVersion:   "example.com/v1alpha1",
+			kind:         "Unknown",
+			hostnamePath: "{.spec.hostnames[*]}",
+			targetsPath:  "{.status.addresses[*].ip}",
+			expectError:  true,
+		},
+		{
+			title:       "missing hostname expression",
+			apiVersion:  "example.com/v1alpha1",
+			kind:        "DNSRecord",
+			targetsPath: "{.status.addresses[*].ip}",
+			expectError: true,
+		},
+		{
+			title:        "invalid targets expression",
+			apiVersion:   "example.com/v1alpha1",
+			kind:         "DNSRecord",
+			hostnamePath: "{.spec.hostnames[*]}",
+			targetsPath:  "{.status.addresses[}",
+			expectError:  true,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			_, err := newCRDJSONPathTestSource(t, tc.apiVersion, tc.kind, tc.hostnamePath, tc.targetsPath, tc.ttlPath)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCRDJSONPathSourceF5VirtualServer(t *testing.T) {
+	virtualServer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cis.f5.com/v1",
+		"kind":       "VirtualServer",
+		"metadata": map[string]interface{}{
+			"namespace":   "default",
+			"name":        "vs",
+			"annotations": map[string]interface{}{"external-dns.alpha.kubernetes.io/ttl": "600"},
+		},
+		"spec": map[string]interface{}{
+			"host":                 "www.example.com",
+			"virtualServerAddress": "192.168.1.100",
+		},
+		"status": map[string]interface{}{"vsAddress": "192.168.1.200"},
+	}}
+
+	src, err := newCRDJSONPathTestSource(t, "cis.f5.com/v1", "VirtualServer", "{.spec.host}", "{.spec.virtualServerAddress}", "", virtualServer)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "www.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.168.1.100"}, RecordTTL: 600},
+	})
+	assert.Equal(t, "virtualserver/default/vs", endpoints[0].Labels[endpoint.ResourceLabelKey])
+}
+
+func TestCRDJSONPathSourceEndpoints(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		objects  []*unstructured.Unstructured
+		expected []*endpoint.Endpoint
+	}{
+		{
+			title: "multiple hostnames and targets with ttl",
+			objects: []*unstructured.Unstructured{newDNSRecord("records", nil,
+				map[string]interface{}{"hostnames": []interface{}{"a.example.org", "b.example.org"}, "ttl": int64(300)},
+				[]interface{}{"10.0.0.2", "10.0.0.1"},
+			)},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2"}, RecordTTL: 300},
+				{DNSName: "b.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2"}, RecordTTL: 300},
+			},
+		},
+		{
+			title: "target annotation overrides targets",
+			objects: []*unstructured.Unstructured{newDNSRecord("records",
+				map[string]interface{}{"external-dns.alpha.kubernetes.io/target": "lb.example.com"},
+				map[string]interface{}{"hostnames": []interface{}{"a.example.org"}},
+				[]interface{}{"10.0.0.1"},
+			)},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+			},
+		},
+		{
+			title: "missing fields are skipped",
+			objects: []*unstructured.Unstructured{
+				newDNSRecord("no-hostnames", nil, map[string]interface{}{}, []interface{}{"10.0.0.1"}),
+				newDNSRecord("no-targets", nil, map[string]interface{}{"hostnames": []interface{}{"a.example.org"}}, nil),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "controller annotation mismatch",
+			objects: []*unstructured.Unstructured{newDNSRecord("records",
+				map[string]interface{}{controllerAnnotationKey: "other-controller"},
+				map[string]interface{}{"hostnames": []interface{}{"a.example.org"}},
+				[]interface{}{"10.0.0.1"},
+			)},
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			src, err := newCRDJSONPathTestSource(t, "example.com/v1alpha1", "DNSRecord",
+				"{.spec.hostnames[*]}", "{.status.addresses[*].ip}", "{.spec.ttl}", tc.objects...)
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+// newDNSRecord creates an example DNSRecord custom resource with the addresses in its status.
+func newDNSRecord(name string, annotations map[string]interface{}, spec map[string]interface{}, ips []interface{}) *unstructured.Unstructured {
+	addresses := make([]interface{}, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, map[string]interface{}{"ip": ip})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1alpha1",
+		"kind":       "DNSRecord",
+		"metadata": map[string]interface{}{
+			"namespace":   "default",
+			"name":        name,
+			"annotations": annotations,
+		},
+		"spec":   spec,
+		"status": map[string]interface{}{"addresses": addresses},
+	}}
+}
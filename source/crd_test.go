@@ -43,6 +43,7 @@ import (
 	cachetesting "k8s.io/client-go/tools/cache/testing"
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 type CRDSuite struct {
@@ -452,7 +453,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 				},
 			},
 			expectEndpoints: false,
-			expectError:     false,
+			expectError:     true,
 		},
 		{
 			title:                "illegal target NAPTR",
@@ -473,8 +474,83 @@ func testCRDSourceEndpoints(t *testing.T) {
 				},
 			},
 			expectEndpoints: false,
+			expectError:     true,
+		},
+		{
+			title:                "Create HTTPS record",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			labels:               map[string]string{"test": "that"},
+			labelFilter:          "test=that",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"1 . alpn=h2,h3 port=443", "0 svc.example.org."},
+					RecordType: endpoint.RecordTypeHTTPS,
+					RecordTTL:  180,
+				},
+			},
+			expectEndpoints: true,
 			expectError:     false,
 		},
+		{
+			title:                "endpoints with provider specific properties",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:       "abc.example.org",
+					Targets:       endpoint.Targets{"1.2.3.4"},
+					RecordType:    endpoint.RecordTypeA,
+					RecordTTL:     180,
+					SetIdentifier: "eu-west-1",
+					ProviderSpecific: endpoint.ProviderSpecific{
+						{Name: "aws/region", Value: "eu-west-1"},
+						{Name: "aws/evaluate-target-health", Value: "true"},
+					},
+				},
+				{
+					DNSName:    "def.example.org",
+					Targets:    endpoint.Targets{"lb.example.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+					RecordTTL:  180,
+					ProviderSpecific: endpoint.ProviderSpecific{
+						{Name: "external-dns.alpha.kubernetes.io/cloudflare-proxied", Value: "true"},
+					},
+				},
+			},
+			expectEndpoints: true,
+			expectError:     false,
+		},
+		{
+			title:                "illegal target HTTPS",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			labels:               map[string]string{"test": "that"},
+			labelFilter:          "test=that",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"1 . alpn=h2 port=https"},
+					RecordType: endpoint.RecordTypeHTTPS,
+					RecordTTL:  180,
+				},
+			},
+			expectEndpoints: false,
+			expectError:     true,
+		},
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			t.Parallel()
@@ -494,7 +570,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 			// At present, client-go's fake.RESTClient (used by crd_test.go) is known to cause race conditions when used
 			// with informers: https://github.com/kubernetes/kubernetes/issues/95372
 			// So don't start the informer during testing.
-			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false)
+			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false, false, false)
 			require.NoError(t, err)
 
 			receivedEndpoints, err := cs.Endpoints(t.Context())
@@ -713,8 +789,10 @@ func TestDNSEndpointsWithSetResourceLabels(t *testing.T) {
 		labelSelector: labels.Everything(),
 	}
 
+	// the target of the NAPTR endpoint is illegal, it is reported along with the valid endpoints
 	res, err := cs.Endpoints(t.Context())
-	require.NoError(t, err)
+	require.True(t, IsInvalidObjectsError(err), "unexpected error: %v", err)
+	require.Len(t, res, 12)
 
 	for _, ep := range res {
 		require.Contains(t, ep.Labels, endpoint.ResourceLabelKey)
@@ -777,3 +855,211 @@ func generateTestFixtureDNSEndpointsByType(namespace string, typeCounts map[stri
 		Items: result,
 	}
 }
+
+func TestCRDSourceClusterScoped(t *testing.T) {
+	apiVersion := "test.k8s.io/v1alpha1"
+	groupVersion, _ := schema.ParseGroupVersion(apiVersion)
+	scheme := runtime.NewScheme()
+	_ = addKnownTypes(scheme, groupVersion)
+
+	dnsEndpoint := apiv1alpha1.DNSEndpoint{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiVersion, Kind: "DNSEndpoint"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-wide", Generation: 1},
+		Spec: apiv1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("abc.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+	}
+
+	codecFactory := serializer.WithoutConversionCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
+	var observedGeneration int64
+	client := &fake.RESTClient{
+		GroupVersion:         groupVersion,
+		VersionedAPIPath:     "/apis/" + apiVersion,
+		NegotiatedSerializer: codecFactory,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			codec := codecFactory.LegacyCodec(groupVersion)
+			// the cluster-scoped resources have no namespace in their paths
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/apis/"+apiVersion+"/dnsendpoints" && m == http.MethodGet:
+				list := &apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{dnsEndpoint}}
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, list)}, nil
+			case p == "/apis/"+apiVersion+"/dnsendpoints/cluster-wide/status" && m == http.MethodPut:
+				var body apiv1alpha1.DNSEndpoint
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				observedGeneration = body.Status.ObservedGeneration
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, &body)}, nil
+			default:
+				return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			}
+		}),
+	}
+
+	// the namespace is ignored for a cluster-scoped CRD
+	src, err := NewCRDSource(client, "default", "DNSEndpoint", "", labels.Everything(), scheme, false, true, false)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{
+			DNSName:    "abc.example.org",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"1.2.3.4"},
+			Labels:     endpoint.Labels{endpoint.ResourceLabelKey: "crd/cluster-wide"},
+		},
+	})
+	require.Equal(t, int64(1), observedGeneration)
+}
+
+func TestCRDSourceFinalizer(t *testing.T) {
+	apiVersion := "test.k8s.io/v1alpha1"
+	groupVersion, _ := schema.ParseGroupVersion(apiVersion)
+	scheme := runtime.NewScheme()
+	_ = addKnownTypes(scheme, groupVersion)
+
+	newDNSEndpoint := func(name string, finalizers ...string) apiv1alpha1.DNSEndpoint {
+		return apiv1alpha1.DNSEndpoint{
+			TypeMeta:   metav1.TypeMeta{APIVersion: apiVersion, Kind: "DNSEndpoint"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1, Finalizers: finalizers},
+			Spec: apiv1alpha1.DNSEndpointSpec{
+				Endpoints: []*endpoint.Endpoint{
+					endpoint.NewEndpoint(name+".example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				},
+			},
+		}
+	}
+	created := newDNSEndpoint("created")
+	deleted := newDNSEndpoint("deleted", "example.org/other", crdFinalizer)
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	codecFactory := serializer.WithoutConversionCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
+	updates := map[string][]string{}
+	client := &fake.RESTClient{
+		GroupVersion:         groupVersion,
+		VersionedAPIPath:     "/apis/" + apiVersion,
+		NegotiatedSerializer: codecFactory,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			codec := codecFactory.LegacyCodec(groupVersion)
+			prefix := "/apis/" + apiVersion + "/namespaces/default/dnsendpoints"
+			switch p, m := req.URL.Path, req.Method; {
+			case p == prefix && m == http.MethodGet:
+				list := &apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{created, deleted}}
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, list)}, nil
+			case strings.HasPrefix(p, prefix+"/") && m == http.MethodPut:
+				var body apiv1alpha1.DNSEndpoint
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				if !strings.HasSuffix(p, "/status") {
+					updates[body.Name] = body.Finalizers
+				}
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, &body)}, nil
+			default:
+				return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			}
+		}),
+	}
+
+	src, err := NewCRDSource(client, "default", "DNSEndpoint", "", labels.Everything(), scheme, false, false, true)
+	require.NoError(t, err)
+
+	remaining := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("deleted.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "crd/default/deleted"),
+	}
+	ctx := context.WithValue(context.Background(), provider.RecordsContextKey, remaining)
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err)
+	// the endpoints of the deleted resource are skipped, to remove its records
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{
+			DNSName:    "created.example.org",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"1.2.3.4"},
+		},
+	})
+	// the finalizer is kept while the records of the resource remain, e.g. when their deletion is deferred
+	require.Equal(t, map[string][]string{"created": {crdFinalizer}}, updates)
+
+	// the finalizer is removed once the records are gone
+	_, err = src.Endpoints(context.WithValue(context.Background(), provider.RecordsContextKey, []*endpoint.Endpoint{}))
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"created": {crdFinalizer}, "deleted": {"example.org/other"}}, updates)
+}
+
+func TestResourceRecordsRemoved(t *testing.T) {
+	remaining := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "crd/default/app"),
+	}
+	withRecords := func(p string, records []*endpoint.Endpoint) context.Context {
+		ctx := context.WithValue(context.Background(), provider.RecordsContextKey, records)
+		return WithRecordsRoute(ctx, p, []string{"aws", "coredns"})
+	}
+	var r resourceRecords
+
+	// not removed outside of a reconciliation
+	require.False(t, r.removed(context.Background(), "crd/default/app"))
+	// not removed until the records of every provider are gone
+	require.False(t, r.removed(withRecords("aws", nil), "crd/default/app"))
+	require.False(t, r.removed(withRecords("coredns", remaining), "crd/default/app"))
+	require.False(t, r.removed(withRecords("aws", nil), "crd/default/app"))
+	require.True(t, r.removed(withRecords("coredns", nil), "crd/default/app"))
+}
+
+func TestCRDSourceInvalidObjects(t *testing.T) {
+	apiVersion := "test.k8s.io/v1alpha1"
+	groupVersion, _ := schema.ParseGroupVersion(apiVersion)
+	scheme := runtime.NewScheme()
+	_ = addKnownTypes(scheme, groupVersion)
+
+	newDNSEndpoint := func(name string, endpoints ...*endpoint.Endpoint) apiv1alpha1.DNSEndpoint {
+		return apiv1alpha1.DNSEndpoint{
+			TypeMeta:   metav1.TypeMeta{APIVersion: apiVersion, Kind: "DNSEndpoint"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       apiv1alpha1.DNSEndpointSpec{Endpoints: endpoints},
+		}
+	}
+	list := &apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{
+		newDNSEndpoint("valid", endpoint.NewEndpoint("valid.example.org", endpoint.RecordTypeA, "1.2.3.4")),
+		newDNSEndpoint("partly-invalid",
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "valid.example.org"),
+			&endpoint.Endpoint{DNSName: "illegal.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"valid.example.org."}}),
+		newDNSEndpoint("invalid",
+			&endpoint.Endpoint{DNSName: "naptr.example.org", RecordType: endpoint.RecordTypeNAPTR, Targets: endpoint.Targets{`100 10 "S" "SIP+D2U" "!^.*$!sip:customer-service@example.org!" _sip._udp.example.org`}}),
+	}}
+
+	codecFactory := serializer.WithoutConversionCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
+	client := &fake.RESTClient{
+		GroupVersion:         groupVersion,
+		VersionedAPIPath:     "/apis/" + apiVersion,
+		NegotiatedSerializer: codecFactory,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			codec := codecFactory.LegacyCodec(groupVersion)
+			switch {
+			case req.Method == http.MethodGet:
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, list)}, nil
+			case req.Method == http.MethodPut:
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: req.Body}, nil
+			default:
+				return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			}
+		}),
+	}
+
+	src, err := NewCRDSource(client, "default", "DNSEndpoint", "", labels.Everything(), scheme, false, false, false)
+	require.NoError(t, err)
+
+	// the valid endpoints are still returned, along with the errors of the illegal ones
+	endpoints, err := src.Endpoints(context.Background())
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "valid.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"valid.example.org"}},
+	})
+	require.EqualError(t, err, "skipped 2 invalid objects: "+
+		"crd/default/partly-invalid: endpoint with DNSName illegal.example.org has an illegal target format; "+
+		"crd/default/invalid: endpoint with DNSName naptr.example.org has an illegal target format")
+	require.True(t, IsInvalidObjectsError(err))
+}
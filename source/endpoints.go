@@ -15,11 +15,15 @@ package source
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
+	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/labels"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // EndpointsForHostname returns the endpoint objects for each host-target combination.
@@ -81,6 +85,121 @@ func EndpointsForHostname(hostname string, targets endpoint.Targets, ttl endpoin
 	return endpoints
 }
 
+// applyObjectAnnotations applies the annotations of the object the endpoints were generated from
+// which are common to the sources. The endpoints of an object with the delete-protection annotation
+// are labelled so that the plan never deletes their records, and the ones of an object with the
+// description annotation are labelled with the description. The endpoints of an object excluded with
+// the exclude annotation are only kept as markers, so that the plan leaves their current records
+// untouched, and the endpoints whose record type is listed in the record-type-exclude annotation are dropped.
+func applyObjectAnnotations(endpoints []*endpoint.Endpoint, objAnnotations map[string]string) []*endpoint.Endpoint {
+	if annotations.IsDeleteProtectedFromAnnotations(objAnnotations) {
+		for _, ep := range endpoints {
+			ep.WithLabel(endpoint.DeleteProtectionLabelKey, "true")
+		}
+	}
+
+	if description := annotations.DescriptionFromAnnotations(objAnnotations); description != "" {
+		for _, ep := range endpoints {
+			ep.WithLabel(endpoint.DescriptionLabelKey, description)
+		}
+	}
+
+	if annotations.IsExcludedFromAnnotations(objAnnotations) {
+		for _, ep := range endpoints {
+			log.Debugf("Skipping endpoint %s because its resource is excluded by annotation", ep.DNSName)
+			ep.WithLabel(endpoint.ExcludedLabelKey, "true")
+		}
+		return endpoints
+	}
+
+	excluded := annotations.ExcludedRecordTypesFromAnnotations(objAnnotations)
+	if len(excluded) == 0 {
+		return endpoints
+	}
+
+	filtered := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if slices.Contains(excluded, ep.RecordType) {
+			log.Debugf("Skipping endpoint %s because record type %s is excluded by annotation", ep.DNSName, ep.RecordType)
+			continue
+		}
+		filtered = append(filtered, ep)
+	}
+	return filtered
+}
+
+// endpointsWithHostnameTargets replaces the targets of the hostnames mapped to their own targets in the
+// hostname annotation of the object the endpoints were generated from, e.g. "a.example.com=1.2.3.4".
+// Only the A, AAAA and CNAME endpoints of a mapped hostname are replaced.
+func endpointsWithHostnameTargets(endpoints []*endpoint.Endpoint, objAnnotations map[string]string) []*endpoint.Endpoint {
+	hostTargets := annotations.HostnameTargetsFromAnnotations(objAnnotations)
+	if len(hostTargets) == 0 {
+		return endpoints
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	replaced := map[string]struct{}{}
+	for _, ep := range endpoints {
+		targets, ok := hostTargets[ep.DNSName]
+		if !ok || !slices.Contains([]string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}, ep.RecordType) {
+			result = append(result, ep)
+			continue
+		}
+		if _, ok := replaced[ep.DNSName]; ok {
+			continue
+		}
+		replaced[ep.DNSName] = struct{}{}
+		log.Debugf("Using the annotation targets %v of %s", targets, ep.DNSName)
+		result = append(result, EndpointsForHostname(ep.DNSName, targets, ep.RecordTTL, ep.ProviderSpecific, ep.SetIdentifier, ep.Labels[endpoint.ResourceLabelKey])...)
+	}
+	return result
+}
+
+// endpointsWithHostnameAliases adds the alias hostnames listed in the hostname-aliases annotation of the
+// object the endpoints were generated from, as CNAME records to the canonical hostname. The canonical
+// hostname is the one of the first A, AAAA or CNAME endpoint, the sources generating the endpoints
+// in the order of the hostnames in the spec of the object.
+func endpointsWithHostnameAliases(endpoints []*endpoint.Endpoint, objAnnotations map[string]string) []*endpoint.Endpoint {
+	aliases := annotations.HostnameAliasesFromAnnotations(objAnnotations)
+	if len(aliases) == 0 {
+		return endpoints
+	}
+
+	i := slices.IndexFunc(endpoints, func(ep *endpoint.Endpoint) bool {
+		return slices.Contains([]string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}, ep.RecordType)
+	})
+	if i < 0 {
+		return endpoints
+	}
+	canonical := endpoints[i]
+	hostnames := map[string]struct{}{}
+	for _, ep := range endpoints {
+		hostnames[ep.DNSName] = struct{}{}
+	}
+
+	for _, alias := range aliases {
+		alias = strings.TrimSuffix(alias, ".")
+		if alias == "" {
+			continue
+		}
+		if _, ok := hostnames[alias]; ok {
+			log.Debugf("Skipping alias %s of %s because it is already a hostname of the object", alias, canonical.DNSName)
+			continue
+		}
+		hostnames[alias] = struct{}{}
+
+		ep := endpoint.NewEndpointWithTTL(alias, endpoint.RecordTypeCNAME, canonical.RecordTTL, canonical.DNSName)
+		if ep == nil {
+			continue
+		}
+		if resource, ok := canonical.Labels[endpoint.ResourceLabelKey]; ok {
+			ep.Labels[endpoint.ResourceLabelKey] = resource
+		}
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints
+}
+
 func EndpointTargetsFromServices(svcInformer coreinformers.ServiceInformer, namespace string, selector map[string]string) (endpoint.Targets, error) {
 	targets := endpoint.Targets{}
 
@@ -123,6 +123,153 @@ func TestEndpointsForHostname(t *testing.T) {
 	}
 }
 
+func TestEndpointsWithHostnameTargets(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoints   []*endpoint.Endpoint
+		annotations map[string]string
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			name: "no hostname targets",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname": "a.example.com"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			},
+		},
+		{
+			name: "per-hostname targets",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.net").WithLabel(endpoint.ResourceLabelKey, "service/default/gw"),
+				endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.net").WithLabel(endpoint.ResourceLabelKey, "service/default/gw"),
+				endpoint.NewEndpointWithTTL("c.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.net").WithLabel(endpoint.ResourceLabelKey, "service/default/gw"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname": "a.example.com=1.2.3.4;b.example.com=5.6.7.8,2001:db8::1;c.example.com"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/gw"),
+				endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeA, 300, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "service/default/gw"),
+				endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1").WithLabel(endpoint.ResourceLabelKey, "service/default/gw"),
+				endpoint.NewEndpointWithTTL("c.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.net").WithLabel(endpoint.ResourceLabelKey, "service/default/gw"),
+			},
+		},
+		{
+			name: "all address endpoints of a hostname are replaced",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeAAAA, "2001:db8::2"),
+				endpoint.NewEndpoint("_http._tcp.a.example.com", endpoint.RecordTypeSRV, "0 50 30080 a.example.com"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname": "a.example.com=lb.example.net"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+				endpoint.NewEndpoint("_http._tcp.a.example.com", endpoint.RecordTypeSRV, "0 50 30080 a.example.com"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, endpointsWithHostnameTargets(tt.endpoints, tt.annotations))
+		})
+	}
+}
+
+func TestEndpointsWithHostnameAliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoints   []*endpoint.Endpoint
+		annotations map[string]string
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			name: "no aliases",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			},
+		},
+		{
+			name:        "no endpoints",
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "www.app.example.com"},
+			expected:    nil,
+		},
+		{
+			name: "aliases of an A record",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "192.0.2.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "www.app.example.com, app.example.net."},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "192.0.2.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+				endpoint.NewEndpointWithTTL("www.app.example.com", endpoint.RecordTypeCNAME, 300, "app.example.com").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+				endpoint.NewEndpointWithTTL("app.example.net", endpoint.RecordTypeCNAME, 300, "app.example.com").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+			},
+		},
+		{
+			name: "aliases of a CNAME record",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "www.app.example.com"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+				endpoint.NewEndpoint("www.app.example.com", endpoint.RecordTypeCNAME, "app.example.com"),
+			},
+		},
+		{
+			name: "aliases of the first A, AAAA or CNAME record",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("_http._tcp.app.example.com", endpoint.RecordTypeSRV, "0 50 80 app.example.com"),
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "www.app.example.com"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("_http._tcp.app.example.com", endpoint.RecordTypeSRV, "0 50 80 app.example.com"),
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("www.app.example.com", endpoint.RecordTypeCNAME, "app.example.com"),
+			},
+		},
+		{
+			name: "no A, AAAA or CNAME record",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeTXT, "text"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "www.app.example.com"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeTXT, "text"),
+			},
+		},
+		{
+			name: "aliases already hostnames of the object",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "other.example.com,www.app.example.com,www.app.example.com"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("www.app.example.com", endpoint.RecordTypeCNAME, "app.example.com"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, endpointsWithHostnameAliases(tt.endpoints, tt.annotations))
+		})
+	}
+}
+
 func TestEndpointTargetsFromServices(t *testing.T) {
 	tests := []struct {
 		name      string
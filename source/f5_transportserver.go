@@ -105,6 +105,8 @@ func (ts *f5TransportServerSource) Endpoints(ctx context.Context) ([]*endpoint.E
 		return nil, err
 	}
 
+	// the TransportServers which can't be converted are skipped and reported along with the valid ones
+	var invalid invalidObjects
 	var transportServers []*f5.TransportServer
 	for _, tsObj := range transportServerObjects {
 		unstructuredHost, ok := tsObj.(*unstructured.Unstructured)
@@ -115,7 +117,8 @@ func (ts *f5TransportServerSource) Endpoints(ctx context.Context) ([]*endpoint.E
 		transportServer := &f5.TransportServer{}
 		err := ts.unstructuredConverter.scheme.Convert(unstructuredHost, transportServer, nil)
 		if err != nil {
-			return nil, err
+			invalid.add(fmt.Sprintf("f5-transportserver/%s/%s", unstructuredHost.GetNamespace(), unstructuredHost.GetName()), err)
+			continue
 		}
 		transportServers = append(transportServers, transportServer)
 	}
@@ -130,7 +133,7 @@ func (ts *f5TransportServerSource) Endpoints(ctx context.Context) ([]*endpoint.E
 		return nil, err
 	}
 
-	return endpoints, nil
+	return endpoints, invalid.err()
 }
 
 func (ts *f5TransportServerSource) AddEventHandler(ctx context.Context, handler func()) {
@@ -162,7 +165,8 @@ func (ts *f5TransportServerSource) endpointsFromTransportServers(transportServer
 			targets = append(targets, transportServer.Status.VSAddress)
 		}
 
-		endpoints = append(endpoints, EndpointsForHostname(transportServer.Spec.Host, targets, ttl, nil, "", resource)...)
+		tsEndpoints := EndpointsForHostname(transportServer.Spec.Host, targets, ttl, nil, "", resource)
+		endpoints = append(endpoints, applyObjectAnnotations(endpointsWithHostnameAliases(tsEndpoints, transportServer.Annotations), transportServer.Annotations)...)
 	}
 
 	return endpoints, nil
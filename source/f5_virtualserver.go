@@ -24,13 +24,16 @@ import (
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
+	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
@@ -56,6 +59,12 @@ type f5VirtualServerSource struct {
 	annotationFilter      string
 	namespace             string
 	unstructuredConverter *unstructuredConverter
+	// hostnameFilter drops the hosts outside of the allowed domains or inside of the denied domains.
+	hostnameFilter *endpoint.DomainFilter
+	// endpointSlicesInformer is only set when the VirtualServers without healthy pool members are skipped.
+	endpointSlicesInformer discoveryinformers.EndpointSliceInformer
+	// hostnamePriority orders the hostname annotation and the spec host, only the latter is used when empty.
+	hostnamePriority []string
 }
 
 func NewF5VirtualServerSource(
@@ -64,6 +73,10 @@ func NewF5VirtualServerSource(
 	kubeClient kubernetes.Interface,
 	namespace string,
 	annotationFilter string,
+	allowedHosts []string,
+	deniedHosts []string,
+	requireHealthyPoolMembers bool,
+	hostnamePriority []string,
 ) (Source, error) {
 	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
 	virtualServerInformer := informerFactory.ForResource(f5VirtualServerGVR)
@@ -87,13 +100,33 @@ func NewF5VirtualServerSource(
 		return nil, fmt.Errorf("failed to setup unstructured converter: %w", err)
 	}
 
+	// The pool members of a VirtualServer are the endpoints of the services of its pools.
+	var endpointSlicesInformer discoveryinformers.EndpointSliceInformer
+	if requireHealthyPoolMembers {
+		kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+		endpointSlicesInformer = kubeInformerFactory.Discovery().V1().EndpointSlices()
+		_, _ = endpointSlicesInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
+		if err := endpointSlicesInformer.Informer().AddIndexers(cache.Indexers{serviceNameIndexKey: endpointSliceServiceNameIndexFunc}); err != nil {
+			return nil, err
+		}
+
+		kubeInformerFactory.Start(ctx.Done())
+
+		if err := informers.WaitForCacheSync(context.Background(), kubeInformerFactory); err != nil {
+			return nil, err
+		}
+	}
+
 	return &f5VirtualServerSource{
-		dynamicKubeClient:     dynamicKubeClient,
-		virtualServerInformer: virtualServerInformer,
-		kubeClient:            kubeClient,
-		namespace:             namespace,
-		annotationFilter:      annotationFilter,
-		unstructuredConverter: uc,
+		dynamicKubeClient:      dynamicKubeClient,
+		virtualServerInformer:  virtualServerInformer,
+		kubeClient:             kubeClient,
+		namespace:              namespace,
+		annotationFilter:       annotationFilter,
+		unstructuredConverter:  uc,
+		hostnameFilter:         endpoint.NewDomainFilterWithExclusions(allowedHosts, deniedHosts),
+		endpointSlicesInformer: endpointSlicesInformer,
+		hostnamePriority:       hostnamePriority,
 	}, nil
 }
 
@@ -105,6 +138,8 @@ func (vs *f5VirtualServerSource) Endpoints(ctx context.Context) ([]*endpoint.End
 		return nil, err
 	}
 
+	// the VirtualServers which can't be converted are skipped and reported along with the valid ones
+	var invalid invalidObjects
 	var virtualServers []*f5.VirtualServer
 	for _, vsObj := range virtualServerObjects {
 		unstructuredHost, ok := vsObj.(*unstructured.Unstructured)
@@ -115,7 +150,8 @@ func (vs *f5VirtualServerSource) Endpoints(ctx context.Context) ([]*endpoint.End
 		virtualServer := &f5.VirtualServer{}
 		err := vs.unstructuredConverter.scheme.Convert(unstructuredHost, virtualServer, nil)
 		if err != nil {
-			return nil, err
+			invalid.add(fmt.Sprintf("f5-virtualserver/%s/%s", unstructuredHost.GetNamespace(), unstructuredHost.GetName()), err)
+			continue
 		}
 		virtualServers = append(virtualServers, virtualServer)
 	}
@@ -135,13 +171,16 @@ func (vs *f5VirtualServerSource) Endpoints(ctx context.Context) ([]*endpoint.End
 		sort.Sort(ep.Targets)
 	}
 
-	return endpoints, nil
+	return endpoints, invalid.err()
 }
 
 func (vs *f5VirtualServerSource) AddEventHandler(ctx context.Context, handler func()) {
 	log.Debug("Adding event handler for VirtualServer")
 
 	vs.virtualServerInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	if vs.endpointSlicesInformer != nil {
+		_, _ = vs.endpointSlicesInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
 }
 
 // endpointsFromVirtualServers extracts the endpoints from a slice of VirtualServers
@@ -155,6 +194,19 @@ func (vs *f5VirtualServerSource) endpointsFromVirtualServers(virtualServers []*f
 			continue
 		}
 
+		hostnames := vs.allowedHostnames(virtualServer)
+		if len(hostnames) == 0 {
+			log.Debugf("Skipping F5 VirtualServer %s/%s because its host %s is not allowed",
+				virtualServer.Namespace, virtualServer.Name, virtualServer.Spec.Host)
+			continue
+		}
+
+		if vs.endpointSlicesInformer != nil && !vs.hasHealthyPoolMembers(virtualServer) {
+			log.Infof("F5 VirtualServer %s/%s has no healthy pool members, skipping endpoint creation.",
+				virtualServer.Namespace, virtualServer.Name)
+			continue
+		}
+
 		resource := fmt.Sprintf("f5-virtualserver/%s/%s", virtualServer.Namespace, virtualServer.Name)
 
 		ttl := annotations.TTLFromAnnotations(virtualServer.Annotations, resource)
@@ -168,12 +220,43 @@ func (vs *f5VirtualServerSource) endpointsFromVirtualServers(virtualServers []*f
 			targets = append(targets, virtualServer.Status.VSAddress)
 		}
 
-		endpoints = append(endpoints, EndpointsForHostname(virtualServer.Spec.Host, targets, ttl, nil, "", resource)...)
+		var vsEndpoints []*endpoint.Endpoint
+		for _, hostname := range hostnames {
+			vsEndpoints = append(vsEndpoints, EndpointsForHostname(hostname, targets, ttl, nil, "", resource)...)
+		}
+		endpoints = append(endpoints, applyObjectAnnotations(endpointsWithHostnameAliases(vsEndpoints, virtualServer.Annotations), virtualServer.Annotations)...)
 	}
 
 	return endpoints, nil
 }
 
+// allowedHostnames returns the allowed hostnames of the VirtualServer: its spec host and host aliases,
+// or the first of the hostname annotation and the spec hosts having any by priority.
+func (vs *f5VirtualServerSource) allowedHostnames(virtualServer *f5.VirtualServer) []string {
+	hostnames := append([]string{virtualServer.Spec.Host}, virtualServer.Spec.HostAliases...)
+	if len(vs.hostnamePriority) > 0 {
+		var specHosts []string
+		if virtualServer.Spec.Host != "" {
+			specHosts = []string{virtualServer.Spec.Host}
+		}
+		specHosts = append(specHosts, virtualServer.Spec.HostAliases...)
+		if priorityHostnames, ok := byHostnamePriority(vs.hostnamePriority, map[string][]string{
+			HostnameOriginAnnotation: annotations.HostnamesFromAnnotations(virtualServer.Annotations),
+			HostnameOriginSpec:       specHosts,
+		}); ok {
+			hostnames = priorityHostnames
+		}
+	}
+
+	var allowed []string
+	for _, hostname := range hostnames {
+		if vs.hostnameFilter.Match(hostname) {
+			allowed = append(allowed, hostname)
+		}
+	}
+	return allowed
+}
+
 // newUnstructuredConverter returns a new unstructuredConverter initialized
 func newVSUnstructuredConverter() (*unstructuredConverter, error) {
 	uc := &unstructuredConverter{
@@ -217,3 +300,46 @@ func hasValidVirtualServerIP(vs *f5.VirtualServer) bool {
 	normalizedAddress := strings.ToLower(vs.Status.VSAddress)
 	return normalizedAddress != "none" && normalizedAddress != ""
 }
+
+// hasHealthyPoolMembers reports whether any pool of the VirtualServer has a ready member,
+// i.e. a ready endpoint of the pool service. VirtualServers without pool services are considered healthy.
+func (vs *f5VirtualServerSource) hasHealthyPoolMembers(virtualServer *f5.VirtualServer) bool {
+	var services []types.NamespacedName
+	addService := func(namespace, name string) {
+		if name == "" {
+			return
+		}
+		if namespace == "" {
+			namespace = virtualServer.Namespace
+		}
+		services = append(services, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	addService(virtualServer.Spec.DefaultPool.ServiceNamespace, virtualServer.Spec.DefaultPool.Service)
+	for _, pool := range virtualServer.Spec.Pools {
+		addService(pool.ServiceNamespace, pool.Service)
+	}
+	if len(services) == 0 {
+		return true
+	}
+
+	for _, service := range services {
+		endpointSlices, err := vs.endpointSlicesInformer.Informer().GetIndexer().ByIndex(serviceNameIndexKey, service.String())
+		if err != nil {
+			log.Errorf("Get EndpointSlices of service %s error: %v", service, err)
+			continue
+		}
+		for _, obj := range endpointSlices {
+			endpointSlice, ok := obj.(*discoveryv1.EndpointSlice)
+			if !ok {
+				continue
+			}
+			for _, ep := range endpointSlice.Endpoints {
+				// A nil ready condition is interpreted as ready.
+				if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -42,9 +43,84 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 	tests := []struct {
 		name             string
 		annotationFilter string
+		allowedHosts     []string
+		deniedHosts      []string
+		hostnamePriority []string
 		virtualServer    f5.VirtualServer
 		expected         []*endpoint.Endpoint
 	}{
+		{
+			name:             "F5 VirtualServer with hostname annotation by priority",
+			hostnamePriority: []string{"annotation", "spec"},
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+					Annotations: map[string]string{
+						hostnameAnnotationKey: "app.example.com",
+					},
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.200",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
+		{
+			name:             "F5 VirtualServer with hostname annotation ignored by default",
+			annotationFilter: "",
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+					Annotations: map[string]string{
+						hostnameAnnotationKey: "app.example.com",
+					},
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.200",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
 		{
 			name:             "F5 VirtualServer with target annotation",
 			annotationFilter: "",
@@ -81,6 +157,89 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "F5 VirtualServer with dual-stack target annotation and AAAA excluded",
+			annotationFilter: "",
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+					Annotations: map[string]string{
+						targetAnnotationKey:            "192.168.1.150,2001:db8::1",
+						recordTypeExcludeAnnotationKey: "AAAA",
+					},
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.200",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"192.168.1.150"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
+		{
+			name:             "F5 VirtualServer with typed target annotations",
+			annotationFilter: "",
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+					Annotations: map[string]string{
+						targetIPv4AnnotationKey: "192.168.1.150",
+						targetIPv6AnnotationKey: "2001:db8::1",
+					},
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.200",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"192.168.1.150"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"2001:db8::1"},
+					RecordType: endpoint.RecordTypeAAAA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
 		{
 			name:             "F5 VirtualServer with host and virtualServerAddress set",
 			annotationFilter: "",
@@ -114,6 +273,128 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "F5 VirtualServer with host aliases",
+			annotationFilter: "",
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					HostAliases:          []string{"app.example.com", "shop.example.com"},
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.200",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+				{
+					DNSName:    "app.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+				{
+					DNSName:    "shop.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
+		{
+			name:             "F5 VirtualServer with host aliases by priority",
+			hostnamePriority: []string{"spec", "annotation"},
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+					Annotations: map[string]string{
+						hostnameAnnotationKey: "other.example.com",
+					},
+				},
+				Spec: f5.VirtualServerSpec{
+					HostAliases:          []string{"app.example.com"},
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.200",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
+		{
+			name:         "F5 VirtualServer with host alias outside of the allowed domains",
+			allowedHosts: []string{"example.com"},
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					HostAliases:          []string{"www.example.org"},
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.200",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
 		{
 			name:             "F5 VirtualServer with host set and IP address from the status field",
 			annotationFilter: "",
@@ -325,6 +606,110 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name:         "F5 VirtualServer with permitted host",
+			allowedHosts: []string{"example.com"},
+			deniedHosts:  []string{"cluster.example.com"},
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.100",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"192.168.1.100"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-virtualserver/virtualserver/test-vs",
+					},
+				},
+			},
+		},
+		{
+			name:         "F5 VirtualServer with reserved host",
+			allowedHosts: []string{"example.com"},
+			deniedHosts:  []string{"cluster.example.com"},
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "api.cluster.example.com",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.100",
+					Status:    "OK",
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:        "F5 VirtualServer with reserved apex host",
+			deniedHosts: []string{"cluster.example.com"},
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "cluster.example.com",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.100",
+					Status:    "OK",
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:         "F5 VirtualServer with host outside of the allowed domains",
+			allowedHosts: []string{"example.com"},
+			virtualServer: f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.org",
+					VirtualServerAddress: "192.168.1.100",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.100",
+					Status:    "OK",
+				},
+			},
+			expected: nil,
+		},
 	}
 
 	for _, tc := range tests {
@@ -344,7 +729,7 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(f5VirtualServerGVR).Namespace(defaultF5VirtualServerNamespace).Create(context.Background(), &virtualServer, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewF5VirtualServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultF5VirtualServerNamespace, tc.annotationFilter)
+			source, err := NewF5VirtualServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultF5VirtualServerNamespace, tc.annotationFilter, tc.allowedHosts, tc.deniedHosts, false, tc.hostnamePriority)
 			require.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -360,3 +745,175 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestF5VirtualServerHealthyPoolMembers(t *testing.T) {
+	t.Parallel()
+
+	newEndpointSlice := func(name, service string, ready ...bool) *discoveryv1.EndpointSlice {
+		endpointSlice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: defaultF5VirtualServerNamespace,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+		}
+		for _, r := range ready {
+			endpointSlice.Endpoints = append(endpointSlice.Endpoints, discoveryv1.Endpoint{
+				Addresses:  []string{"10.0.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &r},
+			})
+		}
+		return endpointSlice
+	}
+
+	tests := []struct {
+		name           string
+		defaultPool    f5.DefaultPool
+		pools          []f5.VSPool
+		endpointSlices []*discoveryv1.EndpointSlice
+		expected       bool
+	}{
+		{
+			name:           "ready pool members",
+			pools:          []f5.VSPool{{Service: "app"}},
+			endpointSlices: []*discoveryv1.EndpointSlice{newEndpointSlice("app-1", "app", false, true)},
+			expected:       true,
+		},
+		{
+			name:           "no ready pool members",
+			pools:          []f5.VSPool{{Service: "app"}},
+			endpointSlices: []*discoveryv1.EndpointSlice{newEndpointSlice("app-1", "app", false, false)},
+			expected:       false,
+		},
+		{
+			name:     "pool service without endpoints",
+			pools:    []f5.VSPool{{Service: "app"}},
+			expected: false,
+		},
+		{
+			name:  "one of the pools has ready members",
+			pools: []f5.VSPool{{Service: "app"}, {Service: "backup"}},
+			endpointSlices: []*discoveryv1.EndpointSlice{
+				newEndpointSlice("app-1", "app", false),
+				newEndpointSlice("backup-1", "backup", true),
+			},
+			expected: true,
+		},
+		{
+			name:           "ready default pool members",
+			defaultPool:    f5.DefaultPool{Service: "app"},
+			endpointSlices: []*discoveryv1.EndpointSlice{newEndpointSlice("app-1", "app", true)},
+			expected:       true,
+		},
+		{
+			name:           "ready members of a pool in another namespace",
+			pools:          []f5.VSPool{{Service: "app", ServiceNamespace: "other"}},
+			endpointSlices: []*discoveryv1.EndpointSlice{newEndpointSlice("app-1", "app", true)},
+			expected:       false,
+		},
+		{
+			name:     "no pools",
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubernetesClient := fakeKube.NewClientset()
+			for _, endpointSlice := range tc.endpointSlices {
+				_, err := fakeKubernetesClient.DiscoveryV1().EndpointSlices(endpointSlice.Namespace).Create(context.Background(), endpointSlice, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			scheme := runtime.NewScheme()
+			scheme.AddKnownTypes(f5VirtualServerGVR.GroupVersion(), &f5.VirtualServer{}, &f5.VirtualServerList{})
+			fakeDynamicClient := fakeDynamic.NewSimpleDynamicClient(scheme)
+
+			virtualServer := f5.VirtualServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+					Kind:       "VirtualServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5VirtualServerNamespace,
+				},
+				Spec: f5.VirtualServerSpec{
+					Host:                 "www.example.com",
+					VirtualServerAddress: "192.168.1.100",
+					DefaultPool:          tc.defaultPool,
+					Pools:                tc.pools,
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "192.168.1.100",
+					Status:    "OK",
+				},
+			}
+			unstructuredVirtualServer := unstructured.Unstructured{}
+			virtualServerJSON, err := json.Marshal(virtualServer)
+			require.NoError(t, err)
+			require.NoError(t, unstructuredVirtualServer.UnmarshalJSON(virtualServerJSON))
+			_, err = fakeDynamicClient.Resource(f5VirtualServerGVR).Namespace(defaultF5VirtualServerNamespace).Create(context.Background(), &unstructuredVirtualServer, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			source, err := NewF5VirtualServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, "", "", nil, nil, true, nil)
+			require.NoError(t, err)
+
+			endpoints, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+			if tc.expected {
+				require.Len(t, endpoints, 1)
+				assert.Equal(t, "www.example.com", endpoints[0].DNSName)
+			} else {
+				assert.Empty(t, endpoints)
+			}
+		})
+	}
+}
+
+func TestF5VirtualServerInvalidObjects(t *testing.T) {
+	fakeKubernetesClient := fakeKube.NewClientset()
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(f5VirtualServerGVR.GroupVersion(), &f5.VirtualServer{}, &f5.VirtualServerList{})
+	fakeDynamicClient := fakeDynamic.NewSimpleDynamicClient(scheme)
+
+	newVirtualServer := func(name string, spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": f5VirtualServerGVR.GroupVersion().String(),
+			"kind":       "VirtualServer",
+			"metadata":   map[string]interface{}{"name": name, "namespace": defaultF5VirtualServerNamespace},
+			"spec":       spec,
+			"status":     map[string]interface{}{"vsAddress": "192.168.1.100", "status": "OK"},
+		}}
+	}
+	for _, virtualServer := range []*unstructured.Unstructured{
+		newVirtualServer("valid", map[string]interface{}{"host": "www.example.com", "virtualServerAddress": "192.168.1.100"}),
+		newVirtualServer("invalid-host", map[string]interface{}{"host": int64(42)}),
+		newVirtualServer("invalid-pools", map[string]interface{}{"host": "app.example.com", "pools": "none"}),
+	} {
+		_, err := fakeDynamicClient.Resource(f5VirtualServerGVR).Namespace(defaultF5VirtualServerNamespace).Create(context.Background(), virtualServer, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	source, err := NewF5VirtualServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultF5VirtualServerNamespace, "", nil, nil, false, nil)
+	require.NoError(t, err)
+
+	// the valid VirtualServer is still published, the invalid ones are reported
+	endpoints, err := source.Endpoints(context.Background())
+	require.Error(t, err)
+	require.True(t, IsInvalidObjectsError(err))
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "www.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.168.1.100"}},
+	})
+
+	var invalid *InvalidObjectsError
+	require.ErrorAs(t, err, &invalid)
+	resources := []string{}
+	for _, objectErr := range invalid.Errors {
+		resources = append(resources, objectErr.Resource)
+	}
+	assert.ElementsMatch(t, []string{
+		"f5-virtualserver/" + defaultF5VirtualServerNamespace + "/invalid-host",
+		"f5-virtualserver/" + defaultF5VirtualServerNamespace + "/invalid-pools",
+	}, resources)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/netip"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
@@ -87,10 +88,15 @@ func newGatewayInformerFactory(client gateway.Interface, namespace string, label
 }
 
 type gatewayRouteSource struct {
-	gwName      string
-	gwNamespace string
-	gwLabels    labels.Selector
-	gwInformer  informers_v1beta1.GatewayInformer
+	gwName         string
+	gwNamespace    string
+	gwLabels       labels.Selector
+	gwAddressTypes []string
+	// gwFallbackTargets are the targets of the Routes whose Gateway has no address yet
+	gwFallbackTargets endpoint.Targets
+	// gwRequireProgrammed only uses the targets of the Gateways with the Programmed condition
+	gwRequireProgrammed bool
+	gwInformer          informers_v1beta1.GatewayInformer
 
 	rtKind        string
 	rtNamespace   string
@@ -167,10 +173,13 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 	}
 
 	src := &gatewayRouteSource{
-		gwName:      config.GatewayName,
-		gwNamespace: config.GatewayNamespace,
-		gwLabels:    gwLabels,
-		gwInformer:  gwInformer,
+		gwName:              config.GatewayName,
+		gwNamespace:         config.GatewayNamespace,
+		gwLabels:            gwLabels,
+		gwAddressTypes:      config.GatewayAddressTypes,
+		gwFallbackTargets:   config.GatewayFallbackTargets,
+		gwRequireProgrammed: config.GatewayRequireProgrammed,
+		gwInformer:          gwInformer,
 
 		rtKind:        kind,
 		rtNamespace:   config.Namespace,
@@ -226,8 +235,8 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 			continue
 		}
 
-		// Get Route hostnames and their targets.
-		hostTargets, err := resolver.resolve(rt)
+		// Get Route hostnames in the order of the Route, their targets and the TTLs of their Gateways.
+		hosts, hostTargets, hostTTLs, err := resolver.resolve(rt)
 		if err != nil {
 			return nil, err
 		}
@@ -241,9 +250,19 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 		resource := fmt.Sprintf("%s/%s/%s", kind, meta.Namespace, meta.Name)
 		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
 		ttl := annotations.TTLFromAnnotations(annots, resource)
-		for host, targets := range hostTargets {
-			routeEndpoints = append(routeEndpoints, EndpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		for _, host := range hosts {
+			targets := hostTargets[host]
+			hostTTL := ttl
+			if !hostTTL.IsConfigured() {
+				// Fall back to the TTL of the parent Gateway.
+				hostTTL = hostTTLs[host]
+			}
+			routeEndpoints = append(routeEndpoints, EndpointsForHostname(host, targets, hostTTL, providerSpecific, setIdentifier, resource)...)
+		}
+		if !src.ignoreHostnameAnnotation {
+			routeEndpoints = endpointsWithHostnameTargets(routeEndpoints, annots)
 		}
+		routeEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(routeEndpoints, annots), annots)
 		log.Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
 
 		endpoints = append(endpoints, routeEndpoints...)
@@ -264,6 +283,8 @@ type gatewayRouteResolver struct {
 type gatewayListeners struct {
 	gateway   *v1beta1.Gateway
 	listeners map[v1.SectionName][]v1.Listener
+	// ttl is the TTL annotation of the Gateway, inherited by the Routes without one
+	ttl endpoint.TTL
 }
 
 func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gateway, namespaces []*corev1.Namespace) *gatewayRouteResolver {
@@ -278,6 +299,7 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 		gws[namespacedName(gw.Namespace, gw.Name)] = gatewayListeners{
 			gateway:   gw,
 			listeners: lss,
+			ttl:       annotations.TTLFromAnnotations(gw.Annotations, fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name)),
 		}
 	}
 	// Create Namespace lookup table.
@@ -292,18 +314,22 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 	}
 }
 
-func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Targets, error) {
+// resolve returns the Route hostnames in the order of the Route, their targets, and the TTLs of the Gateways
+// they are attached to. The lowest TTL is used for a hostname attached to several Gateways.
+func (c *gatewayRouteResolver) resolve(rt gatewayRoute) ([]string, map[string]endpoint.Targets, map[string]endpoint.TTL, error) {
 	rtHosts, err := c.hosts(rt)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	var hosts []string
 	hostTargets := make(map[string]endpoint.Targets)
+	hostTTLs := make(map[string]endpoint.TTL)
 
 	routeParentRefs := rt.ParentRefs()
 
 	if len(routeParentRefs) == 0 {
 		log.Debugf("No parent references found for %s %s/%s", c.src.rtKind, rt.Metadata().Namespace, rt.Metadata().Name)
-		return hostTargets, nil
+		return hosts, hostTargets, hostTTLs, nil
 	}
 
 	meta := rt.Metadata()
@@ -341,6 +367,12 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 			continue
 		}
 
+		// Only use the targets of a Gateway once it is programmed, if required.
+		programmed := !c.src.gwRequireProgrammed || gwIsProgrammed(gw.gateway.Status.Conditions)
+		if !programmed {
+			log.Debugf("Gateway %s/%s is not programmed, not using its targets for %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
+		}
+
 		// Match the Route to all possible Listeners.
 		match := false
 		section := sectionVal(ref.SectionName, "")
@@ -377,13 +409,28 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 				if !ok {
 					continue
 				}
-				override := annotations.TargetsFromTargetAnnotation(gw.gateway.Annotations)
-				hostTargets[host] = append(hostTargets[host], override...)
-				if len(override) == 0 {
+				var gwTargets endpoint.Targets
+				if programmed {
+					gwTargets = annotations.TargetsFromTargetAnnotation(gw.gateway.Annotations)
+				}
+				if programmed && len(gwTargets) == 0 {
 					for _, addr := range gw.gateway.Status.Addresses {
-						hostTargets[host] = append(hostTargets[host], addr.Value)
+						if c.src.addressTypeAllowed(addr.Type) {
+							gwTargets = append(gwTargets, addr.Value)
+						}
 					}
 				}
+				if len(gwTargets) == 0 && len(c.src.gwFallbackTargets) > 0 {
+					log.Debugf("Gateway %s/%s has no address yet, using the fallback targets for %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
+					gwTargets = c.src.gwFallbackTargets
+				}
+				if _, ok := hostTargets[host]; !ok {
+					hosts = append(hosts, host)
+				}
+				hostTargets[host] = append(hostTargets[host], gwTargets...)
+				if gw.ttl.IsConfigured() && (!hostTTLs[host].IsConfigured() || gw.ttl < hostTTLs[host]) {
+					hostTTLs[host] = gw.ttl
+				}
 				match = true
 			}
 		}
@@ -396,7 +443,20 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 	for host, targets := range hostTargets {
 		hostTargets[host] = uniqueTargets(targets)
 	}
-	return hostTargets, nil
+	return hosts, hostTargets, hostTTLs, nil
+}
+
+// addressTypeAllowed returns true if Gateway status addresses of the type are used as targets.
+// Addresses without type are of type IPAddress.
+func (src *gatewayRouteSource) addressTypeAllowed(addrType *v1.AddressType) bool {
+	if len(src.gwAddressTypes) == 0 {
+		return true
+	}
+	t := v1.IPAddressType
+	if addrType != nil {
+		t = *addrType
+	}
+	return slices.Contains(src.gwAddressTypes, string(t))
 }
 
 func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
@@ -507,6 +567,16 @@ func gwRouteIsAccepted(conds []metav1.Condition) bool {
 	return false
 }
 
+// gwIsProgrammed returns whether the Gateway has the Programmed condition, i.e. its addresses are serving.
+func gwIsProgrammed(conds []metav1.Condition) bool {
+	for _, c := range conds {
+		if v1.GatewayConditionType(c.Type) == v1.GatewayConditionProgrammed {
+			return c.Status == metav1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func uniqueTargets(targets endpoint.Targets) endpoint.Targets {
 	if len(targets) < 2 {
 		return targets
@@ -19,6 +19,7 @@ package source
 import (
 	"context"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
@@ -52,6 +53,18 @@ func gatewayStatus(ips ...string) v1.GatewayStatus {
 	return v1.GatewayStatus{Addresses: addrs}
 }
 
+func gwWithProgrammed(status v1.GatewayStatus, programmed bool) v1.GatewayStatus {
+	cond := metav1.ConditionFalse
+	if programmed {
+		cond = metav1.ConditionTrue
+	}
+	status.Conditions = append(status.Conditions, metav1.Condition{
+		Type:   string(v1.GatewayConditionProgrammed),
+		Status: cond,
+	})
+	return status
+}
+
 func httpRouteStatus(refs ...v1.ParentReference) v1.HTTPRouteStatus {
 	return v1.HTTPRouteStatus{RouteStatus: gwRouteStatus(refs...)}
 }
@@ -163,6 +176,15 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 		return v
 	}
 	hostnames := func(names ...v1.Hostname) []v1.Hostname { return names }
+	ipAddressType := v1.IPAddressType
+	hostnameType := v1.HostnameAddressType
+	internalType := v1.AddressType("example.com/internal")
+	mixedAddressesStatus := v1.GatewayStatus{Addresses: []v1.GatewayStatusAddress{
+		{Type: &ipAddressType, Value: "1.2.3.4"},
+		{Type: &hostnameType, Value: "lb.example.com"},
+		{Value: "2.3.4.5"},
+		{Type: &internalType, Value: "10.0.0.1"},
+	}}
 
 	tests := []struct {
 		title           string
@@ -1084,6 +1106,130 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				newTestEndpointWithTTL("valid-ttl.internal", "A", 15, "1.2.3.4"),
 			},
 		},
+		{
+			title:      "TTLInheritedFromGateway",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "with-ttl",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "5m"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "with-lower-ttl",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "60"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "inherited-ttl"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("inherited-ttl.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "with-ttl"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "with-ttl")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "own-ttl",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "15s"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("own-ttl.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "with-ttl"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "with-ttl")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "lowest-ttl"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("lowest-ttl.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "with-ttl"),
+								gwParentRef("default", "with-lower-ttl"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "with-ttl"), gwParentRef("default", "with-lower-ttl")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("inherited-ttl.internal", "A", 300, "1.2.3.4"),
+				newTestEndpointWithTTL("own-ttl.internal", "A", 15, "1.2.3.4"),
+				newTestEndpointWithTTL("lowest-ttl.internal", "A", 60, "1.2.3.4", "2.3.4.5"),
+			},
+		},
+		{
+			title:      "RecordTypeExcludeAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4", "2001:db8::1"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "without-aaaa",
+						Namespace:   "default",
+						Annotations: map[string]string{recordTypeExcludeAnnotationKey: "AAAA"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("without-aaaa.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "dual-stack"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("dual-stack.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("without-aaaa.internal", "A", "1.2.3.4"),
+				newTestEndpoint("dual-stack.internal", "A", "1.2.3.4"),
+				newTestEndpoint("dual-stack.internal", "AAAA", "2001:db8::1"),
+			},
+		},
 		{
 			title:      "ProviderAnnotations",
 			config:     Config{},
@@ -1540,6 +1686,337 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				"Parent reference gateway-namespace/other-gateway not found in routeParentRefs for HTTPRoute route-namespace/test",
 			},
 		},
+		{
+			title: "GatewayFallbackTargets",
+			config: Config{
+				GatewayFallbackTargets: []string{"192.0.2.1"},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "pending"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+				},
+				{
+					ObjectMeta: objectMeta("default", "ready"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "pending"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("pending.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "pending"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "pending")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "ready"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("ready.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "ready"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "ready")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("pending.example.internal", "A", "192.0.2.1"),
+				newTestEndpoint("ready.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Gateway default/pending has no address yet, using the fallback targets for HTTPRoute default/pending",
+			},
+		},
+		{
+			title: "GatewayRequireProgrammed",
+			config: Config{
+				GatewayRequireProgrammed: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "programmed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gwWithProgrammed(gatewayStatus("1.2.3.4"), true),
+				},
+				{
+					ObjectMeta: objectMeta("default", "not-programmed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gwWithProgrammed(gatewayStatus("2.3.4.5"), false),
+				},
+				{
+					ObjectMeta: objectMeta("default", "unknown"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("3.4.5.6"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "programmed"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("programmed.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "programmed"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "programmed")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "not-programmed"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("not-programmed.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "not-programmed"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "not-programmed")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "unknown"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("unknown.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "unknown"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "unknown")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("programmed.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Gateway default/not-programmed is not programmed, not using its targets for HTTPRoute default/not-programmed",
+				"Gateway default/unknown is not programmed, not using its targets for HTTPRoute default/unknown",
+			},
+		},
+		{
+			title: "GatewayRequireProgrammedFallbackTargets",
+			config: Config{
+				GatewayRequireProgrammed: true,
+				GatewayFallbackTargets:   []string{"192.0.2.1"},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "programmed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gwWithProgrammed(gatewayStatus("1.2.3.4"), true),
+				},
+				{
+					ObjectMeta: objectMeta("default", "not-programmed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gwWithProgrammed(gatewayStatus("2.3.4.5"), false),
+				},
+				{
+					ObjectMeta: objectMeta("default", "unknown"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("3.4.5.6"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "programmed"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("programmed.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "programmed"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "programmed")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "not-programmed"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("not-programmed.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "not-programmed"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "not-programmed")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "unknown"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("unknown.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "unknown"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "unknown")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("programmed.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("not-programmed.example.internal", "A", "192.0.2.1"),
+				newTestEndpoint("unknown.example.internal", "A", "192.0.2.1"),
+			},
+			logExpectations: []string{
+				"Gateway default/not-programmed is not programmed, not using its targets for HTTPRoute default/not-programmed",
+			},
+		},
+		{
+			title: "GatewayAddressTypesIPAddress",
+			config: Config{
+				GatewayAddressTypes: []string{"IPAddress"},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: mixedAddressesStatus,
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			},
+		},
+		{
+			title: "GatewayAddressTypesHostname",
+			config: Config{
+				GatewayAddressTypes: []string{"Hostname"},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: mixedAddressesStatus,
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "CNAME", "lb.example.com"),
+			},
+		},
+		{
+			title: "GatewayAddressTypesImplementationSpecific",
+			config: Config{
+				GatewayAddressTypes: []string{"example.com/internal", "IPAddress"},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: mixedAddressesStatus,
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "10.0.0.1", "2.3.4.5"),
+			},
+		},
+		{
+			title:      "HostnameAliasesOfFirstHostname",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"external-dns.alpha.kubernetes.io/hostname-aliases": "www.example.internal",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("c.example.internal", "a.example.internal", "d.example.internal", "b.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("c.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("a.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("d.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("b.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("www.example.internal", "CNAME", "c.example.internal"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.title, func(t *testing.T) {
@@ -1584,4 +2061,86 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 	}
 }
 
+func TestGatewayHTTPRouteSourceAddressSetLater(t *testing.T) {
+	for _, tc := range []struct {
+		title           string
+		fallbackTargets []string
+		expectedBefore  []*endpoint.Endpoint
+	}{
+		{
+			title:          "without fallback targets",
+			expectedBefore: []*endpoint.Endpoint{},
+		},
+		{
+			title:           "with fallback targets",
+			fallbackTargets: []string{"192.0.2.1"},
+			expectedBefore: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "192.0.2.1"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			ctx := t.Context()
+			gw := &v1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+			}
+			rt := &v1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: []v1.Hostname{"test.example.internal"},
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}
+			gwClient := gatewayfake.NewSimpleClientset()
+			_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+			require.NoError(t, err)
+			_, err = gwClient.GatewayV1beta1().HTTPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+			require.NoError(t, err)
+			kubeClient := kubefake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+
+			clients := new(MockClientGenerator)
+			clients.On("GatewayClient").Return(gwClient, nil)
+			clients.On("KubeClient").Return(kubeClient, nil)
+
+			src, err := NewGatewayHTTPRouteSource(clients, &Config{GatewayFallbackTargets: tc.fallbackTargets})
+			require.NoError(t, err)
+
+			events := make(chan struct{}, 10)
+			src.AddEventHandler(ctx, func() { events <- struct{}{} })
+
+			// the Gateway has no address yet
+			endpoints, err := src.Endpoints(ctx)
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expectedBefore)
+
+			// the Gateway gets its address, which triggers a reconciliation publishing it
+			for len(events) > 0 {
+				<-events
+			}
+			gw.Status = gatewayStatus("1.2.3.4")
+			_, err = gwClient.GatewayV1beta1().Gateways("default").UpdateStatus(ctx, gw, metav1.UpdateOptions{})
+			require.NoError(t, err)
+
+			select {
+			case <-events:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the update of the Gateway address did not trigger an event")
+			}
+			endpoints, err = src.Endpoints(ctx)
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+			})
+		})
+	}
+}
+
 func hostnamePtr(val v1.Hostname) *v1.Hostname { return &val }
@@ -25,6 +25,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -148,6 +149,7 @@ func (gs *glooSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, erro
 			if err != nil {
 				return nil, err
 			}
+			proxyEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(proxyEndpoints, proxy.Metadata.Annotations), proxy.Metadata.Annotations)
 			log.Debugf("Gloo[%s]: Generate %d endpoint(s)", proxy.Metadata.Name, len(proxyEndpoints))
 			endpoints = append(endpoints, proxyEndpoints...)
 		}
@@ -162,31 +164,46 @@ func (gs *glooSource) generateEndpointsFromProxy(ctx context.Context, proxy *pro
 
 	for _, listener := range proxy.Spec.Listeners {
 		for _, virtualHost := range listener.HTTPListener.VirtualHosts {
-			ants, err := gs.annotationsFromProxySource(ctx, virtualHost)
+			ants, sourceDomains, err := gs.annotationsFromProxySource(ctx, virtualHost)
 			if err != nil {
 				return nil, err
 			}
 			ttl := annotations.TTLFromAnnotations(ants, resource)
 			providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ants)
-			for _, domain := range virtualHost.Domains {
-				endpoints = append(endpoints, EndpointsForHostname(strings.TrimSuffix(domain, "."), targets, ttl, providerSpecific, setIdentifier, "")...)
+			for _, domain := range virtualHostDomains(virtualHost.Domains, sourceDomains) {
+				domainEndpoints := EndpointsForHostname(domain, targets, ttl, providerSpecific, setIdentifier, "")
+				endpoints = append(endpoints, applyObjectAnnotations(domainEndpoints, ants)...)
 			}
 		}
 	}
 	return endpoints, nil
 }
 
-func (gs *glooSource) annotationsFromProxySource(ctx context.Context, virtualHost proxyVirtualHost) (map[string]string, error) {
+// annotationsFromProxySource returns the annotations of the sources of the virtual host,
+// along with the domains of the source VirtualServices.
+func (gs *glooSource) annotationsFromProxySource(ctx context.Context, virtualHost proxyVirtualHost) (map[string]string, []string, error) {
 	ants := map[string]string{}
+	var domains []string
+	addSource := func(source *unstructured.Unstructured) error {
+		for key, value := range source.GetAnnotations() {
+			ants[key] = value
+		}
+		sourceDomains, _, err := unstructured.NestedStringSlice(source.Object, "spec", "virtualHost", "domains")
+		if err != nil {
+			return fmt.Errorf("failed to get the domains of VirtualService %s/%s: %w", source.GetNamespace(), source.GetName(), err)
+		}
+		domains = append(domains, sourceDomains...)
+		return nil
+	}
 	for _, src := range virtualHost.Metadata.Source {
 		kind := sourceKind(src.Kind)
 		if kind != nil {
 			source, err := gs.dynamicKubeClient.Resource(*kind).Namespace(src.Namespace).Get(ctx, src.Name, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
-			for key, value := range source.GetAnnotations() {
-				ants[key] = value
+			if err := addSource(source); err != nil {
+				return nil, nil, err
 			}
 		}
 	}
@@ -195,14 +212,30 @@ func (gs *glooSource) annotationsFromProxySource(ctx context.Context, virtualHos
 		if kind != nil {
 			source, err := gs.dynamicKubeClient.Resource(*kind).Namespace(src.ResourceRef.Namespace).Get(ctx, src.ResourceRef.Name, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
-			for key, value := range source.GetAnnotations() {
-				ants[key] = value
+			if err := addSource(source); err != nil {
+				return nil, nil, err
 			}
 		}
 	}
-	return ants, nil
+	return ants, domains, nil
+}
+
+// virtualHostDomains returns the domains of the virtual host of the Proxy followed by the domains
+// of its source VirtualServices missing from the Proxy, without trailing dots and duplicates.
+func virtualHostDomains(proxyDomains, sourceDomains []string) []string {
+	seen := map[string]bool{}
+	var domains []string
+	for _, domain := range append(append([]string{}, proxyDomains...), sourceDomains...) {
+		domain = strings.TrimSuffix(domain, ".")
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+	}
+	return domains
 }
 
 func (gs *glooSource) proxyTargets(ctx context.Context, name string, namespace string) (endpoint.Targets, error) {
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -588,3 +589,113 @@ func TestGlooSource(t *testing.T) {
 		},
 	})
 }
+
+func TestGlooSourceVirtualServiceDomains(t *testing.T) {
+	t.Parallel()
+
+	fakeKubernetesClient := fakeKube.NewSimpleClientset()
+	fakeDynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			proxyGVR: "ProxyList",
+		})
+
+	source, err := NewGlooSource(fakeDynamicClient, fakeKubernetesClient, []string{defaultGlooNamespace})
+	require.NoError(t, err)
+
+	gatewayProxy := proxy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: proxyGVR.GroupVersion().String(),
+			Kind:       "Proxy",
+		},
+		Metadata: metav1.ObjectMeta{
+			Name:      "gateway-proxy",
+			Namespace: defaultGlooNamespace,
+		},
+		Spec: proxySpec{
+			Listeners: []proxySpecListener{
+				{
+					HTTPListener: proxySpecHTTPListener{
+						VirtualHosts: []proxyVirtualHost{
+							{
+								Domains: []string{"a.test"},
+								Metadata: proxyVirtualHostMetadata{
+									Source: []proxyVirtualHostMetadataSource{
+										{
+											Kind:      "*v1.VirtualService",
+											Name:      "multiple-domains",
+											Namespace: "apps",
+										},
+									},
+								},
+							},
+							{
+								MetadataStatic: proxyVirtualHostMetadataStatic{
+									Source: []proxyVirtualHostMetadataStaticSource{
+										{
+											ResourceKind: "*v1.VirtualService",
+											ResourceRef: proxyVirtualHostMetadataSourceResourceRef{
+												Name:      "no-proxy-domains",
+												Namespace: "apps",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	proxyJSON, err := json.Marshal(gatewayProxy)
+	require.NoError(t, err)
+	proxyUnstructured := unstructured.Unstructured{}
+	require.NoError(t, proxyUnstructured.UnmarshalJSON(proxyJSON))
+	_, err = fakeDynamicClient.Resource(proxyGVR).Namespace(defaultGlooNamespace).Create(context.Background(), &proxyUnstructured, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	for name, domains := range map[string][]interface{}{
+		"multiple-domains": {"a.test", "b.test", "c.test."},
+		"no-proxy-domains": {"d.test"},
+	} {
+		virtualService := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": virtualServiceGVR.GroupVersion().String(),
+			"kind":       "VirtualService",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "apps",
+			},
+			"spec": map[string]interface{}{
+				"virtualHost": map[string]interface{}{"domains": domains},
+			},
+		}}
+		_, err = fakeDynamicClient.Resource(virtualServiceGVR).Namespace("apps").Create(context.Background(), virtualService, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	proxySvc := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gatewayProxy.Metadata.Name,
+			Namespace: gatewayProxy.Metadata.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}},
+			},
+		},
+	}
+	_, err = fakeKubernetesClient.CoreV1().Services(proxySvc.Namespace).Create(context.Background(), &proxySvc, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "a.test", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"203.0.113.10"}},
+		{DNSName: "b.test", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"203.0.113.10"}},
+		{DNSName: "c.test", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"203.0.113.10"}},
+		{DNSName: "d.test", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"203.0.113.10"}},
+	})
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// haproxyTCPServicesSource is an implementation of Source for the TCP services exposed by the
+// HAProxy Ingress Controller, declared in its TCP services ConfigMap.
+//
+// Each data key of the ConfigMap is a port of HAProxy and its value the exposed Service, in the format
+// <namespace>/<service>:<port>[:<options>]. The hostnames are the hostname annotations of these Services,
+// and the targets the load balancer addresses of the HAProxy Service.
+type haproxyTCPServicesSource struct {
+	configMap         types.NamespacedName
+	haproxyService    types.NamespacedName
+	configMapInformer coreinformers.ConfigMapInformer
+	serviceInformer   coreinformers.ServiceInformer
+}
+
+// NewHAProxyTCPServicesSource creates a new haproxyTCPServicesSource reading the given TCP services ConfigMap
+// and publishing the load balancer addresses of the given HAProxy Service, both in the format <namespace>/<name>.
+func NewHAProxyTCPServicesSource(ctx context.Context, kubeClient kubernetes.Interface, configMap, haproxyService string) (Source, error) {
+	configMapName, err := parseNamespacedName("HAProxy TCP services ConfigMap", configMap)
+	if err != nil {
+		return nil, err
+	}
+	haproxyServiceName, err := parseNamespacedName("HAProxy service", haproxyService)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the namespace of the ConfigMap is cached, but it may reference the Services of any namespace.
+	// Set resync period to 0, to prevent processing when nothing has changed
+	configMapInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(configMapName.Namespace))
+	configMapInformer := configMapInformerFactory.Core().V1().ConfigMaps()
+	serviceInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	serviceInformer := serviceInformerFactory.Core().V1().Services()
+
+	// Add default resource event handler to properly initialize informer.
+	_, _ = configMapInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
+	_, _ = serviceInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+	configMapInformerFactory.Start(ctx.Done())
+	serviceInformerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForCacheSync(context.Background(), configMapInformerFactory); err != nil {
+		return nil, err
+	}
+	if err := informers.WaitForCacheSync(context.Background(), serviceInformerFactory); err != nil {
+		return nil, err
+	}
+
+	return &haproxyTCPServicesSource{
+		configMap:         configMapName,
+		haproxyService:    haproxyServiceName,
+		configMapInformer: configMapInformer,
+		serviceInformer:   serviceInformer,
+	}, nil
+}
+
+// parseNamespacedName parses a <namespace>/<name> reference.
+func parseNamespacedName(kind, value string) (types.NamespacedName, error) {
+	namespace, name, _ := strings.Cut(value, "/")
+	if namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("invalid %s %q, expected format: <namespace>/<name>", kind, value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// parseHAProxyTCPService parses an entry of the TCP services ConfigMap, mapping a port of HAProxy to
+// a Service in the format <namespace>/<service>:<port>[:<options>], and returns the Service.
+func parseHAProxyTCPService(port, value string) (types.NamespacedName, error) {
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return types.NamespacedName{}, fmt.Errorf("invalid port %q", port)
+	}
+	service, servicePort, _ := strings.Cut(strings.TrimSpace(value), ":")
+	if servicePort == "" {
+		return types.NamespacedName{}, fmt.Errorf("invalid service %q of port %s, expected format: <namespace>/<service>:<port>", value, port)
+	}
+	return parseNamespacedName("service of port "+port, service)
+}
+
+// Endpoints returns endpoint objects for the hostnames of each Service of the TCP services ConfigMap.
+func (hs *haproxyTCPServicesSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints := []*endpoint.Endpoint{}
+
+	cm, err := hs.configMapInformer.Lister().ConfigMaps(hs.configMap.Namespace).Get(hs.configMap.Name)
+	if errors.IsNotFound(err) {
+		log.Warnf("HAProxy TCP services ConfigMap %s not found, skipping", hs.configMap)
+		return endpoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check controller annotation to see if we are responsible.
+	if controller, ok := cm.Annotations[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+		log.Debugf("Skipping ConfigMap %s because controller value does not match, found: %s, required: %s",
+			hs.configMap, controller, controllerAnnotationValue)
+		return endpoints, nil
+	}
+
+	haproxyTargets, err := hs.haproxyTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make([]string, 0, len(cm.Data))
+	for port := range cm.Data {
+		ports = append(ports, port)
+	}
+	sort.Strings(ports)
+
+	// a Service exposed on several ports is only published once
+	seen := map[types.NamespacedName]struct{}{}
+	for _, port := range ports {
+		ref, err := parseHAProxyTCPService(port, cm.Data[port])
+		if err != nil {
+			log.Warnf("Skipping entry of HAProxy TCP services ConfigMap %s: %v", hs.configMap, err)
+			continue
+		}
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+		seen[ref] = struct{}{}
+
+		svc, err := hs.serviceInformer.Lister().Services(ref.Namespace).Get(ref.Name)
+		if errors.IsNotFound(err) {
+			log.Debugf("Skipping service %s of port %s because it does not exist", ref, port)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if controller, ok := svc.Annotations[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping service %s because controller value does not match, found: %s, required: %s",
+				ref, controller, controllerAnnotationValue)
+			continue
+		}
+
+		hostnames := annotations.HostnamesFromAnnotations(svc.Annotations)
+		if len(hostnames) == 0 {
+			log.Debugf("Skipping service %s of port %s because it has no hostname annotation", ref, port)
+			continue
+		}
+
+		resource := fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name)
+
+		targets := annotations.TargetsFromTargetAnnotation(svc.Annotations)
+		if len(targets) == 0 {
+			targets = haproxyTargets
+		}
+		if len(targets) == 0 {
+			log.Debugf("Skipping %s because it has no targets", resource)
+			continue
+		}
+
+		ttl := annotations.TTLFromAnnotations(svc.Annotations, resource)
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(svc.Annotations)
+
+		var serviceEndpoints []*endpoint.Endpoint
+		for _, hostname := range hostnames {
+			serviceEndpoints = append(serviceEndpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+		endpoints = append(endpoints, applyObjectAnnotations(serviceEndpoints, svc.Annotations)...)
+	}
+
+	for _, ep := range endpoints {
+		sort.Sort(ep.Targets)
+	}
+
+	return endpoints, nil
+}
+
+// haproxyTargets returns the load balancer addresses of the HAProxy Service.
+func (hs *haproxyTCPServicesSource) haproxyTargets() (endpoint.Targets, error) {
+	svc, err := hs.serviceInformer.Lister().Services(hs.haproxyService.Namespace).Get(hs.haproxyService.Name)
+	if errors.IsNotFound(err) {
+		log.Warnf("HAProxy service %s not found", hs.haproxyService)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return extractLoadBalancerTargets(svc, false), nil
+}
+
+func (hs *haproxyTCPServicesSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for HAProxy TCP services")
+
+	_, _ = hs.configMapInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	_, _ = hs.serviceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseHAProxyTCPService(t *testing.T) {
+	for _, tc := range []struct {
+		port        string
+		value       string
+		expected    types.NamespacedName
+		expectError bool
+	}{
+		{port: "3306", value: "default/mysql:3306", expected: types.NamespacedName{Namespace: "default", Name: "mysql"}},
+		{port: "5432", value: " db/postgres:postgres ", expected: types.NamespacedName{Namespace: "db", Name: "postgres"}},
+		{port: "8883", value: "mqtt/broker:1883:PROXY", expected: types.NamespacedName{Namespace: "mqtt", Name: "broker"}},
+		{port: "6443", value: "default/api:443::PROXY-V2:default/tls-cert:5s", expected: types.NamespacedName{Namespace: "default", Name: "api"}},
+		{port: "mysql", value: "default/mysql:3306", expectError: true},
+		{port: "70000", value: "default/mysql:3306", expectError: true},
+		{port: "3306", value: "default/mysql", expectError: true},
+		{port: "3306", value: "mysql:3306", expectError: true},
+		{port: "3306", value: "", expectError: true},
+	} {
+		t.Run(tc.port+" "+tc.value, func(t *testing.T) {
+			service, err := parseHAProxyTCPService(tc.port, tc.value)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, service)
+		})
+	}
+}
+
+func TestNewHAProxyTCPServicesSource(t *testing.T) {
+	_, err := NewHAProxyTCPServicesSource(t.Context(), fake.NewClientset(), "haproxy/tcp-services", "haproxy/haproxy")
+	require.NoError(t, err)
+
+	_, err = NewHAProxyTCPServicesSource(t.Context(), fake.NewClientset(), "", "haproxy/haproxy")
+	require.EqualError(t, err, `invalid HAProxy TCP services ConfigMap "", expected format: <namespace>/<name>`)
+
+	_, err = NewHAProxyTCPServicesSource(t.Context(), fake.NewClientset(), "haproxy/tcp-services", "haproxy")
+	require.EqualError(t, err, `invalid HAProxy service "haproxy", expected format: <namespace>/<name>`)
+}
+
+func TestHAProxyTCPServicesSourceEndpoints(t *testing.T) {
+	haproxy := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "haproxy", Name: "haproxy"},
+		Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{
+			Ingress: []v1.LoadBalancerIngress{{IP: "192.0.2.2"}, {IP: "192.0.2.1"}},
+		}},
+	}
+
+	for _, tc := range []struct {
+		title     string
+		configMap *v1.ConfigMap
+		services  []*v1.Service
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			title: "services with hostname annotations",
+			configMap: newHAProxyTCPServicesConfigMap(nil, map[string]string{
+				"3306": "default/mysql:3306",
+				"5432": "db/postgres:5432:PROXY",
+				"8080": "default/unannotated:8080",
+				"9000": "default/missing:9000",
+				"bad":  "default/mysql:3306",
+			}),
+			services: []*v1.Service{
+				newHAProxyTCPService("default", "mysql", map[string]string{hostnameAnnotationKey: "mysql.example.org"}),
+				newHAProxyTCPService("db", "postgres", map[string]string{hostnameAnnotationKey: "postgres.example.org,pg.example.org", ttlAnnotationKey: "60"}),
+				newHAProxyTCPService("default", "unannotated", nil),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "mysql.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2"}},
+				{DNSName: "postgres.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2"}, RecordTTL: 60},
+				{DNSName: "pg.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2"}, RecordTTL: 60},
+			},
+		},
+		{
+			title: "service exposed on several ports",
+			configMap: newHAProxyTCPServicesConfigMap(nil, map[string]string{
+				"3306":  "default/mysql:3306",
+				"33060": "default/mysql:33060",
+			}),
+			services: []*v1.Service{
+				newHAProxyTCPService("default", "mysql", map[string]string{hostnameAnnotationKey: "mysql.example.org"}),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "mysql.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2"}},
+			},
+		},
+		{
+			title: "target annotation overrides the HAProxy targets",
+			configMap: newHAProxyTCPServicesConfigMap(nil, map[string]string{
+				"3306": "default/mysql:3306",
+			}),
+			services: []*v1.Service{
+				newHAProxyTCPService("default", "mysql", map[string]string{hostnameAnnotationKey: "mysql.example.org", targetAnnotationKey: "haproxy.example.org"}),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "mysql.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"haproxy.example.org"}},
+			},
+		},
+		{
+			title: "controller annotations",
+			configMap: newHAProxyTCPServicesConfigMap(nil, map[string]string{
+				"3306": "default/mysql:3306",
+				"5432": "db/postgres:5432",
+			}),
+			services: []*v1.Service{
+				newHAProxyTCPService("default", "mysql", map[string]string{hostnameAnnotationKey: "mysql.example.org"}),
+				newHAProxyTCPService("db", "postgres", map[string]string{hostnameAnnotationKey: "postgres.example.org", controllerAnnotationKey: "other-controller"}),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "mysql.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2"}},
+			},
+		},
+		{
+			title: "ConfigMap of another controller",
+			configMap: newHAProxyTCPServicesConfigMap(map[string]string{controllerAnnotationKey: "other-controller"}, map[string]string{
+				"3306": "default/mysql:3306",
+			}),
+			services: []*v1.Service{
+				newHAProxyTCPService("default", "mysql", map[string]string{hostnameAnnotationKey: "mysql.example.org"}),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title:    "missing ConfigMap",
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			kubeClient := fake.NewClientset()
+			if tc.configMap != nil {
+				_, err := kubeClient.CoreV1().ConfigMaps(tc.configMap.Namespace).Create(t.Context(), tc.configMap, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			for _, svc := range append([]*v1.Service{haproxy}, tc.services...) {
+				_, err := kubeClient.CoreV1().Services(svc.Namespace).Create(t.Context(), svc, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewHAProxyTCPServicesSource(t.Context(), kubeClient, "haproxy/tcp-services", "haproxy/haproxy")
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func newHAProxyTCPServicesConfigMap(annotations, data map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "haproxy", Name: "tcp-services", Annotations: annotations},
+		Data:       data,
+	}
+}
+
+func newHAProxyTCPService(namespace, name string, annotations map[string]string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+	}
+}
@@ -28,7 +28,9 @@ import (
 	networkv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	netinformers "k8s.io/client-go/informers/networking/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -64,6 +66,13 @@ type ingressSource struct {
 	ignoreIngressTLSSpec     bool
 	ignoreIngressRulesSpec   bool
 	labelSelector            labels.Selector
+	// the load balancer services of the ingress controllers, by ingress class
+	ingressClassServices map[string]types.NamespacedName
+	serviceInformer      coreinformers.ServiceInformer
+	// the default targets of the ingresses without load balancer address, by ingress class
+	ingressClassTargets map[string]endpoint.Targets
+	// the origins of the hostnames by priority, all of them are combined when empty
+	hostnamePriority []string
 }
 
 // NewIngressSource creates a new ingressSource with the given config.
@@ -73,12 +82,25 @@ func NewIngressSource(
 	namespace, annotationFilter, fqdnTemplate string,
 	combineFqdnAnnotation, ignoreHostnameAnnotation, ignoreIngressTLSSpec, ignoreIngressRulesSpec bool,
 	labelSelector labels.Selector,
-	ingressClassNames []string) (Source, error) {
+	ingressClassNames []string,
+	ingressClassServices []string,
+	hostnamePriority []string,
+	ingressClassTargets []string) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
 	}
 
+	classServices, err := parseIngressClassServices(ingressClassServices)
+	if err != nil {
+		return nil, err
+	}
+
+	classTargets, err := parseIngressClassTargets(ingressClassTargets)
+	if err != nil {
+		return nil, err
+	}
+
 	// ensure that ingress class is only set in either the ingressClassNames or
 	// annotationFilter but not both
 	if ingressClassNames != nil && annotationFilter != "" {
@@ -114,6 +136,23 @@ func NewIngressSource(
 		return nil, err
 	}
 
+	// The controller services usually live in other namespaces than the ingresses.
+	var serviceInformer coreinformers.ServiceInformer
+	if len(classServices) > 0 {
+		serviceInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+		serviceInformer = serviceInformerFactory.Core().V1().Services()
+		serviceInformer.Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+				},
+			},
+		)
+		serviceInformerFactory.Start(ctx.Done())
+		if err := informers.WaitForCacheSync(context.Background(), serviceInformerFactory); err != nil {
+			return nil, err
+		}
+	}
+
 	sc := &ingressSource{
 		client:                   kubeClient,
 		namespace:                namespace,
@@ -126,10 +165,42 @@ func NewIngressSource(
 		ignoreIngressTLSSpec:     ignoreIngressTLSSpec,
 		ignoreIngressRulesSpec:   ignoreIngressRulesSpec,
 		labelSelector:            labelSelector,
+		ingressClassServices:     classServices,
+		serviceInformer:          serviceInformer,
+		hostnamePriority:         hostnamePriority,
+		ingressClassTargets:      classTargets,
 	}
 	return sc, nil
 }
 
+// parseIngressClassServices parses the mappings of ingress classes to services, in the format <class>=<namespace>/<name>.
+func parseIngressClassServices(mappings []string) (map[string]types.NamespacedName, error) {
+	classServices := map[string]types.NamespacedName{}
+	for _, mapping := range mappings {
+		class, service, _ := strings.Cut(mapping, "=")
+		namespace, name, _ := strings.Cut(service, "/")
+		if class == "" || namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid ingress class service %q, expected format: <class>=<namespace>/<name>", mapping)
+		}
+		classServices[class] = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+	return classServices, nil
+}
+
+// parseIngressClassTargets parses the default targets of ingress classes, in the format <class>=<target>.
+// A class mapped several times has several targets.
+func parseIngressClassTargets(mappings []string) (map[string]endpoint.Targets, error) {
+	classTargets := map[string]endpoint.Targets{}
+	for _, mapping := range mappings {
+		class, target, _ := strings.Cut(mapping, "=")
+		if class == "" || target == "" {
+			return nil, fmt.Errorf("invalid ingress class target %q, expected format: <class>=<target>", mapping)
+		}
+		classTargets[class] = append(classTargets[class], target)
+	}
+	return classTargets, nil
+}
+
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all ingress resources on all namespaces
 func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
@@ -157,7 +228,7 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
-		ingEndpoints := endpointsFromIngress(ing, sc.ignoreHostnameAnnotation, sc.ignoreIngressTLSSpec, sc.ignoreIngressRulesSpec)
+		ingEndpoints := endpointsFromIngress(ing, sc.loadBalancerTargets(ing), sc.ignoreHostnameAnnotation, sc.ignoreIngressTLSSpec, sc.ignoreIngressRulesSpec, sc.hostnamePriority)
 
 		// apply template if host is missing on ingress
 		if (sc.combineFQDNAnnotation || len(ingEndpoints) == 0) && sc.fqdnTemplate != nil {
@@ -169,6 +240,11 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			ingEndpoints = append(ingEndpoints, iEndpoints...)
 		}
 
+		if !sc.ignoreHostnameAnnotation {
+			ingEndpoints = endpointsWithHostnameTargets(ingEndpoints, ing.Annotations)
+		}
+		ingEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(ingEndpoints, ing.Annotations), ing.Annotations)
+
 		if len(ingEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from ingress %s/%s", ing.Namespace, ing.Name)
 			continue
@@ -197,7 +273,7 @@ func (sc *ingressSource) endpointsFromTemplate(ing *networkv1.Ingress) ([]*endpo
 
 	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
 	if len(targets) == 0 {
-		targets = targetsFromIngressStatus(ing.Status)
+		targets = sc.loadBalancerTargets(ing)
 	}
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
@@ -277,8 +353,46 @@ func (sc *ingressSource) filterByIngressClass(ingresses []*networkv1.Ingress) ([
 	return filteredList, nil
 }
 
-// endpointsFromIngress extracts the endpoints from ingress object
-func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool) []*endpoint.Endpoint {
+// loadBalancerTargets returns the targets of an ingress without target annotation: the load balancer addresses
+// of the controller service of its ingress class when configured, otherwise the ones of the ingress status.
+// The default targets of its ingress class are used when there is no load balancer address.
+func (sc *ingressSource) loadBalancerTargets(ing *networkv1.Ingress) endpoint.Targets {
+	class := ingressClassName(ing)
+	targets := sc.classLoadBalancerTargets(ing, class)
+	if len(targets) == 0 && len(sc.ingressClassTargets[class]) > 0 {
+		log.Debugf("Using the default targets of ingress class %q for ingress %s/%s without load balancer address", class, ing.Namespace, ing.Name)
+		return sc.ingressClassTargets[class]
+	}
+	return targets
+}
+
+// classLoadBalancerTargets returns the load balancer addresses of the controller service of the ingress class
+// when configured, otherwise the ones of the ingress status.
+func (sc *ingressSource) classLoadBalancerTargets(ing *networkv1.Ingress, class string) endpoint.Targets {
+	service, ok := sc.ingressClassServices[class]
+	if !ok {
+		return targetsFromIngressStatus(ing.Status)
+	}
+
+	svc, err := sc.serviceInformer.Lister().Services(service.Namespace).Get(service.Name)
+	if err != nil {
+		log.Warnf("Unable to get service %s of ingress class %q for ingress %s/%s: %v", service, class, ing.Namespace, ing.Name, err)
+		return nil
+	}
+	return extractLoadBalancerTargets(svc, false)
+}
+
+// ingressClassName returns the class of an ingress, from its spec or from the legacy annotation.
+func ingressClassName(ing *networkv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil && *ing.Spec.IngressClassName != "" {
+		return *ing.Spec.IngressClassName
+	}
+	return ing.Annotations[IngressClassAnnotationKey]
+}
+
+// endpointsFromIngress extracts the endpoints from ingress object, loadBalancerTargets being used
+// when the ingress has no target annotation
+func endpointsFromIngress(ing *networkv1.Ingress, loadBalancerTargets endpoint.Targets, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool, hostnamePriority []string) []*endpoint.Endpoint {
 	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
 
 	ttl := annotations.TTLFromAnnotations(ing.Annotations, resource)
@@ -286,17 +400,20 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
 
 	if len(targets) == 0 {
-		targets = targetsFromIngressStatus(ing.Status)
+		targets = loadBalancerTargets
 	}
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
 
+	// Only the primary hosts of the hosts sections are published when they are listed
+	primaryHosts := annotations.IngressPrimaryHostsFromAnnotations(ing.Annotations)
+
 	// Gather endpoints defined on hosts sections of the ingress
 	var definedHostsEndpoints []*endpoint.Endpoint
 	// Skip endpoints if we do not want entries from Rules section
 	if !ignoreIngressRulesSpec {
 		for _, rule := range ing.Spec.Rules {
-			if rule.Host == "" {
+			if rule.Host == "" || !isPrimaryHost(rule.Host, primaryHosts) {
 				continue
 			}
 			definedHostsEndpoints = append(definedHostsEndpoints, EndpointsForHostname(rule.Host, targets, ttl, providerSpecific, setIdentifier, resource)...)
@@ -307,7 +424,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	if !ignoreIngressTLSSpec {
 		for _, tls := range ing.Spec.TLS {
 			for _, host := range tls.Hosts {
-				if host == "" {
+				if host == "" || !isPrimaryHost(host, primaryHosts) {
 					continue
 				}
 				definedHostsEndpoints = append(definedHostsEndpoints, EndpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
@@ -326,6 +443,14 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	// Determine which hostnames to consider in our final list
 	hostnameSourceAnnotation, hostnameSourceAnnotationExists := ing.Annotations[ingressHostnameSourceKey]
 	if !hostnameSourceAnnotationExists {
+		if len(hostnamePriority) > 0 {
+			if endpoints, ok := byHostnamePriority(hostnamePriority, map[string][]*endpoint.Endpoint{
+				HostnameOriginAnnotation: annotationEndpoints,
+				HostnameOriginSpec:       definedHostsEndpoints,
+			}); ok {
+				return endpoints
+			}
+		}
 		return append(definedHostsEndpoints, annotationEndpoints...)
 	}
 
@@ -340,6 +465,20 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	return endpoints
 }
 
+// isPrimaryHost returns true if there are no primary hosts or if the host is one of them.
+func isPrimaryHost(host string, primaryHosts []string) bool {
+	if primaryHosts == nil {
+		return true
+	}
+	host = strings.TrimSuffix(host, ".")
+	for _, primaryHost := range primaryHosts {
+		if strings.EqualFold(host, strings.TrimSuffix(primaryHost, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
 func targetsFromIngressStatus(status networkv1.IngressStatus) endpoint.Targets {
 	var targets endpoint.Targets
 
@@ -361,4 +500,7 @@ func (sc *ingressSource) AddEventHandler(ctx context.Context, handler func()) {
 	// Right now there is no way to remove event handler from informer, see:
 	// https://github.com/kubernetes/kubernetes/issues/79610
 	sc.ingressInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	if sc.serviceInformer != nil {
+		sc.serviceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
 }
@@ -72,6 +72,9 @@ func TestIngressSourceNewNodeSourceWithFqdn(t *testing.T) {
 				false,
 				labels.Everything(),
 				[]string{},
+				nil,
+				nil,
+				nil,
 			)
 
 			if tt.expectError {
@@ -334,6 +337,9 @@ func TestIngressSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				labels.Everything(),
 				[]string{},
+				nil,
+				nil,
+				nil,
 			)
 
 			require.NoError(t, err)
@@ -23,12 +23,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // Validates that ingressSource is a Source
@@ -65,6 +67,9 @@ func (suite *IngressSuite) SetupTest() {
 		false,
 		labels.Everything(),
 		[]string{},
+		nil,
+		nil,
+		nil,
 	)
 	suite.NoError(err, "should initialize ingress source")
 }
@@ -82,6 +87,7 @@ func TestIngress(t *testing.T) {
 	suite.Run(t, new(IngressSuite))
 	t.Run("endpointsFromIngress", testEndpointsFromIngress)
 	t.Run("endpointsFromIngressHostnameSourceAnnotation", testEndpointsFromIngressHostnameSourceAnnotation)
+	t.Run("endpointsFromIngressHostnamePriority", testEndpointsFromIngressHostnamePriority)
 	t.Run("Endpoints", testIngressEndpoints)
 }
 
@@ -95,6 +101,8 @@ func TestNewIngressSource(t *testing.T) {
 		combineFQDNAndAnnotation bool
 		expectError              bool
 		ingressClassNames        []string
+		ingressClassServices     []string
+		ingressClassTargets      []string
 	}{
 		{
 			title:            "non-empty annotation filter label",
@@ -112,6 +120,31 @@ func TestNewIngressSource(t *testing.T) {
 			ingressClassNames: []string{"internal", "external"},
 			annotationFilter:  "kubernetes.io/ingress.class=nginx",
 		},
+		{
+			title:                "valid ingress class services",
+			expectError:          false,
+			ingressClassServices: []string{"internal=ingress/internal-controller", "external=ingress/external-controller"},
+		},
+		{
+			title:                "ingress class service without namespace",
+			expectError:          true,
+			ingressClassServices: []string{"internal=internal-controller"},
+		},
+		{
+			title:                "ingress class service without class",
+			expectError:          true,
+			ingressClassServices: []string{"ingress/internal-controller"},
+		},
+		{
+			title:               "valid ingress class targets",
+			expectError:         false,
+			ingressClassTargets: []string{"metallb=192.0.2.10", "metallb=192.0.2.11"},
+		},
+		{
+			title:               "ingress class target without target",
+			expectError:         true,
+			ingressClassTargets: []string{"metallb="},
+		},
 	} {
 
 		t.Run(ti.title, func(t *testing.T) {
@@ -129,6 +162,9 @@ func TestNewIngressSource(t *testing.T) {
 				false,
 				labels.Everything(),
 				ti.ingressClassNames,
+				ti.ingressClassServices,
+				nil,
+				ti.ingressClassTargets,
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -260,7 +296,7 @@ func testEndpointsFromIngress(t *testing.T) {
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			realIngress := ti.ingress.Ingress()
-			validateEndpoints(t, endpointsFromIngress(realIngress, ti.ignoreHostnameAnnotation, ti.ignoreIngressTLSSpec, ti.ignoreIngressRulesSpec), ti.expected)
+			validateEndpoints(t, endpointsFromIngress(realIngress, targetsFromIngressStatus(realIngress.Status), ti.ignoreHostnameAnnotation, ti.ignoreIngressTLSSpec, ti.ignoreIngressRulesSpec, nil), ti.expected)
 		})
 	}
 }
@@ -359,7 +395,82 @@ func testEndpointsFromIngressHostnameSourceAnnotation(t *testing.T) {
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			realIngress := ti.ingress.Ingress()
-			validateEndpoints(t, endpointsFromIngress(realIngress, false, false, false), ti.expected)
+			validateEndpoints(t, endpointsFromIngress(realIngress, targetsFromIngressStatus(realIngress.Status), false, false, false, nil), ti.expected)
+		})
+	}
+}
+
+func testEndpointsFromIngressHostnamePriority(t *testing.T) {
+	t.Parallel()
+
+	for _, ti := range []struct {
+		title    string
+		ingress  fakeIngress
+		priority []string
+		expected []*endpoint.Endpoint
+	}{
+		{
+			title: "annotation first",
+			ingress: fakeIngress{
+				dnsnames:    []string{"foo.bar"},
+				annotations: map[string]string{hostnameAnnotationKey: "foo.baz"},
+				hostnames:   []string{"lb.com"},
+			},
+			priority: []string{"annotation", "spec"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.baz", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title: "spec first",
+			ingress: fakeIngress{
+				dnsnames:    []string{"foo.bar"},
+				tlsdnsnames: [][]string{{"tls.bar"}},
+				annotations: map[string]string{hostnameAnnotationKey: "foo.baz"},
+				hostnames:   []string{"lb.com"},
+			},
+			priority: []string{"spec", "annotation"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "tls.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title: "spec first falls back to the annotation",
+			ingress: fakeIngress{
+				annotations: map[string]string{hostnameAnnotationKey: "foo.baz"},
+				hostnames:   []string{"lb.com"},
+			},
+			priority: []string{"spec", "annotation"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.baz", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title: "annotation not in the priority",
+			ingress: fakeIngress{
+				annotations: map[string]string{hostnameAnnotationKey: "foo.baz"},
+				hostnames:   []string{"lb.com"},
+			},
+			priority: []string{"spec"},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "hostname source annotation takes precedence",
+			ingress: fakeIngress{
+				dnsnames:    []string{"foo.bar"},
+				annotations: map[string]string{hostnameAnnotationKey: "foo.baz", ingressHostnameSourceKey: "defined-hosts-only"},
+				hostnames:   []string{"lb.com"},
+			},
+			priority: []string{"annotation", "spec"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			realIngress := ti.ingress.Ingress()
+			validateEndpoints(t, endpointsFromIngress(realIngress, targetsFromIngressStatus(realIngress.Status), false, false, false, ti.priority), ti.expected)
 		})
 	}
 }
@@ -460,6 +571,139 @@ func testIngressEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			title:           "dualstack ingress with AAAA excluded by annotation",
+			targetNamespace: "",
+			ingressItems: []fakeIngress{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					annotations: map[string]string{
+						recordTypeExcludeAnnotationKey: "AAAA",
+					},
+					dnsnames: []string{"example.org"},
+					ips:      []string{"8.8.8.8", "2001:DB8::1"},
+				},
+				{
+					name:      "fake2",
+					namespace: namespace,
+					dnsnames:  []string{"new.org"},
+					ips:       []string{"8.8.4.4", "2001:DB8::2"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"8.8.8.8"},
+				},
+				{
+					DNSName:    "new.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"8.8.4.4"},
+				},
+				{
+					DNSName:    "new.org",
+					RecordType: endpoint.RecordTypeAAAA,
+					Targets:    endpoint.Targets{"2001:DB8::2"},
+				},
+			},
+		},
+		{
+			title:           "ingress excluded by annotation",
+			targetNamespace: "",
+			ingressItems: []fakeIngress{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					annotations: map[string]string{
+						annotations.ExcludeKey: "true",
+					},
+					dnsnames: []string{"example.org"},
+					ips:      []string{"8.8.8.8"},
+				},
+				{
+					name:      "fake2",
+					namespace: namespace,
+					annotations: map[string]string{
+						annotations.ExcludeKey: "false",
+					},
+					dnsnames: []string{"new.org"},
+					ips:      []string{"8.8.4.4"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"8.8.8.8"},
+					Labels: endpoint.Labels{
+						endpoint.ResourceLabelKey: "ingress/" + namespace + "/fake1",
+						endpoint.ExcludedLabelKey: "true",
+					},
+				},
+				{
+					DNSName:    "new.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"8.8.4.4"},
+					Labels: endpoint.Labels{
+						endpoint.ResourceLabelKey: "ingress/" + namespace + "/fake2",
+					},
+				},
+			},
+		},
+		{
+			title:           "ingress protected from the deletion by annotation",
+			targetNamespace: "",
+			ingressItems: []fakeIngress{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					annotations: map[string]string{
+						annotations.DeleteProtectionKey: "true",
+					},
+					dnsnames: []string{"example.org"},
+					ips:      []string{"8.8.8.8"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"8.8.8.8"},
+					Labels: endpoint.Labels{
+						endpoint.ResourceLabelKey:         "ingress/" + namespace + "/fake1",
+						endpoint.DeleteProtectionLabelKey: "true",
+					},
+				},
+			},
+		},
+		{
+			title:           "ingress described by annotation",
+			targetNamespace: "",
+			ingressItems: []fakeIngress{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					annotations: map[string]string{
+						annotations.DescriptionKey: "Checkout frontend",
+					},
+					dnsnames: []string{"example.org"},
+					ips:      []string{"8.8.8.8"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					RecordType: endpoint.RecordTypeA,
+					Targets:    endpoint.Targets{"8.8.8.8"},
+					Labels: endpoint.Labels{
+						endpoint.ResourceLabelKey:    "ingress/" + namespace + "/fake1",
+						endpoint.DescriptionLabelKey: "Checkout frontend",
+					},
+				},
+			},
+		},
 		{
 			title:                  "ignore rules",
 			targetNamespace:        "",
@@ -844,6 +1088,36 @@ func testIngressEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			title:           "wildcard rule.host",
+			targetNamespace: "",
+			ingressItems: []fakeIngress{
+				{
+					name:        "fake1",
+					namespace:   namespace,
+					dnsnames:    []string{"*.example.org", "example.org"},
+					tlsdnsnames: [][]string{{"*.example.org"}},
+					hostnames:   []string{"lb.com"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "*.example.org",
+					Targets:    endpoint.Targets{"lb.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+				},
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"lb.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+				},
+				{
+					DNSName:    "*.example.org",
+					Targets:    endpoint.Targets{"lb.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+				},
+			},
+		},
 		{
 			title:           "ingress rules with single tls having single hostname",
 			targetNamespace: "",
@@ -1426,6 +1700,9 @@ func testIngressEndpoints(t *testing.T) {
 				ti.ignoreIngressRulesSpec,
 				ti.ingressLabelSelector,
 				ti.ingressClassNames,
+				nil,
+				nil,
+				nil,
 			)
 			// Informer cache has all of the ingresses. Retrieve and validate their endpoints.
 			res, err := source.Endpoints(t.Context())
@@ -1444,6 +1721,288 @@ func testIngressEndpoints(t *testing.T) {
 	}
 }
 
+func TestIngressClassServices(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := fake.NewClientset()
+	for _, svc := range []*v1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "internal-controller"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "10.0.0.1"}}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "external-controller"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{Hostname: "external.elb.example.com"}}}},
+		},
+	} {
+		_, err := fakeClient.CoreV1().Services(svc.Namespace).Create(t.Context(), svc, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	for _, item := range []fakeIngress{
+		{name: "internal", namespace: "default", dnsnames: []string{"internal.example.org"}, ips: []string{"192.0.2.1"}, ingressClassName: "internal"},
+		{name: "external", namespace: "default", dnsnames: []string{"external.example.org"}, ips: []string{"192.0.2.1"}, ingressClassName: "external"},
+		{name: "legacy", namespace: "default", dnsnames: []string{"legacy.example.org"}, annotations: map[string]string{IngressClassAnnotationKey: "external"}},
+		{name: "annotated", namespace: "default", dnsnames: []string{"annotated.example.org"}, ingressClassName: "external", annotations: map[string]string{targetAnnotationKey: "192.0.2.2"}},
+		{name: "unmapped", namespace: "default", dnsnames: []string{"unmapped.example.org"}, ips: []string{"192.0.2.1"}, ingressClassName: "other"},
+		{name: "missing", namespace: "default", dnsnames: []string{"missing.example.org"}, ips: []string{"192.0.2.1"}, ingressClassName: "missing"},
+	} {
+		ingress := item.Ingress()
+		_, err := fakeClient.NetworkingV1().Ingresses(ingress.Namespace).Create(t.Context(), ingress, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	source, err := NewIngressSource(
+		t.Context(),
+		fakeClient,
+		"",
+		"",
+		"",
+		false,
+		false,
+		false,
+		false,
+		labels.Everything(),
+		[]string{},
+		[]string{"internal=ingress/internal-controller", "external=ingress/external-controller", "missing=ingress/missing-controller"},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "internal.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+		{DNSName: "external.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"external.elb.example.com"}},
+		{DNSName: "legacy.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"external.elb.example.com"}},
+		{DNSName: "annotated.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}},
+		{DNSName: "unmapped.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+	})
+}
+
+func TestIngressClassTargets(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := fake.NewClientset()
+	for _, item := range []fakeIngress{
+		{name: "pending", namespace: "default", dnsnames: []string{"pending.example.org"}, ingressClassName: "metallb"},
+		{name: "legacy", namespace: "default", dnsnames: []string{"legacy.example.org"}, annotations: map[string]string{IngressClassAnnotationKey: "metallb"}},
+		{name: "assigned", namespace: "default", dnsnames: []string{"assigned.example.org"}, ips: []string{"192.0.2.1"}, ingressClassName: "metallb"},
+		{name: "annotated", namespace: "default", dnsnames: []string{"annotated.example.org"}, ingressClassName: "metallb", annotations: map[string]string{targetAnnotationKey: "192.0.2.2"}},
+		{name: "unmapped", namespace: "default", dnsnames: []string{"unmapped.example.org"}, ingressClassName: "other"},
+	} {
+		ingress := item.Ingress()
+		_, err := fakeClient.NetworkingV1().Ingresses(ingress.Namespace).Create(t.Context(), ingress, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	source, err := NewIngressSource(
+		t.Context(),
+		fakeClient,
+		"",
+		"",
+		"",
+		false,
+		false,
+		false,
+		false,
+		labels.Everything(),
+		[]string{},
+		nil,
+		nil,
+		[]string{"metallb=192.0.2.10", "metallb=192.0.2.11"},
+	)
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "pending.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.10", "192.0.2.11"}},
+		{DNSName: "legacy.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.10", "192.0.2.11"}},
+		{DNSName: "assigned.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "annotated.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}},
+	})
+}
+
+func TestIngressHostnameAliases(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := fake.NewClientset()
+	for _, item := range []fakeIngress{
+		{
+			name:        "app",
+			namespace:   "default",
+			dnsnames:    []string{"app.example.org"},
+			ips:         []string{"192.0.2.1"},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "www.app.example.org,app.example.net"},
+		},
+		{
+			name:        "lb",
+			namespace:   "default",
+			dnsnames:    []string{"lb.example.org"},
+			hostnames:   []string{"lb.elb.example.com"},
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname-aliases": "www.lb.example.org"},
+		},
+	} {
+		ingress := item.Ingress()
+		_, err := fakeClient.NetworkingV1().Ingresses(ingress.Namespace).Create(t.Context(), ingress, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	source, err := NewIngressSource(t.Context(), fakeClient, "", "", "", false, false, false, false, labels.Everything(), []string{}, []string{}, nil, nil)
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "www.app.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"app.example.org"}},
+		{DNSName: "app.example.net", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"app.example.org"}},
+		{DNSName: "lb.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.elb.example.com"}},
+		{DNSName: "www.lb.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.org"}},
+	})
+	for _, ep := range endpoints {
+		assert.Contains(t, []string{"ingress/default/app", "ingress/default/lb"}, ep.Labels[endpoint.ResourceLabelKey])
+	}
+}
+
+func TestIngressHostnameTargets(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := fake.NewClientset()
+	ingress := fakeIngress{
+		name:        "gateway",
+		namespace:   "default",
+		dnsnames:    []string{"gateway.example.org"},
+		ips:         []string{"192.0.2.1"},
+		annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname": "a.example.org=192.0.2.10;b.example.org=192.0.2.20,192.0.2.21;c.example.org"},
+	}.Ingress()
+	_, err := fakeClient.NetworkingV1().Ingresses(ingress.Namespace).Create(t.Context(), ingress, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	source, err := NewIngressSource(t.Context(), fakeClient, "", "", "", false, false, false, false, labels.Everything(), []string{}, []string{}, nil, nil)
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "gateway.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.10"}},
+		{DNSName: "b.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.20", "192.0.2.21"}},
+		{DNSName: "c.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+	})
+	for _, ep := range endpoints {
+		assert.Equal(t, "ingress/default/gateway", ep.Labels[endpoint.ResourceLabelKey])
+	}
+}
+
+func TestIngressTLSOnly(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := fake.NewClientset()
+	ingress := fakeIngress{
+		name:        "tls-only",
+		namespace:   "default",
+		tlsdnsnames: [][]string{{"app.example.org", "www.app.example.org"}, {"api.example.org"}},
+		ips:         []string{"192.0.2.1"},
+	}.Ingress()
+	// the ingress has a default backend and no rules
+	ingress.Spec.DefaultBackend = &networkv1.IngressBackend{
+		Service: &networkv1.IngressServiceBackend{Name: "app", Port: networkv1.ServiceBackendPort{Number: 443}},
+	}
+	_, err := fakeClient.NetworkingV1().Ingresses(ingress.Namespace).Create(t.Context(), ingress, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	source, err := NewIngressSource(t.Context(), fakeClient, "", "", "", false, false, false, false, labels.Everything(), []string{}, []string{}, nil, nil)
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "www.app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+	})
+}
+
+func TestIngressPrimaryHosts(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		title       string
+		annotations map[string]string
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title:       "without primary hosts",
+			annotations: map[string]string{},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+				{DNSName: "app.internal.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+				{DNSName: "legacy.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+				{DNSName: "tls.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+			},
+		},
+		{
+			title:       "single primary host",
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/ingress-primary-hosts": "App.example.org."},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+			},
+		},
+		{
+			title:       "primary hosts of the rules and the TLS section",
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/ingress-primary-hosts": "app.example.org, tls.example.org"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+				{DNSName: "tls.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+			},
+		},
+		{
+			title: "hostname annotation is not restricted",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/ingress-primary-hosts": "app.example.org",
+				"external-dns.alpha.kubernetes.io/hostname":              "extra.example.org",
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+				{DNSName: "extra.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+			},
+		},
+		{
+			title:       "no matching primary host",
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/ingress-primary-hosts": "other.example.org"},
+			expected:    []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			fakeClient := fake.NewClientset()
+			ingress := fakeIngress{
+				name:        "app",
+				namespace:   "default",
+				dnsnames:    []string{"app.example.org", "app.internal.example.org", "legacy.example.org"},
+				tlsdnsnames: [][]string{{"tls.example.org"}},
+				ips:         []string{"192.0.2.1"},
+				annotations: tc.annotations,
+			}.Ingress()
+			_, err := fakeClient.NetworkingV1().Ingresses(ingress.Namespace).Create(t.Context(), ingress, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			source, err := NewIngressSource(t.Context(), fakeClient, "", "", "", false, false, false, false, labels.Everything(), []string{}, []string{}, nil, nil)
+			require.NoError(t, err)
+
+			endpoints, err := source.Endpoints(t.Context())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
 // ingress specific helper functions
 type fakeIngress struct {
 	dnsnames         []string
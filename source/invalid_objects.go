@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// InvalidObjectError is the error of an object skipped by a source because it is invalid.
+type InvalidObjectError struct {
+	// Resource identifies the object as in the resource label of the endpoints, e.g. crd/default/records.
+	Resource string
+	Err      error
+}
+
+func (e *InvalidObjectError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Resource, e.Err)
+}
+
+func (e *InvalidObjectError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidObjectsError aggregates the errors of the invalid objects skipped by the sources.
+// It is returned along with the endpoints of the valid objects, which are still published.
+type InvalidObjectsError struct {
+	Errors []*InvalidObjectError
+}
+
+func (e *InvalidObjectsError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("skipped %d invalid objects: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+func (e *InvalidObjectsError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// IsInvalidObjectsError returns whether err only reports invalid objects,
+// in which case the endpoints returned with it are valid.
+func IsInvalidObjectsError(err error) bool {
+	var invalid *InvalidObjectsError
+	return errors.As(err, &invalid)
+}
+
+// JoinInvalidObjectsErrors returns an InvalidObjectsError aggregating the invalid objects of the given errors,
+// or nil when there are none. The errors are expected to be nil or InvalidObjectsErrors.
+func JoinInvalidObjectsErrors(errs ...error) error {
+	joined := &InvalidObjectsError{}
+	for _, err := range errs {
+		var invalid *InvalidObjectsError
+		if errors.As(err, &invalid) {
+			joined.Errors = append(joined.Errors, invalid.Errors...)
+		}
+	}
+	if len(joined.Errors) == 0 {
+		return nil
+	}
+	return joined
+}
+
+// invalidObjects collects the errors of the invalid objects skipped by a source.
+type invalidObjects []*InvalidObjectError
+
+// add records the error of the invalid object identified by resource.
+func (o *invalidObjects) add(resource string, err error) {
+	*o = append(*o, &InvalidObjectError{Resource: resource, Err: err})
+}
+
+// err returns an InvalidObjectsError of the invalid objects, or nil when there are none.
+func (o invalidObjects) err() error {
+	if len(o) == 0 {
+		return nil
+	}
+	return &InvalidObjectsError{Errors: o}
+}
@@ -182,6 +182,11 @@ func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			return nil, err
 		}
 
+		if !sc.ignoreHostnameAnnotation {
+			gwEndpoints = endpointsWithHostnameTargets(gwEndpoints, gateway.Annotations)
+		}
+		gwEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(gwEndpoints, gateway.Annotations), gateway.Annotations)
+
 		if len(gwEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from gateway %s/%s", gateway.Namespace, gateway.Name)
 			continue
@@ -181,6 +181,11 @@ func (sc *virtualServiceSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			}
 		}
 
+		if !sc.ignoreHostnameAnnotation {
+			gwEndpoints = endpointsWithHostnameTargets(gwEndpoints, vService.Annotations)
+		}
+		gwEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(gwEndpoints, vService.Annotations), vService.Annotations)
+
 		if len(gwEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from VirtualService %s/%s", vService.Namespace, vService.Name)
 			continue
@@ -0,0 +1,324 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+var (
+	knativeDomainMappingGVR = schema.GroupVersionResource{
+		Group:    "serving.knative.dev",
+		Version:  "v1beta1",
+		Resource: "domainmappings",
+	}
+	knativeRouteGVR = schema.GroupVersionResource{
+		Group:    "serving.knative.dev",
+		Version:  "v1",
+		Resource: "routes",
+	}
+)
+
+// knativeVisibilityLabelKey is the label of the Knative objects only reachable from the cluster.
+const knativeVisibilityLabelKey = "networking.knative.dev/visibility"
+
+// Basic redefinition of the Knative DomainMapping and Route status:
+// https://github.com/knative/serving/blob/main/pkg/apis/serving/v1beta1/domainmapping_types.go
+type knativeObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            knativeStatus `json:"status,omitempty"`
+}
+
+type knativeStatus struct {
+	URL        string             `json:"url,omitempty"`
+	Conditions []knativeCondition `json:"conditions,omitempty"`
+}
+
+type knativeCondition struct {
+	Type   string                 `json:"type"`
+	Status corev1.ConditionStatus `json:"status"`
+}
+
+// ready returns whether the Ready condition of the object is true.
+func (o *knativeObject) ready() bool {
+	for _, condition := range o.Status.Conditions {
+		if condition.Type == "Ready" {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// knativeSource is an implementation of Source for the Knative DomainMapping or Route objects.
+// The hostname is the host of the URL of the ready objects, and the targets are the load balancer
+// addresses of the Knative ingress gateway service, unless the target annotation is set.
+type knativeSource struct {
+	kind             string
+	namespace        string
+	annotationFilter string
+	labelSelector    labels.Selector
+	informer         kubeinformers.GenericInformer
+	gatewayService   *types.NamespacedName
+	serviceInformer  coreinformers.ServiceInformer
+	// hostnamePriority orders the hostname annotation and the status URL, only the latter is used when empty.
+	hostnamePriority []string
+}
+
+// NewKnativeDomainMappingSource creates a new knativeSource for the DomainMapping objects.
+func NewKnativeDomainMappingSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	gatewayService string,
+	hostnamePriority []string,
+) (Source, error) {
+	return newKnativeSource(ctx, dynamicKubeClient, kubeClient, knativeDomainMappingGVR, "DomainMapping", namespace, annotationFilter, labelSelector, gatewayService, hostnamePriority)
+}
+
+// NewKnativeRouteSource creates a new knativeSource for the Route objects.
+func NewKnativeRouteSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	gatewayService string,
+	hostnamePriority []string,
+) (Source, error) {
+	return newKnativeSource(ctx, dynamicKubeClient, kubeClient, knativeRouteGVR, "Route", namespace, annotationFilter, labelSelector, gatewayService, hostnamePriority)
+}
+
+func newKnativeSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
+	gvr schema.GroupVersionResource,
+	kind string,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	gatewayService string,
+	hostnamePriority []string,
+) (Source, error) {
+	var gateway *types.NamespacedName
+	if gatewayService != "" {
+		gatewayNamespace, gatewayName, _ := strings.Cut(gatewayService, "/")
+		if gatewayNamespace == "" || gatewayName == "" {
+			return nil, fmt.Errorf("invalid Knative gateway service %q, expected format: <namespace>/<name>", gatewayService)
+		}
+		gateway = &types.NamespacedName{Namespace: gatewayNamespace, Name: gatewayName}
+	}
+
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informer := informerFactory.ForResource(gvr)
+
+	// Add default resource event handler to properly initialize informer.
+	_, _ = informer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	var serviceInformer coreinformers.ServiceInformer
+	if gateway != nil {
+		serviceInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(gateway.Namespace))
+		serviceInformer = serviceInformerFactory.Core().V1().Services()
+		_, _ = serviceInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+		serviceInformerFactory.Start(ctx.Done())
+
+		if err := informers.WaitForCacheSync(context.Background(), serviceInformerFactory); err != nil {
+			return nil, err
+		}
+	}
+
+	return &knativeSource{
+		kind:             kind,
+		namespace:        namespace,
+		annotationFilter: annotationFilter,
+		labelSelector:    labelSelector,
+		informer:         informer,
+		gatewayService:   gateway,
+		serviceInformer:  serviceInformer,
+		hostnamePriority: hostnamePriority,
+	}, nil
+}
+
+// Endpoints returns endpoint objects for the URL host of each ready Knative object.
+func (ks *knativeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	objects, err := ks.informer.Lister().ByNamespace(ks.namespace).List(ks.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := annotations.ParseFilter(ks.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+
+	for _, obj := range objects {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+		ko := &knativeObject{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ko); err != nil {
+			return nil, err
+		}
+
+		if !selector.Empty() && !selector.Matches(labels.Set(ko.Annotations)) {
+			continue
+		}
+
+		// Check controller annotation to see if we are responsible.
+		if controller, ok := ko.Annotations[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping %s %s/%s because controller value does not match, found: %s, required: %s",
+				ks.kind, ko.Namespace, ko.Name, controller, controllerAnnotationValue)
+			continue
+		}
+
+		resource := fmt.Sprintf("%s/%s/%s", strings.ToLower(ks.kind), ko.Namespace, ko.Name)
+
+		if ko.Labels[knativeVisibilityLabelKey] == "cluster-local" {
+			log.Debugf("Skipping %s because it is only visible from the cluster", resource)
+			continue
+		}
+		if !ko.ready() {
+			log.Debugf("Skipping %s because it is not ready", resource)
+			continue
+		}
+		hostnames := ks.hostnames(ko)
+		if len(hostnames) == 0 {
+			log.Debugf("Skipping %s because it has no URL", resource)
+			continue
+		}
+
+		targets := annotations.TargetsFromTargetAnnotation(ko.Annotations)
+		if len(targets) == 0 {
+			targets, err = ks.gatewayTargets()
+			if err != nil {
+				log.Warnf("Could not find the targets of %s: %v", resource, err)
+				continue
+			}
+		}
+		if len(targets) == 0 {
+			log.Debugf("Skipping %s because it has no targets", resource)
+			continue
+		}
+
+		ttl := annotations.TTLFromAnnotations(ko.Annotations, resource)
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ko.Annotations)
+
+		var objEndpoints []*endpoint.Endpoint
+		for _, hostname := range hostnames {
+			objEndpoints = append(objEndpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+		endpoints = append(endpoints, applyObjectAnnotations(endpointsWithHostnameAliases(objEndpoints, ko.Annotations), ko.Annotations)...)
+	}
+
+	for _, ep := range endpoints {
+		sort.Sort(ep.Targets)
+	}
+
+	return endpoints, nil
+}
+
+// hostnames returns the host of the URL of the object, or the first of the hostname annotation
+// and the URL host having any by priority.
+func (ks *knativeSource) hostnames(ko *knativeObject) []string {
+	var statusHosts []string
+	if hostname := knativeHostname(ko.Status.URL); hostname != "" {
+		statusHosts = []string{hostname}
+	}
+	if len(ks.hostnamePriority) == 0 {
+		return statusHosts
+	}
+	if hostnames, ok := byHostnamePriority(ks.hostnamePriority, map[string][]string{
+		HostnameOriginAnnotation: annotations.HostnamesFromAnnotations(ko.Annotations),
+		HostnameOriginStatus:     statusHosts,
+	}); ok {
+		return hostnames
+	}
+	return statusHosts
+}
+
+// knativeHostname returns the host of the URL of a Knative object, or nothing if it is invalid.
+func knativeHostname(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		log.Debugf("Invalid Knative URL %q: %v", rawURL, err)
+		return ""
+	}
+	return u.Hostname()
+}
+
+// gatewayTargets returns the load balancer addresses of the Knative ingress gateway service.
+func (ks *knativeSource) gatewayTargets() (endpoint.Targets, error) {
+	if ks.gatewayService == nil {
+		return nil, nil
+	}
+	svc, err := ks.serviceInformer.Lister().Services(ks.gatewayService.Namespace).Get(ks.gatewayService.Name)
+	if err != nil {
+		return nil, err
+	}
+	return extractLoadBalancerTargets(svc, false), nil
+}
+
+func (ks *knativeSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debugf("Adding event handler for Knative %s", ks.kind)
+
+	_, _ = ks.informer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	if ks.serviceInformer != nil {
+		_, _ = ks.serviceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
+}
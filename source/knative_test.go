@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestKnativeDomainMappingSource(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		objects  []*unstructured.Unstructured
+		expected []*endpoint.Endpoint
+	}{
+		{
+			title:   "ready domain mapping",
+			objects: []*unstructured.Unstructured{newKnativeObject("DomainMapping", "app.example.org", nil, nil, "https://app.example.org", "True")},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+			},
+		},
+		{
+			title: "target annotation overrides the gateway",
+			objects: []*unstructured.Unstructured{newKnativeObject("DomainMapping", "app.example.org",
+				map[string]interface{}{"external-dns.alpha.kubernetes.io/target": "192.0.2.10"}, nil, "https://app.example.org", "True")},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "app.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.10"}},
+			},
+		},
+		{
+			title: "not ready and without URL are skipped",
+			objects: []*unstructured.Unstructured{
+				newKnativeObject("DomainMapping", "pending.example.org", nil, nil, "https://pending.example.org", "Unknown"),
+				newKnativeObject("DomainMapping", "new.example.org", nil, nil, "", "True"),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "controller annotation mismatch",
+			objects: []*unstructured.Unstructured{newKnativeObject("DomainMapping", "app.example.org",
+				map[string]interface{}{controllerAnnotationKey: "other-controller"}, nil, "https://app.example.org", "True")},
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			dynamicClient := newKnativeTestDynamicClient(t, tc.objects...)
+
+			src, err := NewKnativeDomainMappingSource(t.Context(), dynamicClient, newKnativeTestKubeClient(t), "", "", labels.Everything(), "kourier-system/kourier", nil)
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestKnativeRouteSource(t *testing.T) {
+	dynamicClient := newKnativeTestDynamicClient(t,
+		newKnativeObject("Route", "hello", nil, nil, "http://hello.default.example.org", "True"),
+		newKnativeObject("Route", "private", nil, map[string]interface{}{knativeVisibilityLabelKey: "cluster-local"},
+			"http://private.default.svc.cluster.local", "True"),
+	)
+
+	src, err := NewKnativeRouteSource(t.Context(), dynamicClient, newKnativeTestKubeClient(t), "", "", labels.Everything(), "kourier-system/kourier", nil)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "hello.default.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "hello.default.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+	})
+}
+
+func TestKnativeSourceHostnamePriority(t *testing.T) {
+	dynamicClient := newKnativeTestDynamicClient(t,
+		newKnativeObject("DomainMapping", "app.example.org", map[string]interface{}{hostnameAnnotationKey: "www.example.org"}, nil, "https://app.example.org", "True"),
+		newKnativeObject("DomainMapping", "api.example.org", nil, nil, "https://api.example.org", "True"),
+	)
+
+	src, err := NewKnativeDomainMappingSource(t.Context(), dynamicClient, newKnativeTestKubeClient(t), "", "", labels.Everything(), "kourier-system/kourier", []string{"annotation", "status"})
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1"}},
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+	})
+}
+
+func TestKnativeSourceInvalidGatewayService(t *testing.T) {
+	_, err := NewKnativeDomainMappingSource(t.Context(), newKnativeTestDynamicClient(t), fake.NewClientset(), "", "", labels.Everything(), "kourier", nil)
+	require.Error(t, err)
+}
+
+// newKnativeTestKubeClient creates a kubernetes client with the kourier gateway service.
+func newKnativeTestKubeClient(t *testing.T) *fake.Clientset {
+	t.Helper()
+
+	kubeClient := fake.NewClientset()
+	_, err := kubeClient.CoreV1().Services("kourier-system").Create(t.Context(), &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kourier-system", Name: "kourier"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+			{IP: "192.0.2.1"},
+			{Hostname: "lb.example.com"},
+		}}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	return kubeClient
+}
+
+// newKnativeTestDynamicClient creates a dynamic client knowing the Knative DomainMappings and Routes.
+func newKnativeTestDynamicClient(t *testing.T, objects ...*unstructured.Unstructured) *fakeDynamic.FakeDynamicClient {
+	t.Helper()
+
+	dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		knativeDomainMappingGVR: "DomainMappingList",
+		knativeRouteGVR:         "RouteList",
+	})
+	for _, obj := range objects {
+		gvr := knativeDomainMappingGVR
+		if obj.GetKind() == "Route" {
+			gvr = knativeRouteGVR
+		}
+		_, err := dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Create(t.Context(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	return dynamicClient
+}
+
+// newKnativeObject creates a Knative object with the URL and the Ready condition in its status.
+func newKnativeObject(kind, name string, annotations, labels map[string]interface{}, url, ready string) *unstructured.Unstructured {
+	apiVersion := knativeDomainMappingGVR.GroupVersion().String()
+	if kind == "Route" {
+		apiVersion = knativeRouteGVR.GroupVersion().String()
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"namespace":   "default",
+			"name":        name,
+			"annotations": annotations,
+			"labels":      labels,
+		},
+		"status": map[string]interface{}{
+			"url":        url,
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": ready}},
+		},
+	}}
+}
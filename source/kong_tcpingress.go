@@ -146,6 +146,9 @@ func (sc *kongTCPIngressSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 		if err != nil {
 			return nil, err
 		}
+
+		ingressEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(ingressEndpoints, tcpIngress.Annotations), tcpIngress.Annotations)
+
 		if len(ingressEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from Host %s", fullname)
 			continue
@@ -19,6 +19,7 @@ package source
 import (
 	"context"
 	"fmt"
+	"slices"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
@@ -122,6 +123,7 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 		ttl := annotations.TTLFromAnnotations(node.Annotations, fmt.Sprintf("node/%s", node.Name))
 
 		addrs := annotations.TargetsFromTargetAnnotation(node.Annotations)
+		excludedRecordTypes := annotations.ExcludedRecordTypesFromAnnotations(node.Annotations)
 
 		if len(addrs) == 0 {
 			addrs, err = ns.nodeAddresses(node)
@@ -139,6 +141,10 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 			log.Debugf("adding endpoint with %d targets", len(addrs))
 
 			for _, addr := range addrs {
+				if slices.Contains(excludedRecordTypes, suitableType(addr)) {
+					log.Debugf("Skipping target %s of node %s because record type %s is excluded by annotation", addr, node.Name, suitableType(addr))
+					continue
+				}
 				ep := endpoint.NewEndpointWithTTL(dns, suitableType(addr), ttl)
 				ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("node/%s", node.Name))
 
@@ -154,6 +154,8 @@ func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			}
 		}
 
+		orEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(orEndpoints, ocpRoute.Annotations), ocpRoute.Annotations)
+
 		if len(orEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from OpenShift Route %s/%s", ocpRoute.Namespace, ocpRoute.Name)
 			continue
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
@@ -224,6 +225,7 @@ func (ps *podSource) hostsFromTemplate(pod *corev1.Pod) (map[endpoint.EndpointKe
 		return nil, fmt.Errorf("skipping generating endpoints from template for pod %s: %w", pod.Name, err)
 	}
 
+	excludedRecordTypes := annotations.ExcludedRecordTypesFromAnnotations(pod.Annotations)
 	result := make(map[endpoint.EndpointKey][]string)
 	for _, target := range hosts {
 		for _, address := range pod.Status.PodIPs {
@@ -231,6 +233,9 @@ func (ps *podSource) hostsFromTemplate(pod *corev1.Pod) (map[endpoint.EndpointKe
 				log.Debugf("skipping pod %q. PodIP is empty with phase %q", pod.Name, pod.Status.Phase)
 				continue
 			}
+			if slices.Contains(excludedRecordTypes, suitableType(address.IP)) {
+				continue
+			}
 			key := endpoint.EndpointKey{
 				DNSName:    target,
 				RecordType: suitableType(address.IP),
@@ -252,6 +257,10 @@ func addTargetsToEndpointMap(endpointMap map[endpoint.EndpointKey][]string, pod
 }
 
 func addToEndpointMap(endpointMap map[endpoint.EndpointKey][]string, pod *corev1.Pod, domain string, recordType string, address string) {
+	if slices.Contains(annotations.ExcludedRecordTypesFromAnnotations(pod.Annotations), recordType) {
+		log.Debugf("Skipping %s record %s of pod %s because the record type is excluded by annotation", recordType, domain, pod.Name)
+		return
+	}
 	key := endpoint.EndpointKey{
 		DNSName:    domain,
 		RecordType: recordType,
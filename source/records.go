@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type recordsRouteKey struct{}
+
+// recordsRoute is the provider whose records are in the context, among the providers the endpoints are routed to.
+type recordsRoute struct {
+	provider  string
+	providers []string
+}
+
+// WithRecordsRoute returns a context in which the records of the reconciliation are the ones of the named
+// provider, when the endpoints of the sources are routed to several providers.
+func WithRecordsRoute(ctx context.Context, provider string, providers []string) context.Context {
+	return context.WithValue(ctx, recordsRouteKey{}, recordsRoute{provider: provider, providers: providers})
+}
+
+// resourceRecords tracks whether the resources still have records in the providers,
+// from the records of the reconciliations of each provider.
+type resourceRecords struct {
+	mutex sync.Mutex
+	// the providers still having records, by resource
+	remaining map[string]map[string]bool
+}
+
+// removed returns whether the resource has no records left in any provider. It returns false outside of a
+// reconciliation, when the context has no records, and until the records of every provider were checked.
+func (r *resourceRecords) removed(ctx context.Context, resource string) bool {
+	records, ok := ctx.Value(provider.RecordsContextKey).([]*endpoint.Endpoint)
+	if !ok {
+		return false
+	}
+	route, ok := ctx.Value(recordsRouteKey{}).(recordsRoute)
+	if !ok {
+		route = recordsRoute{providers: []string{""}}
+	}
+
+	remaining := false
+	for _, record := range records {
+		if record.Labels[endpoint.ResourceLabelKey] == resource {
+			remaining = true
+			break
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.remaining == nil {
+		r.remaining = map[string]map[string]bool{}
+	}
+	providers, ok := r.remaining[resource]
+	if !ok {
+		// the providers not checked yet are assumed to have records
+		providers = make(map[string]bool, len(route.providers))
+		for _, p := range route.providers {
+			providers[p] = true
+		}
+		r.remaining[resource] = providers
+	}
+	providers[route.provider] = remaining
+	for _, hasRecords := range providers {
+		if hasRecords {
+			return false
+		}
+	}
+	delete(r.remaining, resource)
+	return true
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"maps"
 	"net"
+	"net/netip"
 	"slices"
 	"sort"
 	"strings"
@@ -55,6 +56,15 @@ var (
 	serviceNameIndexKey = "serviceName"
 )
 
+const (
+	// The annotations used to request LoadBalancer IPs from Cilium LB IPAM
+	ciliumLoadBalancerIPAMIPsAnnotationKey       = "lbipam.cilium.io/ips"
+	ciliumLegacyLoadBalancerIPAMIPsAnnotationKey = "io.cilium/lb-ipam-ips"
+	// The annotation set by the AWS cloud controller with the DNS name of the NLB of the service,
+	// e.g. for the cross-zone or dualstack NLBs
+	awsLoadBalancerDNSNameAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-dns-name"
+)
+
 // serviceSource is an implementation of Source for Kubernetes service objects.
 // It will find all services that are under our jurisdiction, i.e. annotated
 // desired hostname and matching or no controller annotation. For each of the
@@ -80,13 +90,21 @@ type serviceSource struct {
 	nodeInformer                   coreinformers.NodeInformer
 	serviceTypeFilter              *serviceTypes
 	exposeInternalIPv6             bool
+	ciliumLoadBalancerIPAM         bool
+	// the origins of the hostnames by priority, the template hostnames being the spec ones
+	hostnamePriority []string
+	// publish SRV records for the named ports of the headless services, targeting the pod hostnames
+	publishHeadlessSRV bool
 
 	// process Services with legacy annotations
 	compatibility string
+
+	// the hostname aggregating the load balancer IPs of all the LoadBalancer services, disabled when empty
+	aggregateHostname string
 }
 
 // NewServiceSource creates a new serviceSource with the given config.
-func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool) (Source, error) {
+func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool, ciliumLoadBalancerIPAM bool, hostnamePriority []string, publishHeadlessSRV bool, aggregateHostname string) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -132,21 +150,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 	}
 
 	// Add an indexer to the EndpointSlice informer to index by the service name label
-	err = endpointSlicesInformer.Informer().AddIndexers(cache.Indexers{
-		serviceNameIndexKey: func(obj any) ([]string, error) {
-			endpointSlice, ok := obj.(*discoveryv1.EndpointSlice)
-			if !ok {
-				// This should never happen because the Informer should only contain EndpointSlice objects
-				return nil, fmt.Errorf("expected %T but got %T instead", endpointSlice, obj)
-			}
-			serviceName := endpointSlice.Labels[discoveryv1.LabelServiceName]
-			if serviceName == "" {
-				return nil, nil
-			}
-			key := types.NamespacedName{Namespace: endpointSlice.Namespace, Name: serviceName}.String()
-			return []string{key}, nil
-		},
-	})
+	err = endpointSlicesInformer.Informer().AddIndexers(cache.Indexers{serviceNameIndexKey: endpointSliceServiceNameIndexFunc})
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +182,10 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		resolveLoadBalancerHostname:    resolveLoadBalancerHostname,
 		listenEndpointEvents:           listenEndpointEvents,
 		exposeInternalIPv6:             exposeInternalIPv6,
+		ciliumLoadBalancerIPAM:         ciliumLoadBalancerIPAM,
+		hostnamePriority:               hostnamePriority,
+		publishHeadlessSRV:             publishHeadlessSRV,
+		aggregateHostname:              aggregateHostname,
 	}, nil
 }
 
@@ -197,6 +205,7 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	}
 
 	endpoints := make([]*endpoint.Endpoint, 0)
+	var aggregateTargets endpoint.Targets
 
 	for _, svc := range services {
 		// Check controller annotation to see if we are responsible.
@@ -207,6 +216,10 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
+		if sc.aggregateHostname != "" && svc.Spec.Type == v1.ServiceTypeLoadBalancer {
+			aggregateTargets = append(aggregateTargets, loadBalancerIPs(svc, sc.resolveLoadBalancerHostname)...)
+		}
+
 		svcEndpoints := sc.endpoints(svc)
 
 		// process legacy annotations if no endpoints were returned and compatibility mode is enabled.
@@ -217,8 +230,25 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			}
 		}
 
-		// apply template if none of the above is found
-		if (sc.combineFQDNAnnotation || len(svcEndpoints) == 0) && sc.fqdnTemplate != nil {
+		// pick the annotation or the template hostnames by priority when configured and the service
+		// has any of its origins, otherwise apply the template if none of the above is found
+		prioritized := false
+		if len(sc.hostnamePriority) > 0 {
+			origins := map[string][]*endpoint.Endpoint{HostnameOriginAnnotation: svcEndpoints}
+			// the template is the spec origin of the services, only when configured
+			if sc.fqdnTemplate != nil {
+				templateEndpoints, err := sc.endpointsFromTemplate(svc)
+				if err != nil {
+					return nil, err
+				}
+				origins[HostnameOriginSpec] = templateEndpoints
+			}
+			var priorityEndpoints []*endpoint.Endpoint
+			if priorityEndpoints, prioritized = byHostnamePriority(sc.hostnamePriority, origins); prioritized {
+				svcEndpoints = priorityEndpoints
+			}
+		}
+		if !prioritized && (sc.combineFQDNAnnotation || len(svcEndpoints) == 0) && sc.fqdnTemplate != nil {
 			sEndpoints, err := sc.endpointsFromTemplate(svc)
 			if err != nil {
 				return nil, err
@@ -231,6 +261,11 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			}
 		}
 
+		if !sc.ignoreHostnameAnnotation {
+			svcEndpoints = endpointsWithHostnameTargets(svcEndpoints, svc.Annotations)
+		}
+		svcEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(svcEndpoints, svc.Annotations), svc.Annotations)
+
 		if len(svcEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from service %s/%s", svc.Namespace, svc.Name)
 			continue
@@ -282,9 +317,44 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 		})
 	}
 
+	if len(aggregateTargets) > 0 {
+		endpoints = append(endpoints, sc.aggregateEndpoints(endpoints, aggregateTargets)...)
+	}
+
 	return endpoints, nil
 }
 
+// loadBalancerIPs returns the load balancer IPs of a LoadBalancer service, the hostnames of the load balancers
+// can't be aggregated with IPs in a single record.
+func loadBalancerIPs(svc *v1.Service, resolveLoadBalancerHostname bool) endpoint.Targets {
+	var ips endpoint.Targets
+	for _, target := range extractLoadBalancerTargets(svc, resolveLoadBalancerHostname) {
+		if net.ParseIP(target) == nil {
+			log.Debugf("Skipping load balancer hostname %s of service %s/%s for the aggregate hostname", target, svc.Namespace, svc.Name)
+			continue
+		}
+		ips = append(ips, target)
+	}
+	return ips
+}
+
+// aggregateEndpoints returns the A and AAAA endpoints of the aggregate hostname, targeting the load balancer IPs
+// of all the LoadBalancer services. They are skipped when a service already publishes the aggregate hostname.
+func (sc *serviceSource) aggregateEndpoints(endpoints []*endpoint.Endpoint, targets endpoint.Targets) []*endpoint.Endpoint {
+	for _, ep := range endpoints {
+		if ep.DNSName == sc.aggregateHostname {
+			log.Warnf("Skipping the aggregate hostname %s, it is already published by %s", sc.aggregateHostname, ep.Labels[endpoint.ResourceLabelKey])
+			return nil
+		}
+	}
+
+	aggregate := EndpointsForHostname(sc.aggregateHostname, targets, endpoint.TTL(0), nil, "", "")
+	for _, ep := range aggregate {
+		ep.UniqueOrderedTargets()
+	}
+	return aggregate
+}
+
 // extractHeadlessEndpoints extracts endpoints from a headless service using the "Endpoints" Kubernetes API resource
 func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) []*endpoint.Endpoint {
 	var endpoints []*endpoint.Endpoint
@@ -359,6 +429,14 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 				headlessDomains = append(headlessDomains, fmt.Sprintf("%s.%s", pod.Spec.Hostname, hostname))
 			}
 
+			if sc.publishHeadlessSRV && pod.Spec.Hostname != "" {
+				for _, key := range headlessSRVKeys(endpointSlice.Ports, hostname) {
+					// the pod hostname targets have a priority of 0 and a weight of 50, as the NodePort ones
+					target := fmt.Sprintf("0 50 %d %s.%s", key.port, pod.Spec.Hostname, hostname)
+					targetsByHeadlessDomainAndType[key.EndpointKey] = append(targetsByHeadlessDomainAndType[key.EndpointKey], target)
+				}
+			}
+
 			for _, headlessDomain := range headlessDomains {
 				targets := annotations.TargetsFromTargetAnnotation(pod.Annotations)
 				if len(targets) == 0 {
@@ -443,6 +521,35 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 	return endpoints
 }
 
+// headlessSRVKey is the key of the SRV endpoint of a named port of a headless service.
+type headlessSRVKey struct {
+	endpoint.EndpointKey
+	port int32
+}
+
+// headlessSRVKeys returns the keys of the SRV endpoints of the named ports, following the RFC 2782 format
+// _service._proto.name with the port name as service.
+func headlessSRVKeys(ports []discoveryv1.EndpointPort, hostname string) []headlessSRVKey {
+	var keys []headlessSRVKey
+	for _, port := range ports {
+		if port.Name == nil || *port.Name == "" || port.Port == nil {
+			continue
+		}
+		protocol := "tcp"
+		if port.Protocol != nil && *port.Protocol != "" {
+			protocol = strings.ToLower(string(*port.Protocol))
+		}
+		keys = append(keys, headlessSRVKey{
+			EndpointKey: endpoint.EndpointKey{
+				DNSName:    fmt.Sprintf("_%s._%s.%s", *port.Name, protocol, hostname),
+				RecordType: endpoint.RecordTypeSRV,
+			},
+			port: *port.Port,
+		})
+	}
+	return keys
+}
+
 func (sc *serviceSource) endpointsFromTemplate(svc *v1.Service) ([]*endpoint.Endpoint, error) {
 	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, svc)
 	if err != nil {
@@ -542,6 +649,12 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 				targets = extractServiceIps(svc)
 			} else {
 				targets = extractLoadBalancerTargets(svc, sc.resolveLoadBalancerHostname)
+				if len(targets) == 0 && sc.ciliumLoadBalancerIPAM {
+					targets = extractCiliumLoadBalancerIPAMTargets(svc)
+				}
+				if len(targets) == 0 {
+					targets = extractAWSLoadBalancerDNSNameTargets(svc)
+				}
 			}
 		case v1.ServiceTypeClusterIP:
 			if svc.Spec.ClusterIP == v1.ClusterIPNone {
@@ -618,6 +731,42 @@ func extractLoadBalancerTargets(svc *v1.Service, resolveLoadBalancerHostname boo
 	return targets
 }
 
+// extractCiliumLoadBalancerIPAMTargets returns the IPs requested from Cilium LB IPAM through the service annotations.
+// They are used until Cilium reports the assigned IPs in the service status.
+func extractCiliumLoadBalancerIPAMTargets(svc *v1.Service) endpoint.Targets {
+	value, ok := svc.Annotations[ciliumLoadBalancerIPAMIPsAnnotationKey]
+	if !ok {
+		value = svc.Annotations[ciliumLegacyLoadBalancerIPAMIPsAnnotationKey]
+	}
+
+	var targets endpoint.Targets
+	for _, ip := range strings.Split(value, ",") {
+		ip = strings.TrimSpace(ip)
+		if ip == "" {
+			continue
+		}
+		if _, err := netip.ParseAddr(ip); err != nil {
+			log.Warnf("Ignoring invalid IP %q in Cilium LB IPAM annotation of service %s/%s", ip, svc.Namespace, svc.Name)
+			continue
+		}
+		targets = append(targets, ip)
+	}
+	return targets
+}
+
+// extractAWSLoadBalancerDNSNameTargets returns the DNS name of the NLB set by the AWS cloud controller
+// in the service annotations. It is used when the service status has no load balancer ingress.
+func extractAWSLoadBalancerDNSNameTargets(svc *v1.Service) endpoint.Targets {
+	if len(svc.Status.LoadBalancer.Ingress) > 0 {
+		return nil
+	}
+	hostname := strings.TrimSuffix(strings.TrimSpace(svc.Annotations[awsLoadBalancerDNSNameAnnotationKey]), ".")
+	if hostname == "" {
+		return nil
+	}
+	return endpoint.Targets{hostname}
+}
+
 func isPodStatusReady(status v1.PodStatus) bool {
 	_, condition := getPodCondition(&status, v1.PodReady)
 	return condition != nil && condition.Status == v1.ConditionTrue
@@ -863,3 +1012,18 @@ func conditionToBool(v *bool) bool {
 	}
 	return *v
 }
+
+// endpointSliceServiceNameIndexFunc indexes the EndpointSlices by the namespaced name of their service.
+func endpointSliceServiceNameIndexFunc(obj any) ([]string, error) {
+	endpointSlice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		// This should never happen because the Informer should only contain EndpointSlice objects
+		return nil, fmt.Errorf("expected %T but got %T instead", endpointSlice, obj)
+	}
+	serviceName := endpointSlice.Labels[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		return nil, nil
+	}
+	key := types.NamespacedName{Namespace: endpointSlice.Namespace, Name: serviceName}.String()
+	return []string{key}, nil
+}
@@ -609,6 +609,10 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				false,
 				true,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -90,6 +90,10 @@ func (suite *ServiceSuite) SetupTest() {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		false,
+		"",
 	)
 	suite.NoError(err, "should initialize service source")
 }
@@ -173,6 +177,10 @@ func testServiceSourceNewServiceSource(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				false,
+				"",
 			)
 
 			if ti.expectError {
@@ -669,6 +677,22 @@ func testServiceSourceEndpoints(t *testing.T) {
 				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4", "8.8.8.8"}},
 			},
 		},
+		{
+			title:        "load balancer ingress entries of three zones return a single endpoint with all their targets",
+			svcNamespace: "testing",
+			svcName:      "foo",
+			svcType:      v1.ServiceTypeLoadBalancer,
+			labels:       map[string]string{},
+			annotations: map[string]string{
+				hostnameAnnotationKey: "foo.example.org.",
+			},
+			externalIPs:        []string{},
+			lbs:                []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"},
+			serviceTypesFilter: []string{},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2", "192.0.2.3"}},
+			},
+		},
 		{
 			title:        "services annotated with legacy mate annotations are ignored in default mode",
 			svcNamespace: "testing",
@@ -1076,6 +1100,24 @@ func testServiceSourceEndpoints(t *testing.T) {
 				{DNSName: "foobar.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
 			},
 		},
+		{
+			title:              "dual-stack load-balancer service with AAAA excluded by annotation gets only IPv4 address",
+			svcNamespace:       "testing",
+			svcName:            "foobar",
+			svcType:            v1.ServiceTypeLoadBalancer,
+			labels:             map[string]string{},
+			clusterIP:          "1.1.1.2,2001:db8::2",
+			externalIPs:        []string{},
+			lbs:                []string{"1.1.1.1", "2001:db8::1"},
+			serviceTypesFilter: []string{},
+			annotations: map[string]string{
+				hostnameAnnotationKey:          "foobar.example.org",
+				recordTypeExcludeAnnotationKey: "aaaa",
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foobar.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1"}},
+			},
+		},
 		{
 			title:              "IPv6-only load-balancer service gets IPv6 endpoint",
 			svcNamespace:       "testing",
@@ -1157,6 +1199,10 @@ func testServiceSourceEndpoints(t *testing.T) {
 				tc.resolveLoadBalancerHostname,
 				false,
 				false,
+				false,
+				nil,
+				false,
+				"",
 			)
 
 			require.NoError(t, err)
@@ -1373,6 +1419,10 @@ func testMultipleServicesEndpoints(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -1678,6 +1728,10 @@ func TestClusterIpServices(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -2455,6 +2509,10 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -3248,6 +3306,10 @@ func TestHeadlessServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -3618,6 +3680,10 @@ func TestMultipleHeadlessServicesPointingToPodsOnTheSameNode(t *testing.T) {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		false,
+		"",
 	)
 	require.NoError(t, err)
 	assert.NotNil(t, src)
@@ -4077,6 +4143,10 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -4098,6 +4168,120 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 	}
 }
 
+func TestHeadlessServicesSRV(t *testing.T) {
+	kubernetes := fake.NewClientset()
+
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "foo",
+			Annotations: map[string]string{annotations.HostnameKey: "service.example.org"},
+		},
+		Spec: v1.ServiceSpec{
+			Type:      v1.ServiceTypeClusterIP,
+			ClusterIP: v1.ClusterIPNone,
+			Selector:  map[string]string{"app": "foo"},
+		},
+	}
+	_, err := kubernetes.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	ready := true
+	var endpointSliceEndpoints []discoveryv1.Endpoint
+	for i, podname := range []string{"foo-0", "foo-1"} {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      podname,
+				Labels:    map[string]string{"app": "foo"},
+			},
+			Spec: v1.PodSpec{Hostname: podname},
+		}
+		_, err = kubernetes.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		endpointSliceEndpoints = append(endpointSliceEndpoints, discoveryv1.Endpoint{
+			Addresses:  []string{fmt.Sprintf("1.1.1.%d", i+1)},
+			TargetRef:  &v1.ObjectReference{APIVersion: "", Kind: "Pod", Name: podname},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		})
+	}
+	tcp := v1.ProtocolTCP
+	udp := v1.ProtocolUDP
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "foo",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "foo"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpointSliceEndpoints,
+		Ports: []discoveryv1.EndpointPort{
+			{Name: testutils.ToPtr("http"), Port: testutils.ToPtr(int32(8080)), Protocol: &tcp},
+			{Name: testutils.ToPtr("dns"), Port: testutils.ToPtr(int32(53)), Protocol: &udp},
+			{Name: testutils.ToPtr(""), Port: testutils.ToPtr(int32(9090)), Protocol: &tcp},
+		},
+	}
+	_, err = kubernetes.DiscoveryV1().EndpointSlices(endpointSlice.Namespace).Create(context.Background(), endpointSlice, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		title              string
+		publishHeadlessSRV bool
+		expected           []*endpoint.Endpoint
+	}{
+		{
+			title: "the SRV records are not published by default",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1"}},
+				{DNSName: "foo-1.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.2"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1", "1.1.1.2"}},
+			},
+		},
+		{
+			title:              "the named ports are published as SRV records",
+			publishHeadlessSRV: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1"}},
+				{DNSName: "foo-1.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.2"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1", "1.1.1.2"}},
+				{DNSName: "_http._tcp.service.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 8080 foo-0.service.example.org", "0 50 8080 foo-1.service.example.org"}},
+				{DNSName: "_dns._udp.service.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 53 foo-0.service.example.org", "0 50 53 foo-1.service.example.org"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			client, err := NewServiceSource(
+				context.TODO(),
+				kubernetes,
+				"",
+				"",
+				"",
+				false,
+				"",
+				true,
+				false,
+				false,
+				[]string{},
+				false,
+				labels.Everything(),
+				false,
+				false,
+				false,
+				false,
+				nil,
+				tc.publishHeadlessSRV,
+				"",
+			)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
 // TestExternalServices tests that external services generate the correct endpoints.
 func TestExternalServices(t *testing.T) {
 	t.Parallel()
@@ -4287,6 +4471,10 @@ func TestExternalServices(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -4349,6 +4537,10 @@ func BenchmarkServiceEndpoints(b *testing.B) {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		false,
+		"",
 	)
 	require.NoError(b, err)
 
@@ -4414,6 +4606,10 @@ func TestNewServiceSourceInformersEnabled(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				false,
+				"",
 			)
 			require.NoError(t, err)
 			svcSrc, ok := svc.(*serviceSource)
@@ -4445,6 +4641,10 @@ func TestNewServiceSourceWithServiceTypeFilters_Unsupported(t *testing.T) {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		false,
+		"",
 	)
 	require.Errorf(t, err, "unsupported service type filter: \"UnknownType\". Supported types are: [\"ClusterIP\" \"NodePort\" \"LoadBalancer\" \"ExternalName\"]")
 	require.Nil(t, svc, "ServiceSource should be nil when an unsupported service type is provided")
@@ -4565,6 +4765,22 @@ func TestFilterByServiceType_WithFixture(t *testing.T) {
 			},
 			expected: 0,
 		},
+		{
+			name: "all types of services with filter enabled for ServiceTypeNodePort",
+			currentServices: createTestServicesByType(namespace, map[v1.ServiceType]int{
+				v1.ServiceTypeLoadBalancer: 3,
+				v1.ServiceTypeNodePort:     4,
+				v1.ServiceTypeClusterIP:    5,
+				v1.ServiceTypeExternalName: 2,
+			}),
+			filter: &serviceTypes{
+				enabled: true,
+				types: map[v1.ServiceType]bool{
+					v1.ServiceTypeNodePort: true,
+				},
+			},
+			expected: 4,
+		},
 		{
 			name: "filter disabled returns all services",
 			currentServices: createTestServicesByType(namespace, map[v1.ServiceType]int{
@@ -4624,6 +4840,10 @@ func TestEndpointSlicesIndexer(t *testing.T) {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		false,
+		"",
 	)
 	require.NoError(t, err)
 	ss, ok := src.(*serviceSource)
@@ -4710,3 +4930,361 @@ func TestServiceTypes_isNodeInformerRequired(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceSourceCiliumLoadBalancerIPAM(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		title                  string
+		annotations            map[string]string
+		lbs                    []string
+		ciliumLoadBalancerIPAM bool
+		expected               []*endpoint.Endpoint
+	}{
+		{
+			title: "empty status falls back to the Cilium LB IPAM annotation",
+			annotations: map[string]string{
+				hostnameAnnotationKey:  "foo.example.org.",
+				"lbipam.cilium.io/ips": "1.2.3.4, 2001:db8::1",
+			},
+			ciliumLoadBalancerIPAM: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title: "empty status falls back to the legacy Cilium LB IPAM annotation",
+			annotations: map[string]string{
+				hostnameAnnotationKey:   "foo.example.org.",
+				"io.cilium/lb-ipam-ips": "1.2.3.4",
+			},
+			ciliumLoadBalancerIPAM: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title: "invalid IPs in the Cilium LB IPAM annotation are ignored",
+			annotations: map[string]string{
+				hostnameAnnotationKey:  "foo.example.org.",
+				"lbipam.cilium.io/ips": "not-an-ip,1.2.3.4",
+			},
+			ciliumLoadBalancerIPAM: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title: "status takes precedence over the Cilium LB IPAM annotation",
+			annotations: map[string]string{
+				hostnameAnnotationKey:  "foo.example.org.",
+				"lbipam.cilium.io/ips": "1.2.3.4",
+			},
+			lbs:                    []string{"5.6.7.8"},
+			ciliumLoadBalancerIPAM: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"5.6.7.8"}},
+			},
+		},
+		{
+			title: "Cilium LB IPAM annotation is ignored when disabled",
+			annotations: map[string]string{
+				hostnameAnnotationKey:  "foo.example.org.",
+				"lbipam.cilium.io/ips": "1.2.3.4",
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+
+			ingresses := []v1.LoadBalancerIngress{}
+			for _, lb := range tc.lbs {
+				ingresses = append(ingresses, v1.LoadBalancerIngress{IP: lb})
+			}
+			service := &v1.Service{
+				Spec: v1.ServiceSpec{
+					Type: v1.ServiceTypeLoadBalancer,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "testing",
+					Name:        "foo",
+					Annotations: tc.annotations,
+				},
+				Status: v1.ServiceStatus{
+					LoadBalancer: v1.LoadBalancerStatus{
+						Ingress: ingresses,
+					},
+				},
+			}
+			_, err := kubernetes.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewServiceSource(
+				context.TODO(),
+				kubernetes,
+				"",
+				"",
+				"",
+				false,
+				"",
+				false,
+				false,
+				false,
+				[]string{},
+				false,
+				labels.Everything(),
+				false,
+				false,
+				false,
+				tc.ciliumLoadBalancerIPAM,
+				nil,
+				false,
+				"",
+			)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestServiceSourceAWSLoadBalancerDNSName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		title       string
+		annotations map[string]string
+		lbs         []v1.LoadBalancerIngress
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title: "empty status falls back to the NLB DNS name annotation",
+			annotations: map[string]string{
+				hostnameAnnotationKey: "foo.example.org.",
+				"service.beta.kubernetes.io/aws-load-balancer-dns-name": "nlb-123.elb.us-east-1.amazonaws.com.",
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"nlb-123.elb.us-east-1.amazonaws.com"}},
+			},
+		},
+		{
+			title: "status takes precedence over the NLB DNS name annotation",
+			annotations: map[string]string{
+				hostnameAnnotationKey: "foo.example.org.",
+				"service.beta.kubernetes.io/aws-load-balancer-dns-name": "nlb-123.elb.us-east-1.amazonaws.com",
+			},
+			lbs: []v1.LoadBalancerIngress{{Hostname: "nlb-456.elb.us-east-1.amazonaws.com"}},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"nlb-456.elb.us-east-1.amazonaws.com"}},
+			},
+		},
+		{
+			title: "empty NLB DNS name annotation is ignored",
+			annotations: map[string]string{
+				hostnameAnnotationKey: "foo.example.org.",
+				"service.beta.kubernetes.io/aws-load-balancer-dns-name": " ",
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+
+			service := &v1.Service{
+				Spec: v1.ServiceSpec{
+					Type: v1.ServiceTypeLoadBalancer,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "testing",
+					Name:        "foo",
+					Annotations: tc.annotations,
+				},
+				Status: v1.ServiceStatus{
+					LoadBalancer: v1.LoadBalancerStatus{
+						Ingress: tc.lbs,
+					},
+				},
+			}
+			_, err := kubernetes.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewServiceSource(
+				context.TODO(),
+				kubernetes,
+				"",
+				"",
+				"",
+				false,
+				"",
+				false,
+				false,
+				false,
+				[]string{},
+				false,
+				labels.Everything(),
+				false,
+				false,
+				false,
+				false,
+				nil,
+				false,
+				"",
+			)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestServiceSourceHostnamePriority(t *testing.T) {
+	for _, tc := range []struct {
+		title       string
+		priority    []string
+		annotations map[string]string
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title:       "template first",
+			priority:    []string{"spec", "annotation"},
+			annotations: map[string]string{hostnameAnnotationKey: "foo.example.org"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.fqdn.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:       "annotation first",
+			priority:    []string{"annotation", "spec"},
+			annotations: map[string]string{hostnameAnnotationKey: "foo.example.org"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:    "annotation first falls back to the template",
+			priority: []string{"annotation", "spec"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.fqdn.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:       "template not in the priority",
+			priority:    []string{"annotation"},
+			annotations: map[string]string{},
+			expected:    []*endpoint.Endpoint{},
+		},
+		{
+			title:       "no origin of the services keeps the annotation",
+			priority:    []string{"status"},
+			annotations: map[string]string{hostnameAnnotationKey: "foo.example.org"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:    "no origin of the services keeps the template",
+			priority: []string{"status"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.fqdn.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			kubeClient := fake.NewClientset()
+			_, err := kubeClient.CoreV1().Services("default").Create(t.Context(), &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", Annotations: tc.annotations},
+				Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+				Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{
+					Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}},
+				}},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			src, err := NewServiceSource(t.Context(), kubeClient, "", "", "{{.Name}}.fqdn.org", false, "", false, false, false,
+				[]string{}, false, labels.Everything(), false, false, false, false, tc.priority, false, "")
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestServiceSourceAggregateHostname(t *testing.T) {
+	loadBalancer := func(namespace, name string, annotations map[string]string, ingresses ...v1.LoadBalancerIngress) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: ingresses}},
+		}
+	}
+
+	for _, tc := range []struct {
+		title    string
+		services []*v1.Service
+		expected []*endpoint.Endpoint
+	}{
+		{
+			title: "load balancer IPs of all the services",
+			services: []*v1.Service{
+				loadBalancer("default", "web", map[string]string{hostnameAnnotationKey: "web.example.org"}, v1.LoadBalancerIngress{IP: "192.0.2.2"}),
+				loadBalancer("default", "api", nil, v1.LoadBalancerIngress{IP: "192.0.2.1"}, v1.LoadBalancerIngress{IP: "2001:db8::1"}),
+				loadBalancer("other", "db", nil, v1.LoadBalancerIngress{IP: "192.0.2.3"}, v1.LoadBalancerIngress{IP: "192.0.2.1"}),
+				loadBalancer("other", "elb", nil, v1.LoadBalancerIngress{Hostname: "lb.elb.example.com"}),
+				loadBalancer("other", "ignored", map[string]string{controllerAnnotationKey: "other-controller"}, v1.LoadBalancerIngress{IP: "192.0.2.4"}),
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster-ip"},
+					Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}},
+				{DNSName: "ingress.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.1", "192.0.2.2", "192.0.2.3"}},
+				{DNSName: "ingress.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title: "aggregate hostname already published by a service",
+			services: []*v1.Service{
+				loadBalancer("default", "web", map[string]string{hostnameAnnotationKey: "ingress.example.org"}, v1.LoadBalancerIngress{IP: "192.0.2.2"}),
+				loadBalancer("default", "api", nil, v1.LoadBalancerIngress{IP: "192.0.2.1"}),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "ingress.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.0.2.2"}},
+			},
+		},
+		{
+			title:    "no load balancer",
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			kubeClient := fake.NewClientset()
+			for _, svc := range tc.services {
+				_, err := kubeClient.CoreV1().Services(svc.Namespace).Create(t.Context(), svc, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewServiceSource(t.Context(), kubeClient, "", "", "", false, "", false, false, false,
+				[]string{}, false, labels.Everything(), false, false, false, false, nil, false, "ingress.example.org")
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
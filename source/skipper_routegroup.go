@@ -276,6 +276,8 @@ func (sc *routeGroupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint
 			}
 		}
 
+		eps = applyObjectAnnotations(endpointsWithHostnameAliases(eps, rg.Metadata.Annotations), rg.Metadata.Annotations)
+
 		if len(eps) == 0 {
 			log.Debugf("No endpoints could be generated from routegroup %s/%s", rg.Metadata.Namespace, rg.Metadata.Name)
 			continue
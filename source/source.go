@@ -28,16 +28,19 @@ import (
 )
 
 const (
-	controllerAnnotationKey       = annotations.ControllerKey
-	hostnameAnnotationKey         = annotations.HostnameKey
-	accessAnnotationKey           = annotations.AccessKey
-	endpointsTypeAnnotationKey    = annotations.EndpointsTypeKey
-	targetAnnotationKey           = annotations.TargetKey
-	ttlAnnotationKey              = annotations.TtlKey
-	aliasAnnotationKey            = annotations.AliasKey
-	ingressHostnameSourceKey      = annotations.IngressHostnameSourceKey
-	controllerAnnotationValue     = annotations.ControllerValue
-	internalHostnameAnnotationKey = annotations.InternalHostnameKey
+	controllerAnnotationKey        = annotations.ControllerKey
+	hostnameAnnotationKey          = annotations.HostnameKey
+	accessAnnotationKey            = annotations.AccessKey
+	endpointsTypeAnnotationKey     = annotations.EndpointsTypeKey
+	targetAnnotationKey            = annotations.TargetKey
+	targetIPv4AnnotationKey        = annotations.TargetIPv4Key
+	targetIPv6AnnotationKey        = annotations.TargetIPv6Key
+	ttlAnnotationKey               = annotations.TtlKey
+	aliasAnnotationKey             = annotations.AliasKey
+	ingressHostnameSourceKey       = annotations.IngressHostnameSourceKey
+	controllerAnnotationValue      = annotations.ControllerValue
+	internalHostnameAnnotationKey  = annotations.InternalHostnameKey
+	recordTypeExcludeAnnotationKey = annotations.RecordTypeExcludeKey
 
 	EndpointsTypeNodeExternalIP = "NodeExternalIP"
 	EndpointsTypeHostIP         = "HostIP"
@@ -22,7 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-
+	"slices"
 	"sync"
 	"time"
 
@@ -35,6 +35,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
@@ -65,6 +66,8 @@ type Config struct {
 	AnnotationFilter               string
 	LabelFilter                    labels.Selector
 	IngressClassNames              []string
+	IngressClassServices           []string
+	IngressClassTargets            []string
 	FQDNTemplate                   string
 	CombineFQDNAndAnnotation       bool
 	IgnoreHostnameAnnotation       bool
@@ -75,15 +78,27 @@ type Config struct {
 	GatewayName                    string
 	GatewayNamespace               string
 	GatewayLabelFilter             string
+	GatewayAddressTypes            []string
+	GatewayFallbackTargets         []string
+	GatewayRequireProgrammed       bool
 	Compatibility                  string
 	PodSourceDomain                string
 	PublishInternal                bool
 	PublishHostIP                  bool
 	AlwaysPublishNotReadyAddresses bool
+	PublishHeadlessSRV             bool
+	ServiceAggregateHostname       string
 	ConnectorServer                string
+	ConfigMapNames                 []string
 	CRDSourceAPIVersion            string
 	CRDSourceKind                  string
+	CRDSourceClusterScoped         bool
+	CRDSourceFinalizer             bool
+	CRDSourceHostnameJSONPath      string
+	CRDSourceTargetsJSONPath       string
+	CRDSourceTTLJSONPath           string
 	KubeConfig                     string
+	KubeContext                    string
 	APIServerURL                   string
 	ServiceTypeFilter              []string
 	CFAPIEndpoint                  string
@@ -96,11 +111,21 @@ type Config struct {
 	ForceDefaultTargets            bool
 	OCPRouterName                  string
 	UpdateEvents                   bool
+	UpdateEventsSources            []string
 	ResolveLoadBalancerHostname    bool
 	TraefikEnableLegacy            bool
 	TraefikDisableNew              bool
+	F5VirtualServerAllowedHosts    []string
+	F5VirtualServerDeniedHosts     []string
+	F5RequireHealthyPoolMembers    bool
 	ExcludeUnschedulable           bool
 	ExposeInternalIPv6             bool
+	CiliumLoadBalancerIPAM         bool
+	KnativeGatewayService          string
+	CertManagerCertificateTargets  []string
+	HAProxyTCPServicesConfigMap    string
+	HAProxyService                 string
+	HostnameSourcePriority         []string
 }
 
 func NewSourceConfig(cfg *externaldns.Config) *Config {
@@ -111,6 +136,8 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		AnnotationFilter:               cfg.AnnotationFilter,
 		LabelFilter:                    labelSelector,
 		IngressClassNames:              cfg.IngressClassNames,
+		IngressClassServices:           cfg.IngressClassServices,
+		IngressClassTargets:            cfg.IngressClassTargets,
 		FQDNTemplate:                   cfg.FQDNTemplate,
 		CombineFQDNAndAnnotation:       cfg.CombineFQDNAndAnnotation,
 		IgnoreHostnameAnnotation:       cfg.IgnoreHostnameAnnotation,
@@ -121,14 +148,25 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		GatewayName:                    cfg.GatewayName,
 		GatewayNamespace:               cfg.GatewayNamespace,
 		GatewayLabelFilter:             cfg.GatewayLabelFilter,
+		GatewayAddressTypes:            cfg.GatewayAddressTypes,
+		GatewayFallbackTargets:         cfg.GatewayFallbackTargets,
+		GatewayRequireProgrammed:       cfg.GatewayRequireProgrammed,
 		Compatibility:                  cfg.Compatibility,
 		PodSourceDomain:                cfg.PodSourceDomain,
 		PublishInternal:                cfg.PublishInternal,
 		PublishHostIP:                  cfg.PublishHostIP,
 		AlwaysPublishNotReadyAddresses: cfg.AlwaysPublishNotReadyAddresses,
+		PublishHeadlessSRV:             cfg.PublishHeadlessSRV,
+		ServiceAggregateHostname:       cfg.ServiceAggregateHostname,
 		ConnectorServer:                cfg.ConnectorSourceServer,
+		ConfigMapNames:                 cfg.ConfigMapSourceNames,
 		CRDSourceAPIVersion:            cfg.CRDSourceAPIVersion,
 		CRDSourceKind:                  cfg.CRDSourceKind,
+		CRDSourceClusterScoped:         cfg.CRDSourceClusterScoped,
+		CRDSourceFinalizer:             cfg.CRDSourceFinalizer,
+		CRDSourceHostnameJSONPath:      cfg.CRDSourceHostnameJSONPath,
+		CRDSourceTargetsJSONPath:       cfg.CRDSourceTargetsJSONPath,
+		CRDSourceTTLJSONPath:           cfg.CRDSourceTTLJSONPath,
 		KubeConfig:                     cfg.KubeConfig,
 		APIServerURL:                   cfg.APIServerURL,
 		ServiceTypeFilter:              cfg.ServiceTypeFilter,
@@ -142,14 +180,30 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		ForceDefaultTargets:            cfg.ForceDefaultTargets,
 		OCPRouterName:                  cfg.OCPRouterName,
 		UpdateEvents:                   cfg.UpdateEvents,
+		UpdateEventsSources:            cfg.UpdateEventsSources,
 		ResolveLoadBalancerHostname:    cfg.ResolveServiceLoadBalancerHostname,
 		TraefikEnableLegacy:            cfg.TraefikEnableLegacy,
 		TraefikDisableNew:              cfg.TraefikDisableNew,
+		F5VirtualServerAllowedHosts:    cfg.F5VirtualServerAllowedHosts,
+		F5VirtualServerDeniedHosts:     cfg.F5VirtualServerDeniedHosts,
+		F5RequireHealthyPoolMembers:    cfg.F5RequireHealthyPoolMembers,
 		ExcludeUnschedulable:           cfg.ExcludeUnschedulable,
 		ExposeInternalIPv6:             cfg.ExposeInternalIPV6,
+		CiliumLoadBalancerIPAM:         cfg.ServiceCiliumLoadBalancerIPAM,
+		KnativeGatewayService:          cfg.KnativeGatewayService,
+		CertManagerCertificateTargets:  cfg.CertManagerCertificateTargets,
+		HAProxyTCPServicesConfigMap:    cfg.HAProxyTCPServicesConfigMap,
+		HAProxyService:                 cfg.HAProxyService,
+		HostnameSourcePriority:         cfg.HostnameSourcePriority,
 	}
 }
 
+// UpdateEventsFor returns whether the changes of the named source trigger reconciliations:
+// when events are enabled, for all the sources unless they are limited to some of them.
+func (cfg *Config) UpdateEventsFor(name string) bool {
+	return cfg.UpdateEvents && (len(cfg.UpdateEventsSources) == 0 || slices.Contains(cfg.UpdateEventsSources, name))
+}
+
 // ClientGenerator provides clients for various Kubernetes APIs and external services.
 // This interface abstracts client creation and enables dependency injection for testing.
 // It uses the singleton pattern to ensure only one instance of each client is created
@@ -183,10 +237,12 @@ type ClientGenerator interface {
 // Memory Efficiency: Prevents creating multiple instances of expensive client objects
 // that maintain their own connection pools and caches.
 //
-// Configuration: Clients are configured using KubeConfig, APIServerURL, and RequestTimeout
-// which are set during SingletonClientGenerator initialization.
+// Configuration: Clients are configured using KubeConfig, KubeContext, APIServerURL, and RequestTimeout
+// which are set during SingletonClientGenerator initialization. An empty KubeContext uses the current
+// context of the KubeConfig.
 type SingletonClientGenerator struct {
 	KubeConfig      string
+	KubeContext     string
 	APIServerURL    string
 	RequestTimeout  time.Duration
 	kubeClient      kubernetes.Interface
@@ -207,7 +263,7 @@ type SingletonClientGenerator struct {
 func (p *SingletonClientGenerator) KubeClient() (kubernetes.Interface, error) {
 	var err error
 	p.kubeOnce.Do(func() {
-		p.kubeClient, err = NewKubeClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.kubeClient, err = NewKubeClient(p.KubeConfig, p.KubeContext, p.APIServerURL, p.RequestTimeout)
 	})
 	return p.kubeClient, err
 }
@@ -216,13 +272,13 @@ func (p *SingletonClientGenerator) KubeClient() (kubernetes.Interface, error) {
 func (p *SingletonClientGenerator) GatewayClient() (gateway.Interface, error) {
 	var err error
 	p.gatewayOnce.Do(func() {
-		p.gatewayClient, err = newGatewayClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.gatewayClient, err = newGatewayClient(p.KubeConfig, p.KubeContext, p.APIServerURL, p.RequestTimeout)
 	})
 	return p.gatewayClient, err
 }
 
-func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (gateway.Interface, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func newGatewayClient(kubeConfig, kubeContext, apiServerURL string, requestTimeout time.Duration) (gateway.Interface, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, kubeContext, apiServerURL, requestTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -238,7 +294,7 @@ func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Durat
 func (p *SingletonClientGenerator) IstioClient() (istioclient.Interface, error) {
 	var err error
 	p.istioOnce.Do(func() {
-		p.istioClient, err = NewIstioClient(p.KubeConfig, p.APIServerURL)
+		p.istioClient, err = NewIstioClient(p.KubeConfig, p.KubeContext, p.APIServerURL)
 	})
 	return p.istioClient, err
 }
@@ -271,7 +327,7 @@ func NewCFClient(cfAPIEndpoint string, cfUsername string, cfPassword string) (*c
 func (p *SingletonClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
 	var err error
 	p.dynCliOnce.Do(func() {
-		p.dynKubeClient, err = NewDynamicKubernetesClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.dynKubeClient, err = NewDynamicKubernetesClient(p.KubeConfig, p.KubeContext, p.APIServerURL, p.RequestTimeout)
 	})
 	return p.dynKubeClient, err
 }
@@ -280,7 +336,7 @@ func (p *SingletonClientGenerator) DynamicKubernetesClient() (dynamic.Interface,
 func (p *SingletonClientGenerator) OpenShiftClient() (openshift.Interface, error) {
 	var err error
 	p.openshiftOnce.Do(func() {
-		p.openshiftClient, err = NewOpenShiftClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.openshiftClient, err = NewOpenShiftClient(p.KubeConfig, p.KubeContext, p.APIServerURL, p.RequestTimeout)
 	})
 	return p.openshiftClient, err
 }
@@ -325,8 +381,12 @@ func ByNames(ctx context.Context, p ClientGenerator, names []string, cfg *Config
 // - "skipper-routegroup": Skipper RouteGroup resources
 // - "kong-tcpingress": Kong TCP Ingress resources
 // - "f5-*": F5 resources (virtualserver, transportserver)
+// - "knative-*": Knative resources (domainmapping, route)
+// - "cert-manager-certificate": cert-manager Certificate resources
+// - "haproxy-tcp-services": TCP services of the HAProxy Ingress Controller read from its ConfigMap
 // - "fake": Fake source for testing
 // - "connector": Connector source for external systems
+// - "configmap": Static map of hostnames to targets read from ConfigMaps
 //
 // Design Note: Gateway API sources use a different pattern (direct constructor calls)
 // because they have simpler initialization requirements.
@@ -370,8 +430,12 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		return NewFakeSource(cfg.FQDNTemplate)
 	case "connector":
 		return NewConnectorSource(cfg.ConnectorServer)
+	case "configmap":
+		return buildConfigMapSource(ctx, p, cfg)
 	case "crd":
 		return buildCRDSource(ctx, p, cfg)
+	case "crd-jsonpath":
+		return buildCRDJSONPathSource(ctx, p, cfg)
 	case "skipper-routegroup":
 		return buildSkipperRouteGroupSource(ctx, cfg)
 	case "kong-tcpingress":
@@ -380,6 +444,14 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		return buildF5VirtualServerSource(ctx, p, cfg)
 	case "f5-transportserver":
 		return buildF5TransportServerSource(ctx, p, cfg)
+	case "knative-domainmapping":
+		return buildKnativeSource(ctx, p, cfg, NewKnativeDomainMappingSource)
+	case "knative-route":
+		return buildKnativeSource(ctx, p, cfg, NewKnativeRouteSource)
+	case "cert-manager-certificate":
+		return buildCertManagerCertificateSource(ctx, p, cfg)
+	case "haproxy-tcp-services":
+		return buildHAProxyTCPServicesSource(ctx, p, cfg)
 	}
 	return nil, ErrSourceNotFound
 }
@@ -429,7 +501,7 @@ func buildServiceSource(ctx context.Context, p ClientGenerator, cfg *Config) (So
 	if err != nil {
 		return nil, err
 	}
-	return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6)
+	return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6, cfg.CiliumLoadBalancerIPAM, cfg.HostnameSourcePriority, cfg.PublishHeadlessSRV, cfg.ServiceAggregateHostname)
 }
 
 // buildIngressSource creates an Ingress source for exposing Kubernetes ingresses as DNS records.
@@ -439,7 +511,7 @@ func buildIngressSource(ctx context.Context, p ClientGenerator, cfg *Config) (So
 	if err != nil {
 		return nil, err
 	}
-	return NewIngressSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames)
+	return NewIngressSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames, cfg.IngressClassServices, cfg.HostnameSourcePriority, cfg.IngressClassTargets)
 }
 
 // buildPodSource creates a Pod source for exposing Kubernetes pods as DNS records.
@@ -553,11 +625,26 @@ func buildCRDSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source
 	if err != nil {
 		return nil, err
 	}
-	crdClient, scheme, err := NewCRDClientForAPIVersionKind(client, cfg.KubeConfig, cfg.APIServerURL, cfg.CRDSourceAPIVersion, cfg.CRDSourceKind)
+	crdClient, scheme, err := NewCRDClientForAPIVersionKind(client, cfg.KubeConfig, cfg.KubeContext, cfg.APIServerURL, cfg.CRDSourceAPIVersion, cfg.CRDSourceKind)
 	if err != nil {
 		return nil, err
 	}
-	return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents)
+	return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEventsFor("crd"), cfg.CRDSourceClusterScoped, cfg.CRDSourceFinalizer)
+}
+
+// buildCRDJSONPathSource creates a source for exposing arbitrary custom resources as DNS records.
+// Reads the custom resources of --crd-source-apiversion and --crd-source-kind using the JSONPath expressions.
+func buildCRDJSONPathSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
+	kubernetesClient, err := p.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := p.DynamicKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewCRDJSONPathSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.CRDSourceAPIVersion, cfg.CRDSourceKind,
+		cfg.CRDSourceHostnameJSONPath, cfg.CRDSourceTargetsJSONPath, cfg.CRDSourceTTLJSONPath, cfg.AnnotationFilter, cfg.LabelFilter)
 }
 
 // buildSkipperRouteGroupSource creates a Skipper RouteGroup source for exposing route groups as DNS records.
@@ -567,7 +654,7 @@ func buildSkipperRouteGroupSource(ctx context.Context, cfg *Config) (Source, err
 	apiServerURL := cfg.APIServerURL
 	tokenPath := ""
 	token := ""
-	restConfig, err := GetRestConfig(cfg.KubeConfig, cfg.APIServerURL)
+	restConfig, err := GetRestConfig(cfg.KubeConfig, cfg.KubeContext, cfg.APIServerURL)
 	if err == nil {
 		apiServerURL = restConfig.Host
 		tokenPath = restConfig.BearerTokenFile
@@ -597,7 +684,7 @@ func buildF5VirtualServerSource(ctx context.Context, p ClientGenerator, cfg *Con
 	if err != nil {
 		return nil, err
 	}
-	return NewF5VirtualServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter)
+	return NewF5VirtualServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.F5VirtualServerAllowedHosts, cfg.F5VirtualServerDeniedHosts, cfg.F5RequireHealthyPoolMembers, cfg.HostnameSourcePriority)
 }
 
 func buildF5TransportServerSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
@@ -612,6 +699,51 @@ func buildF5TransportServerSource(ctx context.Context, p ClientGenerator, cfg *C
 	return NewF5TransportServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter)
 }
 
+// buildKnativeSource creates a Knative DomainMapping or Route source with the given constructor.
+// Requires both dynamic and standard Kubernetes clients, the latter for the gateway service.
+func buildKnativeSource(ctx context.Context, p ClientGenerator, cfg *Config,
+	newSource func(context.Context, dynamic.Interface, kubernetes.Interface, string, string, labels.Selector, string, []string) (Source, error)) (Source, error) {
+	kubernetesClient, err := p.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := p.DynamicKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+	return newSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.KnativeGatewayService, cfg.HostnameSourcePriority)
+}
+
+// buildCertManagerCertificateSource creates a cert-manager Certificate source for exposing the DNS names of the certificates.
+// Requires the dynamic Kubernetes client only.
+func buildCertManagerCertificateSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
+	dynamicClient, err := p.DynamicKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewCertManagerCertificateSource(ctx, dynamicClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.CertManagerCertificateTargets)
+}
+
+// buildHAProxyTCPServicesSource creates an HAProxy TCP services source for exposing the TCP services of the HAProxy Ingress Controller.
+// Deviates from standard pattern: the ConfigMap is referenced by namespace and name, no filters apply.
+func buildHAProxyTCPServicesSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
+	client, err := p.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewHAProxyTCPServicesSource(ctx, client, cfg.HAProxyTCPServicesConfigMap, cfg.HAProxyService)
+}
+
+// buildConfigMapSource creates a ConfigMap source for exposing static maps of hostnames to targets as DNS records.
+// Deviates from standard pattern: the ConfigMaps are referenced by namespace and name, no filters apply.
+func buildConfigMapSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
+	client, err := p.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewConfigMapSource(ctx, client, cfg.ConfigMapNames)
+}
+
 // instrumentedRESTConfig creates a REST config with request instrumentation for monitoring.
 // Adds HTTP transport wrapper for Prometheus metrics collection and request timeout configuration.
 //
@@ -619,8 +751,8 @@ func buildF5TransportServerSource(ctx context.Context, p ClientGenerator, cfg *C
 // reducing cardinality of metric labels for better performance.
 //
 // Timeout: Applies the specified request timeout to prevent hanging requests.
-func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*rest.Config, error) {
-	config, err := GetRestConfig(kubeConfig, apiServerURL)
+func instrumentedRESTConfig(kubeConfig, kubeContext, apiServerURL string, requestTimeout time.Duration) (*rest.Config, error) {
+	config, err := GetRestConfig(kubeConfig, kubeContext, apiServerURL)
 	if err != nil {
 		return nil, err
 	}
@@ -639,11 +771,11 @@ func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time
 // Configuration Priority:
 // 1. If kubeConfig is empty, tries the recommended home file (~/.kube/config)
 // 2. If kubeConfig is still empty, uses in-cluster service account
-// 3. Otherwise, uses the specified kubeConfig file
+// 3. Otherwise, uses the specified kubeConfig file, with the kubeContext instead of its current context if set
 //
 // API Server Override: The apiServerURL parameter can override the server URL
 // from the kubeconfig file, useful for proxy scenarios or custom endpoints.
-func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
+func GetRestConfig(kubeConfig, kubeContext, apiServerURL string) (*rest.Config, error) {
 	if kubeConfig == "" {
 		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
 			kubeConfig = clientcmd.RecommendedHomeFile
@@ -651,6 +783,7 @@ func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
 	}
 	log.Debugf("apiServerURL: %s", apiServerURL)
 	log.Debugf("kubeConfig: %s", kubeConfig)
+	log.Debugf("kubeContext: %s", kubeContext)
 
 	// evaluate whether to use kubeConfig-file or serviceaccount-token
 	var (
@@ -658,11 +791,14 @@ func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
 		err    error
 	)
 	if kubeConfig == "" {
+		if kubeContext != "" {
+			return nil, fmt.Errorf("no kubeConfig found for the context %q", kubeContext)
+		}
 		log.Infof("Using inCluster-config based on serviceaccount-token")
 		config, err = rest.InClusterConfig()
 	} else {
 		log.Infof("Using kubeConfig")
-		config, err = clientcmd.BuildConfigFromFlags(apiServerURL, kubeConfig)
+		config, err = buildConfigFromFlags(apiServerURL, kubeConfig, kubeContext)
 	}
 	if err != nil {
 		return nil, err
@@ -671,12 +807,24 @@ func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
 	return config, nil
 }
 
+// buildConfigFromFlags builds the REST client configuration from the kubeConfig file as
+// clientcmd.BuildConfigFromFlags does, using the kubeContext instead of the current context if set.
+func buildConfigFromFlags(apiServerURL, kubeConfig, kubeContext string) (*rest.Config, error) {
+	if kubeContext == "" {
+		return clientcmd.BuildConfigFromFlags(apiServerURL, kubeConfig)
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeConfig},
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext, ClusterInfo: clientcmdapi.Cluster{Server: apiServerURL}},
+	).ClientConfig()
+}
+
 // NewKubeClient returns a new Kubernetes client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*kubernetes.Clientset, error) {
+func NewKubeClient(kubeConfig, kubeContext, apiServerURL string, requestTimeout time.Duration) (*kubernetes.Clientset, error) {
 	log.Infof("Instantiating new Kubernetes client")
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+	config, err := instrumentedRESTConfig(kubeConfig, kubeContext, apiServerURL, requestTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -696,14 +844,14 @@ func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration
 // wrappers) to the client's config at this level. Furthermore, the Istio client
 // constructor does not expose the ability to override the Kubernetes API server endpoint,
 // so the apiServerURL config attribute has no effect.
-func NewIstioClient(kubeConfig string, apiServerURL string) (*istioclient.Clientset, error) {
+func NewIstioClient(kubeConfig, kubeContext, apiServerURL string) (*istioclient.Clientset, error) {
 	if kubeConfig == "" {
 		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
 			kubeConfig = clientcmd.RecommendedHomeFile
 		}
 	}
 
-	restCfg, err := clientcmd.BuildConfigFromFlags(apiServerURL, kubeConfig)
+	restCfg, err := buildConfigFromFlags(apiServerURL, kubeConfig, kubeContext)
 	if err != nil {
 		return nil, err
 	}
@@ -719,8 +867,8 @@ func NewIstioClient(kubeConfig string, apiServerURL string) (*istioclient.Client
 // NewDynamicKubernetesClient returns a new Dynamic Kubernetes client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (dynamic.Interface, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func NewDynamicKubernetesClient(kubeConfig, kubeContext, apiServerURL string, requestTimeout time.Duration) (dynamic.Interface, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, kubeContext, apiServerURL, requestTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -735,8 +883,8 @@ func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout
 // NewOpenShiftClient returns a new Openshift client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewOpenShiftClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*openshift.Clientset, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func NewOpenShiftClient(kubeConfig, kubeContext, apiServerURL string, requestTimeout time.Duration) (*openshift.Clientset, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, kubeContext, apiServerURL, requestTimeout)
 	if err != nil {
 		return nil, err
 	}
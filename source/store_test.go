@@ -19,11 +19,15 @@ package source
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
 	openshift "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	istioclient "istio.io/client-go/pkg/clientset/versioned"
 	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
@@ -199,7 +203,8 @@ func (suite *ByNamesTestSuite) TestKubeClientFails() {
 	sourcesDependentOnKubeClient := []string{
 		"node", "service", "ingress", "pod", "istio-gateway", "istio-virtualservice",
 		"ambassador-host", "gloo-proxy", "traefik-proxy", "crd", "kong-tcpingress",
-		"f5-virtualserver", "f5-transportserver",
+		"f5-virtualserver", "f5-transportserver", "crd-jsonpath", "knative-domainmapping", "knative-route",
+		"haproxy-tcp-services",
 	}
 
 	for _, source := range sourcesDependentOnKubeClient {
@@ -229,7 +234,8 @@ func (suite *ByNamesTestSuite) TestDynamicKubernetesClientFails() {
 	mockClientGenerator.On("DynamicKubernetesClient").Return(nil, errors.New("foo"))
 
 	sourcesDependentOnDynamicKubernetesClient := []string{"ambassador-host", "contour-httpproxy", "gloo-proxy", "traefik-proxy",
-		"kong-tcpingress", "f5-virtualserver", "f5-transportserver"}
+		"kong-tcpingress", "f5-virtualserver", "f5-transportserver", "crd-jsonpath", "knative-domainmapping", "knative-route",
+		"cert-manager-certificate"}
 
 	for _, source := range sourcesDependentOnDynamicKubernetesClient {
 		_, err := ByNames(context.TODO(), mockClientGenerator, []string{source}, &Config{})
@@ -273,3 +279,73 @@ func TestBuildWithConfig_InvalidSource(t *testing.T) {
 		t.Errorf("expected ErrSourceNotFound, got: %v", err)
 	}
 }
+
+func TestConfigUpdateEventsFor(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		cfg      *Config
+		expected map[string]bool
+	}{
+		{
+			title:    "events disabled",
+			cfg:      &Config{UpdateEventsSources: []string{"service"}},
+			expected: map[string]bool{"service": false, "node": false},
+		},
+		{
+			title:    "events enabled for all the sources",
+			cfg:      &Config{UpdateEvents: true},
+			expected: map[string]bool{"service": true, "node": true},
+		},
+		{
+			title:    "events limited to some sources",
+			cfg:      &Config{UpdateEvents: true, UpdateEventsSources: []string{"service", "crd"}},
+			expected: map[string]bool{"service": true, "crd": true, "node": false},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			for name, expected := range tc.expected {
+				if got := tc.cfg.UpdateEventsFor(name); got != expected {
+					t.Errorf("UpdateEventsFor(%q) = %v, expected %v", name, got, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestGetRestConfigWithContext(t *testing.T) {
+	kubeConfig := filepath.Join(t.TempDir(), "kubeconfig")
+	err := os.WriteFile(kubeConfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: main
+  cluster:
+    server: https://main.example.org
+- name: other
+  cluster:
+    server: https://other.example.org
+contexts:
+- name: main
+  context:
+    cluster: main
+- name: other
+  context:
+    cluster: other
+current-context: main
+`), 0o600)
+	require.NoError(t, err)
+
+	config, err := GetRestConfig(kubeConfig, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://main.example.org", config.Host)
+
+	config, err = GetRestConfig(kubeConfig, "other", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://other.example.org", config.Host)
+
+	config, err = GetRestConfig(kubeConfig, "other", "https://override.example.org")
+	require.NoError(t, err)
+	assert.Equal(t, "https://override.example.org", config.Host)
+
+	_, err = GetRestConfig(kubeConfig, "unknown", "")
+	require.Error(t, err)
+}
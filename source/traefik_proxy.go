@@ -284,6 +284,7 @@ func (ts *traefikSource) ingressRouteTCPEndpoints() ([]*endpoint.Endpoint, error
 		fullname := fmt.Sprintf("%s/%s", ingressRouteTCP.Namespace, ingressRouteTCP.Name)
 
 		ingressEndpoints := ts.endpointsFromIngressRouteTCP(ingressRouteTCP, targets)
+		ingressEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(ingressEndpoints, ingressRouteTCP.Annotations), ingressRouteTCP.Annotations)
 		if len(ingressEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from Host %s", fullname)
 			continue
@@ -887,6 +888,7 @@ func extractEndpoints[T any](
 
 		name := getObjectFullName(item)
 		ingressEndpoints := generateEndpoints(item, targets)
+		ingressEndpoints = applyObjectAnnotations(endpointsWithHostnameAliases(ingressEndpoints, getAnnotations(item)), getAnnotations(item))
 
 		if len(ingressEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from Host %s", name)
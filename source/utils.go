@@ -68,3 +68,28 @@ func MatchesServiceSelector(selector, svcSelector map[string]string) bool {
 	}
 	return true
 }
+
+// The origins of the hostnames of the sources supporting several of them, ordered by the hostname source priority.
+const (
+	HostnameOriginAnnotation = "annotation"
+	HostnameOriginSpec       = "spec"
+	HostnameOriginStatus     = "status"
+)
+
+// byHostnamePriority returns the values of the first origin of the priority having any.
+// The origins missing from the priority are never used. It returns false when the source has
+// none of the origins of the priority, in which case the source keeps its default hostnames.
+func byHostnamePriority[T any](priority []string, origins map[string][]T) ([]T, bool) {
+	known := false
+	for _, origin := range priority {
+		values, ok := origins[origin]
+		if !ok {
+			continue
+		}
+		if len(values) > 0 {
+			return values, true
+		}
+		known = true
+	}
+	return nil, known
+}
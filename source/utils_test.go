@@ -149,3 +149,29 @@ func TestSelectorMatchesService(t *testing.T) {
 		})
 	}
 }
+
+func TestByHostnamePriority(t *testing.T) {
+	origins := map[string][]string{
+		HostnameOriginAnnotation: {"annotation.example.org"},
+		HostnameOriginSpec:       {"spec.example.org"},
+	}
+
+	for _, tc := range []struct {
+		priority []string
+		expected []string
+		known    bool
+	}{
+		{[]string{HostnameOriginAnnotation, HostnameOriginSpec}, []string{"annotation.example.org"}, true},
+		{[]string{HostnameOriginSpec, HostnameOriginAnnotation}, []string{"spec.example.org"}, true},
+		{[]string{HostnameOriginStatus, HostnameOriginSpec}, []string{"spec.example.org"}, true},
+		{[]string{HostnameOriginStatus}, nil, false},
+	} {
+		values, known := byHostnamePriority(tc.priority, origins)
+		assert.Equal(t, tc.expected, values)
+		assert.Equal(t, tc.known, known)
+	}
+
+	values, known := byHostnamePriority([]string{HostnameOriginSpec}, map[string][]string{HostnameOriginSpec: nil})
+	assert.Empty(t, values)
+	assert.True(t, known)
+}
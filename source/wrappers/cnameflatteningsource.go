@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// HostResolver looks up the addresses of a hostname, as net.Resolver does.
+type HostResolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// cnameFlatteningSource is a Source that replaces the CNAME endpoints with multiple targets,
+// which are invalid DNS, by A and AAAA endpoints with the addresses of all the targets.
+type cnameFlatteningSource struct {
+	source   source.Source
+	resolver HostResolver
+
+	// lastAddrs holds the last addresses resolved for each hostname, used when a lookup fails.
+	lastAddrs map[string][]netip.Addr
+	mutex     sync.Mutex
+}
+
+// NewCNAMEFlatteningSource creates a new cnameFlatteningSource wrapping the provided Source.
+func NewCNAMEFlatteningSource(source source.Source, resolver HostResolver) source.Source {
+	return &cnameFlatteningSource{source: source, resolver: resolver, lastAddrs: map[string][]netip.Addr{}}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them with the multi-target CNAME
+// endpoints flattened. The targets are resolved on every call, so the addresses follow the targets.
+func (s *cnameFlatteningSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil && !source.IsInvalidObjectsError(err) {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME || len(ep.Targets) < 2 {
+			result = append(result, ep)
+			continue
+		}
+
+		var v4Targets, v6Targets endpoint.Targets
+		for _, target := range ep.Targets {
+			for _, addr := range s.lookup(ctx, target) {
+				if addr.Is4() {
+					v4Targets = append(v4Targets, addr.String())
+				} else {
+					v6Targets = append(v6Targets, addr.String())
+				}
+			}
+		}
+
+		if len(v4Targets) == 0 && len(v6Targets) == 0 {
+			log.WithField("endpoint", ep).Warn("Skipping endpoint because none of its CNAME targets could be resolved")
+			continue
+		}
+
+		result = append(result, flattenedEndpoint(ep, endpoint.RecordTypeA, v4Targets)...)
+		result = append(result, flattenedEndpoint(ep, endpoint.RecordTypeAAAA, v6Targets)...)
+	}
+
+	return result, err
+}
+
+// flattenedEndpoint returns a copy of the CNAME endpoint with the record type and the targets,
+// or nothing without targets.
+func flattenedEndpoint(ep *endpoint.Endpoint, recordType string, targets endpoint.Targets) []*endpoint.Endpoint {
+	if len(targets) == 0 {
+		return nil
+	}
+	flattened := ep.DeepCopy()
+	flattened.RecordType = recordType
+	flattened.Targets = targets
+	flattened.UniqueOrderedTargets()
+	return []*endpoint.Endpoint{flattened}
+}
+
+// lookup resolves the hostname, falling back to the last resolved addresses when the lookup
+// fails, so that a temporary resolution failure doesn't remove the records.
+func (s *cnameFlatteningSource) lookup(ctx context.Context, host string) []netip.Addr {
+	addrs, err := s.resolver.LookupNetIP(ctx, "ip", host)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err != nil {
+		log.Warnf("Failed to resolve CNAME target %s, using its last known addresses: %v", host, err)
+		return s.lastAddrs[host]
+	}
+	for i := range addrs {
+		addrs[i] = addrs[i].Unmap()
+	}
+	s.lastAddrs[host] = addrs
+	return addrs
+}
+
+func (s *cnameFlatteningSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// mockHostResolver resolves the hostnames from a static map, failing for unknown hostnames.
+type mockHostResolver map[string][]string
+
+func (r mockHostResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	addrs := make([]netip.Addr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, netip.MustParseAddr(ip))
+	}
+	return addrs, nil
+}
+
+func TestCNAMEFlatteningSource(t *testing.T) {
+	resolver := mockHostResolver{
+		"lb1.example.com": {"192.0.2.1", "192.0.2.2", "2001:db8::1"},
+		"lb2.example.com": {"192.0.2.3", "192.0.2.1", "::ffff:192.0.2.4"},
+	}
+
+	for _, tc := range []struct {
+		title     string
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			title: "two hostname targets",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeCNAME, 300, "lb1.example.com", "lb2.example.com").
+					WithSetIdentifier("eu").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4").
+					WithSetIdentifier("eu").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeAAAA, 300, "2001:db8::1").
+					WithSetIdentifier("eu").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+			},
+		},
+		{
+			title: "other endpoints are kept",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("single.example.org", endpoint.RecordTypeCNAME, "lb1.example.com"),
+				endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "192.0.2.10", "192.0.2.11"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("single.example.org", endpoint.RecordTypeCNAME, "lb1.example.com"),
+				endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "192.0.2.10", "192.0.2.11"),
+			},
+		},
+		{
+			title: "unresolvable targets are skipped",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("partial.example.org", endpoint.RecordTypeCNAME, "lb2.example.com", "unknown.example.com"),
+				endpoint.NewEndpoint("none.example.org", endpoint.RecordTypeCNAME, "unknown.example.com", "other.example.com"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("partial.example.org", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.3", "192.0.2.4"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			src := NewCNAMEFlatteningSource(NewEchoSource(tc.endpoints), resolver)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, endpoints)
+		})
+	}
+}
+
+func TestCNAMEFlatteningSourceRefresh(t *testing.T) {
+	resolver := mockHostResolver{
+		"lb1.example.com": {"192.0.2.1"},
+		"lb2.example.com": {"192.0.2.2"},
+	}
+	src := NewCNAMEFlatteningSource(NewEchoSource([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeCNAME, "lb1.example.com", "lb2.example.com"),
+	}), resolver)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1", "192.0.2.2"}, endpoints[0].Targets)
+
+	// The addresses are resolved again on every call.
+	resolver["lb2.example.com"] = []string{"192.0.2.3"}
+	endpoints, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1", "192.0.2.3"}, endpoints[0].Targets)
+
+	// The last known addresses are used when the lookup fails.
+	delete(resolver, "lb1.example.com")
+	endpoints, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1", "192.0.2.3"}, endpoints[0].Targets)
+}
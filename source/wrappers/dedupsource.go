@@ -18,6 +18,7 @@ package wrappers
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -38,12 +39,15 @@ func NewDedupSource(source source.Source) source.Source {
 }
 
 // Endpoints collects endpoints from its wrapped source and returns them without duplicates.
+// Endpoints with the same targets in a different order are duplicates too. The labels and the
+// provider-specific properties of the duplicates missing from the first endpoint are merged into it.
 func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	result := []*endpoint.Endpoint{}
-	collected := map[string]bool{}
+	collected := map[string]int{}
+	merged := map[int]bool{}
 
 	endpoints, err := ms.source.Endpoints(ctx)
-	if err != nil {
+	if err != nil && !source.IsInvalidObjectsError(err) {
 		return nil, err
 	}
 
@@ -52,18 +56,45 @@ func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
-		identifier := strings.Join([]string{ep.RecordType, ep.DNSName, ep.SetIdentifier, ep.Targets.String()}, "/")
+		targets := make([]string, len(ep.Targets))
+		copy(targets, ep.Targets)
+		sort.Strings(targets)
+		identifier := strings.Join([]string{ep.RecordType, ep.DNSName, ep.SetIdentifier, strings.Join(targets, ";")}, "/")
 
-		if _, ok := collected[identifier]; ok {
+		if i, ok := collected[identifier]; ok {
 			log.Debugf("Removing duplicate endpoint %s", ep)
+			if !merged[i] {
+				// Merge into a copy to leave the endpoints of the wrapped source untouched.
+				result[i] = result[i].DeepCopy()
+				merged[i] = true
+			}
+			mergeDuplicateEndpoint(result[i], ep)
 			continue
 		}
 
-		collected[identifier] = true
+		collected[identifier] = len(result)
 		result = append(result, ep)
 	}
 
-	return result, nil
+	return result, err
+}
+
+// mergeDuplicateEndpoint adds the labels and the provider-specific properties of the duplicate
+// which are missing from the endpoint.
+func mergeDuplicateEndpoint(ep, duplicate *endpoint.Endpoint) {
+	for key, value := range duplicate.Labels {
+		if _, ok := ep.Labels[key]; !ok {
+			if ep.Labels == nil {
+				ep.Labels = endpoint.NewLabels()
+			}
+			ep.Labels[key] = value
+		}
+	}
+	for _, property := range duplicate.ProviderSpecific {
+		if _, ok := ep.GetProviderSpecificProperty(property.Name); !ok {
+			ep.WithProviderSpecific(property.Name, property.Value)
+		}
+	}
 }
 
 func (ms *dedupSource) AddEventHandler(ctx context.Context, handler func()) {
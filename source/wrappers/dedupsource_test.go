@@ -18,8 +18,11 @@ package wrappers
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/source"
@@ -123,6 +126,47 @@ func testDedupEndpoints(t *testing.T) {
 				{DNSName: "foo.example.org", Targets: endpoint.Targets{"1.2.3.4"}},
 			},
 		},
+		{
+			"two endpoints with same dnsname and same targets in a different order return one endpoint",
+			[]*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: "A", Targets: endpoint.Targets{"1.2.3.4", "4.5.6.7"}},
+				{DNSName: "foo.example.org", RecordType: "A", Targets: endpoint.Targets{"4.5.6.7", "1.2.3.4"}},
+			},
+			[]*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: "A", Targets: endpoint.Targets{"1.2.3.4", "4.5.6.7"}},
+			},
+		},
+		{
+			"two endpoints with same dnsname, same target and different set identifiers return two endpoints",
+			[]*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: "A", SetIdentifier: "a", Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "foo.example.org", RecordType: "A", SetIdentifier: "b", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+			[]*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: "A", SetIdentifier: "a", Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "foo.example.org", RecordType: "A", SetIdentifier: "b", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			"labels and provider-specific properties of duplicates are merged",
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/foo").
+					WithProviderSpecific("alias", "false"),
+				endpoint.NewEndpoint("foo.example.org", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "ingress/default/foo").
+					WithLabel("extra", "value").
+					WithProviderSpecific("alias", "true").
+					WithProviderSpecific("aws/weight", "10"),
+			},
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/foo").
+					WithLabel("extra", "value").
+					WithProviderSpecific("alias", "false").
+					WithProviderSpecific("aws/weight", "10"),
+			},
+		},
 	} {
 		t.Run(tc.title, func(t *testing.T) {
 			mockSource := new(testutils.MockSource)
@@ -144,3 +188,39 @@ func testDedupEndpoints(t *testing.T) {
 		})
 	}
 }
+
+// TestDedupEndpointsMergeCopy tests that merging duplicates leaves the endpoints of the wrapped source untouched.
+func TestDedupEndpointsMergeCopy(t *testing.T) {
+	first := endpoint.NewEndpoint("foo.example.org", "A", "1.2.3.4")
+	second := endpoint.NewEndpoint("foo.example.org", "A", "1.2.3.4").WithLabel("extra", "value")
+
+	endpoints, err := NewDedupSource(NewEchoSource([]*endpoint.Endpoint{first, second})).Endpoints(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", "A", "1.2.3.4").WithLabel("extra", "value"),
+	})
+	if _, ok := first.Labels["extra"]; ok {
+		t.Errorf("the endpoint of the wrapped source was modified: %s", first)
+	}
+}
+
+func TestDedupEndpointsWithInvalidObjects(t *testing.T) {
+	invalid := &source.InvalidObjectsError{Errors: []*source.InvalidObjectError{
+		{Resource: "crd/default/foo", Err: errors.New("illegal target")},
+	}}
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "foo.example.org", Targets: endpoint.Targets{"1.2.3.4"}},
+		{DNSName: "foo.example.org", Targets: endpoint.Targets{"1.2.3.4"}},
+	}, invalid)
+
+	// the endpoints of the valid objects are deduplicated and returned with the invalid objects
+	endpoints, err := NewDedupSource(mockSource).Endpoints(context.Background())
+	require.ErrorIs(t, err, invalid)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "foo.example.org", Targets: endpoint.Targets{"1.2.3.4"}},
+	})
+}
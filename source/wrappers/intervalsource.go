@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// intervalSource is a Source that polls its wrapped source at most once per interval,
+// returning the endpoints of the last poll in between. The events of the wrapped source
+// make the next reconciliation poll it again.
+type intervalSource struct {
+	name     string
+	source   source.Source
+	interval time.Duration
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	polledAt  time.Time
+	endpoints []*endpoint.Endpoint
+	err       error
+}
+
+// NewIntervalSource creates a new intervalSource polling the provided Source of the given name every interval.
+func NewIntervalSource(name string, source source.Source, interval time.Duration) source.Source {
+	return &intervalSource{name: name, source: source, interval: interval, now: time.Now}
+}
+
+// Endpoints returns the endpoints of its wrapped source, polled again once the interval elapsed.
+func (s *intervalSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.polledAt.IsZero() || s.now().Sub(s.polledAt) >= s.interval {
+		endpoints, err := s.source.Endpoints(ctx)
+		if err != nil && !source.IsInvalidObjectsError(err) {
+			return nil, err
+		}
+		s.polledAt, s.endpoints, s.err = s.now(), endpoints, err
+	} else {
+		log.Debugf("Reusing the endpoints of source %s polled at %s, it is polled every %s", s.name, s.polledAt.Format(time.RFC3339), s.interval)
+	}
+
+	// the endpoints are copied, as the other wrappers may modify them
+	endpoints := make([]*endpoint.Endpoint, 0, len(s.endpoints))
+	for _, ep := range s.endpoints {
+		endpoints = append(endpoints, ep.DeepCopy())
+	}
+	return endpoints, s.err
+}
+
+// AddEventHandler registers the handler on the wrapped source, polling it again on its events.
+func (s *intervalSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, func() {
+		s.mutex.Lock()
+		s.polledAt = time.Time{}
+		s.mutex.Unlock()
+		handler()
+	})
+}
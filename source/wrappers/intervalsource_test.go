@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// pollingSource is a Source counting its polls and keeping its event handler.
+type pollingSource struct {
+	endpoints []*endpoint.Endpoint
+	err       error
+	polls     int
+	handler   func()
+}
+
+func (s *pollingSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	s.polls++
+	return s.endpoints, s.err
+}
+
+func (s *pollingSource) AddEventHandler(_ context.Context, handler func()) {
+	s.handler = handler
+}
+
+func TestIntervalSource(t *testing.T) {
+	nodes := &pollingSource{endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("node.example.org", endpoint.RecordTypeA, "10.0.0.1")}}
+	ingresses := &pollingSource{endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("ingress.example.org", endpoint.RecordTypeA, "10.0.0.2")}}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nodesSource := NewIntervalSource("node", nodes, 10*time.Minute)
+	nodesSource.(*intervalSource).now = func() time.Time { return now }
+	src := NewMultiSource([]source.Source{nodesSource, ingresses}, nil, false)
+
+	// the reconciliations run every minute, the node source is only polled every 10 minutes
+	for range 25 {
+		endpoints, err := src.Endpoints(t.Context())
+		require.NoError(t, err)
+		assert.Len(t, endpoints, 2)
+		now = now.Add(time.Minute)
+	}
+	assert.Equal(t, 3, nodes.polls)
+	assert.Equal(t, 25, ingresses.polls)
+
+	// the endpoints returned between two polls are copies of the polled endpoints
+	endpoints, err := nodesSource.Endpoints(t.Context())
+	require.NoError(t, err)
+	endpoints[0].Targets = endpoint.Targets{"10.0.0.10"}
+	assert.Equal(t, endpoint.Targets{"10.0.0.1"}, nodes.endpoints[0].Targets)
+
+	// an event of the source polls it again
+	src.AddEventHandler(t.Context(), func() {})
+	nodes.handler()
+	_, err = nodesSource.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 4, nodes.polls)
+}
+
+func TestIntervalSourceErrors(t *testing.T) {
+	nodes := &pollingSource{err: errors.New("connection refused")}
+	src := NewIntervalSource("node", nodes, 10*time.Minute)
+
+	// the failed polls are retried by the next reconciliation
+	_, err := src.Endpoints(t.Context())
+	require.Error(t, err)
+	_, err = src.Endpoints(t.Context())
+	require.Error(t, err)
+	assert.Equal(t, 2, nodes.polls)
+
+	// the endpoints of the valid objects are reused along with the invalid objects
+	nodes.endpoints = []*endpoint.Endpoint{endpoint.NewEndpoint("node.example.org", endpoint.RecordTypeA, "10.0.0.1")}
+	nodes.err = &source.InvalidObjectsError{Errors: []*source.InvalidObjectError{
+		{Resource: "node/invalid", Err: errors.New("illegal target")},
+	}}
+	for range 2 {
+		endpoints, err := src.Endpoints(t.Context())
+		require.ErrorIs(t, err, nodes.err)
+		assert.Len(t, endpoints, 1)
+	}
+	assert.Equal(t, 3, nodes.polls)
+}
@@ -38,11 +38,16 @@ func (ms *multiSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 	result := []*endpoint.Endpoint{}
 	hasDefaultTargets := len(ms.defaultTargets) > 0
 
+	// the invalid objects of the children are aggregated, their valid endpoints are still returned
+	var invalid []error
 	for _, s := range ms.children {
 		endpoints, err := s.Endpoints(ctx)
-		if err != nil {
+		if err != nil && !source.IsInvalidObjectsError(err) {
 			return nil, err
 		}
+		if err != nil {
+			invalid = append(invalid, err)
+		}
 
 		if !hasDefaultTargets {
 			result = append(result, endpoints...)
@@ -66,7 +71,7 @@ func (ms *multiSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 		}
 	}
 
-	return result, nil
+	return result, source.JoinInvalidObjectsErrors(invalid...)
 }
 
 func (ms *multiSource) AddEventHandler(ctx context.Context, handler func()) {
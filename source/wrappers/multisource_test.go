@@ -35,6 +35,7 @@ func TestMultiSource(t *testing.T) {
 	t.Run("Interface", testMultiSourceImplementsSource)
 	t.Run("Endpoints", testMultiSourceEndpoints)
 	t.Run("EndpointsWithError", testMultiSourceEndpointsWithError)
+	t.Run("EndpointsWithInvalidObjects", testMultiSourceEndpointsWithInvalidObjects)
 	t.Run("EndpointsDefaultTargets", testMultiSourceEndpointsDefaultTargets)
 }
 
@@ -127,6 +128,35 @@ func testMultiSourceEndpointsWithError(t *testing.T) {
 	src.AssertExpectations(t)
 }
 
+// testMultiSourceEndpointsWithInvalidObjects tests that the invalid objects of the nested sources are aggregated
+// while their valid endpoints are still returned.
+func testMultiSourceEndpointsWithInvalidObjects(t *testing.T) {
+	foo := &endpoint.Endpoint{DNSName: "foo", Targets: endpoint.Targets{"8.8.8.8"}}
+	bar := &endpoint.Endpoint{DNSName: "bar", Targets: endpoint.Targets{"8.8.4.4"}}
+	invalidFoo := &source.InvalidObjectError{Resource: "crd/default/foo", Err: errors.New("illegal target")}
+	invalidBar := &source.InvalidObjectError{Resource: "f5-virtualserver/default/bar", Err: errors.New("invalid spec")}
+
+	fooSource := new(testutils.MockSource)
+	fooSource.On("Endpoints").Return([]*endpoint.Endpoint{foo}, &source.InvalidObjectsError{Errors: []*source.InvalidObjectError{invalidFoo}})
+	validSource := new(testutils.MockSource)
+	validSource.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	barSource := new(testutils.MockSource)
+	barSource.On("Endpoints").Return([]*endpoint.Endpoint{bar}, &source.InvalidObjectsError{Errors: []*source.InvalidObjectError{invalidBar}})
+
+	endpoints, err := NewMultiSource([]source.Source{fooSource, validSource, barSource}, nil, false).Endpoints(context.Background())
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{foo, bar})
+
+	var invalid *source.InvalidObjectsError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []*source.InvalidObjectError{invalidFoo, invalidBar}, invalid.Errors)
+
+	// the other errors are still bubbled up
+	failingSource := new(testutils.MockSource)
+	failingSource.On("Endpoints").Return(nil, errors.New("some error"))
+	_, err = NewMultiSource([]source.Source{fooSource, failingSource}, nil, false).Endpoints(context.Background())
+	assert.EqualError(t, err, "some error")
+}
+
 func testMultiSourceEndpointsDefaultTargets(t *testing.T) {
 	t.Run("Defaults applied when source targets are empty", func(t *testing.T) {
 		defaultTargetsA := []string{"127.0.0.1", "127.0.0.2"}
@@ -54,7 +54,7 @@ func (s *nat64Source) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, erro
 	additionalEndpoints := []*endpoint.Endpoint{}
 
 	endpoints, err := s.source.Endpoints(ctx)
-	if err != nil {
+	if err != nil && !source.IsInvalidObjectsError(err) {
 		return nil, err
 	}
 
@@ -105,7 +105,7 @@ func (s *nat64Source) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, erro
 
 		additionalEndpoints = append(additionalEndpoints, v4EP)
 	}
-	return append(endpoints, additionalEndpoints...), nil
+	return append(endpoints, additionalEndpoints...), err
 }
 
 func (s *nat64Source) AddEventHandler(ctx context.Context, handler func()) {
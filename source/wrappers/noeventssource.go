@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// noEventsSource is a Source that doesn't register the event handlers on its wrapped source,
+// so that the changes of the wrapped source only get reconciled every interval.
+type noEventsSource struct {
+	name   string
+	source source.Source
+}
+
+// NewNoEventsSource creates a new noEventsSource wrapping the provided Source of the given name.
+func NewNoEventsSource(name string, source source.Source) source.Source {
+	return &noEventsSource{name: name, source: source}
+}
+
+// Endpoints returns the endpoints of its wrapped source.
+func (s *noEventsSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return s.source.Endpoints(ctx)
+}
+
+func (s *noEventsSource) AddEventHandler(_ context.Context, _ func()) {
+	log.Debugf("Not adding event handler for source %s, its changes are reconciled every interval", s.name)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+func TestNoEventsSource(t *testing.T) {
+	nodes := NewEchoSource([]*endpoint.Endpoint{endpoint.NewEndpoint("node.example.org", endpoint.RecordTypeA, "10.0.0.1")})
+	services := NewEchoSource([]*endpoint.Endpoint{endpoint.NewEndpoint("svc.example.org", endpoint.RecordTypeA, "10.0.0.2")})
+	nodes.(*echoSource).On("AddEventHandler", t.Context()).Return()
+	services.(*echoSource).On("AddEventHandler", t.Context()).Return()
+
+	src := NewMultiSource([]source.Source{NewNoEventsSource("node", nodes), services}, nil, false)
+	src.AddEventHandler(t.Context(), func() {})
+
+	// Only the sources that aren't wrapped register their event handlers.
+	nodes.(*echoSource).AssertNumberOfCalls(t, "AddEventHandler", 0)
+	services.(*echoSource).AssertNumberOfCalls(t, "AddEventHandler", 1)
+
+	// The endpoints of the wrapped source are still returned.
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 2)
+}
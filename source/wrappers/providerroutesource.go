@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// providerDomain maps a domain to the provider managing it.
+type providerDomain struct {
+	provider string
+	domain   string
+	filter   *endpoint.DomainFilter
+}
+
+// ProviderRouter decides which of several providers is responsible for an endpoint.
+//
+// An endpoint is routed to the provider named by its provider annotation, else to the
+// provider mapped to the longest domain matching its name, else to the default provider.
+type ProviderRouter struct {
+	defaultProvider string
+	providers       []string
+	domains         []providerDomain
+}
+
+// NewProviderRouter creates a new ProviderRouter for the default and the additional providers.
+// Each provider domain is in the format <provider>=<domain>.
+func NewProviderRouter(defaultProvider string, additionalProviders []string, providerDomains []string) (*ProviderRouter, error) {
+	providers := []string{defaultProvider}
+	for _, p := range additionalProviders {
+		if slices.Contains(providers, p) {
+			return nil, fmt.Errorf("provider %q is configured more than once", p)
+		}
+		providers = append(providers, p)
+	}
+
+	domains := make([]providerDomain, 0, len(providerDomains))
+	for _, pd := range providerDomains {
+		p, domain, _ := strings.Cut(pd, "=")
+		if p == "" || domain == "" {
+			return nil, fmt.Errorf("invalid provider domain %q, expected format: <provider>=<domain>", pd)
+		}
+		if !slices.Contains(providers, p) {
+			return nil, fmt.Errorf("provider domain %q refers to provider %q which is not configured", pd, p)
+		}
+		domains = append(domains, providerDomain{
+			provider: p,
+			domain:   strings.Trim(domain, "."),
+			filter:   endpoint.NewDomainFilter([]string{domain}),
+		})
+	}
+
+	return &ProviderRouter{
+		defaultProvider: defaultProvider,
+		providers:       providers,
+		domains:         domains,
+	}, nil
+}
+
+// Providers returns the names of all providers, starting with the default provider.
+func (r *ProviderRouter) Providers() []string {
+	return r.providers
+}
+
+// Route returns the name of the provider responsible for the endpoint.
+func (r *ProviderRouter) Route(ep *endpoint.Endpoint) string {
+	if p, ok := ep.GetProviderSpecificProperty(annotations.ProviderKey); ok {
+		return p
+	}
+
+	route := providerDomain{provider: r.defaultProvider}
+	for _, pd := range r.domains {
+		if len(pd.domain) > len(route.domain) && pd.filter.Match(ep.DNSName) {
+			route = pd
+		}
+	}
+	return route.provider
+}
+
+// providerRouteSource is a Source that only returns the endpoints of its wrapped source
+// which are routed to a single provider.
+type providerRouteSource struct {
+	source   source.Source
+	router   *ProviderRouter
+	provider string
+}
+
+// NewProviderRouteSource creates a new providerRouteSource wrapping the provided Source.
+func NewProviderRouteSource(source source.Source, router *ProviderRouter, provider string) source.Source {
+	return &providerRouteSource{source: source, router: router, provider: provider}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns
+// the ones routed to the provider, without the routing annotation.
+func (ps *providerRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	// the records of the reconciliation are the ones of the provider
+	endpoints, err := ps.source.Endpoints(source.WithRecordsRoute(ctx, ps.provider, ps.router.Providers()))
+	if err != nil && !source.IsInvalidObjectsError(err) {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+
+	for _, ep := range endpoints {
+		p := ps.router.Route(ep)
+		if p != ps.provider {
+			// warned once, by the route of the default provider
+			if ps.provider == ps.router.defaultProvider && !slices.Contains(ps.router.providers, p) {
+				log.WithField("endpoint", ep).Warnf("Skipping endpoint because provider %q is not configured", p)
+			}
+			continue
+		}
+
+		// The same endpoint may be returned to the routes of the other providers, so it
+		// is copied instead of modified in place.
+		if _, ok := ep.GetProviderSpecificProperty(annotations.ProviderKey); ok {
+			ep = ep.DeepCopy()
+			ep.DeleteProviderSpecificProperty(annotations.ProviderKey)
+		}
+
+		result = append(result, ep)
+	}
+
+	return result, err
+}
+
+func (ps *providerRouteSource) AddEventHandler(ctx context.Context, handler func()) {
+	ps.source.AddEventHandler(ctx, handler)
+}
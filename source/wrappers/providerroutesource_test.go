@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestNewProviderRouter(t *testing.T) {
+	for _, tc := range []struct {
+		title               string
+		additionalProviders []string
+		providerDomains     []string
+		expectError         bool
+	}{
+		{
+			title: "single provider",
+		},
+		{
+			title:               "additional provider with domains",
+			additionalProviders: []string{"coredns"},
+			providerDomains:     []string{"coredns=cluster.local", "aws=example.org"},
+		},
+		{
+			title:               "duplicate provider",
+			additionalProviders: []string{"aws"},
+			expectError:         true,
+		},
+		{
+			title:           "domain of unknown provider",
+			providerDomains: []string{"coredns=cluster.local"},
+			expectError:     true,
+		},
+		{
+			title:           "invalid domain",
+			providerDomains: []string{"cluster.local"},
+			expectError:     true,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			_, err := NewProviderRouter("aws", tc.additionalProviders, tc.providerDomains)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProviderRouterRoute(t *testing.T) {
+	router, err := NewProviderRouter("aws", []string{"coredns", "pihole"}, []string{
+		"coredns=internal.example.org",
+		"pihole=example.org",
+		"coredns=cluster.local",
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		ep       *endpoint.Endpoint
+		expected string
+	}{
+		{ep: endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"), expected: "aws"},
+		{ep: endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"), expected: "pihole"},
+		{ep: endpoint.NewEndpoint("db.internal.example.org", endpoint.RecordTypeA, "1.2.3.4"), expected: "coredns"},
+		{ep: endpoint.NewEndpoint("db.cluster.local", endpoint.RecordTypeA, "1.2.3.4"), expected: "coredns"},
+		{
+			ep:       endpoint.NewEndpoint("db.cluster.local", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(annotations.ProviderKey, "aws"),
+			expected: "aws",
+		},
+	} {
+		t.Run(tc.ep.DNSName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, router.Route(tc.ep))
+		})
+	}
+}
+
+func TestProviderRouteSource(t *testing.T) {
+	router, err := NewProviderRouter("aws", []string{"coredns"}, []string{"coredns=cluster.local"})
+	require.NoError(t, err)
+
+	src := NewEchoSource([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("db.cluster.local", endpoint.RecordTypeA, "10.0.0.1"),
+		endpoint.NewEndpoint("internal.example.org", endpoint.RecordTypeA, "10.0.0.2").WithProviderSpecific(annotations.ProviderKey, "coredns"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "10.0.0.3").WithProviderSpecific(annotations.ProviderKey, "pihole"),
+	})
+
+	for _, tc := range []struct {
+		provider string
+		expected []string
+		warned   bool
+	}{
+		{provider: "aws", expected: []string{"www.example.org"}, warned: true},
+		{provider: "coredns", expected: []string{"db.cluster.local", "internal.example.org"}},
+	} {
+		t.Run(tc.provider, func(t *testing.T) {
+			hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+			endpoints, err := NewProviderRouteSource(src, router, tc.provider).Endpoints(context.Background())
+			require.NoError(t, err)
+
+			// the endpoints of the unconfigured providers are warned about once
+			if tc.warned {
+				testutils.TestHelperLogContains(`Skipping endpoint because provider "pihole" is not configured`, hook, t)
+			} else {
+				testutils.TestHelperLogNotContains(`Skipping endpoint because provider "pihole" is not configured`, hook, t)
+			}
+
+			names := make([]string, 0, len(endpoints))
+			for _, ep := range endpoints {
+				names = append(names, ep.DNSName)
+				_, ok := ep.GetProviderSpecificProperty(annotations.ProviderKey)
+				assert.False(t, ok, "provider annotation of %s was not removed", ep.DNSName)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+
+	// The endpoints of the wrapped source are left untouched for the routes of the other providers.
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	_, ok := endpoints[2].GetProviderSpecificProperty(annotations.ProviderKey)
+	assert.True(t, ok)
+}
@@ -41,12 +41,12 @@ func NewTargetFilterSource(source source.Source, targetFilter endpoint.TargetFil
 // them without targets matching the target filter.
 func (ms *targetFilterSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	endpoints, err := ms.source.Endpoints(ctx)
-	if err != nil {
+	if err != nil && !source.IsInvalidObjectsError(err) {
 		return nil, err
 	}
 
 	if !ms.targetFilter.IsEnabled() {
-		return endpoints, nil
+		return endpoints, err
 	}
 
 	result := make([]*endpoint.Endpoint, 0, len(endpoints))
@@ -71,7 +71,7 @@ func (ms *targetFilterSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoi
 		result = append(result, ep)
 	}
 
-	return result, nil
+	return result, err
 }
 
 func (ms *targetFilterSource) AddEventHandler(ctx context.Context, handler func()) {
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// txtMergeSource is a Source that merges the TXT endpoints with the same name and set identifier,
+// which would otherwise conflict, into a single endpoint with the targets of all of them.
+type txtMergeSource struct {
+	source source.Source
+}
+
+// NewTXTMergeSource creates a new txtMergeSource wrapping the provided Source.
+func NewTXTMergeSource(source source.Source) source.Source {
+	return &txtMergeSource{source: source}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them with the TXT endpoints of the
+// same name merged into the first of them. The TTL of the first endpoint is kept, and the labels and
+// the provider-specific properties of the others missing from it are added. Each target is published as
+// its own TXT record, so an SPF policy is not merged into an endpoint which already has one.
+func (s *txtMergeSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil && !source.IsInvalidObjectsError(err) {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	collected := map[endpoint.EndpointKey]int{}
+	merged := map[int]bool{}
+
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			result = append(result, ep)
+			continue
+		}
+
+		key := endpoint.EndpointKey{DNSName: ep.DNSName, RecordType: ep.RecordType, SetIdentifier: ep.SetIdentifier}
+		i, ok := collected[key]
+		if !ok {
+			collected[key] = len(result)
+			result = append(result, ep)
+			continue
+		}
+
+		log.Debugf("Merging TXT endpoint %s into %s", ep, result[i])
+		if !merged[i] {
+			// Merge into a copy to leave the endpoints of the wrapped source untouched.
+			result[i] = result[i].DeepCopy()
+			merged[i] = true
+		}
+		for _, target := range ep.Targets {
+			if slices.Contains(result[i].Targets, target) {
+				continue
+			}
+			if isSPF(target) && slices.ContainsFunc(result[i].Targets, isSPF) {
+				log.Warnf("Not merging the SPF policy %q of %s into %s, a name can only have one SPF policy", target, ep, result[i])
+				continue
+			}
+			result[i].Targets = append(result[i].Targets, target)
+		}
+		mergeDuplicateEndpoint(result[i], ep)
+	}
+
+	return result, err
+}
+
+// isSPF returns true if the TXT target is an SPF policy.
+func isSPF(target string) bool {
+	fields := strings.Fields(strings.Trim(target, `"`))
+	return len(fields) > 0 && strings.EqualFold(fields[0], "v=spf1")
+}
+
+func (s *txtMergeSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestTXTMergeSource(t *testing.T) {
+	for _, tc := range []struct {
+		title     string
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			title: "two TXT endpoints for the same name",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 300, "v=spf1 include:_spf.google.com ~all").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/mail"),
+				endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 600, "google-site-verification=abc").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/site").
+					WithProviderSpecific("provider", "value"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 300, "v=spf1 include:_spf.google.com ~all", "google-site-verification=abc").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/mail").
+					WithProviderSpecific("provider", "value"),
+			},
+		},
+		{
+			title: "a second SPF policy is not merged",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "v=spf1 include:_spf.google.com ~all"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, `"V=SPF1 include:mailgun.org ~all"`, "google-site-verification=abc"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "v=spf1x not an SPF policy"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "v=spf1 include:_spf.google.com ~all", "google-site-verification=abc", "v=spf1x not an SPF policy"),
+			},
+		},
+		{
+			title: "duplicate targets are merged once",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "a", "b"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "b", "c"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "a", "b", "c"),
+			},
+		},
+		{
+			title: "other names, set identifiers and record types are kept",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "a"),
+				endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeTXT, "b"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "c").WithSetIdentifier("eu"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "192.0.2.2"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "a"),
+				endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeTXT, "b"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "c").WithSetIdentifier("eu"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "192.0.2.2"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			src := NewTXTMergeSource(NewEchoSource(tc.endpoints))
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, endpoints)
+		})
+	}
+}
+
+func TestTXTMergeSourceKeepsWrappedEndpoints(t *testing.T) {
+	first := endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "a")
+	src := NewTXTMergeSource(NewEchoSource([]*endpoint.Endpoint{
+		first,
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "b"),
+	}))
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"a", "b"}, endpoints[0].Targets)
+	assert.Equal(t, endpoint.Targets{"a"}, first.Targets)
+}